@@ -1,14 +1,54 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+
 	"github.com/romanpitatelev/clothing-service/internal/app"
 	"github.com/romanpitatelev/clothing-service/internal/configs"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
 )
 
+const importFeedCommand = "import-feed"
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == importFeedCommand {
+		if err := importFeed(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	cfg := configs.New()
 
 	if err := app.Run(cfg); err != nil {
 		panic(err)
 	}
 }
+
+// importFeed runs "import-feed -feed <id> -format yml|csv <file>".
+func importFeed(args []string) error {
+	flags := flag.NewFlagSet(importFeedCommand, flag.ContinueOnError)
+	feedID := flags.String("feed", "", "partner feed id, a lowercase slug such as acme-store")
+	format := flags.String("format", string(entity.FeedFormatYML), "feed format: yml or csv")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s %s -feed <id> [-format yml|csv] <file>\n", os.Args[0], importFeedCommand)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if *feedID == "" || flags.NArg() != 1 {
+		flags.Usage()
+
+		return fmt.Errorf("%w: feed id and file are required", entity.ErrInvalidFeed)
+	}
+
+	return app.ImportFeed(configs.New(), *feedID, entity.FeedFormat(*format), flags.Arg(0)) //nolint:wrapcheck
+}
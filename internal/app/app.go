@@ -3,18 +3,67 @@ package app
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/romanpitatelev/clothing-service/internal/configs"
 	"github.com/romanpitatelev/clothing-service/internal/controller/rest"
+	cataloghandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/catalog-handler"
+	fileshandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/files-handler"
 	iamhandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/iam-handler"
+	marketplacehandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/marketplace-handler"
+	messaginghandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/messaging-handler"
+	moderationhandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/moderation-handler"
+	notificationshandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/notifications-handler"
+	ordershandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/orders-handler"
+	outfitshandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/outfits-handler"
+	realtimehandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/realtime-handler"
+	sizeshandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/sizes-handler"
+	socialhandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/social-handler"
+	taxonomyhandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/taxonomy-handler"
 	usershandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/users-handler"
+	wardrobehandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/wardrobe-handler"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/pricing"
+	catalogrepo "github.com/romanpitatelev/clothing-service/internal/repository/catalog-repo"
+	eventsrepo "github.com/romanpitatelev/clothing-service/internal/repository/events-repo"
+	filesrepo "github.com/romanpitatelev/clothing-service/internal/repository/files-repo"
+	followsrepo "github.com/romanpitatelev/clothing-service/internal/repository/follows-repo"
+	marketplacerepo "github.com/romanpitatelev/clothing-service/internal/repository/marketplace-repo"
+	messagingrepo "github.com/romanpitatelev/clothing-service/internal/repository/messaging-repo"
+	moderationrepo "github.com/romanpitatelev/clothing-service/internal/repository/moderation-repo"
+	notificationsrepo "github.com/romanpitatelev/clothing-service/internal/repository/notifications-repo"
+	ordersrepo "github.com/romanpitatelev/clothing-service/internal/repository/orders-repo"
+	outboxrepo "github.com/romanpitatelev/clothing-service/internal/repository/outbox-repo"
+	outfitsrepo "github.com/romanpitatelev/clothing-service/internal/repository/outfits-repo"
+	postsrepo "github.com/romanpitatelev/clothing-service/internal/repository/posts-repo"
+	searchrepo "github.com/romanpitatelev/clothing-service/internal/repository/search-repo"
+	sizesrepo "github.com/romanpitatelev/clothing-service/internal/repository/sizes-repo"
 	smsregistrationrepo "github.com/romanpitatelev/clothing-service/internal/repository/sms-registration-repo"
 	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+	taxonomyrepo "github.com/romanpitatelev/clothing-service/internal/repository/taxonomy-repo"
 	usersrepo "github.com/romanpitatelev/clothing-service/internal/repository/users-repo"
+	wardroberepo "github.com/romanpitatelev/clothing-service/internal/repository/wardrobe-repo"
+	yookassarepo "github.com/romanpitatelev/clothing-service/internal/repository/yookassa-repo"
+	catalogservice "github.com/romanpitatelev/clothing-service/internal/usecase/catalog-service"
+	filesservice "github.com/romanpitatelev/clothing-service/internal/usecase/files-service"
+	marketplaceservice "github.com/romanpitatelev/clothing-service/internal/usecase/marketplace-service"
+	messagingservice "github.com/romanpitatelev/clothing-service/internal/usecase/messaging-service"
+	moderationservice "github.com/romanpitatelev/clothing-service/internal/usecase/moderation-service"
+	notificationsservice "github.com/romanpitatelev/clothing-service/internal/usecase/notifications-service"
+	ordersservice "github.com/romanpitatelev/clothing-service/internal/usecase/orders-service"
+	outboxservice "github.com/romanpitatelev/clothing-service/internal/usecase/outbox-service"
+	outfitsservice "github.com/romanpitatelev/clothing-service/internal/usecase/outfits-service"
+	realtimeservice "github.com/romanpitatelev/clothing-service/internal/usecase/realtime-service"
+	searchservice "github.com/romanpitatelev/clothing-service/internal/usecase/search-service"
+	sizesservice "github.com/romanpitatelev/clothing-service/internal/usecase/sizes-service"
+	socialservice "github.com/romanpitatelev/clothing-service/internal/usecase/social-service"
+	suggestionsservice "github.com/romanpitatelev/clothing-service/internal/usecase/suggestions-service"
+	taxonomyservice "github.com/romanpitatelev/clothing-service/internal/usecase/taxonomy-service"
 	"github.com/romanpitatelev/clothing-service/internal/usecase/token-service"
 	usersservice "github.com/romanpitatelev/clothing-service/internal/usecase/users-service"
+	wardrobeservice "github.com/romanpitatelev/clothing-service/internal/usecase/wardrobe-service"
 	"github.com/rs/zerolog/log"
 	migrate "github.com/rubenv/sql-migrate"
 )
@@ -23,7 +72,12 @@ func Run(cfg *configs.Config) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	db, err := store.New(ctx, store.Config{Dsn: cfg.PostgresDSN})
+	db, err := store.New(ctx, store.Config{
+		Dsn:          cfg.PostgresDSN,
+		TxIsoLevel:   cfg.TxIsoLevel,
+		TxMaxRetries: cfg.TxMaxRetries,
+		TxRetryDelay: cfg.TxRetryDelay,
+	})
 	if err != nil {
 		log.Panic().Err(err).Msg("failed to connect to database")
 	}
@@ -35,6 +89,7 @@ func Run(cfg *configs.Config) error {
 	log.Info().Msg("successful migration")
 
 	usersRepo := usersrepo.New(db)
+	outboxRepo := outboxrepo.New(db)
 	smsClient := smsregistrationrepo.New(smsregistrationrepo.Config{
 		Schema:   cfg.SMSAPISchema,
 		Host:     cfg.SMSAPIHost,
@@ -44,6 +99,37 @@ func Run(cfg *configs.Config) error {
 		TestMode: cfg.SMSSenderTestMode,
 	})
 
+	paymentClient := yookassarepo.New(yookassarepo.Config{
+		Host:          cfg.YooKassaHost,
+		Schema:        cfg.YooKassaSchema,
+		ShopID:        cfg.YooKassaShopID,
+		SecretKey:     cfg.YooKassaSecretKey,
+		ReturnURL:     cfg.PaymentsReturnURL,
+		WebhookSecret: cfg.PaymentsWebhookSecret,
+		Timeout:       cfg.PaymentsTimeout,
+	})
+
+	storage, err := newStorage(cfg)
+	if err != nil {
+		return err
+	}
+
+	filesRepo := filesrepo.NewRepo(db, storage)
+	wardrobeRepo := wardroberepo.New(db)
+	taxonomyRepo := taxonomyrepo.New(db)
+	outfitsRepo := outfitsrepo.New(db)
+	searchRepo := searchrepo.New(db)
+	sizesRepo := sizesrepo.New(db)
+	postsRepo := postsrepo.New(db)
+	followsRepo := followsrepo.New(db)
+	moderationRepo := moderationrepo.New(db)
+	catalogRepo := catalogrepo.New(db)
+	ordersRepo := ordersrepo.New(db)
+	marketplaceRepo := marketplacerepo.New(db)
+	messagingRepo := messagingrepo.New(db)
+	eventsRepo := eventsrepo.New(db)
+	notificationsRepo := notificationsrepo.New(db)
+
 	tokenService := tokenservice.New(tokenservice.Config{
 		OTPLifetime: cfg.OTPLifetime,
 		PublicKey:   cfg.JWTPublicKey,
@@ -51,15 +137,116 @@ func Run(cfg *configs.Config) error {
 	}, usersRepo)
 	usersService := usersservice.New(usersservice.Config{
 		OTPMaxValue: cfg.OTPMaxValue,
-	}, usersRepo, smsClient)
+		OTPLifetime: cfg.OTPLifetime,
+	}, usersRepo, outboxRepo, db)
+	filesService := filesservice.New(filesservice.Config{
+		MaxFileSize: cfg.FilesMaxSize,
+		URLLifetime: cfg.FilesURLLifetime,
+	}, filesRepo)
+
+	wardrobeService := wardrobeservice.New(wardrobeRepo, taxonomyRepo, db)
+	taxonomyService := taxonomyservice.New(taxonomyRepo)
+	outfitsService := outfitsservice.New(outfitsRepo)
+	suggestionsService := suggestionsservice.New(wardrobeRepo)
+	searchService := searchservice.New(searchRepo)
+	sizesService := sizesservice.New(sizesRepo, taxonomyRepo)
+	notificationsService := notificationsservice.New(notificationsRepo)
+	socialService := socialservice.New(postsRepo, followsRepo, outboxRepo, notificationsService, db)
+	moderationService := moderationservice.New(moderationRepo)
+	catalogService := newCatalogService(cfg, catalogRepo, filesRepo)
+	pricingEngine := pricing.New(pricing.Config{
+		ShippingFee: cfg.ShippingFee,
+	})
+	ordersService := ordersservice.New(ordersRepo, paymentClient, pricingEngine, outboxRepo, notificationsService, db)
+	marketplaceService := marketplaceservice.New(marketplaceservice.Config{
+		URLLifetime: cfg.FilesURLLifetime,
+	}, marketplaceRepo, filesRepo)
+	messagingService := messagingservice.New(messagingservice.Config{
+		URLLifetime: cfg.FilesURLLifetime,
+	}, messagingRepo, filesRepo, eventsRepo)
+
+	filesReconciler := filesservice.NewReconciler(filesservice.ReconcilerConfig{
+		Interval:          cfg.FilesReconcileInterval,
+		PendingTTL:        cfg.FilesPendingTTL,
+		OrphanGracePeriod: cfg.FilesOrphanGracePeriod,
+	}, filesRepo)
+
+	go filesReconciler.Run(ctx)
+
+	outboxRelay := outboxservice.NewRelay(outboxservice.RelayConfig{
+		Interval:    cfg.OutboxInterval,
+		BatchSize:   cfg.OutboxBatchSize,
+		MaxAttempts: cfg.OutboxMaxAttempts,
+		BaseBackoff: cfg.OutboxBaseBackoff,
+		MaxBackoff:  cfg.OutboxMaxBackoff,
+		Lease:       cfg.OutboxLease,
+		Retention:   cfg.OutboxRetention,
+	}, outboxRepo, smsClient, eventsRepo)
+
+	go outboxRelay.Run(ctx)
+
+	priceWatcher := catalogservice.NewPriceWatcher(catalogservice.PriceWatcherConfig{
+		Interval:  cfg.PriceDropInterval,
+		BatchSize: cfg.PriceDropBatchSize,
+	}, catalogRepo, notificationsService, outboxRepo, db)
+
+	go priceWatcher.Run(ctx)
+
+	notificationsDispatcher := notificationsservice.NewDispatcher(notificationsservice.DispatcherConfig{
+		Interval:    cfg.NotificationsInterval,
+		BatchSize:   cfg.NotificationsBatchSize,
+		MaxAttempts: cfg.NotificationsMaxAttempts,
+		BaseBackoff: cfg.NotificationsBaseBackoff,
+		MaxBackoff:  cfg.NotificationsMaxBackoff,
+		Lease:       cfg.NotificationsLease,
+	}, notificationsRepo, outboxRepo, db)
+
+	go notificationsDispatcher.Run(ctx)
+
+	realtimeHub := realtimeservice.NewHub(realtimeservice.HubConfig{
+		BufferSize:    cfg.RealtimeBufferSize,
+		RetryInterval: cfg.RealtimeRetryInterval,
+	}, eventsRepo)
+
+	go realtimeHub.Run(ctx)
 
 	usersHandler := usershandler.New(usersService)
 	iamHandler := iamhandler.New(tokenService)
+	filesHandler := fileshandler.New(fileshandler.Config{
+		MaxUploadSize: cfg.FilesMaxSize,
+	}, filesService)
+	wardrobeHandler := wardrobehandler.New(wardrobeService, searchService)
+	taxonomyHandler := taxonomyhandler.New(taxonomyService)
+	outfitsHandler := outfitshandler.New(outfitsService, suggestionsService)
+	sizesHandler := sizeshandler.New(sizesService)
+	socialHandler := socialhandler.New(socialService)
+	moderationHandler := moderationhandler.New(moderationService)
+	catalogHandler := cataloghandler.New(catalogService)
+	ordersHandler := ordershandler.New(ordersService)
+	marketplaceHandler := marketplacehandler.New(marketplaceService)
+	messagingHandler := messaginghandler.New(messagingService)
+	realtimeHandler := realtimehandler.New(realtimehandler.Config{
+		HeartbeatInterval: cfg.RealtimeHeartbeatInterval,
+	}, realtimeHub)
+	notificationsHandler := notificationshandler.New(notificationsService)
 
 	server := rest.New(
 		rest.Config{Port: cfg.AppPort},
 		usersHandler,
 		iamHandler,
+		filesHandler,
+		wardrobeHandler,
+		taxonomyHandler,
+		outfitsHandler,
+		sizesHandler,
+		socialHandler,
+		moderationHandler,
+		catalogHandler,
+		ordersHandler,
+		marketplaceHandler,
+		messagingHandler,
+		realtimeHandler,
+		notificationsHandler,
 	)
 
 	if err := server.Run(ctx); err != nil {
@@ -68,3 +255,93 @@ func Run(cfg *configs.Config) error {
 
 	return nil
 }
+
+// ImportFeed imports a partner feed from a local file and logs the import's
+// stats.
+func ImportFeed(cfg *configs.Config, feedID string, format entity.FeedFormat, path string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db, err := store.New(ctx, store.Config{
+		Dsn:          cfg.PostgresDSN,
+		TxIsoLevel:   cfg.TxIsoLevel,
+		TxMaxRetries: cfg.TxMaxRetries,
+		TxRetryDelay: cfg.TxRetryDelay,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := db.Migrate(migrate.Up); err != nil {
+		return fmt.Errorf("failed to migrate: %w", err)
+	}
+
+	storage, err := newStorage(cfg)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to open feed: %w", err)
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close feed")
+		}
+	}()
+
+	catalogService := newCatalogService(cfg, catalogrepo.New(db), filesrepo.NewRepo(db, storage))
+
+	imp, err := catalogService.Import(ctx, feedID, format, file)
+	if err != nil {
+		return fmt.Errorf("failed to import feed: %w", err)
+	}
+
+	log.Info().
+		Str("feed", imp.FeedID).
+		Int("offers", imp.Offers).
+		Int("created", imp.Created).
+		Int("updated", imp.Updated).
+		Int("unchanged", imp.Unchanged).
+		Int("rejected", imp.Rejected).
+		Int("deactivated", imp.Deactivated).
+		Int("imagesMirrored", imp.ImagesMirrored).
+		Int("imagesFailed", imp.ImagesFailed).
+		Msg("feed imported")
+
+	for _, importErr := range imp.Errors {
+		log.Warn().Str("feed", imp.FeedID).Msg(importErr)
+	}
+
+	return nil
+}
+
+func newStorage(cfg *configs.Config) (filesrepo.Storage, error) {
+	storage, err := filesrepo.NewStorage(filesrepo.StorageConfig{
+		Backend: cfg.StorageBackend,
+		S3: filesrepo.S3Config{
+			Address: cfg.S3Address,
+			Bucket:  cfg.S3Bucket,
+			Access:  cfg.S3Access,
+			Secret:  cfg.S3Secret,
+			Region:  cfg.S3Region,
+		},
+		LocalDir: cfg.StorageLocalDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob storage: %w", err)
+	}
+
+	return storage, nil
+}
+
+func newCatalogService(cfg *configs.Config, catalogRepo *catalogrepo.Repo, filesRepo *filesrepo.Repo) *catalogservice.Service {
+	return catalogservice.New(catalogservice.Config{
+		URLLifetime: cfg.FilesURLLifetime,
+	}, catalogRepo, filesRepo, catalogrepo.NewFetcher(catalogrepo.FetcherConfig{
+		Timeout: cfg.CatalogImageTimeout,
+		MaxSize: cfg.FilesMaxSize,
+	}))
+}
@@ -0,0 +1,199 @@
+// Package catalogfeed parses partner product feeds into catalog offers.
+//
+// Feeds list one offer per size. Offers sharing a group id are merged into a
+// single offer carrying every size, priced at the cheapest of them.
+package catalogfeed
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const minorUnits = 100
+
+var ErrInvalidPrice = errors.New("invalid price")
+
+// Result is a parsed feed.
+type Result struct {
+	Offers   []entity.CatalogOffer
+	Rejected []Rejection
+}
+
+// Rejection is an offer that was left out of the result.
+type Rejection struct {
+	ExternalID string
+	Err        error
+}
+
+// rawOffer is a single feed entry before offers of the same group are merged.
+type rawOffer struct {
+	groupID string
+	offer   entity.CatalogOffer
+	size    *entity.ProductSize
+}
+
+// Parse reads a whole feed. It fails only when the feed itself is unreadable;
+// broken offers are reported in Result.Rejected.
+func Parse(format entity.FeedFormat, r io.Reader) (Result, error) {
+	var (
+		raws     []rawOffer
+		rejected []Rejection
+		err      error
+	)
+
+	switch format {
+	case entity.FeedFormatYML:
+		raws, rejected, err = parseYML(r)
+	case entity.FeedFormatCSV:
+		raws, rejected, err = parseCSV(r)
+	default:
+		err = format.Validate()
+	}
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := merge(raws)
+	result.Rejected = append(rejected, result.Rejected...)
+
+	return result, nil
+}
+
+func merge(raws []rawOffer) Result {
+	var (
+		result Result
+		order  []string
+	)
+
+	groups := make(map[string]*entity.CatalogOffer, len(raws))
+
+	for _, raw := range raws {
+		key := raw.groupID
+		if key == "" {
+			key = raw.offer.ExternalID
+		}
+
+		offer, ok := groups[key]
+		if !ok {
+			merged := raw.offer
+			merged.ExternalID = key
+			merged.Sizes = nil
+			merged.Pictures = nil
+
+			offer = &merged
+			groups[key] = offer
+			order = append(order, key)
+		}
+
+		if ok && raw.offer.Price > 0 && (offer.Price <= 0 || raw.offer.Price < offer.Price) {
+			offer.Price = raw.offer.Price
+			offer.OldPrice = raw.offer.OldPrice
+		}
+
+		offer.Available = offer.Available || raw.offer.Available
+
+		for _, picture := range raw.offer.Pictures {
+			if !slices.Contains(offer.Pictures, picture) {
+				offer.Pictures = append(offer.Pictures, picture)
+			}
+		}
+
+		if raw.size != nil && !slices.ContainsFunc(offer.Sizes, func(s entity.ProductSize) bool {
+			return s.Size == raw.size.Size
+		}) {
+			offer.Sizes = append(offer.Sizes, *raw.size)
+		}
+	}
+
+	for _, key := range order {
+		offer, err := groups[key].Validate()
+		if err != nil {
+			result.Rejected = append(result.Rejected, Rejection{ExternalID: key, Err: err})
+
+			continue
+		}
+
+		result.Offers = append(result.Offers, offer)
+	}
+
+	return result
+}
+
+// parsePrice turns a decimal amount such as "1990.50" into minor units.
+func parsePrice(value string) (int64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	value = strings.ReplaceAll(value, " ", "")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("%w: %q has more than two decimal places", ErrInvalidPrice, value)
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidPrice, value)
+	}
+
+	var cents int64
+
+	if fraction != "" {
+		fraction += strings.Repeat("0", 2-len(fraction))
+
+		if cents, err = strconv.ParseInt(fraction, 10, 64); err != nil || cents < 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidPrice, value)
+		}
+	}
+
+	return units*minorUnits + cents, nil
+}
+
+// parseOptionalPrice parses a price that may be left empty.
+func parseOptionalPrice(value string) (*int64, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	price, err := parsePrice(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &price, nil
+}
+
+// parseAvailable treats anything but an explicit "no" as available, which is
+// what YML prescribes for a missing attribute.
+func parseAvailable(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "false", "0", "no", "n":
+		return false
+	default:
+		return true
+	}
+}
+
+func parseStock(value string) *int {
+	stock, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || stock < 0 {
+		return nil
+	}
+
+	return &stock
+}
+
+func optional(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,162 @@
+package catalogfeed_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/romanpitatelev/clothing-service/internal/catalogfeed"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const ymlFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<yml_catalog date="2025-05-01 10:00">
+  <shop>
+    <name>Shop</name>
+    <currencies><currency id="RUR" rate="1"/></currencies>
+    <categories>
+      <category id="1">Women</category>
+      <category id="2" parentId="1">Dresses</category>
+    </categories>
+    <offers>
+      <offer id="d-44" group_id="d" available="true">
+        <url>https://shop.example/d</url>
+        <price>4990</price>
+        <oldprice>6990</oldprice>
+        <currencyId>RUR</currencyId>
+        <categoryId>2</categoryId>
+        <picture>https://shop.example/d-1.jpg</picture>
+        <picture>https://shop.example/d-2.jpg</picture>
+        <name>Linen dress</name>
+        <vendor>Acme</vendor>
+        <param name="Размер" unit="RU">44</param>
+      </offer>
+      <offer id="d-46" group_id="d" available="false">
+        <price>4490.50</price>
+        <currencyId>RUR</currencyId>
+        <categoryId>2</categoryId>
+        <picture>https://shop.example/d-1.jpg</picture>
+        <name>Linen dress</name>
+        <param name="Размер" unit="RU">46</param>
+        <count>0</count>
+      </offer>
+      <offer id="s-1" type="vendor.model">
+        <price>1990</price>
+        <currencyId>USD</currencyId>
+        <typePrefix>Scarf</typePrefix>
+        <vendor>Acme</vendor>
+        <model>Silk</model>
+        <picture>ftp://shop.example/s.jpg</picture>
+      </offer>
+      <offer id="broken">
+        <price>12.345</price>
+        <name>Broken</name>
+      </offer>
+      <offer id="free">
+        <price>0</price>
+        <name>Free</name>
+      </offer>
+    </offers>
+  </shop>
+</yml_catalog>`
+
+func TestParseYML(t *testing.T) {
+	t.Parallel()
+
+	result, err := catalogfeed.Parse(entity.FeedFormatYML, strings.NewReader(ymlFeed))
+	require.NoError(t, err)
+
+	require.Equal(t, []entity.CatalogOffer{
+		{
+			ExternalID: "d",
+			Name:       "Linen dress",
+			Brand:      utils.Pointer("Acme"),
+			URL:        utils.Pointer("https://shop.example/d"),
+			Price:      449050,
+			Currency:   entity.DefaultCurrency,
+			Category:   utils.Pointer("Women > Dresses"),
+			Available:  true,
+			Sizes: []entity.ProductSize{
+				{Size: "44", Available: true},
+				{Size: "46", Available: false, Stock: utils.Pointer(0)},
+			},
+			Pictures: []string{"https://shop.example/d-1.jpg", "https://shop.example/d-2.jpg"},
+		},
+		{
+			ExternalID: "s-1",
+			Name:       "Scarf Acme Silk",
+			Brand:      utils.Pointer("Acme"),
+			Price:      199000,
+			Currency:   "USD",
+			Available:  true,
+			Pictures:   []string{},
+		},
+	}, result.Offers)
+
+	require.Len(t, result.Rejected, 2)
+	require.Equal(t, "broken", result.Rejected[0].ExternalID)
+	require.ErrorIs(t, result.Rejected[0].Err, catalogfeed.ErrInvalidPrice)
+	require.Equal(t, "free", result.Rejected[1].ExternalID)
+	require.ErrorIs(t, result.Rejected[1].Err, entity.ErrInvalidOffer)
+}
+
+func TestParseYMLWindows1251(t *testing.T) {
+	t.Parallel()
+
+	feed := strings.Replace(ymlFeed, "UTF-8", "windows-1251", 1)
+
+	encoded, err := charmap.Windows1251.NewEncoder().String(strings.ReplaceAll(feed, "Dresses", "Платья"))
+	require.NoError(t, err)
+
+	result, err := catalogfeed.Parse(entity.FeedFormatYML, bytes.NewBufferString(encoded))
+	require.NoError(t, err)
+	require.Equal(t, "Women > Платья", *result.Offers[0].Category)
+	require.Equal(t, "44", result.Offers[0].Sizes[0].Size)
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+
+	feed := "id;group_id;name;brand;price;old_price;currency;category;size;stock;pictures\n" +
+		"t-s;t;Tee;Acme;990,00;1290;RUB;tops;S;3;https://shop.example/t.jpg | https://shop.example/t2.jpg\n" +
+		"t-m;t;Tee;Acme;990;;RUB;tops;M;0;https://shop.example/t.jpg\n" +
+		"x;;No price;;;;;;;;\n"
+
+	result, err := catalogfeed.Parse(entity.FeedFormatCSV, strings.NewReader(feed))
+	require.NoError(t, err)
+
+	require.Equal(t, []entity.CatalogOffer{{
+		ExternalID: "t",
+		Name:       "Tee",
+		Brand:      utils.Pointer("Acme"),
+		Price:      99000,
+		OldPrice:   utils.Pointer[int64](129000),
+		Currency:   "RUB",
+		Category:   utils.Pointer("tops"),
+		Available:  true,
+		Sizes: []entity.ProductSize{
+			{Size: "S", Available: true, Stock: utils.Pointer(3)},
+			{Size: "M", Available: false, Stock: utils.Pointer(0)},
+		},
+		Pictures: []string{"https://shop.example/t.jpg", "https://shop.example/t2.jpg"},
+	}}, result.Offers)
+
+	require.Len(t, result.Rejected, 1)
+	require.Equal(t, "x", result.Rejected[0].ExternalID)
+}
+
+func TestParseInvalidFeed(t *testing.T) {
+	t.Parallel()
+
+	_, err := catalogfeed.Parse(entity.FeedFormatCSV, strings.NewReader("sku,title\n1,Tee\n"))
+	require.ErrorIs(t, err, entity.ErrInvalidFeed)
+
+	_, err = catalogfeed.Parse(entity.FeedFormatYML, strings.NewReader("<yml_catalog><shop>"))
+	require.ErrorIs(t, err, entity.ErrInvalidFeed)
+
+	_, err = catalogfeed.Parse("json", strings.NewReader("{}"))
+	require.ErrorIs(t, err, entity.ErrInvalidFeed)
+}
@@ -0,0 +1,168 @@
+package catalogfeed
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// CSV columns. Only id, name and price are required; pictures holds one or
+// more URLs separated by "|" or whitespace.
+const (
+	csvID          = "id"
+	csvGroupID     = "group_id"
+	csvName        = "name"
+	csvBrand       = "brand"
+	csvDescription = "description"
+	csvURL         = "url"
+	csvPrice       = "price"
+	csvOldPrice    = "old_price"
+	csvCurrency    = "currency"
+	csvCategory    = "category"
+	csvSize        = "size"
+	csvAvailable   = "available"
+	csvStock       = "stock"
+	csvPictures    = "pictures"
+)
+
+//nolint:gochecknoglobals
+var csvRequired = []string{csvID, csvName, csvPrice}
+
+type csvRow struct {
+	columns map[string]int
+	record  []string
+}
+
+func (r csvRow) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+
+	return strings.TrimSpace(r.record[i])
+}
+
+func parseCSV(r io.Reader) ([]rawOffer, []Rejection, error) {
+	buffered := bufio.NewReader(r)
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = detectDelimiter(buffered)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to read header: %w", entity.ErrInvalidFeed, err)
+	}
+
+	columns := make(map[string]int, len(header))
+
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		columns[column] = i
+	}
+
+	for _, column := range csvRequired {
+		if _, ok := columns[column]; !ok {
+			return nil, nil, fmt.Errorf("%w: missing %q column", entity.ErrInvalidFeed, column)
+		}
+	}
+
+	var (
+		raws     []rawOffer
+		rejected []Rejection
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidFeed, err)
+		}
+
+		row := csvRow{columns: columns, record: record}
+
+		raw, err := row.toRaw()
+		if err != nil {
+			rejected = append(rejected, Rejection{ExternalID: row.get(csvID), Err: err})
+
+			continue
+		}
+
+		raws = append(raws, raw)
+	}
+
+	return raws, rejected, nil
+}
+
+// detectDelimiter picks between the comma and the semicolon spreadsheets
+// use in locales with a decimal comma, whichever the header has more of.
+func detectDelimiter(r *bufio.Reader) rune {
+	line, _ := r.Peek(r.Size())
+
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+
+	if bytes.Count(line, []byte{';'}) > bytes.Count(line, []byte{','}) {
+		return ';'
+	}
+
+	return ','
+}
+
+func (r csvRow) toRaw() (rawOffer, error) {
+	price, err := parsePrice(r.get(csvPrice))
+	if err != nil {
+		return rawOffer{}, fmt.Errorf("%w: %w", entity.ErrInvalidOffer, err)
+	}
+
+	oldPrice, err := parseOptionalPrice(r.get(csvOldPrice))
+	if err != nil {
+		return rawOffer{}, fmt.Errorf("%w: %w", entity.ErrInvalidOffer, err)
+	}
+
+	raw := rawOffer{
+		groupID: r.get(csvGroupID),
+		offer: entity.CatalogOffer{
+			ExternalID:  r.get(csvID),
+			Name:        r.get(csvName),
+			Brand:       optional(r.get(csvBrand)),
+			Description: optional(r.get(csvDescription)),
+			URL:         optional(r.get(csvURL)),
+			Price:       price,
+			OldPrice:    oldPrice,
+			Currency:    r.get(csvCurrency),
+			Category:    optional(r.get(csvCategory)),
+			Available:   parseAvailable(r.get(csvAvailable)),
+			Pictures: strings.FieldsFunc(r.get(csvPictures), func(c rune) bool {
+				return c == '|' || unicode.IsSpace(c)
+			}),
+		},
+	}
+
+	stock := parseStock(r.get(csvStock))
+	if stock != nil && *stock == 0 {
+		raw.offer.Available = false
+	}
+
+	if size := r.get(csvSize); size != "" {
+		raw.size = &entity.ProductSize{
+			Size:      size,
+			Available: raw.offer.Available,
+			Stock:     stock,
+		}
+	}
+
+	return raw, nil
+}
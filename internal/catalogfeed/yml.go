@@ -0,0 +1,187 @@
+package catalogfeed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const categoryPathSeparator = " > "
+
+//nolint:gochecknoglobals
+var sizeParams = []string{"размер", "size"}
+
+// ymlCatalog is the part of a Yandex Market YML document the catalog uses.
+type ymlCatalog struct {
+	XMLName xml.Name `xml:"yml_catalog"`
+	Shop    struct {
+		Categories []ymlCategory `xml:"categories>category"`
+		Offers     []ymlOffer    `xml:"offers>offer"`
+	} `xml:"shop"`
+}
+
+type ymlCategory struct {
+	ID       string `xml:"id,attr"`
+	ParentID string `xml:"parentId,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type ymlOffer struct {
+	ID          string     `xml:"id,attr"`
+	GroupID     string     `xml:"group_id,attr"`
+	Available   string     `xml:"available,attr"`
+	URL         string     `xml:"url"`
+	Price       string     `xml:"price"`
+	OldPrice    string     `xml:"oldprice"`
+	CurrencyID  string     `xml:"currencyId"`
+	CategoryID  string     `xml:"categoryId"`
+	Pictures    []string   `xml:"picture"`
+	Name        string     `xml:"name"`
+	TypePrefix  string     `xml:"typePrefix"`
+	Model       string     `xml:"model"`
+	Vendor      string     `xml:"vendor"`
+	Description string     `xml:"description"`
+	Count       string     `xml:"count"`
+	Params      []ymlParam `xml:"param"`
+}
+
+type ymlParam struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+func parseYML(r io.Reader) ([]rawOffer, []Rejection, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charsetReader
+
+	var catalog ymlCatalog
+
+	if err := decoder.Decode(&catalog); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidFeed, err)
+	}
+
+	paths := categoryPaths(catalog.Shop.Categories)
+
+	raws := make([]rawOffer, 0, len(catalog.Shop.Offers))
+
+	var rejected []Rejection
+
+	for _, o := range catalog.Shop.Offers {
+		raw, err := o.toRaw(paths)
+		if err != nil {
+			rejected = append(rejected, Rejection{ExternalID: o.ID, Err: err})
+
+			continue
+		}
+
+		raws = append(raws, raw)
+	}
+
+	return raws, rejected, nil
+}
+
+// charsetReader lets feeds exported by Russian shop software, which are
+// usually windows-1251 encoded, be read as they are.
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "windows-1251", "cp1251":
+		return charmap.Windows1251.NewDecoder().Reader(input), nil
+	case "utf-8", "utf8":
+		return input, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported encoding %q", entity.ErrInvalidFeed, label)
+	}
+}
+
+// categoryPaths maps category ids to their full path, root first.
+func categoryPaths(categories []ymlCategory) map[string]string {
+	byID := make(map[string]ymlCategory, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	paths := make(map[string]string, len(categories))
+
+	for _, c := range categories {
+		var names []string
+
+		seen := make(map[string]bool)
+
+		for current, ok := c, true; ok && !seen[current.ID]; current, ok = byID[current.ParentID] {
+			seen[current.ID] = true
+			names = append([]string{strings.TrimSpace(current.Name)}, names...)
+		}
+
+		paths[c.ID] = strings.Join(names, categoryPathSeparator)
+	}
+
+	return paths
+}
+
+func (o ymlOffer) toRaw(paths map[string]string) (rawOffer, error) {
+	price, err := parsePrice(o.Price)
+	if err != nil {
+		return rawOffer{}, fmt.Errorf("%w: %w", entity.ErrInvalidOffer, err)
+	}
+
+	oldPrice, err := parseOptionalPrice(o.OldPrice)
+	if err != nil {
+		return rawOffer{}, fmt.Errorf("%w: %w", entity.ErrInvalidOffer, err)
+	}
+
+	name := o.Name
+	if name == "" {
+		// vendor.model offers are named by their parts.
+		name = strings.Join(strings.Fields(o.TypePrefix+" "+o.Vendor+" "+o.Model), " ")
+	}
+
+	raw := rawOffer{
+		groupID: strings.TrimSpace(o.GroupID),
+		offer: entity.CatalogOffer{
+			ExternalID:  o.ID,
+			Name:        name,
+			Brand:       optional(o.Vendor),
+			Description: optional(o.Description),
+			URL:         optional(o.URL),
+			Price:       price,
+			OldPrice:    oldPrice,
+			Currency:    o.CurrencyID,
+			Available:   parseAvailable(o.Available),
+			Pictures:    o.Pictures,
+		},
+	}
+
+	if path, ok := paths[strings.TrimSpace(o.CategoryID)]; ok {
+		raw.offer.Category = optional(path)
+	}
+
+	stock := parseStock(o.Count)
+	if stock != nil && *stock == 0 {
+		raw.offer.Available = false
+	}
+
+	for _, param := range o.Params {
+		if !isSizeParam(param.Name) || strings.TrimSpace(param.Value) == "" {
+			continue
+		}
+
+		raw.size = &entity.ProductSize{
+			Size:      strings.TrimSpace(param.Value),
+			Available: raw.offer.Available,
+			Stock:     stock,
+		}
+
+		break
+	}
+
+	return raw, nil
+}
+
+func isSizeParam(name string) bool {
+	return slices.Contains(sizeParams, strings.ToLower(strings.TrimSpace(name)))
+}
@@ -0,0 +1,151 @@
+package cataloghandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type catalogService interface {
+	ListProducts(ctx context.Context, filter entity.ProductFilter) (entity.ProductPage, error)
+	GetProduct(ctx context.Context, productID entity.ProductID) (entity.Product, error)
+	GetProductImageURL(ctx context.Context, productID entity.ProductID, position int) (entity.FileURL, error)
+	ListImports(ctx context.Context, filter entity.FeedImportFilter) ([]entity.FeedImport, error)
+	AddWishlistItem(ctx context.Context, userID entity.UserID, item entity.WishlistItem) (entity.WishlistItem, error)
+	ListWishlist(ctx context.Context, userID entity.UserID, filter entity.WishlistFilter) ([]entity.WishlistItem, error)
+	UpdateWishlistItem(ctx context.Context, userID entity.UserID, productID entity.ProductID,
+		update entity.WishlistItemUpdate) (entity.WishlistItem, error)
+	DeleteWishlistItem(ctx context.Context, userID entity.UserID, productID entity.ProductID) error
+	GetPriceHistory(ctx context.Context, productID entity.ProductID) ([]entity.PricePoint, error)
+	ListPriceAlerts(ctx context.Context, userID entity.UserID, filter entity.PriceAlertFilter) ([]entity.PriceAlert, error)
+}
+
+type Handler struct {
+	catalogService catalogService
+}
+
+func New(catalogService catalogService) *Handler {
+	return &Handler{
+		catalogService: catalogService,
+	}
+}
+
+func (h *Handler) ListProducts(w http.ResponseWriter, r *http.Request) {
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	query := r.URL.Query()
+
+	filter := entity.ProductFilter{Page: page}
+
+	if feedID := query.Get("feed"); feedID != "" {
+		filter.FeedID = &feedID
+	}
+
+	if categoryID := query.Get("category"); categoryID != "" {
+		filter.CategoryID = &categoryID
+	}
+
+	if brand := query.Get("brand"); brand != "" {
+		filter.Brand = &brand
+	}
+
+	if value := query.Get("available"); value != "" {
+		available, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "invalid available parameter", http.StatusBadRequest)
+
+			return
+		}
+
+		filter.Available = &available
+	}
+
+	products, err := h.catalogService.ListProducts(r.Context(), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing products", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, products)
+}
+
+func (h *Handler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	product, err := h.catalogService.GetProduct(r.Context(), entity.ProductID(productID))
+	if err != nil {
+		common.ErrorResponse(w, "error getting product", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, product)
+}
+
+func (h *Handler) GetProductImageURL(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	position, err := strconv.Atoi(chi.URLParam(r, "position"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	imageURL, err := h.catalogService.GetProductImageURL(r.Context(), entity.ProductID(productID), position)
+	if err != nil {
+		common.ErrorResponse(w, "error getting product image url", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, imageURL)
+}
+
+func (h *Handler) ListImports(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter := entity.FeedImportFilter{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if feedID := r.URL.Query().Get("feed"); feedID != "" {
+		filter.FeedID = &feedID
+	}
+
+	imports, err := h.catalogService.ListImports(r.Context(), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing imports", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, imports)
+}
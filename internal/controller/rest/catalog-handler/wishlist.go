@@ -0,0 +1,208 @@
+package cataloghandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (h *Handler) AddWishlistItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error adding wishlist item", err)
+
+		return
+	}
+
+	var item entity.WishlistItem
+
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	addedItem, err := h.catalogService.AddWishlistItem(ctx, entity.UserID(userID), item)
+	if err != nil {
+		common.ErrorResponse(w, "error adding wishlist item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, addedItem)
+}
+
+func (h *Handler) ListWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing wishlist", err)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	items, err := h.catalogService.ListWishlist(ctx, entity.UserID(userID), entity.WishlistFilter{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		common.ErrorResponse(w, "error listing wishlist", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, items)
+}
+
+func (h *Handler) UpdateWishlistItem(w http.ResponseWriter, r *http.Request) {
+	userID, productID, err := parseWishlistPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error updating wishlist item", err)
+
+		return
+	}
+
+	var update entity.WishlistItemUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedItem, err := h.catalogService.UpdateWishlistItem(ctx, userID, productID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating wishlist item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedItem)
+}
+
+func (h *Handler) DeleteWishlistItem(w http.ResponseWriter, r *http.Request) {
+	userID, productID, err := parseWishlistPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error deleting wishlist item", err)
+
+		return
+	}
+
+	if err := h.catalogService.DeleteWishlistItem(ctx, userID, productID); err != nil {
+		common.ErrorResponse(w, "error deleting wishlist item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "wishlist item deleted successfully")
+}
+
+func (h *Handler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(chi.URLParam(r, "productId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	history, err := h.catalogService.GetPriceHistory(r.Context(), entity.ProductID(productID))
+	if err != nil {
+		common.ErrorResponse(w, "error getting price history", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, history)
+}
+
+func (h *Handler) ListPriceAlerts(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing price alerts", err)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	alerts, err := h.catalogService.ListPriceAlerts(ctx, entity.UserID(userID), entity.PriceAlertFilter{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		common.ErrorResponse(w, "error listing price alerts", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, alerts)
+}
+
+func parseWishlistPath(r *http.Request) (entity.UserID, entity.ProductID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.ProductID{}, err //nolint:wrapcheck
+	}
+
+	productID, err := uuid.Parse(chi.URLParam(r, "productId"))
+	if err != nil {
+		return entity.UserID{}, entity.ProductID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.ProductID(productID), nil
+}
@@ -1,23 +1,49 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/romanpitatelev/clothing-service/internal/entity"
 	"github.com/rs/zerolog/log"
 )
 
-const DefaultLimit = 25
+const (
+	DefaultLimit = 25
+	MaxLimit     = 100
+)
+
+var ErrInvalidPagination = errors.New("invalid pagination parameters")
 
+type validationErrorResponse struct {
+	Error  string              `json:"error"`
+	Fields []entity.FieldError `json:"fields"`
+}
+
+// ErrorResponse writes the error text as a JSON string. Validation errors are
+// written as an object listing every invalid field instead, internal errors
+// are logged and answered with a generic message.
 func ErrorResponse(w http.ResponseWriter, errorText string, err error) {
 	statusCode := getStatusCode(err)
 
-	errResp := fmt.Errorf("%s: %w", errorText, err).Error()
-	if statusCode == http.StatusInternalServerError {
+	var errResp any = fmt.Errorf("%s: %w", errorText, err).Error()
+
+	var validationErr *entity.ValidationError
+
+	switch {
+	case statusCode == http.StatusInternalServerError:
+		log.Error().Err(err).Msg(errorText)
+
 		errResp = http.StatusText(http.StatusInternalServerError)
+	case errors.As(err, &validationErr):
+		errResp = validationErrorResponse{
+			Error:  errorText + ": " + validationErr.Err.Error(),
+			Fields: validationErr.Fields,
+		}
 	}
 
 	response, err := json.Marshal(errResp)
@@ -41,21 +67,161 @@ func OkResponse(w http.ResponseWriter, status int, response any) {
 	}
 }
 
+// ParsePagination reads limit and offset query parameters, falling back to
+// DefaultLimit and capping the limit at MaxLimit.
+func ParsePagination(r *http.Request) (int, int, error) {
+	limit, offset := DefaultLimit, 0
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return 0, 0, ErrInvalidPagination
+		}
+
+		limit = min(parsed, MaxLimit)
+	}
+
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return 0, 0, ErrInvalidPagination
+		}
+
+		offset = parsed
+	}
+
+	return limit, offset, nil
+}
+
+// ParsePage reads the limit like ParsePagination does and the cursor a
+// previous page returned.
+func ParsePage(r *http.Request) (entity.Page, error) {
+	limit, _, err := ParsePagination(r)
+	if err != nil {
+		return entity.Page{}, err
+	}
+
+	return entity.Page{Limit: limit, Cursor: r.URL.Query().Get("cursor")}, nil
+}
+
+// GetUserInfo returns the caller identity JWTAuth stored in the context.
+func GetUserInfo(ctx context.Context) (entity.UserInfo, error) {
+	userInfo, ok := ctx.Value(entity.UserInfo{}).(entity.UserInfo)
+	if !ok {
+		return entity.UserInfo{}, entity.ErrInvalidToken
+	}
+
+	return userInfo, nil
+}
+
+// CheckOwner rejects requests where the caller acts on another user's resources.
+func CheckOwner(ctx context.Context, userID entity.UserID) error {
+	userInfo, err := GetUserInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userInfo.UserID != userID {
+		return entity.ErrForbidden
+	}
+
+	return nil
+}
+
 func getStatusCode(err error) int {
 	switch {
-	case errors.Is(err, entity.ErrUserNotFound):
+	case errors.Is(err, entity.ErrUserNotFound) ||
+		errors.Is(err, entity.ErrFileNotFound) ||
+		errors.Is(err, entity.ErrWardrobeItemNotFound) ||
+		errors.Is(err, entity.ErrCategoryNotFound) ||
+		errors.Is(err, entity.ErrCategoryAttributeNotFound) ||
+		errors.Is(err, entity.ErrOutfitNotFound) ||
+		errors.Is(err, entity.ErrWearLogEntryNotFound) ||
+		errors.Is(err, entity.ErrSizeProfileNotFound) ||
+		errors.Is(err, entity.ErrPostNotFound) ||
+		errors.Is(err, entity.ErrCommentNotFound) ||
+		errors.Is(err, entity.ErrCollectionNotFound) ||
+		errors.Is(err, entity.ErrReportNotFound) ||
+		errors.Is(err, entity.ErrProductNotFound) ||
+		errors.Is(err, entity.ErrProductImageNotFound) ||
+		errors.Is(err, entity.ErrWishlistItemNotFound) ||
+		errors.Is(err, entity.ErrCartItemNotFound) ||
+		errors.Is(err, entity.ErrOrderNotFound) ||
+		errors.Is(err, entity.ErrPaymentNotFound) ||
+		errors.Is(err, entity.ErrPromoCodeNotFound) ||
+		errors.Is(err, entity.ErrAddressNotFound) ||
+		errors.Is(err, entity.ErrListingNotFound) ||
+		errors.Is(err, entity.ErrListingOfferNotFound) ||
+		errors.Is(err, entity.ErrConversationNotFound) ||
+		errors.Is(err, entity.ErrBlockNotFound) ||
+		errors.Is(err, entity.ErrNotificationNotFound):
 		return http.StatusNotFound
-	case errors.Is(err, entity.ErrInvalidPhone):
+	case errors.Is(err, entity.ErrInvalidPhone) ||
+		errors.Is(err, entity.ErrInvalidWardrobeItem) ||
+		errors.Is(err, entity.ErrInvalidCategory) ||
+		errors.Is(err, entity.ErrInvalidOutfit) ||
+		errors.Is(err, entity.ErrInvalidSuggestionRequest) ||
+		errors.Is(err, entity.ErrInvalidWearLogEntry) ||
+		errors.Is(err, entity.ErrInvalidSearchQuery) ||
+		errors.Is(err, entity.ErrInvalidSizeProfile) ||
+		errors.Is(err, entity.ErrUnknownSize) ||
+		errors.Is(err, entity.ErrInvalidCursor) ||
+		errors.Is(err, entity.ErrInvalidPost) ||
+		errors.Is(err, entity.ErrInvalidFollow) ||
+		errors.Is(err, entity.ErrInvalidComment) ||
+		errors.Is(err, entity.ErrInvalidCollection) ||
+		errors.Is(err, entity.ErrInvalidReport) ||
+		errors.Is(err, entity.ErrInvalidModeration) ||
+		errors.Is(err, entity.ErrInvalidFeed) ||
+		errors.Is(err, entity.ErrInvalidWishlistItem) ||
+		errors.Is(err, entity.ErrInvalidCartItem) ||
+		errors.Is(err, entity.ErrInvalidOrder) ||
+		errors.Is(err, entity.ErrEmptyCart) ||
+		errors.Is(err, entity.ErrInvalidPayment) ||
+		errors.Is(err, entity.ErrInvalidPromoCode) ||
+		errors.Is(err, entity.ErrInvalidAddress) ||
+		errors.Is(err, entity.ErrInvalidListing) ||
+		errors.Is(err, entity.ErrInvalidListingOffer) ||
+		errors.Is(err, entity.ErrInvalidConversation) ||
+		errors.Is(err, entity.ErrInvalidMessage) ||
+		errors.Is(err, entity.ErrInvalidBlock) ||
+		errors.Is(err, entity.ErrInvalidNotification) ||
+		errors.Is(err, entity.ErrInvalidNotificationSettings):
 		return http.StatusBadRequest
-	case errors.Is(err, entity.ErrInvalidOTP):
+	case errors.Is(err, entity.ErrInvalidOTP) ||
+		errors.Is(err, entity.ErrForbidden) ||
+		errors.Is(err, entity.ErrUserSuspended) ||
+		errors.Is(err, entity.ErrUserBlocked):
 		return http.StatusForbidden
-	case errors.Is(err, entity.ErrDuplicateContact):
+	case errors.Is(err, entity.ErrDuplicateContact) ||
+		errors.Is(err, entity.ErrDuplicateCategory) ||
+		errors.Is(err, entity.ErrCategoryInUse) ||
+		errors.Is(err, entity.ErrDuplicateCollection) ||
+		errors.Is(err, entity.ErrDuplicateReport) ||
+		errors.Is(err, entity.ErrDuplicateWishlistItem) ||
+		errors.Is(err, entity.ErrDuplicateCartItem) ||
+		errors.Is(err, entity.ErrOutOfStock) ||
+		errors.Is(err, entity.ErrInvalidOrderTransition) ||
+		errors.Is(err, entity.ErrDuplicatePromoCode) ||
+		errors.Is(err, entity.ErrDuplicateListing) ||
+		errors.Is(err, entity.ErrInvalidListingTransition) ||
+		errors.Is(err, entity.ErrDuplicateListingOffer):
 		return http.StatusConflict
+	case errors.Is(err, entity.ErrIncompleteSizeProfile) ||
+		errors.Is(err, entity.ErrPromoCodeNotApplicable):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, entity.ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, entity.ErrUnsupportedContentType):
+		return http.StatusUnsupportedMediaType
 	case errors.Is(err, entity.ErrTokenExpired) ||
 		errors.Is(err, entity.ErrInvalidToken) ||
 		errors.Is(err, entity.ErrInvalidUUIDFormat) ||
-		errors.Is(err, entity.ErrInvalidSigningMethod):
+		errors.Is(err, entity.ErrInvalidSigningMethod) ||
+		errors.Is(err, entity.ErrInvalidSignature):
 		return http.StatusUnauthorized
+	case errors.Is(err, entity.ErrPaymentFailed):
+		return http.StatusBadGateway
 	default:
 		return http.StatusInternalServerError
 	}
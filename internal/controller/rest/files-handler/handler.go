@@ -0,0 +1,208 @@
+package fileshandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+type filesService interface {
+	UploadFile(ctx context.Context, userID entity.UserID, data []byte) (entity.File, error)
+	GetFile(ctx context.Context, userID entity.UserID, fileID entity.FileID) (entity.File, error)
+	DownloadFile(ctx context.Context, userID entity.UserID, fileID entity.FileID) (io.ReadCloser, entity.File, error)
+	GetFileURL(ctx context.Context, userID entity.UserID, fileID entity.FileID) (entity.FileURL, error)
+	DeleteFile(ctx context.Context, userID entity.UserID, fileID entity.FileID) error
+}
+
+type Config struct {
+	MaxUploadSize int64
+}
+
+type Handler struct {
+	cfg          Config
+	filesService filesService
+}
+
+func New(cfg Config, filesService filesService) *Handler {
+	return &Handler{
+		cfg:          cfg,
+		filesService: filesService,
+	}
+}
+
+func (h *Handler) UploadFile(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error uploading file", err)
+
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.cfg.MaxUploadSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			common.ErrorResponse(w, "error uploading file", entity.ErrFileTooLarge)
+
+			return
+		}
+
+		http.Error(w, "error reading request body", http.StatusBadRequest)
+
+		return
+	}
+
+	file, err := h.filesService.UploadFile(ctx, entity.UserID(userID), data)
+	if err != nil {
+		common.ErrorResponse(w, "error uploading file", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, file)
+}
+
+func (h *Handler) GetFile(w http.ResponseWriter, r *http.Request) {
+	userID, fileID, err := parseFilePath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting file", err)
+
+		return
+	}
+
+	file, err := h.filesService.GetFile(ctx, userID, fileID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting file", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, file)
+}
+
+func (h *Handler) DownloadFile(w http.ResponseWriter, r *http.Request) {
+	userID, fileID, err := parseFilePath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error downloading file", err)
+
+		return
+	}
+
+	body, file, err := h.filesService.DownloadFile(ctx, userID, fileID)
+	if err != nil {
+		common.ErrorResponse(w, "error downloading file", err)
+
+		return
+	}
+
+	defer func() {
+		if err := body.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close file body")
+		}
+	}()
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, body); err != nil {
+		log.Warn().Err(err).Msg("error writing file")
+	}
+}
+
+func (h *Handler) GetFileURL(w http.ResponseWriter, r *http.Request) {
+	userID, fileID, err := parseFilePath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting file url", err)
+
+		return
+	}
+
+	fileURL, err := h.filesService.GetFileURL(ctx, userID, fileID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting file url", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, fileURL)
+}
+
+func (h *Handler) DeleteFile(w http.ResponseWriter, r *http.Request) {
+	userID, fileID, err := parseFilePath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error deleting file", err)
+
+		return
+	}
+
+	if err := h.filesService.DeleteFile(ctx, userID, fileID); err != nil {
+		common.ErrorResponse(w, "error deleting file", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "file deleted successfully")
+}
+
+func parseFilePath(r *http.Request) (entity.UserID, entity.FileID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.FileID{}, err //nolint:wrapcheck
+	}
+
+	fileID, err := uuid.Parse(chi.URLParam(r, "fileId"))
+	if err != nil {
+		return entity.UserID{}, entity.FileID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.FileID(fileID), nil
+}
@@ -14,7 +14,7 @@ import (
 type tokenService interface {
 	ValidateUser(ctx context.Context, user entity.ValidateUserRequest) (entity.Tokens, error)
 	RefreshToken(ctx context.Context, tokens entity.Tokens) (entity.Tokens, error)
-	ParseToken(tokenStr string) (entity.Claims, error)
+	Authenticate(ctx context.Context, tokenStr string) (entity.Claims, error)
 }
 
 type Handler struct {
@@ -3,9 +3,11 @@ package iamhandler
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
 	"github.com/romanpitatelev/clothing-service/internal/entity"
 )
 
@@ -37,9 +39,15 @@ func (h *Handler) JWTAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		claims, err := h.tokenService.ParseToken(headerParts[1])
+		claims, err := h.tokenService.Authenticate(r.Context(), headerParts[1])
 		if err != nil {
-			w.WriteHeader(http.StatusUnauthorized)
+			if errors.Is(err, entity.ErrInvalidToken) || errors.Is(err, entity.ErrTokenExpired) {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			common.ErrorResponse(w, "error authenticating user", err)
 
 			return
 		}
@@ -47,7 +55,7 @@ func (h *Handler) JWTAuth(next http.Handler) http.Handler {
 		userInfo := entity.UserInfo{
 			UserID: claims.UserID,
 			Email:  claims.Email,
-			Role:   claims.Phone,
+			Role:   claims.Role,
 		}
 
 		r = r.WithContext(context.WithValue(r.Context(), entity.UserInfo{}, userInfo))
@@ -55,3 +63,24 @@ func (h *Handler) JWTAuth(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// RequireAdmin lets through only callers whose token carries the admin role.
+// It has to be mounted after JWTAuth.
+func (h *Handler) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userInfo, ok := r.Context().Value(entity.UserInfo{}).(entity.UserInfo)
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if userInfo.Role != entity.RoleAdmin {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
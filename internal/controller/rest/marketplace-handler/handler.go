@@ -0,0 +1,359 @@
+package marketplacehandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+var errInvalidPrice = errors.New("invalid price parameter")
+
+type marketplaceService interface {
+	CreateListing(ctx context.Context, sellerID entity.UserID, listing entity.Listing) (entity.Listing, error)
+	GetListing(ctx context.Context, viewerID entity.UserID, listingID entity.ListingID) (entity.Listing, error)
+	BrowseListings(ctx context.Context, filter entity.ListingFilter) ([]entity.Listing, error)
+	SearchListings(ctx context.Context, filter entity.ListingFilter) ([]entity.Listing, error)
+	ListSellerListings(ctx context.Context, sellerID entity.UserID, filter entity.ListingFilter) ([]entity.Listing, error)
+	UpdateListing(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID,
+		update entity.ListingUpdate) (entity.Listing, error)
+	PublishListing(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID) (entity.Listing, error)
+	WithdrawListing(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID) (entity.Listing, error)
+	MarkListingSold(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID) (entity.Listing, error)
+	GetListingPhotoURL(ctx context.Context, viewerID entity.UserID, listingID entity.ListingID,
+		position int) (entity.FileURL, error)
+	CreateOffer(ctx context.Context, buyerID entity.UserID, listingID entity.ListingID,
+		offer entity.ListingOffer) (entity.ListingOffer, error)
+	ListListingOffers(ctx context.Context, viewerID entity.UserID, listingID entity.ListingID,
+		filter entity.ListingOfferFilter) ([]entity.ListingOffer, error)
+	ListBuyerOffers(ctx context.Context, buyerID entity.UserID, filter entity.ListingOfferFilter) ([]entity.ListingOffer, error)
+	AcceptOffer(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID,
+		offerID entity.ListingOfferID) (entity.ListingOffer, error)
+	DeclineOffer(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID,
+		offerID entity.ListingOfferID) (entity.ListingOffer, error)
+}
+
+type Handler struct {
+	marketplaceService marketplaceService
+}
+
+func New(marketplaceService marketplaceService) *Handler {
+	return &Handler{
+		marketplaceService: marketplaceService,
+	}
+}
+
+func (h *Handler) BrowseListings(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListingFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	listings, err := h.marketplaceService.BrowseListings(r.Context(), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing listings", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, listings)
+}
+
+func (h *Handler) SearchListings(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListingFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter.Text = r.URL.Query().Get("q")
+
+	listings, err := h.marketplaceService.SearchListings(r.Context(), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error searching listings", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, listings)
+}
+
+func (h *Handler) GetListing(w http.ResponseWriter, r *http.Request) {
+	listingID, err := uuid.Parse(chi.URLParam(r, "listingId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error getting listing", err)
+
+		return
+	}
+
+	listing, err := h.marketplaceService.GetListing(ctx, userInfo.UserID, entity.ListingID(listingID))
+	if err != nil {
+		common.ErrorResponse(w, "error getting listing", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, listing)
+}
+
+func (h *Handler) GetListingPhotoURL(w http.ResponseWriter, r *http.Request) {
+	listingID, err := uuid.Parse(chi.URLParam(r, "listingId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	position, err := strconv.Atoi(chi.URLParam(r, "position"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error getting listing photo url", err)
+
+		return
+	}
+
+	photoURL, err := h.marketplaceService.GetListingPhotoURL(ctx, userInfo.UserID, entity.ListingID(listingID), position)
+	if err != nil {
+		common.ErrorResponse(w, "error getting listing photo url", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, photoURL)
+}
+
+func (h *Handler) CreateListing(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error creating listing", err)
+
+		return
+	}
+
+	var listing entity.Listing
+
+	if err := json.NewDecoder(r.Body).Decode(&listing); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdListing, err := h.marketplaceService.CreateListing(ctx, entity.UserID(userID), listing)
+	if err != nil {
+		common.ErrorResponse(w, "error creating listing", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdListing)
+}
+
+func (h *Handler) ListSellerListings(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing listings", err)
+
+		return
+	}
+
+	filter, err := parseListingFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	for _, status := range r.URL.Query()["status"] {
+		filter.Statuses = append(filter.Statuses, entity.ListingStatus(status))
+	}
+
+	listings, err := h.marketplaceService.ListSellerListings(ctx, entity.UserID(userID), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing listings", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, listings)
+}
+
+func (h *Handler) UpdateListing(w http.ResponseWriter, r *http.Request) {
+	userID, listingID, err := parseListingPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error updating listing", err)
+
+		return
+	}
+
+	var update entity.ListingUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	listing, err := h.marketplaceService.UpdateListing(ctx, userID, listingID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating listing", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, listing)
+}
+
+func (h *Handler) PublishListing(w http.ResponseWriter, r *http.Request) {
+	h.transitionListing(w, r, "error publishing listing", h.marketplaceService.PublishListing)
+}
+
+func (h *Handler) WithdrawListing(w http.ResponseWriter, r *http.Request) {
+	h.transitionListing(w, r, "error withdrawing listing", h.marketplaceService.WithdrawListing)
+}
+
+func (h *Handler) MarkListingSold(w http.ResponseWriter, r *http.Request) {
+	h.transitionListing(w, r, "error marking listing sold", h.marketplaceService.MarkListingSold)
+}
+
+func (h *Handler) transitionListing(
+	w http.ResponseWriter,
+	r *http.Request,
+	errorText string,
+	transition func(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID) (entity.Listing, error),
+) {
+	userID, listingID, err := parseListingPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	listing, err := transition(ctx, userID, listingID)
+	if err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, listing)
+}
+
+func parseListingPath(r *http.Request) (entity.UserID, entity.ListingID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.ListingID{}, err //nolint:wrapcheck
+	}
+
+	listingID, err := uuid.Parse(chi.URLParam(r, "listingId"))
+	if err != nil {
+		return entity.UserID{}, entity.ListingID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.ListingID(listingID), nil
+}
+
+func parseListingFilter(r *http.Request) (entity.ListingFilter, error) {
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		return entity.ListingFilter{}, err //nolint:wrapcheck
+	}
+
+	query := r.URL.Query()
+
+	filter := entity.ListingFilter{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	for _, field := range []struct {
+		name  string
+		value **string
+	}{
+		{"category", &filter.Category},
+		{"brand", &filter.Brand},
+		{"size", &filter.Size},
+	} {
+		if value := query.Get(field.name); value != "" {
+			*field.value = &value
+		}
+	}
+
+	if value := query.Get("condition"); value != "" {
+		condition := entity.Condition(value)
+		filter.Condition = &condition
+	}
+
+	for name, price := range map[string]**int64{
+		"minPrice": &filter.MinPrice,
+		"maxPrice": &filter.MaxPrice,
+	} {
+		if value := query.Get(name); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return entity.ListingFilter{}, errInvalidPrice
+			}
+
+			*price = &parsed
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,163 @@
+package marketplacehandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (h *Handler) CreateOffer(w http.ResponseWriter, r *http.Request) {
+	listingID, err := uuid.Parse(chi.URLParam(r, "listingId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error creating offer", err)
+
+		return
+	}
+
+	var offer entity.ListingOffer
+
+	if err := json.NewDecoder(r.Body).Decode(&offer); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdOffer, err := h.marketplaceService.CreateOffer(ctx, userInfo.UserID, entity.ListingID(listingID), offer)
+	if err != nil {
+		common.ErrorResponse(w, "error creating offer", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdOffer)
+}
+
+func (h *Handler) ListListingOffers(w http.ResponseWriter, r *http.Request) {
+	listingID, err := uuid.Parse(chi.URLParam(r, "listingId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error listing offers", err)
+
+		return
+	}
+
+	offers, err := h.marketplaceService.ListListingOffers(ctx, userInfo.UserID, entity.ListingID(listingID),
+		entity.ListingOfferFilter{Limit: limit, Offset: offset})
+	if err != nil {
+		common.ErrorResponse(w, "error listing offers", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, offers)
+}
+
+func (h *Handler) ListBuyerOffers(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing offers", err)
+
+		return
+	}
+
+	offers, err := h.marketplaceService.ListBuyerOffers(ctx, entity.UserID(userID),
+		entity.ListingOfferFilter{Limit: limit, Offset: offset})
+	if err != nil {
+		common.ErrorResponse(w, "error listing offers", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, offers)
+}
+
+func (h *Handler) AcceptOffer(w http.ResponseWriter, r *http.Request) {
+	h.respondToOffer(w, r, "error accepting offer", h.marketplaceService.AcceptOffer)
+}
+
+func (h *Handler) DeclineOffer(w http.ResponseWriter, r *http.Request) {
+	h.respondToOffer(w, r, "error declining offer", h.marketplaceService.DeclineOffer)
+}
+
+func (h *Handler) respondToOffer(
+	w http.ResponseWriter,
+	r *http.Request,
+	errorText string,
+	respond func(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID,
+		offerID entity.ListingOfferID) (entity.ListingOffer, error),
+) {
+	userID, listingID, err := parseListingPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	offerID, err := uuid.Parse(chi.URLParam(r, "offerId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	offer, err := respond(ctx, userID, listingID, entity.ListingOfferID(offerID))
+	if err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, offer)
+}
@@ -0,0 +1,103 @@
+package messaginghandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (h *Handler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error blocking user", err)
+
+		return
+	}
+
+	var block entity.Block
+
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdBlock, err := h.messagingService.BlockUser(ctx, entity.UserID(userID), block.BlockedID)
+	if err != nil {
+		common.ErrorResponse(w, "error blocking user", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdBlock)
+}
+
+func (h *Handler) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing blocks", err)
+
+		return
+	}
+
+	blocks, err := h.messagingService.ListBlocks(ctx, entity.UserID(userID))
+	if err != nil {
+		common.ErrorResponse(w, "error listing blocks", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, blocks)
+}
+
+func (h *Handler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	blockedID, err := uuid.Parse(chi.URLParam(r, "blockedId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error unblocking user", err)
+
+		return
+	}
+
+	if err := h.messagingService.UnblockUser(ctx, entity.UserID(userID), entity.UserID(blockedID)); err != nil {
+		common.ErrorResponse(w, "error unblocking user", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "user unblocked successfully")
+}
@@ -0,0 +1,290 @@
+package messaginghandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type messagingService interface {
+	StartConversation(ctx context.Context, buyerID entity.UserID, listingID entity.ListingID) (entity.Conversation, error)
+	GetConversation(ctx context.Context, userID entity.UserID, conversationID entity.ConversationID) (entity.Conversation, error)
+	ListConversations(ctx context.Context, userID entity.UserID, filter entity.ConversationFilter) ([]entity.Conversation, error)
+	MarkConversationRead(ctx context.Context, userID entity.UserID,
+		conversationID entity.ConversationID) (entity.Conversation, error)
+	GetUnreadCount(ctx context.Context, userID entity.UserID) (entity.UnreadCount, error)
+	SendMessage(ctx context.Context, senderID entity.UserID, conversationID entity.ConversationID,
+		message entity.Message) (entity.Message, error)
+	ListMessages(ctx context.Context, userID entity.UserID, conversationID entity.ConversationID,
+		page entity.Page) (entity.MessagePage, error)
+	GetMessagePhotoURL(ctx context.Context, userID entity.UserID, conversationID entity.ConversationID,
+		messageID entity.MessageID, position int) (entity.FileURL, error)
+	BlockUser(ctx context.Context, blockerID, blockedID entity.UserID) (entity.Block, error)
+	UnblockUser(ctx context.Context, blockerID, blockedID entity.UserID) error
+	ListBlocks(ctx context.Context, blockerID entity.UserID) ([]entity.Block, error)
+}
+
+type Handler struct {
+	messagingService messagingService
+}
+
+func New(messagingService messagingService) *Handler {
+	return &Handler{
+		messagingService: messagingService,
+	}
+}
+
+func (h *Handler) StartConversation(w http.ResponseWriter, r *http.Request) {
+	listingID, err := uuid.Parse(chi.URLParam(r, "listingId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error starting conversation", err)
+
+		return
+	}
+
+	conversation, err := h.messagingService.StartConversation(ctx, userInfo.UserID, entity.ListingID(listingID))
+	if err != nil {
+		common.ErrorResponse(w, "error starting conversation", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, conversation)
+}
+
+func (h *Handler) ListConversations(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing conversations", err)
+
+		return
+	}
+
+	conversations, err := h.messagingService.ListConversations(ctx, entity.UserID(userID), entity.ConversationFilter{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		common.ErrorResponse(w, "error listing conversations", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, conversations)
+}
+
+func (h *Handler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error getting unread count", err)
+
+		return
+	}
+
+	count, err := h.messagingService.GetUnreadCount(ctx, entity.UserID(userID))
+	if err != nil {
+		common.ErrorResponse(w, "error getting unread count", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, count)
+}
+
+func (h *Handler) GetConversation(w http.ResponseWriter, r *http.Request) {
+	h.conversation(w, r, "error getting conversation", h.messagingService.GetConversation)
+}
+
+func (h *Handler) MarkConversationRead(w http.ResponseWriter, r *http.Request) {
+	h.conversation(w, r, "error marking conversation read", h.messagingService.MarkConversationRead)
+}
+
+func (h *Handler) conversation(
+	w http.ResponseWriter,
+	r *http.Request,
+	errorText string,
+	get func(ctx context.Context, userID entity.UserID, conversationID entity.ConversationID) (entity.Conversation, error),
+) {
+	userID, conversationID, err := parseConversationPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	conversation, err := get(ctx, userID, conversationID)
+	if err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, conversation)
+}
+
+func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
+	userID, conversationID, err := parseConversationPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error sending message", err)
+
+		return
+	}
+
+	var message entity.Message
+
+	if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	sentMessage, err := h.messagingService.SendMessage(ctx, userID, conversationID, message)
+	if err != nil {
+		common.ErrorResponse(w, "error sending message", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, sentMessage)
+}
+
+func (h *Handler) ListMessages(w http.ResponseWriter, r *http.Request) {
+	userID, conversationID, err := parseConversationPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error listing messages", err)
+
+		return
+	}
+
+	messages, err := h.messagingService.ListMessages(ctx, userID, conversationID, page)
+	if err != nil {
+		common.ErrorResponse(w, "error listing messages", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, messages)
+}
+
+func (h *Handler) GetMessagePhotoURL(w http.ResponseWriter, r *http.Request) {
+	userID, conversationID, err := parseConversationPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	messageID, err := uuid.Parse(chi.URLParam(r, "messageId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	position, err := strconv.Atoi(chi.URLParam(r, "position"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting message photo url", err)
+
+		return
+	}
+
+	photoURL, err := h.messagingService.GetMessagePhotoURL(ctx, userID, conversationID, entity.MessageID(messageID), position)
+	if err != nil {
+		common.ErrorResponse(w, "error getting message photo url", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, photoURL)
+}
+
+func parseConversationPath(r *http.Request) (entity.UserID, entity.ConversationID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.ConversationID{}, err //nolint:wrapcheck
+	}
+
+	conversationID, err := uuid.Parse(chi.URLParam(r, "conversationId"))
+	if err != nil {
+		return entity.UserID{}, entity.ConversationID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.ConversationID(conversationID), nil
+}
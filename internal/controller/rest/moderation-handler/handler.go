@@ -0,0 +1,231 @@
+package moderationhandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type moderationService interface {
+	CreateReport(ctx context.Context, reporterID entity.UserID, report entity.Report) (entity.Report, error)
+	ListReports(ctx context.Context, filter entity.ReportFilter) (entity.ReportPage, error)
+	DismissReport(ctx context.Context, moderatorID entity.UserID, reportID entity.ReportID,
+		request entity.ModerationRequest) (entity.Report, error)
+	HidePost(ctx context.Context, moderatorID entity.UserID, postID entity.PostID, request entity.ModerationRequest) error
+	HideComment(ctx context.Context, moderatorID entity.UserID, commentID entity.CommentID, request entity.ModerationRequest) error
+	SuspendUser(ctx context.Context, moderatorID, userID entity.UserID, request entity.ModerationRequest) error
+	UnsuspendUser(ctx context.Context, moderatorID, userID entity.UserID, request entity.ModerationRequest) error
+	ListAuditLog(ctx context.Context, page entity.Page) (entity.AuditLogPage, error)
+}
+
+type Handler struct {
+	moderationService moderationService
+}
+
+func New(moderationService moderationService) *Handler {
+	return &Handler{
+		moderationService: moderationService,
+	}
+}
+
+func (h *Handler) CreateReport(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error creating report", err)
+
+		return
+	}
+
+	var report entity.Report
+
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdReport, err := h.moderationService.CreateReport(ctx, entity.UserID(userID), report)
+	if err != nil {
+		common.ErrorResponse(w, "error creating report", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdReport)
+}
+
+func (h *Handler) ListReports(w http.ResponseWriter, r *http.Request) {
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	query := r.URL.Query()
+
+	filter := entity.ReportFilter{
+		Status: entity.ReportStatus(query.Get("status")),
+		Page:   page,
+	}
+
+	if targetType := query.Get("targetType"); targetType != "" {
+		filter.TargetType = (*entity.ReportTargetType)(&targetType)
+	}
+
+	reports, err := h.moderationService.ListReports(r.Context(), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing reports", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, reports)
+}
+
+func (h *Handler) DismissReport(w http.ResponseWriter, r *http.Request) {
+	reportID, err := uuid.Parse(chi.URLParam(r, "reportId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error dismissing report", err)
+
+		return
+	}
+
+	request, err := parseModerationRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	report, err := h.moderationService.DismissReport(ctx, userInfo.UserID, entity.ReportID(reportID), request)
+	if err != nil {
+		common.ErrorResponse(w, "error dismissing report", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, report)
+}
+
+func (h *Handler) HidePost(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "postId", "error hiding post", "post hidden successfully",
+		func(ctx context.Context, moderatorID entity.UserID, targetID uuid.UUID, request entity.ModerationRequest) error {
+			return h.moderationService.HidePost(ctx, moderatorID, entity.PostID(targetID), request)
+		})
+}
+
+func (h *Handler) HideComment(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "commentId", "error hiding comment", "comment hidden successfully",
+		func(ctx context.Context, moderatorID entity.UserID, targetID uuid.UUID, request entity.ModerationRequest) error {
+			return h.moderationService.HideComment(ctx, moderatorID, entity.CommentID(targetID), request)
+		})
+}
+
+func (h *Handler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "userId", "error suspending user", "user suspended successfully",
+		func(ctx context.Context, moderatorID entity.UserID, targetID uuid.UUID, request entity.ModerationRequest) error {
+			return h.moderationService.SuspendUser(ctx, moderatorID, entity.UserID(targetID), request)
+		})
+}
+
+func (h *Handler) UnsuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "userId", "error unsuspending user", "user unsuspended successfully",
+		func(ctx context.Context, moderatorID entity.UserID, targetID uuid.UUID, request entity.ModerationRequest) error {
+			return h.moderationService.UnsuspendUser(ctx, moderatorID, entity.UserID(targetID), request)
+		})
+}
+
+func (h *Handler) moderate(
+	w http.ResponseWriter,
+	r *http.Request,
+	param, errorText, okText string,
+	act func(ctx context.Context, moderatorID entity.UserID, targetID uuid.UUID, request entity.ModerationRequest) error,
+) {
+	targetID, err := uuid.Parse(chi.URLParam(r, param))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	request, err := parseModerationRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if err := act(ctx, userInfo.UserID, targetID, request); err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, okText)
+}
+
+func (h *Handler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	entries, err := h.moderationService.ListAuditLog(r.Context(), page)
+	if err != nil {
+		common.ErrorResponse(w, "error listing audit log", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, entries)
+}
+
+var errDecodeBody = errors.New("error decoding request body")
+
+// parseModerationRequest reads the note of a moderation action. The body is
+// optional.
+func parseModerationRequest(r *http.Request) (entity.ModerationRequest, error) {
+	var request entity.ModerationRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		return entity.ModerationRequest{}, errDecodeBody
+	}
+
+	return request, nil
+}
@@ -0,0 +1,200 @@
+package notificationshandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type notificationsService interface {
+	ListNotifications(ctx context.Context, userID entity.UserID, filter entity.NotificationFilter) ([]entity.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID entity.UserID,
+		notificationID entity.NotificationID) (entity.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context, userID entity.UserID) error
+	GetNotificationSettings(ctx context.Context, userID entity.UserID) (entity.NotificationSettings, error)
+	UpdateNotificationSettings(ctx context.Context, userID entity.UserID,
+		settings entity.NotificationSettings) (entity.NotificationSettings, error)
+}
+
+type Handler struct {
+	notificationsService notificationsService
+}
+
+func New(notificationsService notificationsService) *Handler {
+	return &Handler{
+		notificationsService: notificationsService,
+	}
+}
+
+func (h *Handler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter := entity.NotificationFilter{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if value := r.URL.Query().Get("unread"); value != "" {
+		unread, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "invalid unread parameter", http.StatusBadRequest)
+
+			return
+		}
+
+		filter.Unread = unread
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing notifications", err)
+
+		return
+	}
+
+	notifications, err := h.notificationsService.ListNotifications(ctx, entity.UserID(userID), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing notifications", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, notifications)
+}
+
+func (h *Handler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	notificationID, err := uuid.Parse(chi.URLParam(r, "notificationId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error marking notification read", err)
+
+		return
+	}
+
+	notification, err := h.notificationsService.MarkNotificationRead(ctx, entity.UserID(userID), entity.NotificationID(notificationID))
+	if err != nil {
+		common.ErrorResponse(w, "error marking notification read", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, notification)
+}
+
+func (h *Handler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error marking notifications read", err)
+
+		return
+	}
+
+	if err := h.notificationsService.MarkAllNotificationsRead(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error marking notifications read", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "notifications marked read successfully")
+}
+
+func (h *Handler) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error getting notification settings", err)
+
+		return
+	}
+
+	settings, err := h.notificationsService.GetNotificationSettings(ctx, entity.UserID(userID))
+	if err != nil {
+		common.ErrorResponse(w, "error getting notification settings", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, settings)
+}
+
+func (h *Handler) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error updating notification settings", err)
+
+		return
+	}
+
+	var settings entity.NotificationSettings
+
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedSettings, err := h.notificationsService.UpdateNotificationSettings(ctx, entity.UserID(userID), settings)
+	if err != nil {
+		common.ErrorResponse(w, "error updating notification settings", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedSettings)
+}
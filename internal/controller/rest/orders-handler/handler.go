@@ -0,0 +1,353 @@
+package ordershandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type ordersService interface {
+	AddCartItem(ctx context.Context, userID entity.UserID, item entity.CartItem) (entity.CartItem, error)
+	GetCart(ctx context.Context, userID entity.UserID, promoCode *string) (entity.Cart, error)
+	UpdateCartItem(ctx context.Context, userID entity.UserID, itemID entity.CartItemID,
+		update entity.CartItemUpdate) (entity.CartItem, error)
+	DeleteCartItem(ctx context.Context, userID entity.UserID, itemID entity.CartItemID) error
+	Checkout(ctx context.Context, userID entity.UserID, request entity.CheckoutRequest) (entity.Order, error)
+	GetOrder(ctx context.Context, userID entity.UserID, orderID entity.OrderID) (entity.Order, error)
+	ListOrders(ctx context.Context, userID entity.UserID, filter entity.OrderFilter) ([]entity.Order, error)
+	CancelOrder(ctx context.Context, userID entity.UserID, orderID entity.OrderID) (entity.Order, error)
+	UpdateOrderStatus(ctx context.Context, orderID entity.OrderID, update entity.OrderStatusUpdate) (entity.Order, error)
+	PayOrder(ctx context.Context, userID entity.UserID, orderID entity.OrderID) (entity.Payment, error)
+	ListPayments(ctx context.Context, userID entity.UserID, orderID entity.OrderID) ([]entity.Payment, error)
+	RefundOrder(ctx context.Context, orderID entity.OrderID) (entity.Payment, error)
+	HandleWebhook(ctx context.Context, body []byte, signature string) error
+	CreatePromoCode(ctx context.Context, promo entity.PromoCode) (entity.PromoCode, error)
+	GetPromoCode(ctx context.Context, promoID entity.PromoCodeID) (entity.PromoCode, error)
+	ListPromoCodes(ctx context.Context, filter entity.PromoCodeFilter) ([]entity.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, promoID entity.PromoCodeID, update entity.PromoCodeUpdate) (entity.PromoCode, error)
+}
+
+type Handler struct {
+	ordersService ordersService
+}
+
+func New(ordersService ordersService) *Handler {
+	return &Handler{
+		ordersService: ordersService,
+	}
+}
+
+func (h *Handler) GetCart(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error getting cart", err)
+
+		return
+	}
+
+	var promoCode *string
+
+	if code := r.URL.Query().Get("promoCode"); code != "" {
+		promoCode = &code
+	}
+
+	cart, err := h.ordersService.GetCart(ctx, entity.UserID(userID), promoCode)
+	if err != nil {
+		common.ErrorResponse(w, "error getting cart", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, cart)
+}
+
+func (h *Handler) AddCartItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error adding cart item", err)
+
+		return
+	}
+
+	var item entity.CartItem
+
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	addedItem, err := h.ordersService.AddCartItem(ctx, entity.UserID(userID), item)
+	if err != nil {
+		common.ErrorResponse(w, "error adding cart item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, addedItem)
+}
+
+func (h *Handler) UpdateCartItem(w http.ResponseWriter, r *http.Request) {
+	userID, itemID, err := parseCartItemPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error updating cart item", err)
+
+		return
+	}
+
+	var update entity.CartItemUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedItem, err := h.ordersService.UpdateCartItem(ctx, userID, itemID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating cart item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedItem)
+}
+
+func (h *Handler) DeleteCartItem(w http.ResponseWriter, r *http.Request) {
+	userID, itemID, err := parseCartItemPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error deleting cart item", err)
+
+		return
+	}
+
+	if err := h.ordersService.DeleteCartItem(ctx, userID, itemID); err != nil {
+		common.ErrorResponse(w, "error deleting cart item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "cart item deleted successfully")
+}
+
+func (h *Handler) Checkout(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error checking out", err)
+
+		return
+	}
+
+	var request entity.CheckoutRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	order, err := h.ordersService.Checkout(ctx, entity.UserID(userID), request)
+	if err != nil {
+		common.ErrorResponse(w, "error checking out", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, order)
+}
+
+func (h *Handler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing orders", err)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter := entity.OrderFilter{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		orderStatus := entity.OrderStatus(status)
+		filter.Status = &orderStatus
+	}
+
+	orders, err := h.ordersService.ListOrders(ctx, entity.UserID(userID), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing orders", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, orders)
+}
+
+func (h *Handler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	userID, orderID, err := parseOrderPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting order", err)
+
+		return
+	}
+
+	order, err := h.ordersService.GetOrder(ctx, userID, orderID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting order", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, order)
+}
+
+func (h *Handler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	userID, orderID, err := parseOrderPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error cancelling order", err)
+
+		return
+	}
+
+	order, err := h.ordersService.CancelOrder(ctx, userID, orderID)
+	if err != nil {
+		common.ErrorResponse(w, "error cancelling order", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, order)
+}
+
+func (h *Handler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "orderId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var update entity.OrderStatusUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	order, err := h.ordersService.UpdateOrderStatus(r.Context(), entity.OrderID(orderID), update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating order status", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, order)
+}
+
+func parseCartItemPath(r *http.Request) (entity.UserID, entity.CartItemID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.CartItemID{}, err //nolint:wrapcheck
+	}
+
+	itemID, err := uuid.Parse(chi.URLParam(r, "itemId"))
+	if err != nil {
+		return entity.UserID{}, entity.CartItemID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.CartItemID(itemID), nil
+}
+
+func parseOrderPath(r *http.Request) (entity.UserID, entity.OrderID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.OrderID{}, err //nolint:wrapcheck
+	}
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "orderId"))
+	if err != nil {
+		return entity.UserID{}, entity.OrderID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.OrderID(orderID), nil
+}
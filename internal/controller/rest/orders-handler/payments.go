@@ -0,0 +1,107 @@
+package ordershandler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const (
+	// SignatureHeader carries the signature of a payment provider notification.
+	SignatureHeader = "X-Webhook-Signature"
+
+	maxWebhookSize = 1 << 20
+)
+
+func (h *Handler) PayOrder(w http.ResponseWriter, r *http.Request) {
+	userID, orderID, err := parseOrderPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error paying for order", err)
+
+		return
+	}
+
+	payment, err := h.ordersService.PayOrder(ctx, userID, orderID)
+	if err != nil {
+		common.ErrorResponse(w, "error paying for order", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, payment)
+}
+
+func (h *Handler) ListPayments(w http.ResponseWriter, r *http.Request) {
+	userID, orderID, err := parseOrderPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error listing payments", err)
+
+		return
+	}
+
+	payments, err := h.ordersService.ListPayments(ctx, userID, orderID)
+	if err != nil {
+		common.ErrorResponse(w, "error listing payments", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, payments)
+}
+
+func (h *Handler) RefundOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "orderId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	payment, err := h.ordersService.RefundOrder(r.Context(), entity.OrderID(orderID))
+	if err != nil {
+		common.ErrorResponse(w, "error refunding order", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, payment)
+}
+
+// PaymentWebhook receives payment provider notifications. It is not behind
+// JWT auth; notifications are trusted by their signature instead.
+func (h *Handler) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		http.Error(w, "error reading request body", http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.ordersService.HandleWebhook(r.Context(), body, r.Header.Get(SignatureHeader)); err != nil {
+		common.ErrorResponse(w, "error handling payment notification", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, "notification handled successfully")
+}
@@ -0,0 +1,109 @@
+package ordershandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (h *Handler) CreatePromoCode(w http.ResponseWriter, r *http.Request) {
+	var promo entity.PromoCode
+
+	if err := json.NewDecoder(r.Body).Decode(&promo); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdPromo, err := h.ordersService.CreatePromoCode(r.Context(), promo)
+	if err != nil {
+		common.ErrorResponse(w, "error creating promo code", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdPromo)
+}
+
+func (h *Handler) ListPromoCodes(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter := entity.PromoCodeFilter{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if value := r.URL.Query().Get("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "invalid active parameter", http.StatusBadRequest)
+
+			return
+		}
+
+		filter.Active = &active
+	}
+
+	promos, err := h.ordersService.ListPromoCodes(r.Context(), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing promo codes", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, promos)
+}
+
+func (h *Handler) GetPromoCode(w http.ResponseWriter, r *http.Request) {
+	promoID, err := uuid.Parse(chi.URLParam(r, "promoId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	promo, err := h.ordersService.GetPromoCode(r.Context(), entity.PromoCodeID(promoID))
+	if err != nil {
+		common.ErrorResponse(w, "error getting promo code", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, promo)
+}
+
+func (h *Handler) UpdatePromoCode(w http.ResponseWriter, r *http.Request) {
+	promoID, err := uuid.Parse(chi.URLParam(r, "promoId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var update entity.PromoCodeUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	promo, err := h.ordersService.UpdatePromoCode(r.Context(), entity.PromoCodeID(promoID), update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating promo code", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, promo)
+}
@@ -0,0 +1,315 @@
+package outfitshandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type outfitsService interface {
+	CreateOutfit(ctx context.Context, userID entity.UserID, outfit entity.Outfit) (entity.Outfit, error)
+	GetOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) (entity.Outfit, error)
+	ListOutfits(ctx context.Context, userID entity.UserID, filter entity.OutfitFilter) ([]entity.Outfit, error)
+	UpdateOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID, update entity.OutfitUpdate) (entity.Outfit, error)
+	DeleteOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) error
+	DuplicateOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) (entity.Outfit, error)
+}
+
+type suggestionsService interface {
+	SuggestOutfits(ctx context.Context, userID entity.UserID, request entity.SuggestionRequest) ([]entity.OutfitSuggestion, error)
+}
+
+var errInvalidTemperature = errors.New("invalid temperature")
+
+type Handler struct {
+	outfitsService     outfitsService
+	suggestionsService suggestionsService
+}
+
+func New(outfitsService outfitsService, suggestionsService suggestionsService) *Handler {
+	return &Handler{
+		outfitsService:     outfitsService,
+		suggestionsService: suggestionsService,
+	}
+}
+
+func (h *Handler) CreateOutfit(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error creating outfit", err)
+
+		return
+	}
+
+	var outfit entity.Outfit
+
+	if err := json.NewDecoder(r.Body).Decode(&outfit); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdOutfit, err := h.outfitsService.CreateOutfit(ctx, entity.UserID(userID), outfit)
+	if err != nil {
+		common.ErrorResponse(w, "error creating outfit", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdOutfit)
+}
+
+func (h *Handler) ListOutfits(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing outfits", err)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter := entity.OutfitFilter{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if occasion := r.URL.Query().Get("occasion"); occasion != "" {
+		filter.Occasion = &occasion
+	}
+
+	if season := entity.Season(r.URL.Query().Get("season")); season != "" {
+		filter.Season = &season
+	}
+
+	outfits, err := h.outfitsService.ListOutfits(ctx, entity.UserID(userID), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing outfits", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, outfits)
+}
+
+func (h *Handler) GetOutfit(w http.ResponseWriter, r *http.Request) {
+	userID, outfitID, err := parseOutfitPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting outfit", err)
+
+		return
+	}
+
+	outfit, err := h.outfitsService.GetOutfit(ctx, userID, outfitID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting outfit", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, outfit)
+}
+
+func (h *Handler) UpdateOutfit(w http.ResponseWriter, r *http.Request) {
+	userID, outfitID, err := parseOutfitPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error updating outfit", err)
+
+		return
+	}
+
+	var update entity.OutfitUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedOutfit, err := h.outfitsService.UpdateOutfit(ctx, userID, outfitID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating outfit", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedOutfit)
+}
+
+func (h *Handler) DeleteOutfit(w http.ResponseWriter, r *http.Request) {
+	userID, outfitID, err := parseOutfitPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error deleting outfit", err)
+
+		return
+	}
+
+	if err := h.outfitsService.DeleteOutfit(ctx, userID, outfitID); err != nil {
+		common.ErrorResponse(w, "error deleting outfit", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "outfit deleted successfully")
+}
+
+func (h *Handler) DuplicateOutfit(w http.ResponseWriter, r *http.Request) {
+	userID, outfitID, err := parseOutfitPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error duplicating outfit", err)
+
+		return
+	}
+
+	duplicate, err := h.outfitsService.DuplicateOutfit(ctx, userID, outfitID)
+	if err != nil {
+		common.ErrorResponse(w, "error duplicating outfit", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, duplicate)
+}
+
+func (h *Handler) SuggestOutfits(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error suggesting outfits", err)
+
+		return
+	}
+
+	limit, _, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	query := r.URL.Query()
+
+	request := entity.SuggestionRequest{
+		Occasion: query.Get("occasion"),
+		Limit:    limit,
+	}
+
+	if season := entity.Season(query.Get("season")); season != "" {
+		request.Season = &season
+	}
+
+	if request.MinTemperature, err = parseTemperature(query.Get("minTemp")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if request.MaxTemperature, err = parseTemperature(query.Get("maxTemp")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	suggestions, err := h.suggestionsService.SuggestOutfits(ctx, entity.UserID(userID), request)
+	if err != nil {
+		common.ErrorResponse(w, "error suggesting outfits", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, suggestions)
+}
+
+func parseTemperature(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	temperature, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, errInvalidTemperature
+	}
+
+	return &temperature, nil
+}
+
+func parseOutfitPath(r *http.Request) (entity.UserID, entity.OutfitID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.OutfitID{}, err //nolint:wrapcheck
+	}
+
+	outfitID, err := uuid.Parse(chi.URLParam(r, "outfitId"))
+	if err != nil {
+		return entity.UserID{}, entity.OutfitID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.OutfitID(outfitID), nil
+}
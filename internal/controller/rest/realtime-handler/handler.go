@@ -0,0 +1,93 @@
+package realtimehandler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+type eventsHub interface {
+	Subscribe(userID entity.UserID) (<-chan entity.Event, func())
+}
+
+type Config struct {
+	HeartbeatInterval time.Duration
+}
+
+type Handler struct {
+	cfg       Config
+	eventsHub eventsHub
+}
+
+func New(cfg Config, eventsHub eventsHub) *Handler {
+	return &Handler{
+		cfg:       cfg,
+		eventsHub: eventsHub,
+	}
+}
+
+// Events streams the caller's events as server-sent events until the client
+// disconnects or the server shuts down. Comments sent every HeartbeatInterval keep proxies from
+// closing an idle stream.
+func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error streaming events", err)
+
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+
+		return
+	}
+
+	events, unsubscribe := h.eventsHub.Subscribe(userInfo.UserID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	heartbeat := time.NewTicker(h.cfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		return
+	}
+
+	flusher.Flush()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+				log.Debug().Err(err).Msg("failed to write event")
+
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
@@ -23,10 +23,23 @@ type Config struct {
 }
 
 type Server struct {
-	cfg          Config
-	server       *http.Server
-	usersHandler usersHandler
-	tokenHandler tokenHandler
+	cfg                  Config
+	server               *http.Server
+	usersHandler         usersHandler
+	tokenHandler         tokenHandler
+	filesHandler         filesHandler
+	wardrobeHandler      wardrobeHandler
+	taxonomyHandler      taxonomyHandler
+	outfitsHandler       outfitsHandler
+	sizesHandler         sizesHandler
+	socialHandler        socialHandler
+	moderationHandler    moderationHandler
+	catalogHandler       catalogHandler
+	ordersHandler        ordersHandler
+	marketplaceHandler   marketplaceHandler
+	messagingHandler     messagingHandler
+	realtimeHandler      realtimeHandler
+	notificationsHandler notificationsHandler
 }
 
 type usersHandler interface {
@@ -35,15 +48,201 @@ type usersHandler interface {
 	GetUser(w http.ResponseWriter, r *http.Request)
 	UpdateUser(w http.ResponseWriter, r *http.Request)
 	DeleteUser(w http.ResponseWriter, r *http.Request)
+	CreateAddress(w http.ResponseWriter, r *http.Request)
+	ListAddresses(w http.ResponseWriter, r *http.Request)
+	GetAddress(w http.ResponseWriter, r *http.Request)
+	UpdateAddress(w http.ResponseWriter, r *http.Request)
+	DeleteAddress(w http.ResponseWriter, r *http.Request)
 }
 
 type tokenHandler interface {
 	ValidateUser(w http.ResponseWriter, r *http.Request)
 	RefreshToken(w http.ResponseWriter, r *http.Request)
 	JWTAuth(next http.Handler) http.Handler
+	RequireAdmin(next http.Handler) http.Handler
 }
 
-func New(cfg Config, userHandler usersHandler, tokenHandler tokenHandler) *Server {
+type filesHandler interface {
+	UploadFile(w http.ResponseWriter, r *http.Request)
+	GetFile(w http.ResponseWriter, r *http.Request)
+	DownloadFile(w http.ResponseWriter, r *http.Request)
+	GetFileURL(w http.ResponseWriter, r *http.Request)
+	DeleteFile(w http.ResponseWriter, r *http.Request)
+}
+
+type wardrobeHandler interface {
+	CreateItem(w http.ResponseWriter, r *http.Request)
+	ListItems(w http.ResponseWriter, r *http.Request)
+	GetItem(w http.ResponseWriter, r *http.Request)
+	UpdateItem(w http.ResponseWriter, r *http.Request)
+	DeleteItem(w http.ResponseWriter, r *http.Request)
+	SearchItems(w http.ResponseWriter, r *http.Request)
+	GetItemWearStats(w http.ResponseWriter, r *http.Request)
+	CreateWearLogEntry(w http.ResponseWriter, r *http.Request)
+	ListWearLog(w http.ResponseWriter, r *http.Request)
+	DeleteWearLogEntry(w http.ResponseWriter, r *http.Request)
+}
+
+type taxonomyHandler interface {
+	ListCategories(w http.ResponseWriter, r *http.Request)
+	GetCategory(w http.ResponseWriter, r *http.Request)
+	CreateCategory(w http.ResponseWriter, r *http.Request)
+	UpdateCategory(w http.ResponseWriter, r *http.Request)
+	DeleteCategory(w http.ResponseWriter, r *http.Request)
+	UpsertAttribute(w http.ResponseWriter, r *http.Request)
+	DeleteAttribute(w http.ResponseWriter, r *http.Request)
+}
+
+type outfitsHandler interface {
+	CreateOutfit(w http.ResponseWriter, r *http.Request)
+	ListOutfits(w http.ResponseWriter, r *http.Request)
+	GetOutfit(w http.ResponseWriter, r *http.Request)
+	UpdateOutfit(w http.ResponseWriter, r *http.Request)
+	DeleteOutfit(w http.ResponseWriter, r *http.Request)
+	DuplicateOutfit(w http.ResponseWriter, r *http.Request)
+	SuggestOutfits(w http.ResponseWriter, r *http.Request)
+}
+
+type sizesHandler interface {
+	GetProfile(w http.ResponseWriter, r *http.Request)
+	UpdateProfile(w http.ResponseWriter, r *http.Request)
+	GetSizeHint(w http.ResponseWriter, r *http.Request)
+}
+
+type socialHandler interface {
+	CreatePost(w http.ResponseWriter, r *http.Request)
+	ListPosts(w http.ResponseWriter, r *http.Request)
+	GetPost(w http.ResponseWriter, r *http.Request)
+	UpdatePost(w http.ResponseWriter, r *http.Request)
+	DeletePost(w http.ResponseWriter, r *http.Request)
+	Feed(w http.ResponseWriter, r *http.Request)
+	Follow(w http.ResponseWriter, r *http.Request)
+	Unfollow(w http.ResponseWriter, r *http.Request)
+	ListFollowers(w http.ResponseWriter, r *http.Request)
+	ListFollowing(w http.ResponseWriter, r *http.Request)
+	LikePost(w http.ResponseWriter, r *http.Request)
+	UnlikePost(w http.ResponseWriter, r *http.Request)
+	CreateComment(w http.ResponseWriter, r *http.Request)
+	ListComments(w http.ResponseWriter, r *http.Request)
+	ListReplies(w http.ResponseWriter, r *http.Request)
+	UpdateComment(w http.ResponseWriter, r *http.Request)
+	DeleteComment(w http.ResponseWriter, r *http.Request)
+	CreateCollection(w http.ResponseWriter, r *http.Request)
+	ListCollections(w http.ResponseWriter, r *http.Request)
+	GetCollection(w http.ResponseWriter, r *http.Request)
+	UpdateCollection(w http.ResponseWriter, r *http.Request)
+	DeleteCollection(w http.ResponseWriter, r *http.Request)
+	ListCollectionPosts(w http.ResponseWriter, r *http.Request)
+	SavePost(w http.ResponseWriter, r *http.Request)
+	UnsavePost(w http.ResponseWriter, r *http.Request)
+}
+
+type moderationHandler interface {
+	CreateReport(w http.ResponseWriter, r *http.Request)
+	ListReports(w http.ResponseWriter, r *http.Request)
+	DismissReport(w http.ResponseWriter, r *http.Request)
+	HidePost(w http.ResponseWriter, r *http.Request)
+	HideComment(w http.ResponseWriter, r *http.Request)
+	SuspendUser(w http.ResponseWriter, r *http.Request)
+	UnsuspendUser(w http.ResponseWriter, r *http.Request)
+	ListAuditLog(w http.ResponseWriter, r *http.Request)
+}
+
+type catalogHandler interface {
+	ListProducts(w http.ResponseWriter, r *http.Request)
+	GetProduct(w http.ResponseWriter, r *http.Request)
+	GetProductImageURL(w http.ResponseWriter, r *http.Request)
+	ListImports(w http.ResponseWriter, r *http.Request)
+	AddWishlistItem(w http.ResponseWriter, r *http.Request)
+	ListWishlist(w http.ResponseWriter, r *http.Request)
+	UpdateWishlistItem(w http.ResponseWriter, r *http.Request)
+	DeleteWishlistItem(w http.ResponseWriter, r *http.Request)
+	GetPriceHistory(w http.ResponseWriter, r *http.Request)
+	ListPriceAlerts(w http.ResponseWriter, r *http.Request)
+}
+
+type ordersHandler interface {
+	GetCart(w http.ResponseWriter, r *http.Request)
+	AddCartItem(w http.ResponseWriter, r *http.Request)
+	UpdateCartItem(w http.ResponseWriter, r *http.Request)
+	DeleteCartItem(w http.ResponseWriter, r *http.Request)
+	Checkout(w http.ResponseWriter, r *http.Request)
+	ListOrders(w http.ResponseWriter, r *http.Request)
+	GetOrder(w http.ResponseWriter, r *http.Request)
+	CancelOrder(w http.ResponseWriter, r *http.Request)
+	UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
+	PayOrder(w http.ResponseWriter, r *http.Request)
+	ListPayments(w http.ResponseWriter, r *http.Request)
+	RefundOrder(w http.ResponseWriter, r *http.Request)
+	PaymentWebhook(w http.ResponseWriter, r *http.Request)
+	CreatePromoCode(w http.ResponseWriter, r *http.Request)
+	ListPromoCodes(w http.ResponseWriter, r *http.Request)
+	GetPromoCode(w http.ResponseWriter, r *http.Request)
+	UpdatePromoCode(w http.ResponseWriter, r *http.Request)
+}
+
+type marketplaceHandler interface {
+	BrowseListings(w http.ResponseWriter, r *http.Request)
+	SearchListings(w http.ResponseWriter, r *http.Request)
+	GetListing(w http.ResponseWriter, r *http.Request)
+	GetListingPhotoURL(w http.ResponseWriter, r *http.Request)
+	CreateListing(w http.ResponseWriter, r *http.Request)
+	ListSellerListings(w http.ResponseWriter, r *http.Request)
+	UpdateListing(w http.ResponseWriter, r *http.Request)
+	PublishListing(w http.ResponseWriter, r *http.Request)
+	WithdrawListing(w http.ResponseWriter, r *http.Request)
+	MarkListingSold(w http.ResponseWriter, r *http.Request)
+	CreateOffer(w http.ResponseWriter, r *http.Request)
+	ListListingOffers(w http.ResponseWriter, r *http.Request)
+	ListBuyerOffers(w http.ResponseWriter, r *http.Request)
+	AcceptOffer(w http.ResponseWriter, r *http.Request)
+	DeclineOffer(w http.ResponseWriter, r *http.Request)
+}
+
+type messagingHandler interface {
+	StartConversation(w http.ResponseWriter, r *http.Request)
+	ListConversations(w http.ResponseWriter, r *http.Request)
+	GetUnreadCount(w http.ResponseWriter, r *http.Request)
+	GetConversation(w http.ResponseWriter, r *http.Request)
+	MarkConversationRead(w http.ResponseWriter, r *http.Request)
+	SendMessage(w http.ResponseWriter, r *http.Request)
+	ListMessages(w http.ResponseWriter, r *http.Request)
+	GetMessagePhotoURL(w http.ResponseWriter, r *http.Request)
+	BlockUser(w http.ResponseWriter, r *http.Request)
+	ListBlocks(w http.ResponseWriter, r *http.Request)
+	UnblockUser(w http.ResponseWriter, r *http.Request)
+}
+
+type realtimeHandler interface {
+	Events(w http.ResponseWriter, r *http.Request)
+}
+
+type notificationsHandler interface {
+	ListNotifications(w http.ResponseWriter, r *http.Request)
+	MarkNotificationRead(w http.ResponseWriter, r *http.Request)
+	MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request)
+	GetNotificationSettings(w http.ResponseWriter, r *http.Request)
+	UpdateNotificationSettings(w http.ResponseWriter, r *http.Request)
+}
+
+func New(
+	cfg Config,
+	userHandler usersHandler,
+	tokenHandler tokenHandler,
+	filesHandler filesHandler,
+	wardrobeHandler wardrobeHandler,
+	taxonomyHandler taxonomyHandler,
+	outfitsHandler outfitsHandler,
+	sizesHandler sizesHandler,
+	socialHandler socialHandler,
+	moderationHandler moderationHandler,
+	catalogHandler catalogHandler,
+	ordersHandler ordersHandler,
+	marketplaceHandler marketplaceHandler,
+	messagingHandler messagingHandler,
+	realtimeHandler realtimeHandler,
+	notificationsHandler notificationsHandler,
+) *Server {
 	router := chi.NewRouter()
 	s := &Server{
 		server: &http.Server{
@@ -51,9 +250,22 @@ func New(cfg Config, userHandler usersHandler, tokenHandler tokenHandler) *Serve
 			Handler:           router,
 			ReadHeaderTimeout: ReadHeaderTimeoutValue * time.Second,
 		},
-		usersHandler: userHandler,
-		cfg:          cfg,
-		tokenHandler: tokenHandler,
+		usersHandler:         userHandler,
+		cfg:                  cfg,
+		tokenHandler:         tokenHandler,
+		filesHandler:         filesHandler,
+		wardrobeHandler:      wardrobeHandler,
+		taxonomyHandler:      taxonomyHandler,
+		outfitsHandler:       outfitsHandler,
+		sizesHandler:         sizesHandler,
+		socialHandler:        socialHandler,
+		moderationHandler:    moderationHandler,
+		catalogHandler:       catalogHandler,
+		ordersHandler:        ordersHandler,
+		marketplaceHandler:   marketplaceHandler,
+		messagingHandler:     messagingHandler,
+		realtimeHandler:      realtimeHandler,
+		notificationsHandler: notificationsHandler,
 	}
 
 	router.Get("/metrics", promhttp.Handler().ServeHTTP)
@@ -65,12 +277,171 @@ func New(cfg Config, userHandler usersHandler, tokenHandler tokenHandler) *Serve
 			r.Post("/users/{userId}/login", s.usersHandler.LoginUser)
 			r.Post("/users/{userId}/otp", s.tokenHandler.ValidateUser)
 			r.Post("/users/{userId}/refresh", s.tokenHandler.RefreshToken)
+			r.Post("/payments/webhook", s.ordersHandler.PaymentWebhook)
 
 			r.Group(func(r chi.Router) {
 				r.Use(s.tokenHandler.JWTAuth)
 				r.Get("/users/{userId}", s.usersHandler.GetUser)
 				r.Patch("/users/{userId}", s.usersHandler.UpdateUser)
 				r.Delete("/users/{userId}", s.usersHandler.DeleteUser)
+
+				r.Post("/users/{userId}/addresses", s.usersHandler.CreateAddress)
+				r.Get("/users/{userId}/addresses", s.usersHandler.ListAddresses)
+				r.Get("/users/{userId}/addresses/{addressId}", s.usersHandler.GetAddress)
+				r.Patch("/users/{userId}/addresses/{addressId}", s.usersHandler.UpdateAddress)
+				r.Delete("/users/{userId}/addresses/{addressId}", s.usersHandler.DeleteAddress)
+
+				r.Get("/users/{userId}/size-profile", s.sizesHandler.GetProfile)
+				r.Patch("/users/{userId}/size-profile", s.sizesHandler.UpdateProfile)
+				r.Get("/users/{userId}/size-profile/hint", s.sizesHandler.GetSizeHint)
+
+				r.Post("/users/{userId}/files", s.filesHandler.UploadFile)
+				r.Get("/users/{userId}/files/{fileId}", s.filesHandler.GetFile)
+				r.Get("/users/{userId}/files/{fileId}/content", s.filesHandler.DownloadFile)
+				r.Get("/users/{userId}/files/{fileId}/url", s.filesHandler.GetFileURL)
+				r.Delete("/users/{userId}/files/{fileId}", s.filesHandler.DeleteFile)
+
+				r.Post("/users/{userId}/wardrobe", s.wardrobeHandler.CreateItem)
+				r.Get("/users/{userId}/wardrobe", s.wardrobeHandler.ListItems)
+				r.Get("/users/{userId}/wardrobe/search", s.wardrobeHandler.SearchItems)
+				r.Get("/users/{userId}/wardrobe/{itemId}", s.wardrobeHandler.GetItem)
+				r.Patch("/users/{userId}/wardrobe/{itemId}", s.wardrobeHandler.UpdateItem)
+				r.Delete("/users/{userId}/wardrobe/{itemId}", s.wardrobeHandler.DeleteItem)
+				r.Get("/users/{userId}/wardrobe/{itemId}/stats", s.wardrobeHandler.GetItemWearStats)
+
+				r.Post("/users/{userId}/wear-log", s.wardrobeHandler.CreateWearLogEntry)
+				r.Get("/users/{userId}/wear-log", s.wardrobeHandler.ListWearLog)
+				r.Delete("/users/{userId}/wear-log/{entryId}", s.wardrobeHandler.DeleteWearLogEntry)
+
+				r.Post("/users/{userId}/outfits", s.outfitsHandler.CreateOutfit)
+				r.Get("/users/{userId}/outfits", s.outfitsHandler.ListOutfits)
+				r.Get("/users/{userId}/outfits/suggestions", s.outfitsHandler.SuggestOutfits)
+				r.Get("/users/{userId}/outfits/{outfitId}", s.outfitsHandler.GetOutfit)
+				r.Patch("/users/{userId}/outfits/{outfitId}", s.outfitsHandler.UpdateOutfit)
+				r.Delete("/users/{userId}/outfits/{outfitId}", s.outfitsHandler.DeleteOutfit)
+				r.Post("/users/{userId}/outfits/{outfitId}/duplicate", s.outfitsHandler.DuplicateOutfit)
+
+				r.Post("/users/{userId}/posts", s.socialHandler.CreatePost)
+				r.Get("/users/{userId}/posts", s.socialHandler.ListPosts)
+				r.Get("/users/{userId}/posts/{postId}", s.socialHandler.GetPost)
+				r.Patch("/users/{userId}/posts/{postId}", s.socialHandler.UpdatePost)
+				r.Delete("/users/{userId}/posts/{postId}", s.socialHandler.DeletePost)
+				r.Put("/users/{userId}/posts/{postId}/like", s.socialHandler.LikePost)
+				r.Delete("/users/{userId}/posts/{postId}/like", s.socialHandler.UnlikePost)
+				r.Post("/users/{userId}/posts/{postId}/comments", s.socialHandler.CreateComment)
+				r.Get("/users/{userId}/posts/{postId}/comments", s.socialHandler.ListComments)
+				r.Patch("/users/{userId}/posts/{postId}/comments/{commentId}", s.socialHandler.UpdateComment)
+				r.Delete("/users/{userId}/posts/{postId}/comments/{commentId}", s.socialHandler.DeleteComment)
+				r.Get("/users/{userId}/posts/{postId}/comments/{commentId}/replies", s.socialHandler.ListReplies)
+				r.Get("/users/{userId}/feed", s.socialHandler.Feed)
+
+				r.Post("/users/{userId}/collections", s.socialHandler.CreateCollection)
+				r.Get("/users/{userId}/collections", s.socialHandler.ListCollections)
+				r.Get("/users/{userId}/collections/{collectionId}", s.socialHandler.GetCollection)
+				r.Patch("/users/{userId}/collections/{collectionId}", s.socialHandler.UpdateCollection)
+				r.Delete("/users/{userId}/collections/{collectionId}", s.socialHandler.DeleteCollection)
+				r.Get("/users/{userId}/collections/{collectionId}/posts", s.socialHandler.ListCollectionPosts)
+				r.Put("/users/{userId}/collections/{collectionId}/posts/{postId}", s.socialHandler.SavePost)
+				r.Delete("/users/{userId}/collections/{collectionId}/posts/{postId}", s.socialHandler.UnsavePost)
+
+				r.Get("/users/{userId}/followers", s.socialHandler.ListFollowers)
+				r.Get("/users/{userId}/following", s.socialHandler.ListFollowing)
+				r.Put("/users/{userId}/following/{followeeId}", s.socialHandler.Follow)
+				r.Delete("/users/{userId}/following/{followeeId}", s.socialHandler.Unfollow)
+
+				r.Post("/users/{userId}/reports", s.moderationHandler.CreateReport)
+
+				r.Post("/users/{userId}/wishlist", s.catalogHandler.AddWishlistItem)
+				r.Get("/users/{userId}/wishlist", s.catalogHandler.ListWishlist)
+				r.Patch("/users/{userId}/wishlist/{productId}", s.catalogHandler.UpdateWishlistItem)
+				r.Delete("/users/{userId}/wishlist/{productId}", s.catalogHandler.DeleteWishlistItem)
+				r.Get("/users/{userId}/price-alerts", s.catalogHandler.ListPriceAlerts)
+
+				r.Get("/users/{userId}/cart", s.ordersHandler.GetCart)
+				r.Post("/users/{userId}/cart/items", s.ordersHandler.AddCartItem)
+				r.Patch("/users/{userId}/cart/items/{itemId}", s.ordersHandler.UpdateCartItem)
+				r.Delete("/users/{userId}/cart/items/{itemId}", s.ordersHandler.DeleteCartItem)
+				r.Post("/users/{userId}/orders", s.ordersHandler.Checkout)
+				r.Get("/users/{userId}/orders", s.ordersHandler.ListOrders)
+				r.Get("/users/{userId}/orders/{orderId}", s.ordersHandler.GetOrder)
+				r.Post("/users/{userId}/orders/{orderId}/cancel", s.ordersHandler.CancelOrder)
+				r.Post("/users/{userId}/orders/{orderId}/payments", s.ordersHandler.PayOrder)
+				r.Get("/users/{userId}/orders/{orderId}/payments", s.ordersHandler.ListPayments)
+
+				r.Post("/users/{userId}/listings", s.marketplaceHandler.CreateListing)
+				r.Get("/users/{userId}/listings", s.marketplaceHandler.ListSellerListings)
+				r.Patch("/users/{userId}/listings/{listingId}", s.marketplaceHandler.UpdateListing)
+				r.Post("/users/{userId}/listings/{listingId}/publish", s.marketplaceHandler.PublishListing)
+				r.Post("/users/{userId}/listings/{listingId}/withdraw", s.marketplaceHandler.WithdrawListing)
+				r.Post("/users/{userId}/listings/{listingId}/sold", s.marketplaceHandler.MarkListingSold)
+				r.Post("/users/{userId}/listings/{listingId}/offers/{offerId}/accept", s.marketplaceHandler.AcceptOffer)
+				r.Post("/users/{userId}/listings/{listingId}/offers/{offerId}/decline", s.marketplaceHandler.DeclineOffer)
+				r.Get("/users/{userId}/offers", s.marketplaceHandler.ListBuyerOffers)
+
+				r.Get("/users/{userId}/conversations", s.messagingHandler.ListConversations)
+				r.Get("/users/{userId}/conversations/unread", s.messagingHandler.GetUnreadCount)
+				r.Get("/users/{userId}/conversations/{conversationId}", s.messagingHandler.GetConversation)
+				r.Post("/users/{userId}/conversations/{conversationId}/read", s.messagingHandler.MarkConversationRead)
+				r.Post("/users/{userId}/conversations/{conversationId}/messages", s.messagingHandler.SendMessage)
+				r.Get("/users/{userId}/conversations/{conversationId}/messages", s.messagingHandler.ListMessages)
+				r.Get("/users/{userId}/conversations/{conversationId}/messages/{messageId}/photos/{position}/url",
+					s.messagingHandler.GetMessagePhotoURL)
+				r.Post("/users/{userId}/blocks", s.messagingHandler.BlockUser)
+				r.Get("/users/{userId}/blocks", s.messagingHandler.ListBlocks)
+				r.Delete("/users/{userId}/blocks/{blockedId}", s.messagingHandler.UnblockUser)
+
+				r.Get("/users/{userId}/notifications", s.notificationsHandler.ListNotifications)
+				r.Post("/users/{userId}/notifications/read", s.notificationsHandler.MarkAllNotificationsRead)
+				r.Post("/users/{userId}/notifications/{notificationId}/read", s.notificationsHandler.MarkNotificationRead)
+				r.Get("/users/{userId}/notification-settings", s.notificationsHandler.GetNotificationSettings)
+				r.Put("/users/{userId}/notification-settings", s.notificationsHandler.UpdateNotificationSettings)
+
+				r.Get("/categories", s.taxonomyHandler.ListCategories)
+				r.Get("/categories/{categoryId}", s.taxonomyHandler.GetCategory)
+
+				r.Get("/products", s.catalogHandler.ListProducts)
+				r.Get("/products/{productId}", s.catalogHandler.GetProduct)
+				r.Get("/products/{productId}/images/{position}/url", s.catalogHandler.GetProductImageURL)
+				r.Get("/products/{productId}/price-history", s.catalogHandler.GetPriceHistory)
+
+				r.Get("/listings", s.marketplaceHandler.BrowseListings)
+				r.Get("/listings/search", s.marketplaceHandler.SearchListings)
+				r.Get("/listings/{listingId}", s.marketplaceHandler.GetListing)
+				r.Get("/listings/{listingId}/photos/{position}/url", s.marketplaceHandler.GetListingPhotoURL)
+				r.Post("/listings/{listingId}/offers", s.marketplaceHandler.CreateOffer)
+				r.Get("/listings/{listingId}/offers", s.marketplaceHandler.ListListingOffers)
+				r.Post("/listings/{listingId}/conversations", s.messagingHandler.StartConversation)
+
+				r.Get("/events", s.realtimeHandler.Events)
+			})
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(s.tokenHandler.JWTAuth)
+				r.Use(s.tokenHandler.RequireAdmin)
+
+				r.Post("/categories", s.taxonomyHandler.CreateCategory)
+				r.Patch("/categories/{categoryId}", s.taxonomyHandler.UpdateCategory)
+				r.Delete("/categories/{categoryId}", s.taxonomyHandler.DeleteCategory)
+				r.Put("/categories/{categoryId}/attributes/{attribute}", s.taxonomyHandler.UpsertAttribute)
+				r.Delete("/categories/{categoryId}/attributes/{attribute}", s.taxonomyHandler.DeleteAttribute)
+
+				r.Get("/reports", s.moderationHandler.ListReports)
+				r.Post("/reports/{reportId}/dismiss", s.moderationHandler.DismissReport)
+				r.Post("/posts/{postId}/hide", s.moderationHandler.HidePost)
+				r.Post("/comments/{commentId}/hide", s.moderationHandler.HideComment)
+				r.Post("/users/{userId}/suspend", s.moderationHandler.SuspendUser)
+				r.Post("/users/{userId}/unsuspend", s.moderationHandler.UnsuspendUser)
+				r.Get("/audit-log", s.moderationHandler.ListAuditLog)
+
+				r.Get("/catalog/imports", s.catalogHandler.ListImports)
+
+				r.Post("/orders/{orderId}/status", s.ordersHandler.UpdateOrderStatus)
+				r.Post("/orders/{orderId}/refund", s.ordersHandler.RefundOrder)
+
+				r.Post("/promo-codes", s.ordersHandler.CreatePromoCode)
+				r.Get("/promo-codes", s.ordersHandler.ListPromoCodes)
+				r.Get("/promo-codes/{promoId}", s.ordersHandler.GetPromoCode)
+				r.Patch("/promo-codes/{promoId}", s.ordersHandler.UpdatePromoCode)
 			})
 		})
 	})
@@ -0,0 +1,125 @@
+package sizeshandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type sizesService interface {
+	GetProfile(ctx context.Context, userID entity.UserID) (entity.SizeProfile, error)
+	UpdateProfile(ctx context.Context, userID entity.UserID, update entity.SizeProfileUpdate) (entity.SizeProfile, error)
+	GetSizeHint(ctx context.Context, userID entity.UserID, request entity.SizeHintRequest) (entity.SizeHint, error)
+}
+
+type Handler struct {
+	sizesService sizesService
+}
+
+func New(sizesService sizesService) *Handler {
+	return &Handler{
+		sizesService: sizesService,
+	}
+}
+
+func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error getting size profile", err)
+
+		return
+	}
+
+	profile, err := h.sizesService.GetProfile(ctx, entity.UserID(userID))
+	if err != nil {
+		common.ErrorResponse(w, "error getting size profile", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, profile)
+}
+
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error updating size profile", err)
+
+		return
+	}
+
+	var update entity.SizeProfileUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	profile, err := h.sizesService.UpdateProfile(ctx, entity.UserID(userID), update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating size profile", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, profile)
+}
+
+func (h *Handler) GetSizeHint(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error getting size hint", err)
+
+		return
+	}
+
+	query := r.URL.Query()
+
+	request := entity.SizeHintRequest{
+		Category: query.Get("category"),
+		Size:     query.Get("size"),
+	}
+
+	if region := entity.SizeRegion(query.Get("region")); region != "" {
+		request.Region = &region
+	}
+
+	hint, err := h.sizesService.GetSizeHint(ctx, entity.UserID(userID), request)
+	if err != nil {
+		common.ErrorResponse(w, "error getting size hint", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, hint)
+}
@@ -0,0 +1,360 @@
+package socialhandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type socialService interface {
+	CreatePost(ctx context.Context, userID entity.UserID, post entity.Post) (entity.Post, error)
+	GetPost(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.Post, error)
+	ListPosts(ctx context.Context, viewerID, authorID entity.UserID, page entity.Page) (entity.PostPage, error)
+	Feed(ctx context.Context, userID entity.UserID, page entity.Page) (entity.PostPage, error)
+	UpdatePost(ctx context.Context, userID entity.UserID, postID entity.PostID, update entity.PostUpdate) (entity.Post, error)
+	DeletePost(ctx context.Context, userID entity.UserID, postID entity.PostID) error
+	Follow(ctx context.Context, followerID, followeeID entity.UserID) error
+	Unfollow(ctx context.Context, followerID, followeeID entity.UserID) error
+	ListFollowers(ctx context.Context, userID entity.UserID, page entity.Page) (entity.FollowPage, error)
+	ListFollowing(ctx context.Context, userID entity.UserID, page entity.Page) (entity.FollowPage, error)
+
+	Like(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error)
+	Unlike(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error)
+
+	CreateComment(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID,
+		comment entity.Comment) (entity.Comment, error)
+	ListComments(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID,
+		parentID *entity.CommentID, page entity.Page) (entity.CommentPage, error)
+	UpdateComment(ctx context.Context, userID, authorID entity.UserID, postID entity.PostID,
+		commentID entity.CommentID, update entity.CommentUpdate) (entity.Comment, error)
+	DeleteComment(ctx context.Context, userID, authorID entity.UserID, postID entity.PostID, commentID entity.CommentID) error
+
+	CreateCollection(ctx context.Context, userID entity.UserID, collection entity.Collection) (entity.Collection, error)
+	GetCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) (entity.Collection, error)
+	ListCollections(ctx context.Context, userID entity.UserID, filter entity.CollectionFilter) ([]entity.Collection, error)
+	UpdateCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID,
+		update entity.CollectionUpdate) (entity.Collection, error)
+	DeleteCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) error
+	SavePost(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error
+	UnsavePost(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error
+	ListCollectionPosts(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID,
+		page entity.Page) (entity.PostPage, error)
+}
+
+type Handler struct {
+	socialService socialService
+}
+
+func New(socialService socialService) *Handler {
+	return &Handler{
+		socialService: socialService,
+	}
+}
+
+func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error creating post", err)
+
+		return
+	}
+
+	var post entity.Post
+
+	if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdPost, err := h.socialService.CreatePost(ctx, entity.UserID(userID), post)
+	if err != nil {
+		common.ErrorResponse(w, "error creating post", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdPost)
+}
+
+func (h *Handler) ListPosts(w http.ResponseWriter, r *http.Request) {
+	authorID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error listing posts", err)
+
+		return
+	}
+
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	posts, err := h.socialService.ListPosts(ctx, userInfo.UserID, entity.UserID(authorID), page)
+	if err != nil {
+		common.ErrorResponse(w, "error listing posts", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, posts)
+}
+
+func (h *Handler) GetPost(w http.ResponseWriter, r *http.Request) {
+	authorID, postID, err := parsePostPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error getting post", err)
+
+		return
+	}
+
+	post, err := h.socialService.GetPost(ctx, userInfo.UserID, authorID, postID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting post", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, post)
+}
+
+func (h *Handler) UpdatePost(w http.ResponseWriter, r *http.Request) {
+	userID, postID, err := parsePostPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error updating post", err)
+
+		return
+	}
+
+	var update entity.PostUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedPost, err := h.socialService.UpdatePost(ctx, userID, postID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating post", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedPost)
+}
+
+func (h *Handler) DeletePost(w http.ResponseWriter, r *http.Request) {
+	userID, postID, err := parsePostPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error deleting post", err)
+
+		return
+	}
+
+	if err := h.socialService.DeletePost(ctx, userID, postID); err != nil {
+		common.ErrorResponse(w, "error deleting post", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "post deleted successfully")
+}
+
+func (h *Handler) Feed(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error getting feed", err)
+
+		return
+	}
+
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	feed, err := h.socialService.Feed(ctx, entity.UserID(userID), page)
+	if err != nil {
+		common.ErrorResponse(w, "error getting feed", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, feed)
+}
+
+func (h *Handler) Follow(w http.ResponseWriter, r *http.Request) {
+	userID, followeeID, err := parseFollowPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error following user", err)
+
+		return
+	}
+
+	if err := h.socialService.Follow(ctx, userID, followeeID); err != nil {
+		common.ErrorResponse(w, "error following user", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "user followed successfully")
+}
+
+func (h *Handler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	userID, followeeID, err := parseFollowPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error unfollowing user", err)
+
+		return
+	}
+
+	if err := h.socialService.Unfollow(ctx, userID, followeeID); err != nil {
+		common.ErrorResponse(w, "error unfollowing user", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "user unfollowed successfully")
+}
+
+func (h *Handler) ListFollowers(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, "error listing followers", h.socialService.ListFollowers)
+}
+
+func (h *Handler) ListFollowing(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, "error listing following", h.socialService.ListFollowing)
+}
+
+func (h *Handler) listFollows(
+	w http.ResponseWriter,
+	r *http.Request,
+	errorText string,
+	list func(ctx context.Context, userID entity.UserID, page entity.Page) (entity.FollowPage, error),
+) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	follows, err := list(r.Context(), entity.UserID(userID), page)
+	if err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, follows)
+}
+
+func parsePostPath(r *http.Request) (entity.UserID, entity.PostID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.PostID{}, err //nolint:wrapcheck
+	}
+
+	postID, err := uuid.Parse(chi.URLParam(r, "postId"))
+	if err != nil {
+		return entity.UserID{}, entity.PostID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.PostID(postID), nil
+}
+
+func parseFollowPath(r *http.Request) (entity.UserID, entity.UserID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.UserID{}, err //nolint:wrapcheck
+	}
+
+	followeeID, err := uuid.Parse(chi.URLParam(r, "followeeId"))
+	if err != nil {
+		return entity.UserID{}, entity.UserID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.UserID(followeeID), nil
+}
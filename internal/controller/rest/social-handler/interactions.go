@@ -0,0 +1,458 @@
+package socialhandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (h *Handler) LikePost(w http.ResponseWriter, r *http.Request) {
+	h.toggleLike(w, r, "error liking post", h.socialService.Like)
+}
+
+func (h *Handler) UnlikePost(w http.ResponseWriter, r *http.Request) {
+	h.toggleLike(w, r, "error unliking post", h.socialService.Unlike)
+}
+
+func (h *Handler) toggleLike(
+	w http.ResponseWriter,
+	r *http.Request,
+	errorText string,
+	toggle func(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error),
+) {
+	authorID, postID, err := parsePostPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	state, err := toggle(ctx, userInfo.UserID, authorID, postID)
+	if err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, state)
+}
+
+func (h *Handler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	authorID, postID, err := parsePostPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error creating comment", err)
+
+		return
+	}
+
+	var comment entity.Comment
+
+	if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdComment, err := h.socialService.CreateComment(ctx, userInfo.UserID, authorID, postID, comment)
+	if err != nil {
+		common.ErrorResponse(w, "error creating comment", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdComment)
+}
+
+func (h *Handler) ListComments(w http.ResponseWriter, r *http.Request) {
+	h.listComments(w, r, nil)
+}
+
+func (h *Handler) ListReplies(w http.ResponseWriter, r *http.Request) {
+	commentID, err := uuid.Parse(chi.URLParam(r, "commentId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	h.listComments(w, r, (*entity.CommentID)(&commentID))
+}
+
+func (h *Handler) listComments(w http.ResponseWriter, r *http.Request, parentID *entity.CommentID) {
+	authorID, postID, err := parsePostPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error listing comments", err)
+
+		return
+	}
+
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	comments, err := h.socialService.ListComments(ctx, userInfo.UserID, authorID, postID, parentID, page)
+	if err != nil {
+		common.ErrorResponse(w, "error listing comments", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, comments)
+}
+
+func (h *Handler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	authorID, postID, commentID, err := parseCommentPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error updating comment", err)
+
+		return
+	}
+
+	var update entity.CommentUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedComment, err := h.socialService.UpdateComment(ctx, userInfo.UserID, authorID, postID, commentID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating comment", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedComment)
+}
+
+func (h *Handler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	authorID, postID, commentID, err := parseCommentPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	userInfo, err := common.GetUserInfo(ctx)
+	if err != nil {
+		common.ErrorResponse(w, "error deleting comment", err)
+
+		return
+	}
+
+	if err := h.socialService.DeleteComment(ctx, userInfo.UserID, authorID, postID, commentID); err != nil {
+		common.ErrorResponse(w, "error deleting comment", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "comment deleted successfully")
+}
+
+func (h *Handler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error creating collection", err)
+
+		return
+	}
+
+	var collection entity.Collection
+
+	if err := json.NewDecoder(r.Body).Decode(&collection); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdCollection, err := h.socialService.CreateCollection(ctx, entity.UserID(userID), collection)
+	if err != nil {
+		common.ErrorResponse(w, "error creating collection", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdCollection)
+}
+
+func (h *Handler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing collections", err)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	collections, err := h.socialService.ListCollections(ctx, entity.UserID(userID), entity.CollectionFilter{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		common.ErrorResponse(w, "error listing collections", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, collections)
+}
+
+func (h *Handler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	userID, collectionID, err := parseCollectionPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting collection", err)
+
+		return
+	}
+
+	collection, err := h.socialService.GetCollection(ctx, userID, collectionID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting collection", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, collection)
+}
+
+func (h *Handler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	userID, collectionID, err := parseCollectionPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error updating collection", err)
+
+		return
+	}
+
+	var update entity.CollectionUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedCollection, err := h.socialService.UpdateCollection(ctx, userID, collectionID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating collection", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedCollection)
+}
+
+func (h *Handler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	userID, collectionID, err := parseCollectionPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error deleting collection", err)
+
+		return
+	}
+
+	if err := h.socialService.DeleteCollection(ctx, userID, collectionID); err != nil {
+		common.ErrorResponse(w, "error deleting collection", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "collection deleted successfully")
+}
+
+func (h *Handler) ListCollectionPosts(w http.ResponseWriter, r *http.Request) {
+	userID, collectionID, err := parseCollectionPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error listing collection posts", err)
+
+		return
+	}
+
+	page, err := common.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	posts, err := h.socialService.ListCollectionPosts(ctx, userID, collectionID, page)
+	if err != nil {
+		common.ErrorResponse(w, "error listing collection posts", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, posts)
+}
+
+func (h *Handler) SavePost(w http.ResponseWriter, r *http.Request) {
+	h.toggleSave(w, r, "error saving post", "post saved successfully", h.socialService.SavePost)
+}
+
+func (h *Handler) UnsavePost(w http.ResponseWriter, r *http.Request) {
+	h.toggleSave(w, r, "error unsaving post", "post unsaved successfully", h.socialService.UnsavePost)
+}
+
+func (h *Handler) toggleSave(
+	w http.ResponseWriter,
+	r *http.Request,
+	errorText, okText string,
+	toggle func(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error,
+) {
+	userID, collectionID, err := parseCollectionPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	postID, err := uuid.Parse(chi.URLParam(r, "postId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	if err := toggle(ctx, userID, collectionID, entity.PostID(postID)); err != nil {
+		common.ErrorResponse(w, errorText, err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, okText)
+}
+
+func parseCommentPath(r *http.Request) (entity.UserID, entity.PostID, entity.CommentID, error) {
+	authorID, postID, err := parsePostPath(r)
+	if err != nil {
+		return entity.UserID{}, entity.PostID{}, entity.CommentID{}, err
+	}
+
+	commentID, err := uuid.Parse(chi.URLParam(r, "commentId"))
+	if err != nil {
+		return entity.UserID{}, entity.PostID{}, entity.CommentID{}, err //nolint:wrapcheck
+	}
+
+	return authorID, postID, entity.CommentID(commentID), nil
+}
+
+func parseCollectionPath(r *http.Request) (entity.UserID, entity.CollectionID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.CollectionID{}, err //nolint:wrapcheck
+	}
+
+	collectionID, err := uuid.Parse(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		return entity.UserID{}, entity.CollectionID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.CollectionID(collectionID), nil
+}
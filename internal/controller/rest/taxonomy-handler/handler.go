@@ -0,0 +1,133 @@
+package taxonomyhandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type taxonomyService interface {
+	ListCategories(ctx context.Context) ([]entity.Category, error)
+	GetCategory(ctx context.Context, categoryID string) (entity.Category, error)
+	CreateCategory(ctx context.Context, category entity.Category) (entity.Category, error)
+	UpdateCategory(ctx context.Context, categoryID string, update entity.CategoryUpdate) (entity.Category, error)
+	DeleteCategory(ctx context.Context, categoryID string) error
+	UpsertAttribute(ctx context.Context, categoryID string, attribute entity.CategoryAttribute) (entity.Category, error)
+	DeleteAttribute(ctx context.Context, categoryID, name string) error
+}
+
+type Handler struct {
+	taxonomyService taxonomyService
+}
+
+func New(taxonomyService taxonomyService) *Handler {
+	return &Handler{
+		taxonomyService: taxonomyService,
+	}
+}
+
+func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.taxonomyService.ListCategories(r.Context())
+	if err != nil {
+		common.ErrorResponse(w, "error listing categories", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, categories)
+}
+
+func (h *Handler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	category, err := h.taxonomyService.GetCategory(r.Context(), chi.URLParam(r, "categoryId"))
+	if err != nil {
+		common.ErrorResponse(w, "error getting category", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, category)
+}
+
+func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var category entity.Category
+
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdCategory, err := h.taxonomyService.CreateCategory(r.Context(), category)
+	if err != nil {
+		common.ErrorResponse(w, "error creating category", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdCategory)
+}
+
+func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	var update entity.CategoryUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedCategory, err := h.taxonomyService.UpdateCategory(r.Context(), chi.URLParam(r, "categoryId"), update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating category", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedCategory)
+}
+
+func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	if err := h.taxonomyService.DeleteCategory(r.Context(), chi.URLParam(r, "categoryId")); err != nil {
+		common.ErrorResponse(w, "error deleting category", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "category deleted successfully")
+}
+
+func (h *Handler) UpsertAttribute(w http.ResponseWriter, r *http.Request) {
+	var attribute entity.CategoryAttribute
+
+	if err := json.NewDecoder(r.Body).Decode(&attribute); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	attribute.Name = chi.URLParam(r, "attribute")
+
+	category, err := h.taxonomyService.UpsertAttribute(r.Context(), chi.URLParam(r, "categoryId"), attribute)
+	if err != nil {
+		common.ErrorResponse(w, "error saving category attribute", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, category)
+}
+
+func (h *Handler) DeleteAttribute(w http.ResponseWriter, r *http.Request) {
+	err := h.taxonomyService.DeleteAttribute(r.Context(), chi.URLParam(r, "categoryId"), chi.URLParam(r, "attribute"))
+	if err != nil {
+		common.ErrorResponse(w, "error deleting category attribute", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "category attribute deleted successfully")
+}
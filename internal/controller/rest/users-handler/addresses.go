@@ -0,0 +1,170 @@
+package usershandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (h *Handler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error creating address", err)
+
+		return
+	}
+
+	var address entity.Address
+
+	if err := json.NewDecoder(r.Body).Decode(&address); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdAddress, err := h.usersService.CreateAddress(ctx, entity.UserID(userID), address)
+	if err != nil {
+		common.ErrorResponse(w, "error creating address", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdAddress)
+}
+
+func (h *Handler) ListAddresses(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing addresses", err)
+
+		return
+	}
+
+	addresses, err := h.usersService.ListAddresses(ctx, entity.UserID(userID))
+	if err != nil {
+		common.ErrorResponse(w, "error listing addresses", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, addresses)
+}
+
+func (h *Handler) GetAddress(w http.ResponseWriter, r *http.Request) {
+	userID, addressID, err := parseAddressPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting address", err)
+
+		return
+	}
+
+	address, err := h.usersService.GetAddress(ctx, userID, addressID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting address", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, address)
+}
+
+func (h *Handler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	userID, addressID, err := parseAddressPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error updating address", err)
+
+		return
+	}
+
+	var update entity.AddressUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedAddress, err := h.usersService.UpdateAddress(ctx, userID, addressID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating address", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedAddress)
+}
+
+func (h *Handler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	userID, addressID, err := parseAddressPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error deleting address", err)
+
+		return
+	}
+
+	if err := h.usersService.DeleteAddress(ctx, userID, addressID); err != nil {
+		common.ErrorResponse(w, "error deleting address", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "address deleted successfully")
+}
+
+func parseAddressPath(r *http.Request) (entity.UserID, entity.AddressID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.AddressID{}, err //nolint:wrapcheck
+	}
+
+	addressID, err := uuid.Parse(chi.URLParam(r, "addressId"))
+	if err != nil {
+		return entity.UserID{}, entity.AddressID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.AddressID(addressID), nil
+}
@@ -17,6 +17,16 @@ type usersService interface {
 	GetUser(ctx context.Context, userID entity.UserID) (entity.User, error)
 	UpdateUser(ctx context.Context, userID entity.UserID, updatedUser entity.UserUpdate) (entity.User, error)
 	DeleteUser(ctx context.Context, userID entity.UserID) error
+	CreateAddress(ctx context.Context, userID entity.UserID, address entity.Address) (entity.Address, error)
+	GetAddress(ctx context.Context, userID entity.UserID, addressID entity.AddressID) (entity.Address, error)
+	ListAddresses(ctx context.Context, userID entity.UserID) ([]entity.Address, error)
+	UpdateAddress(
+		ctx context.Context,
+		userID entity.UserID,
+		addressID entity.AddressID,
+		update entity.AddressUpdate,
+	) (entity.Address, error)
+	DeleteAddress(ctx context.Context, userID entity.UserID, addressID entity.AddressID) error
 }
 
 type Handler struct {
@@ -0,0 +1,280 @@
+package wardrobehandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type wardrobeService interface {
+	CreateItem(ctx context.Context, userID entity.UserID, item entity.WardrobeItem) (entity.WardrobeItem, error)
+	GetItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) (entity.WardrobeItem, error)
+	ListItems(ctx context.Context, userID entity.UserID, filter entity.WardrobeFilter) ([]entity.WardrobeItem, error)
+	UpdateItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID, update entity.WardrobeItemUpdate) (entity.WardrobeItem, error)
+	DeleteItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) error
+	CreateWearLogEntry(ctx context.Context, userID entity.UserID, entry entity.WearLogEntry) (entity.WearLogEntry, error)
+	ListWearLog(ctx context.Context, userID entity.UserID, filter entity.WearLogFilter) ([]entity.WearLogEntry, error)
+	DeleteWearLogEntry(ctx context.Context, userID entity.UserID, entryID entity.WearLogEntryID) error
+	GetItemWearStats(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) (entity.ItemWearStats, error)
+}
+
+type searchService interface {
+	SearchItems(ctx context.Context, userID entity.UserID, query entity.SearchQuery) (entity.SearchResult, error)
+}
+
+type Handler struct {
+	wardrobeService wardrobeService
+	searchService   searchService
+}
+
+func New(wardrobeService wardrobeService, searchService searchService) *Handler {
+	return &Handler{
+		wardrobeService: wardrobeService,
+		searchService:   searchService,
+	}
+}
+
+func (h *Handler) CreateItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error creating wardrobe item", err)
+
+		return
+	}
+
+	var item entity.WardrobeItem
+
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdItem, err := h.wardrobeService.CreateItem(ctx, entity.UserID(userID), item)
+	if err != nil {
+		common.ErrorResponse(w, "error creating wardrobe item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdItem)
+}
+
+func (h *Handler) ListItems(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing wardrobe items", err)
+
+		return
+	}
+
+	limit, offset, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter := entity.WardrobeFilter{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if category := r.URL.Query().Get("category"); category != "" {
+		filter.Category = &category
+	}
+
+	if season := entity.Season(r.URL.Query().Get("season")); season != "" {
+		filter.Season = &season
+	}
+
+	if value := r.URL.Query().Get("archived"); value != "" {
+		archived, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "invalid archived parameter", http.StatusBadRequest)
+
+			return
+		}
+
+		filter.Archived = archived
+	}
+
+	items, err := h.wardrobeService.ListItems(ctx, entity.UserID(userID), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing wardrobe items", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, items)
+}
+
+func (h *Handler) GetItem(w http.ResponseWriter, r *http.Request) {
+	userID, itemID, err := parseItemPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting wardrobe item", err)
+
+		return
+	}
+
+	item, err := h.wardrobeService.GetItem(ctx, userID, itemID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting wardrobe item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, item)
+}
+
+func (h *Handler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	userID, itemID, err := parseItemPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error updating wardrobe item", err)
+
+		return
+	}
+
+	var update entity.WardrobeItemUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	updatedItem, err := h.wardrobeService.UpdateItem(ctx, userID, itemID, update)
+	if err != nil {
+		common.ErrorResponse(w, "error updating wardrobe item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, updatedItem)
+}
+
+func (h *Handler) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	userID, itemID, err := parseItemPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error deleting wardrobe item", err)
+
+		return
+	}
+
+	if err := h.wardrobeService.DeleteItem(ctx, userID, itemID); err != nil {
+		common.ErrorResponse(w, "error deleting wardrobe item", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "wardrobe item deleted successfully")
+}
+
+func (h *Handler) SearchItems(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error searching wardrobe items", err)
+
+		return
+	}
+
+	limit, _, err := common.ParsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	values := r.URL.Query()
+
+	query := entity.SearchQuery{
+		Text:       values.Get("q"),
+		Categories: values["category"],
+		Colors:     values["color"],
+		Sizes:      values["size"],
+		Brands:     values["brand"],
+		Limit:      limit,
+		Cursor:     values.Get("cursor"),
+	}
+
+	for _, season := range values["season"] {
+		query.Seasons = append(query.Seasons, entity.Season(season))
+	}
+
+	result, err := h.searchService.SearchItems(ctx, entity.UserID(userID), query)
+	if err != nil {
+		common.ErrorResponse(w, "error searching wardrobe items", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, result)
+}
+
+func parseItemPath(r *http.Request) (entity.UserID, entity.WardrobeItemID, error) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		return entity.UserID{}, entity.WardrobeItemID{}, err //nolint:wrapcheck
+	}
+
+	itemID, err := uuid.Parse(chi.URLParam(r, "itemId"))
+	if err != nil {
+		return entity.UserID{}, entity.WardrobeItemID{}, err //nolint:wrapcheck
+	}
+
+	return entity.UserID(userID), entity.WardrobeItemID(itemID), nil
+}
@@ -0,0 +1,160 @@
+package wardrobehandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/controller/rest/common"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+var errInvalidDate = errors.New("invalid date, expected YYYY-MM-DD")
+
+func (h *Handler) CreateWearLogEntry(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error logging worn items", err)
+
+		return
+	}
+
+	var entry entity.WearLogEntry
+
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	createdEntry, err := h.wardrobeService.CreateWearLogEntry(ctx, entity.UserID(userID), entry)
+	if err != nil {
+		common.ErrorResponse(w, "error logging worn items", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, createdEntry)
+}
+
+func (h *Handler) ListWearLog(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error listing wear log", err)
+
+		return
+	}
+
+	var filter entity.WearLogFilter
+
+	if filter.From, err = parseDate(r.URL.Query().Get("from")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if filter.To, err = parseDate(r.URL.Query().Get("to")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	entries, err := h.wardrobeService.ListWearLog(ctx, entity.UserID(userID), filter)
+	if err != nil {
+		common.ErrorResponse(w, "error listing wear log", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, entries)
+}
+
+func (h *Handler) DeleteWearLogEntry(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	entryID, err := uuid.Parse(chi.URLParam(r, "entryId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, entity.UserID(userID)); err != nil {
+		common.ErrorResponse(w, "error deleting wear log entry", err)
+
+		return
+	}
+
+	if err := h.wardrobeService.DeleteWearLogEntry(ctx, entity.UserID(userID), entity.WearLogEntryID(entryID)); err != nil {
+		common.ErrorResponse(w, "error deleting wear log entry", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusNoContent, "wear log entry deleted successfully")
+}
+
+func (h *Handler) GetItemWearStats(w http.ResponseWriter, r *http.Request) {
+	userID, itemID, err := parseItemPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := common.CheckOwner(ctx, userID); err != nil {
+		common.ErrorResponse(w, "error getting wear stats", err)
+
+		return
+	}
+
+	stats, err := h.wardrobeService.GetItemWearStats(ctx, userID, itemID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting wear stats", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, stats)
+}
+
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, errInvalidDate
+	}
+
+	return date, nil
+}
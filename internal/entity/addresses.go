@@ -0,0 +1,244 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxAddressFieldLength = 200
+	maxLatitude           = 90
+	maxLongitude          = 180
+)
+
+//nolint:gochecknoglobals
+var (
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	postalCodePattern  = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,8}[A-Z0-9]$`)
+)
+
+type AddressID uuid.UUID //nolint:recvcheck
+
+func (a AddressID) String() string {
+	return uuid.UUID(a).String()
+}
+
+func (a *AddressID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(a), data)
+}
+
+func (a AddressID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(a))
+}
+
+// Address is a delivery address in a user's address book. Country is an
+// ISO 3166-1 alpha-2 code. A user has at most one default address; the
+// first address a user saves becomes the default.
+type Address struct {
+	ID         AddressID `json:"id"`
+	UserID     UserID    `json:"userId"`
+	Label      string    `json:"label"`
+	Recipient  string    `json:"recipient"`
+	Phone      string    `json:"phone"`
+	Country    string    `json:"country"`
+	Region     *string   `json:"region"`
+	City       string    `json:"city"`
+	Street     string    `json:"street"`
+	House      string    `json:"house"`
+	Apartment  *string   `json:"apartment"`
+	PostalCode *string   `json:"postalCode"`
+	Latitude   *float64  `json:"latitude"`
+	Longitude  *float64  `json:"longitude"`
+	IsDefault  bool      `json:"isDefault"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// AddressUpdate changes the given fields of an address. Coordinates are
+// changed together. Making an address the default unsets the previous one.
+type AddressUpdate struct {
+	Label      *string  `json:"label"`
+	Recipient  *string  `json:"recipient"`
+	Phone      *string  `json:"phone"`
+	Country    *string  `json:"country"`
+	Region     *string  `json:"region"`
+	City       *string  `json:"city"`
+	Street     *string  `json:"street"`
+	House      *string  `json:"house"`
+	Apartment  *string  `json:"apartment"`
+	PostalCode *string  `json:"postalCode"`
+	Latitude   *float64 `json:"latitude"`
+	Longitude  *float64 `json:"longitude"`
+	IsDefault  *bool    `json:"isDefault"`
+}
+
+var (
+	ErrAddressNotFound = errors.New("address not found")
+	ErrInvalidAddress  = errors.New("invalid address")
+)
+
+func validateAddressField(name, value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case value == "":
+		return "", fmt.Errorf("%w: %s must not be empty", ErrInvalidAddress, name)
+	case len(value) > maxAddressFieldLength:
+		return "", fmt.Errorf("%w: %s is too long", ErrInvalidAddress, name)
+	}
+
+	return value, nil
+}
+
+func validateOptionalAddressField(name string, value *string) (*string, error) {
+	if value == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	validated, err := validateAddressField(name, *value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validated, nil
+}
+
+func validateCountry(country string) (string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if !countryCodePattern.MatchString(country) {
+		return "", fmt.Errorf("%w: country must be a two-letter code", ErrInvalidAddress)
+	}
+
+	return country, nil
+}
+
+func validatePostalCode(postalCode *string) (*string, error) {
+	if postalCode == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	validated := strings.ToUpper(strings.TrimSpace(*postalCode))
+	if !postalCodePattern.MatchString(validated) {
+		return nil, fmt.Errorf("%w: malformed postal code", ErrInvalidAddress)
+	}
+
+	return &validated, nil
+}
+
+func validateCoordinates(latitude, longitude *float64) error {
+	switch {
+	case latitude == nil && longitude == nil:
+		return nil
+	case latitude == nil || longitude == nil:
+		return fmt.Errorf("%w: latitude and longitude go together", ErrInvalidAddress)
+	case *latitude < -maxLatitude || *latitude > maxLatitude:
+		return fmt.Errorf("%w: latitude must be between -%d and %d", ErrInvalidAddress, maxLatitude, maxLatitude)
+	case *longitude < -maxLongitude || *longitude > maxLongitude:
+		return fmt.Errorf("%w: longitude must be between -%d and %d", ErrInvalidAddress, maxLongitude, maxLongitude)
+	}
+
+	return nil
+}
+
+func (a *Address) Validate() (Address, error) {
+	var err error
+
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"label", &a.Label},
+		{"recipient", &a.Recipient},
+		{"city", &a.City},
+		{"street", &a.Street},
+		{"house", &a.House},
+	} {
+		if *field.value, err = validateAddressField(field.name, *field.value); err != nil {
+			return Address{}, err
+		}
+	}
+
+	if a.Phone, err = validatePhone(a.Phone); err != nil {
+		return Address{}, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+
+	if a.Country, err = validateCountry(a.Country); err != nil {
+		return Address{}, err
+	}
+
+	if a.Region, err = validateOptionalAddressField("region", a.Region); err != nil {
+		return Address{}, err
+	}
+
+	if a.Apartment, err = validateOptionalAddressField("apartment", a.Apartment); err != nil {
+		return Address{}, err
+	}
+
+	if a.PostalCode, err = validatePostalCode(a.PostalCode); err != nil {
+		return Address{}, err
+	}
+
+	if err = validateCoordinates(a.Latitude, a.Longitude); err != nil {
+		return Address{}, err
+	}
+
+	return *a, nil
+}
+
+func (au *AddressUpdate) Validate() (AddressUpdate, error) {
+	if *au == (AddressUpdate{}) {
+		return AddressUpdate{}, fmt.Errorf("%w: nothing to update", ErrInvalidAddress)
+	}
+
+	var err error
+
+	for _, field := range []struct {
+		name  string
+		value **string
+	}{
+		{"label", &au.Label},
+		{"recipient", &au.Recipient},
+		{"region", &au.Region},
+		{"city", &au.City},
+		{"street", &au.Street},
+		{"house", &au.House},
+		{"apartment", &au.Apartment},
+	} {
+		if *field.value, err = validateOptionalAddressField(field.name, *field.value); err != nil {
+			return AddressUpdate{}, err
+		}
+	}
+
+	if au.Phone != nil {
+		phone, err := validatePhone(*au.Phone)
+		if err != nil {
+			return AddressUpdate{}, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+		}
+
+		au.Phone = &phone
+	}
+
+	if au.Country != nil {
+		country, err := validateCountry(*au.Country)
+		if err != nil {
+			return AddressUpdate{}, err
+		}
+
+		au.Country = &country
+	}
+
+	if au.PostalCode, err = validatePostalCode(au.PostalCode); err != nil {
+		return AddressUpdate{}, err
+	}
+
+	if err = validateCoordinates(au.Latitude, au.Longitude); err != nil {
+		return AddressUpdate{}, err
+	}
+
+	return *au, nil
+}
@@ -0,0 +1,252 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxFeedImportErrors = 100
+	maxProductImages    = 10
+)
+
+//nolint:gochecknoglobals
+var feedIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type ProductID uuid.UUID //nolint:recvcheck
+
+func (p ProductID) String() string {
+	return uuid.UUID(p).String()
+}
+
+func (p *ProductID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(p), data)
+}
+
+func (p ProductID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(p))
+}
+
+type FeedFormat string
+
+const (
+	FeedFormatYML FeedFormat = "yml"
+	FeedFormatCSV FeedFormat = "csv"
+)
+
+func (f FeedFormat) Validate() error {
+	if f != FeedFormatYML && f != FeedFormatCSV {
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidFeed, f)
+	}
+
+	return nil
+}
+
+// ProductSize is a size variant of a product. Stock is only known when the
+// partner reports it.
+type ProductSize struct {
+	Size      string `json:"size"`
+	Available bool   `json:"available"`
+	Stock     *int   `json:"stock"`
+}
+
+// ProductImage is a product picture mirrored from the partner's site into our
+// bucket. Images that could not be mirrored yet keep only their source URL.
+type ProductImage struct {
+	Position    int     `json:"position"`
+	SourceURL   string  `json:"sourceUrl"`
+	Mirrored    bool    `json:"mirrored"`
+	Key         *string `json:"-"`
+	ContentType *string `json:"-"`
+}
+
+// Product is a shoppable catalog offer imported from a partner feed. Offers
+// of one model in different sizes become a single product. Prices are kept
+// in minor currency units.
+type Product struct {
+	ID          ProductID      `json:"id"`
+	FeedID      string         `json:"feedId"`
+	ExternalID  string         `json:"externalId"`
+	Name        string         `json:"name"`
+	Brand       *string        `json:"brand"`
+	Description *string        `json:"description"`
+	URL         *string        `json:"url"`
+	Price       int64          `json:"price"`
+	OldPrice    *int64         `json:"oldPrice"`
+	Currency    string         `json:"currency"`
+	Category    *string        `json:"category"`
+	CategoryID  *string        `json:"categoryId"`
+	Available   bool           `json:"available"`
+	Sizes       []ProductSize  `json:"sizes"`
+	Images      []ProductImage `json:"images"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type ProductFilter struct {
+	FeedID     *string
+	CategoryID *string
+	Brand      *string
+	Available  *bool
+	Page       Page
+}
+
+type ProductPage struct {
+	Products   []Product `json:"products"`
+	NextCursor *string   `json:"nextCursor"`
+}
+
+// CatalogOffer is a product as a partner feed describes it. Category is the
+// partner's category path, e.g. "Women > Dresses"; its last segment is matched
+// against the taxonomy on import.
+type CatalogOffer struct {
+	ExternalID  string
+	Name        string
+	Brand       *string
+	Description *string
+	URL         *string
+	Price       int64
+	OldPrice    *int64
+	Currency    string
+	Category    *string
+	Available   bool
+	Sizes       []ProductSize
+	Pictures    []string
+}
+
+// ImportResult tells what an import did to a single product.
+type ImportResult string
+
+const (
+	ImportResultCreated   ImportResult = "created"
+	ImportResultUpdated   ImportResult = "updated"
+	ImportResultUnchanged ImportResult = "unchanged"
+)
+
+type FeedImportID uuid.UUID //nolint:recvcheck
+
+func (f FeedImportID) String() string {
+	return uuid.UUID(f).String()
+}
+
+func (f *FeedImportID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(f), data)
+}
+
+func (f FeedImportID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(f))
+}
+
+type FeedImportStatus string
+
+const (
+	FeedImportStatusRunning   FeedImportStatus = "running"
+	FeedImportStatusSucceeded FeedImportStatus = "succeeded"
+	FeedImportStatusFailed    FeedImportStatus = "failed"
+)
+
+// FeedImport holds the stats of one import run. Offers counts the valid
+// offers in the feed, Rejected the ones that could not be imported and
+// Deactivated the products that were missing from the feed.
+type FeedImport struct {
+	ID             FeedImportID     `json:"id"`
+	FeedID         string           `json:"feedId"`
+	Format         FeedFormat       `json:"format"`
+	Status         FeedImportStatus `json:"status"`
+	Offers         int              `json:"offers"`
+	Created        int              `json:"created"`
+	Updated        int              `json:"updated"`
+	Unchanged      int              `json:"unchanged"`
+	Rejected       int              `json:"rejected"`
+	Deactivated    int              `json:"deactivated"`
+	ImagesMirrored int              `json:"imagesMirrored"`
+	ImagesFailed   int              `json:"imagesFailed"`
+	Errors         []string         `json:"errors"`
+	StartedAt      time.Time        `json:"startedAt"`
+	FinishedAt     *time.Time       `json:"finishedAt"`
+}
+
+type FeedImportFilter struct {
+	FeedID *string
+	Limit  int
+	Offset int
+}
+
+var (
+	ErrProductNotFound      = errors.New("product not found")
+	ErrProductImageNotFound = errors.New("product image not found")
+	ErrInvalidFeed          = errors.New("invalid feed")
+	ErrInvalidOffer         = errors.New("invalid offer")
+)
+
+func ValidateFeedID(feedID string) error {
+	if !feedIDPattern.MatchString(feedID) {
+		return fmt.Errorf("%w: feed id must be a lowercase slug, got %q", ErrInvalidFeed, feedID)
+	}
+
+	return nil
+}
+
+// AddError records a problem found during the import. Only the first
+// maxFeedImportErrors problems are kept.
+func (f *FeedImport) AddError(format string, args ...any) {
+	if len(f.Errors) < maxFeedImportErrors {
+		f.Errors = append(f.Errors, fmt.Sprintf(format, args...))
+	}
+}
+
+func (c *CatalogOffer) Validate() (CatalogOffer, error) {
+	c.ExternalID = strings.TrimSpace(c.ExternalID)
+	c.Name = strings.TrimSpace(c.Name)
+	c.Currency = strings.ToUpper(strings.TrimSpace(c.Currency))
+
+	switch {
+	case c.ExternalID == "":
+		return CatalogOffer{}, fmt.Errorf("%w: id is required", ErrInvalidOffer)
+	case c.Name == "":
+		return CatalogOffer{}, fmt.Errorf("%w: name is required", ErrInvalidOffer)
+	case c.Price <= 0:
+		return CatalogOffer{}, fmt.Errorf("%w: price must be positive", ErrInvalidOffer)
+	}
+
+	if c.OldPrice != nil && *c.OldPrice <= c.Price {
+		c.OldPrice = nil
+	}
+
+	if c.Currency == "" || c.Currency == "RUR" {
+		c.Currency = DefaultCurrency
+	}
+
+	pictures := make([]string, 0, len(c.Pictures))
+
+	for _, picture := range c.Pictures {
+		if len(pictures) == maxProductImages {
+			break
+		}
+
+		u, err := url.Parse(strings.TrimSpace(picture))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			continue
+		}
+
+		pictures = append(pictures, u.String())
+	}
+
+	c.Pictures = pictures
+
+	if len(c.Sizes) > 0 {
+		c.Available = false
+
+		for _, size := range c.Sizes {
+			c.Available = c.Available || size.Available
+		}
+	}
+
+	return *c, nil
+}
@@ -0,0 +1,277 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+type AttributeType string
+
+const (
+	AttributeTypeEnum    AttributeType = "enum"
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+)
+
+//nolint:gochecknoglobals
+var (
+	attributeTypes = []AttributeType{AttributeTypeEnum, AttributeTypeString, AttributeTypeNumber, AttributeTypeBoolean}
+
+	categoryIDPattern    = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	attributeNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+)
+
+// CategoryAttribute describes an attribute items of a category may carry.
+// Attributes are inherited by every subcategory.
+type CategoryAttribute struct {
+	Name          string        `json:"name"`
+	Type          AttributeType `json:"type"`
+	AllowedValues []string      `json:"allowedValues"`
+	Required      bool          `json:"required"`
+}
+
+// Category is a node of the clothing taxonomy, e.g. tops > shirts > oxford.
+// IDs are slugs and are what wardrobe items refer to.
+type Category struct {
+	ID         string              `json:"id"`
+	ParentID   *string             `json:"parentId"`
+	Name       string              `json:"name"`
+	Position   int                 `json:"position"`
+	Attributes []CategoryAttribute `json:"attributes"`
+	Children   []Category          `json:"children,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+}
+
+// CategoryUpdate changes a category. An empty ParentID moves the category to
+// the root of the taxonomy.
+type CategoryUpdate struct {
+	ParentID *string `json:"parentId"`
+	Name     *string `json:"name"`
+	Position *int    `json:"position"`
+}
+
+// CategoryPath is a category preceded by all of its ancestors, root first.
+type CategoryPath []Category
+
+var (
+	ErrCategoryNotFound          = errors.New("category not found")
+	ErrCategoryAttributeNotFound = errors.New("category attribute not found")
+	ErrInvalidCategory           = errors.New("invalid category")
+	ErrDuplicateCategory         = errors.New("duplicate category")
+	ErrCategoryInUse             = errors.New("category in use")
+)
+
+// FieldError points at a single invalid field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError collects every invalid field of a request. It wraps Err, so
+// callers can still match on the sentinel error.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func (v *ValidationError) Error() string {
+	messages := make([]string, 0, len(v.Fields))
+	for _, field := range v.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+
+	return fmt.Sprintf("%v: %s", v.Err, strings.Join(messages, "; "))
+}
+
+func (v *ValidationError) Unwrap() error {
+	return v.Err
+}
+
+func (a *CategoryAttribute) Validate() error {
+	a.Name = strings.TrimSpace(a.Name)
+
+	if !attributeNamePattern.MatchString(a.Name) {
+		return fmt.Errorf("%w: invalid attribute name %q", ErrInvalidCategory, a.Name)
+	}
+
+	if !slices.Contains(attributeTypes, a.Type) {
+		return fmt.Errorf("%w: unknown attribute type %q", ErrInvalidCategory, a.Type)
+	}
+
+	if a.AllowedValues == nil {
+		a.AllowedValues = []string{}
+	}
+
+	if a.Type != AttributeTypeEnum {
+		if len(a.AllowedValues) > 0 {
+			return fmt.Errorf("%w: only enum attributes have allowed values", ErrInvalidCategory)
+		}
+
+		return nil
+	}
+
+	if len(a.AllowedValues) == 0 {
+		return fmt.Errorf("%w: enum attribute %q needs allowed values", ErrInvalidCategory, a.Name)
+	}
+
+	for i, value := range a.AllowedValues {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("%w: empty allowed value", ErrInvalidCategory)
+		}
+
+		if slices.Contains(a.AllowedValues[:i], value) {
+			return fmt.Errorf("%w: duplicate allowed value %q", ErrInvalidCategory, value)
+		}
+	}
+
+	return nil
+}
+
+// check returns why value does not fit the attribute, or an empty string.
+func (a CategoryAttribute) check(value any) string {
+	switch a.Type {
+	case AttributeTypeEnum:
+		if s, ok := value.(string); !ok || !slices.Contains(a.AllowedValues, s) {
+			return "must be one of " + strings.Join(a.AllowedValues, ", ")
+		}
+	case AttributeTypeString:
+		if s, ok := value.(string); !ok || strings.TrimSpace(s) == "" {
+			return "must be a non-empty string"
+		}
+	case AttributeTypeNumber:
+		switch value.(type) {
+		case float64, int, int64:
+		default:
+			return "must be a number"
+		}
+	case AttributeTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean"
+		}
+	}
+
+	return ""
+}
+
+func (c *Category) Validate() (Category, error) {
+	c.ID = strings.TrimSpace(c.ID)
+	c.Name = strings.TrimSpace(c.Name)
+
+	if !categoryIDPattern.MatchString(c.ID) {
+		return Category{}, fmt.Errorf("%w: id must be a lowercase slug", ErrInvalidCategory)
+	}
+
+	if c.Name == "" {
+		return Category{}, fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+
+	if c.ParentID != nil && *c.ParentID == "" {
+		c.ParentID = nil
+	}
+
+	if c.Attributes == nil {
+		c.Attributes = []CategoryAttribute{}
+	}
+
+	for i := range c.Attributes {
+		if err := c.Attributes[i].Validate(); err != nil {
+			return Category{}, err
+		}
+
+		if slices.ContainsFunc(c.Attributes[:i], func(a CategoryAttribute) bool { return a.Name == c.Attributes[i].Name }) {
+			return Category{}, fmt.Errorf("%w: duplicate attribute %q", ErrInvalidCategory, c.Attributes[i].Name)
+		}
+	}
+
+	return *c, nil
+}
+
+func (cu *CategoryUpdate) Validate() (CategoryUpdate, error) {
+	if cu.Name != nil && strings.TrimSpace(*cu.Name) == "" {
+		return CategoryUpdate{}, fmt.Errorf("%w: name must not be empty", ErrInvalidCategory)
+	}
+
+	return *cu, nil
+}
+
+// Attributes returns the attributes available along the path, keyed by name.
+// A subcategory redefining an attribute overrides its ancestors.
+func (p CategoryPath) Attributes() map[string]CategoryAttribute {
+	attributes := make(map[string]CategoryAttribute)
+
+	for _, category := range p {
+		for _, attribute := range category.Attributes {
+			attributes[attribute.Name] = attribute
+		}
+	}
+
+	return attributes
+}
+
+// ValidateItem checks that subcategory, when set, is the last category of the
+// path and lies below category, and that attributes match the definitions
+// inherited along the path.
+func (p CategoryPath) ValidateItem(category string, subcategory *string, attributes map[string]any) error {
+	var fields []FieldError
+
+	index := slices.IndexFunc(p, func(c Category) bool { return c.ID == category })
+
+	switch {
+	case index < 0 && subcategory == nil:
+		fields = append(fields, FieldError{Field: "category", Message: "unknown category"})
+	case index < 0 || (subcategory != nil && index == len(p)-1):
+		fields = append(fields, FieldError{
+			Field:   "subcategory",
+			Message: fmt.Sprintf("must be a subcategory of %q", category),
+		})
+	}
+
+	definitions := p.Attributes()
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		definition, ok := definitions[name]
+		if !ok {
+			fields = append(fields, FieldError{Field: "attributes." + name, Message: "not supported by the category"})
+
+			continue
+		}
+
+		if message := definition.check(attributes[name]); message != "" {
+			fields = append(fields, FieldError{Field: "attributes." + name, Message: message})
+		}
+	}
+
+	required := make([]string, 0, len(definitions))
+
+	for name, definition := range definitions {
+		if _, ok := attributes[name]; definition.Required && !ok {
+			required = append(required, name)
+		}
+	}
+
+	sort.Strings(required)
+
+	for _, name := range required {
+		fields = append(fields, FieldError{Field: "attributes." + name, Message: "is required"})
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Err: ErrInvalidWardrobeItem, Fields: fields}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Page asks for Limit rows following the row Cursor points at.
+type Page struct {
+	Limit  int
+	Cursor string
+}
+
+// Cursor points at the last row of a page ordered by creation time. The id
+// breaks ties between rows created at the same time.
+type Cursor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// String encodes the cursor for clients, which should treat it as opaque.
+func (c Cursor) String() string {
+	data, _ := json.Marshal(c) //nolint:errchkjson
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor decodes a cursor. An empty value means the first page.
+func ParseCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+
+	if err := json.Unmarshal(data, &c); err != nil || c.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
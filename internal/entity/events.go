@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names what happened. Clients receive it as the server-sent event
+// name.
+type EventType string
+
+const (
+	EventMessageReceived    EventType = "message.received"
+	EventFollowerAdded      EventType = "follower.added"
+	EventOrderStatusChanged EventType = "order.status_changed"
+	EventPriceDropped       EventType = "price.dropped"
+)
+
+// Event is a real-time update for UserID. Data only carries identifiers and
+// a few fields, so that events stay small; clients fetch the rest through
+// the API.
+type Event struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      EventType       `json:"type"`
+	UserID    UserID          `json:"userId"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type MessageReceivedData struct {
+	ConversationID ConversationID `json:"conversationId"`
+	MessageID      MessageID      `json:"messageId"`
+	SenderID       UserID         `json:"senderId"`
+}
+
+type FollowerAddedData struct {
+	FollowerID UserID `json:"followerId"`
+}
+
+type OrderStatusChangedData struct {
+	OrderID OrderID     `json:"orderId"`
+	Status  OrderStatus `json:"status"`
+}
+
+type PriceDroppedData struct {
+	ProductID ProductID `json:"productId"`
+	OldPrice  int64     `json:"oldPrice"`
+	NewPrice  int64     `json:"newPrice"`
+	Currency  string    `json:"currency"`
+}
+
+func NewEvent(userID UserID, eventType EventType, data any) Event {
+	encoded, _ := json.Marshal(data) //nolint:errchkjson
+
+	return Event{
+		ID:        uuid.New(),
+		Type:      eventType,
+		UserID:    userID,
+		Data:      encoded,
+		CreatedAt: time.Now(),
+	}
+}
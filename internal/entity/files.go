@@ -1,5 +1,54 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"time"
 
-var ErrFileNotFound = errors.New("file not found")
+	"github.com/google/uuid"
+)
+
+type FileID uuid.UUID //nolint:recvcheck
+
+func (f FileID) String() string {
+	return uuid.UUID(f).String()
+}
+
+func (f *FileID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(f), data)
+}
+
+func (f FileID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(f))
+}
+
+type FileStatus string
+
+const (
+	FileStatusPending FileStatus = "pending"
+	FileStatusReady   FileStatus = "ready"
+	FileStatusDeleted FileStatus = "deleted"
+)
+
+type File struct {
+	ID          FileID     `json:"id"`
+	UserID      UserID     `json:"userId"`
+	Key         string     `json:"-"`
+	ContentType string     `json:"contentType"`
+	Size        int64      `json:"size"`
+	Checksum    string     `json:"checksum"`
+	Status      FileStatus `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+}
+
+type FileURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+var (
+	ErrFileNotFound           = errors.New("file not found")
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	ErrFileTooLarge           = errors.New("file is too large")
+)
@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxCommentLength        = 1000
+	maxCollectionNameLength = 100
+)
+
+// LikeState is the viewer's like on a post after a like or an unlike.
+type LikeState struct {
+	Liked      bool `json:"liked"`
+	LikesCount int  `json:"likesCount"`
+}
+
+type CommentID uuid.UUID //nolint:recvcheck
+
+func (c CommentID) String() string {
+	return uuid.UUID(c).String()
+}
+
+func (c *CommentID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(c), data)
+}
+
+func (c CommentID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(c))
+}
+
+// Comment is a comment on a post or, when ParentID is set, a reply to a
+// top-level comment. Replies to replies join the thread of the top-level
+// comment. Deleted comments keep their place in the thread without a body.
+type Comment struct {
+	ID             CommentID  `json:"id"`
+	PostID         PostID     `json:"postId"`
+	AuthorID       UserID     `json:"authorId"`
+	AuthorNickName string     `json:"authorNickName"`
+	ParentID       *CommentID `json:"parentId"`
+	Body           *string    `json:"body"`
+	Deleted        bool       `json:"deleted"`
+	RepliesCount   int        `json:"repliesCount"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type CommentUpdate struct {
+	Body string `json:"body"`
+}
+
+type CommentPage struct {
+	Comments   []Comment `json:"comments"`
+	NextCursor *string   `json:"nextCursor"`
+}
+
+type CollectionID uuid.UUID //nolint:recvcheck
+
+func (c CollectionID) String() string {
+	return uuid.UUID(c).String()
+}
+
+func (c *CollectionID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(c), data)
+}
+
+func (c CollectionID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(c))
+}
+
+// Collection is a named set of posts a user saved. Names are unique per user
+// regardless of case.
+type Collection struct {
+	ID         CollectionID `json:"id"`
+	UserID     UserID       `json:"userId"`
+	Name       string       `json:"name"`
+	PostsCount int          `json:"postsCount"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	UpdatedAt  time.Time    `json:"updatedAt"`
+}
+
+type CollectionUpdate struct {
+	Name *string `json:"name"`
+}
+
+type CollectionFilter struct {
+	Limit  int
+	Offset int
+}
+
+var (
+	ErrCommentNotFound     = errors.New("comment not found")
+	ErrInvalidComment      = errors.New("invalid comment")
+	ErrCollectionNotFound  = errors.New("collection not found")
+	ErrInvalidCollection   = errors.New("invalid collection")
+	ErrDuplicateCollection = errors.New("duplicate collection")
+)
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+
+	if body == "" {
+		return "", fmt.Errorf("%w: body is required", ErrInvalidComment)
+	}
+
+	if len([]rune(body)) > maxCommentLength {
+		return "", fmt.Errorf("%w: body is longer than %d characters", ErrInvalidComment, maxCommentLength)
+	}
+
+	return body, nil
+}
+
+func (c *Comment) Validate() (Comment, error) {
+	if c.Body == nil {
+		return Comment{}, fmt.Errorf("%w: body is required", ErrInvalidComment)
+	}
+
+	body, err := validateCommentBody(*c.Body)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	c.Body = &body
+
+	return *c, nil
+}
+
+func (cu *CommentUpdate) Validate() (CommentUpdate, error) {
+	body, err := validateCommentBody(cu.Body)
+	if err != nil {
+		return CommentUpdate{}, err
+	}
+
+	cu.Body = body
+
+	return *cu, nil
+}
+
+func validateCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidCollection)
+	}
+
+	if len([]rune(name)) > maxCollectionNameLength {
+		return "", fmt.Errorf("%w: name is longer than %d characters", ErrInvalidCollection, maxCollectionNameLength)
+	}
+
+	return name, nil
+}
+
+func (c *Collection) Validate() (Collection, error) {
+	name, err := validateCollectionName(c.Name)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	c.Name = name
+
+	return *c, nil
+}
+
+func (cu *CollectionUpdate) Validate() (CollectionUpdate, error) {
+	if cu.Name == nil {
+		return *cu, nil
+	}
+
+	name, err := validateCollectionName(*cu.Name)
+	if err != nil {
+		return CollectionUpdate{}, err
+	}
+
+	cu.Name = &name
+
+	return *cu, nil
+}
@@ -0,0 +1,370 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxListingTitleLength       = 120
+	maxListingDescriptionLength = 4000
+	maxOfferMessageLength       = 500
+)
+
+type ListingID uuid.UUID //nolint:recvcheck
+
+func (l ListingID) String() string {
+	return uuid.UUID(l).String()
+}
+
+func (l *ListingID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(l), data)
+}
+
+func (l ListingID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(l))
+}
+
+type ListingOfferID uuid.UUID //nolint:recvcheck
+
+func (o ListingOfferID) String() string {
+	return uuid.UUID(o).String()
+}
+
+func (o *ListingOfferID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(o), data)
+}
+
+func (o ListingOfferID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(o))
+}
+
+// ListingStatus moves forward along listingTransitions only. Drafts are seen
+// by their sellers alone; buyers see every listing that was published.
+type ListingStatus string
+
+const (
+	ListingStatusDraft     ListingStatus = "draft"
+	ListingStatusActive    ListingStatus = "active"
+	ListingStatusReserved  ListingStatus = "reserved"
+	ListingStatusSold      ListingStatus = "sold"
+	ListingStatusWithdrawn ListingStatus = "withdrawn"
+)
+
+//nolint:gochecknoglobals
+var listingTransitions = map[ListingStatus][]ListingStatus{
+	ListingStatusDraft:    {ListingStatusActive, ListingStatusWithdrawn},
+	ListingStatusActive:   {ListingStatusReserved, ListingStatusWithdrawn},
+	ListingStatusReserved: {ListingStatusSold, ListingStatusWithdrawn},
+}
+
+func (l ListingStatus) Validate() error {
+	switch l {
+	case ListingStatusDraft, ListingStatusActive, ListingStatusReserved, ListingStatusSold, ListingStatusWithdrawn:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidListing, l)
+	}
+}
+
+// CanTransition reports whether a listing in status l may be moved to status to.
+func (l ListingStatus) CanTransition(to ListingStatus) bool {
+	return slices.Contains(listingTransitions[l], to)
+}
+
+// Editable reports whether the seller may still change the listing.
+func (l ListingStatus) Editable() bool {
+	return l == ListingStatusDraft || l == ListingStatusActive
+}
+
+// Condition is how worn a resold item is.
+type Condition string
+
+const (
+	ConditionNewWithTags Condition = "new_with_tags"
+	ConditionLikeNew     Condition = "like_new"
+	ConditionGood        Condition = "good"
+	ConditionFair        Condition = "fair"
+)
+
+func (c Condition) Validate() error {
+	switch c {
+	case ConditionNewWithTags, ConditionLikeNew, ConditionGood, ConditionFair:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown condition %q", ErrInvalidListing, c)
+	}
+}
+
+// Listing is a wardrobe item its owner offers for sale. Categories, Brand
+// and Size are taken from the item when the listing is created, and so are
+// the photos unless the seller picks others. Price is in Currency minor units.
+// BuyerID is the buyer whose offer the seller accepted.
+type Listing struct {
+	ID          ListingID      `json:"id"`
+	SellerID    UserID         `json:"sellerId"`
+	ItemID      WardrobeItemID `json:"itemId"`
+	Title       string         `json:"title"`
+	Description *string        `json:"description"`
+	Price       int64          `json:"price"`
+	Currency    string         `json:"currency"`
+	Condition   Condition      `json:"condition"`
+	Category    string         `json:"category"`
+	Subcategory *string        `json:"subcategory"`
+	Brand       *string        `json:"brand"`
+	Size        *string        `json:"size"`
+	PhotoIDs    []FileID       `json:"photoIds"`
+	Status      ListingStatus  `json:"status"`
+	BuyerID     *UserID        `json:"buyerId"`
+	PublishedAt *time.Time     `json:"publishedAt"`
+	SoldAt      *time.Time     `json:"soldAt"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type ListingUpdate struct {
+	Title       *string    `json:"title"`
+	Description *string    `json:"description"`
+	Price       *int64     `json:"price"`
+	Condition   *Condition `json:"condition"`
+	PhotoIDs    *[]FileID  `json:"photoIds"`
+}
+
+// ListingFilter narrows down listings. Text searches titles, brands and
+// descriptions and orders the results by relevance; listings are newest
+// first otherwise. SellerID lists a seller's own listings in every status.
+type ListingFilter struct {
+	SellerID  *UserID
+	Statuses  []ListingStatus
+	Text      string
+	Category  *string
+	Brand     *string
+	Size      *string
+	Condition *Condition
+	MinPrice  *int64
+	MaxPrice  *int64
+	Limit     int
+	Offset    int
+}
+
+type ListingOfferStatus string
+
+const (
+	ListingOfferStatusPending   ListingOfferStatus = "pending"
+	ListingOfferStatusAccepted  ListingOfferStatus = "accepted"
+	ListingOfferStatusDeclined  ListingOfferStatus = "declined"
+	ListingOfferStatusCancelled ListingOfferStatus = "cancelled"
+)
+
+// ListingOffer is what a buyer proposes to pay for a listing. Accepting an offer
+// reserves the listing for its buyer. Offers still pending when the listing
+// is sold are declined, and offers of a withdrawn listing are cancelled.
+type ListingOffer struct {
+	ID        ListingOfferID     `json:"id"`
+	ListingID ListingID          `json:"listingId"`
+	BuyerID   UserID             `json:"buyerId"`
+	Amount    int64              `json:"amount"`
+	Message   *string            `json:"message"`
+	Status    ListingOfferStatus `json:"status"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+type ListingOfferFilter struct {
+	Limit  int
+	Offset int
+}
+
+var (
+	ErrListingNotFound          = errors.New("listing not found")
+	ErrInvalidListing           = errors.New("invalid listing")
+	ErrDuplicateListing         = errors.New("item is already listed")
+	ErrInvalidListingTransition = errors.New("invalid listing status transition")
+	ErrListingOfferNotFound     = errors.New("offer not found")
+	ErrInvalidListingOffer      = errors.New("invalid offer")
+	ErrDuplicateListingOffer    = errors.New("offer is already pending")
+)
+
+func validateListingTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+
+	switch {
+	case title == "":
+		return "", fmt.Errorf("%w: title must not be empty", ErrInvalidListing)
+	case len([]rune(title)) > maxListingTitleLength:
+		return "", fmt.Errorf("%w: title must be at most %d characters", ErrInvalidListing, maxListingTitleLength)
+	}
+
+	return title, nil
+}
+
+func validateListingDescription(description *string) (*string, error) {
+	if description == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	trimmed := strings.TrimSpace(*description)
+
+	switch {
+	case trimmed == "":
+		return nil, nil //nolint:nilnil
+	case len([]rune(trimmed)) > maxListingDescriptionLength:
+		return nil, fmt.Errorf("%w: description must be at most %d characters", ErrInvalidListing, maxListingDescriptionLength)
+	}
+
+	return &trimmed, nil
+}
+
+func validateListingPrice(price int64) error {
+	if price < 1 {
+		return fmt.Errorf("%w: price must be positive", ErrInvalidListing)
+	}
+
+	return nil
+}
+
+func validateListingPhotos(photoIDs []FileID) error {
+	if err := validatePhotoIDs(photoIDs); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidListing, err)
+	}
+
+	return nil
+}
+
+// Validate checks a new listing. The title defaults to the item's name when
+// the listing is created.
+func (l *Listing) Validate() (Listing, error) {
+	if strings.TrimSpace(l.Title) != "" {
+		title, err := validateListingTitle(l.Title)
+		if err != nil {
+			return Listing{}, err
+		}
+
+		l.Title = title
+	}
+
+	description, err := validateListingDescription(l.Description)
+	if err != nil {
+		return Listing{}, err
+	}
+
+	l.Description = description
+
+	if err := validateListingPrice(l.Price); err != nil {
+		return Listing{}, err
+	}
+
+	if l.Currency == "" {
+		l.Currency = DefaultCurrency
+	}
+
+	if err := l.Condition.Validate(); err != nil {
+		return Listing{}, err
+	}
+
+	if err := validateListingPhotos(l.PhotoIDs); err != nil {
+		return Listing{}, err
+	}
+
+	l.Status = ListingStatusDraft
+
+	return *l, nil
+}
+
+func (lu *ListingUpdate) Validate() (ListingUpdate, error) {
+	if *lu == (ListingUpdate{}) {
+		return ListingUpdate{}, fmt.Errorf("%w: nothing to update", ErrInvalidListing)
+	}
+
+	if lu.Title != nil {
+		title, err := validateListingTitle(*lu.Title)
+		if err != nil {
+			return ListingUpdate{}, err
+		}
+
+		lu.Title = &title
+	}
+
+	if lu.Description != nil {
+		description, err := validateListingDescription(lu.Description)
+		if err != nil {
+			return ListingUpdate{}, err
+		}
+
+		if description == nil {
+			description = new(string)
+		}
+
+		lu.Description = description
+	}
+
+	if lu.Price != nil {
+		if err := validateListingPrice(*lu.Price); err != nil {
+			return ListingUpdate{}, err
+		}
+	}
+
+	if lu.Condition != nil {
+		if err := lu.Condition.Validate(); err != nil {
+			return ListingUpdate{}, err
+		}
+	}
+
+	if lu.PhotoIDs != nil {
+		if err := validateListingPhotos(*lu.PhotoIDs); err != nil {
+			return ListingUpdate{}, err
+		}
+	}
+
+	return *lu, nil
+}
+
+func (f *ListingFilter) Validate() (ListingFilter, error) {
+	f.Text = strings.TrimSpace(f.Text)
+
+	for _, status := range f.Statuses {
+		if err := status.Validate(); err != nil {
+			return ListingFilter{}, err
+		}
+	}
+
+	if f.Condition != nil {
+		if err := f.Condition.Validate(); err != nil {
+			return ListingFilter{}, err
+		}
+	}
+
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return ListingFilter{}, fmt.Errorf("%w: minimum price is above maximum price", ErrInvalidListing)
+	}
+
+	return *f, nil
+}
+
+func (o *ListingOffer) Validate() (ListingOffer, error) {
+	if o.Amount < 1 {
+		return ListingOffer{}, fmt.Errorf("%w: amount must be positive", ErrInvalidListingOffer)
+	}
+
+	if o.Message != nil {
+		message := strings.TrimSpace(*o.Message)
+
+		switch {
+		case message == "":
+			o.Message = nil
+		case len([]rune(message)) > maxOfferMessageLength:
+			return ListingOffer{}, fmt.Errorf("%w: message must be at most %d characters", ErrInvalidListingOffer, maxOfferMessageLength)
+		default:
+			o.Message = &message
+		}
+	}
+
+	o.Status = ListingOfferStatusPending
+
+	return *o, nil
+}
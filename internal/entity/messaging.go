@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxMessageLength = 2000
+
+type ConversationID uuid.UUID //nolint:recvcheck
+
+func (c ConversationID) String() string {
+	return uuid.UUID(c).String()
+}
+
+func (c *ConversationID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(c), data)
+}
+
+func (c ConversationID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(c))
+}
+
+type MessageID uuid.UUID //nolint:recvcheck
+
+func (m MessageID) String() string {
+	return uuid.UUID(m).String()
+}
+
+func (m *MessageID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(m), data)
+}
+
+func (m MessageID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(m))
+}
+
+// Conversation is the thread between a listing's seller and one buyer. A
+// buyer has one conversation per listing. UnreadCount counts the messages
+// the viewing participant has not read yet.
+type Conversation struct {
+	ID            ConversationID `json:"id"`
+	ListingID     ListingID      `json:"listingId"`
+	SellerID      UserID         `json:"sellerId"`
+	BuyerID       UserID         `json:"buyerId"`
+	LastMessageAt *time.Time     `json:"lastMessageAt"`
+	UnreadCount   int            `json:"unreadCount"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+// Message is a text, photos or both sent to a conversation. ReadAt is when
+// the recipient read it.
+type Message struct {
+	ID             MessageID      `json:"id"`
+	ConversationID ConversationID `json:"conversationId"`
+	SenderID       UserID         `json:"senderId"`
+	Body           *string        `json:"body"`
+	PhotoIDs       []FileID       `json:"photoIds"`
+	ReadAt         *time.Time     `json:"readAt"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+// MessagePage is a page of messages, newest first. NextCursor continues
+// with older messages and is nil on the last page.
+type MessagePage struct {
+	Messages   []Message `json:"messages"`
+	NextCursor *string   `json:"nextCursor"`
+}
+
+type ConversationFilter struct {
+	Limit  int
+	Offset int
+}
+
+// UnreadCount sums up what a user has not read across conversations.
+type UnreadCount struct {
+	Conversations int `json:"conversations"`
+	Messages      int `json:"messages"`
+}
+
+// Block stops BlockedID and BlockerID from messaging each other.
+type Block struct {
+	BlockerID UserID    `json:"blockerId"`
+	BlockedID UserID    `json:"blockedId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+var (
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrInvalidConversation  = errors.New("invalid conversation")
+	ErrInvalidMessage       = errors.New("invalid message")
+	ErrBlockNotFound        = errors.New("block not found")
+	ErrInvalidBlock         = errors.New("invalid block")
+	ErrUserBlocked          = errors.New("user is blocked")
+)
+
+func (m *Message) Validate() (Message, error) {
+	if m.Body != nil {
+		body := strings.TrimSpace(*m.Body)
+
+		switch {
+		case body == "":
+			m.Body = nil
+		case len([]rune(body)) > maxMessageLength:
+			return Message{}, fmt.Errorf("%w: message must be at most %d characters", ErrInvalidMessage, maxMessageLength)
+		default:
+			m.Body = &body
+		}
+	}
+
+	if m.Body == nil && len(m.PhotoIDs) == 0 {
+		return Message{}, fmt.Errorf("%w: message must have a text or photos", ErrInvalidMessage)
+	}
+
+	if err := validatePhotoIDs(m.PhotoIDs); err != nil {
+		return Message{}, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+
+	return *m, nil
+}
+
+func (b *Block) Validate() (Block, error) {
+	if b.BlockerID == b.BlockedID {
+		return Block{}, fmt.Errorf("%w: users cannot block themselves", ErrInvalidBlock)
+	}
+
+	return *b, nil
+}
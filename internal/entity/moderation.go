@@ -0,0 +1,230 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxReportDetailsLength  = 1000
+	maxModerationNoteLength = 1000
+)
+
+// UserStatus is set by moderators. Suspended users can neither use their
+// tokens nor get new ones.
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended"
+)
+
+type ReportID uuid.UUID //nolint:recvcheck
+
+func (r ReportID) String() string {
+	return uuid.UUID(r).String()
+}
+
+func (r *ReportID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(r), data)
+}
+
+func (r ReportID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(r))
+}
+
+type ReportTargetType string
+
+const (
+	ReportTargetPost    ReportTargetType = "post"
+	ReportTargetComment ReportTargetType = "comment"
+	ReportTargetUser    ReportTargetType = "user"
+)
+
+//nolint:gochecknoglobals
+var reportTargetTypes = []ReportTargetType{ReportTargetPost, ReportTargetComment, ReportTargetUser}
+
+func (r ReportTargetType) Validate() error {
+	if !slices.Contains(reportTargetTypes, r) {
+		return fmt.Errorf("%w: unknown target type %q", ErrInvalidReport, r)
+	}
+
+	return nil
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam        ReportReason = "spam"
+	ReportReasonHarassment  ReportReason = "harassment"
+	ReportReasonHate        ReportReason = "hate"
+	ReportReasonNudity      ReportReason = "nudity"
+	ReportReasonViolence    ReportReason = "violence"
+	ReportReasonScam        ReportReason = "scam"
+	ReportReasonCounterfeit ReportReason = "counterfeit"
+	ReportReasonOther       ReportReason = "other"
+)
+
+//nolint:gochecknoglobals
+var reportReasons = []ReportReason{
+	ReportReasonSpam, ReportReasonHarassment, ReportReasonHate, ReportReasonNudity,
+	ReportReasonViolence, ReportReasonScam, ReportReasonCounterfeit, ReportReasonOther,
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusResolved  ReportStatus = "resolved"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+//nolint:gochecknoglobals
+var reportStatuses = []ReportStatus{ReportStatusOpen, ReportStatusResolved, ReportStatusDismissed}
+
+// Report flags a post, a comment or a user for moderators. Reports are
+// resolved when a moderator acts on the target and dismissed when the
+// moderator finds nothing wrong.
+type Report struct {
+	ID         ReportID         `json:"id"`
+	ReporterID UserID           `json:"reporterId"`
+	TargetType ReportTargetType `json:"targetType"`
+	TargetID   uuid.UUID        `json:"targetId"`
+	Reason     ReportReason     `json:"reason"`
+	Details    *string          `json:"details"`
+	Status     ReportStatus     `json:"status"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	ResolvedAt *time.Time       `json:"resolvedAt"`
+	ResolvedBy *UserID          `json:"resolvedBy"`
+}
+
+// ReportFilter selects the moderation queue. Status defaults to open.
+type ReportFilter struct {
+	Status     ReportStatus
+	TargetType *ReportTargetType
+	Page       Page
+}
+
+type ReportPage struct {
+	Reports    []Report `json:"reports"`
+	NextCursor *string  `json:"nextCursor"`
+}
+
+type ModerationAction string
+
+const (
+	ModerationActionHidePost      ModerationAction = "hide_post"
+	ModerationActionHideComment   ModerationAction = "hide_comment"
+	ModerationActionDismissReport ModerationAction = "dismiss_report"
+	ModerationActionSuspendUser   ModerationAction = "suspend_user"
+	ModerationActionUnsuspendUser ModerationAction = "unsuspend_user"
+)
+
+// ModerationRequest carries the moderator's note for the audit log.
+type ModerationRequest struct {
+	Note *string `json:"note"`
+}
+
+type AuditLogEntryID uuid.UUID //nolint:recvcheck
+
+func (a AuditLogEntryID) String() string {
+	return uuid.UUID(a).String()
+}
+
+func (a *AuditLogEntryID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(a), data)
+}
+
+func (a AuditLogEntryID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(a))
+}
+
+// AuditLogEntry records a moderation action. TargetType is a report target
+// type or "report" for dismissed reports.
+type AuditLogEntry struct {
+	ID          AuditLogEntryID  `json:"id"`
+	ModeratorID UserID           `json:"moderatorId"`
+	Action      ModerationAction `json:"action"`
+	TargetType  string           `json:"targetType"`
+	TargetID    uuid.UUID        `json:"targetId"`
+	Note        *string          `json:"note"`
+	CreatedAt   time.Time        `json:"createdAt"`
+}
+
+type AuditLogPage struct {
+	Entries    []AuditLogEntry `json:"entries"`
+	NextCursor *string         `json:"nextCursor"`
+}
+
+var (
+	ErrReportNotFound    = errors.New("report not found")
+	ErrInvalidReport     = errors.New("invalid report")
+	ErrDuplicateReport   = errors.New("target is already reported")
+	ErrInvalidModeration = errors.New("invalid moderation request")
+	ErrUserSuspended     = errors.New("user is suspended")
+)
+
+func (r *Report) Validate() (Report, error) {
+	if err := r.TargetType.Validate(); err != nil {
+		return Report{}, err
+	}
+
+	if r.TargetID == uuid.Nil {
+		return Report{}, fmt.Errorf("%w: target is required", ErrInvalidReport)
+	}
+
+	if !slices.Contains(reportReasons, r.Reason) {
+		return Report{}, fmt.Errorf("%w: unknown reason %q", ErrInvalidReport, r.Reason)
+	}
+
+	if r.Details != nil {
+		details := strings.TrimSpace(*r.Details)
+
+		if len([]rune(details)) > maxReportDetailsLength {
+			return Report{}, fmt.Errorf("%w: details are longer than %d characters", ErrInvalidReport, maxReportDetailsLength)
+		}
+
+		r.Details = &details
+	}
+
+	return *r, nil
+}
+
+func (f *ReportFilter) Validate() (ReportFilter, error) {
+	if f.Status == "" {
+		f.Status = ReportStatusOpen
+	}
+
+	if !slices.Contains(reportStatuses, f.Status) {
+		return ReportFilter{}, fmt.Errorf("%w: unknown status %q", ErrInvalidModeration, f.Status)
+	}
+
+	if f.TargetType != nil {
+		if !slices.Contains(reportTargetTypes, *f.TargetType) {
+			return ReportFilter{}, fmt.Errorf("%w: unknown target type %q", ErrInvalidModeration, *f.TargetType)
+		}
+	}
+
+	return *f, nil
+}
+
+func (m *ModerationRequest) Validate() (ModerationRequest, error) {
+	if m.Note == nil {
+		return *m, nil
+	}
+
+	note := strings.TrimSpace(*m.Note)
+
+	if len([]rune(note)) > maxModerationNoteLength {
+		return ModerationRequest{}, fmt.Errorf("%w: note is longer than %d characters", ErrInvalidModeration, maxModerationNoteLength)
+	}
+
+	m.Note = &note
+
+	return *m, nil
+}
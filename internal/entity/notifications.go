@@ -0,0 +1,284 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // quiet hours are kept in the user's time zone
+
+	"github.com/google/uuid"
+)
+
+const (
+	quietHoursLayout     = "15:04"
+	DefaultTimeZone      = "Europe/Moscow"
+	maxNotificationTitle = 200
+)
+
+type NotificationID uuid.UUID //nolint:recvcheck
+
+func (n NotificationID) String() string {
+	return uuid.UUID(n).String()
+}
+
+func (n *NotificationID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(n), data)
+}
+
+func (n NotificationID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(n))
+}
+
+// NotificationCategory groups notifications so users can choose how they
+// hear about each group.
+type NotificationCategory string
+
+const (
+	NotificationCategorySocial     NotificationCategory = "social"
+	NotificationCategoryOrders     NotificationCategory = "orders"
+	NotificationCategoryPriceDrops NotificationCategory = "price_drops"
+	NotificationCategoryMarketing  NotificationCategory = "marketing"
+)
+
+//nolint:gochecknoglobals
+var NotificationCategories = []NotificationCategory{
+	NotificationCategorySocial,
+	NotificationCategoryOrders,
+	NotificationCategoryPriceDrops,
+	NotificationCategoryMarketing,
+}
+
+func (n NotificationCategory) Validate() error {
+	switch n {
+	case NotificationCategorySocial, NotificationCategoryOrders,
+		NotificationCategoryPriceDrops, NotificationCategoryMarketing:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown category %q", ErrInvalidNotificationSettings, n)
+	}
+}
+
+// NotificationChannel is where a notification is delivered. In-app
+// notifications are listed by the API, the other channels reach the user
+// outside the app.
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelSMS   NotificationChannel = "sms"
+)
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusPending NotificationDeliveryStatus = "pending"
+	NotificationDeliveryStatusSent    NotificationDeliveryStatus = "sent"
+	NotificationDeliveryStatusFailed  NotificationDeliveryStatus = "failed"
+)
+
+// Notification is something that happened to a user. ReadAt is when the
+// user read it in the app.
+type Notification struct {
+	ID        NotificationID       `json:"id"`
+	UserID    UserID               `json:"userId"`
+	Category  NotificationCategory `json:"category"`
+	Title     string               `json:"title"`
+	Body      string               `json:"body"`
+	ReadAt    *time.Time           `json:"readAt"`
+	CreatedAt time.Time            `json:"createdAt"`
+}
+
+type NotificationFilter struct {
+	Unread bool
+	Limit  int
+	Offset int
+}
+
+// NotificationDelivery is a notification waiting to be sent through a
+// channel outside the app. Attempts counts the attempts to send it, the
+// current one included.
+type NotificationDelivery struct {
+	Notification Notification
+	Channel      NotificationChannel
+	Phone        string
+	QuietHours   *QuietHours
+	Attempts     int
+}
+
+// NotificationPreference is the channels a user opted in to for a category.
+type NotificationPreference struct {
+	Category NotificationCategory `json:"category"`
+	InApp    bool                 `json:"inApp"`
+	SMS      bool                 `json:"sms"`
+}
+
+// DefaultNotificationPreference applies to categories the user has not set
+// up: everything but marketing is shown in the app and nothing is texted.
+func DefaultNotificationPreference(category NotificationCategory) NotificationPreference {
+	return NotificationPreference{
+		Category: category,
+		InApp:    category != NotificationCategoryMarketing,
+	}
+}
+
+// Channels lists the channels the preference opted in to.
+func (n NotificationPreference) Channels() []NotificationChannel {
+	var channels []NotificationChannel
+
+	if n.InApp {
+		channels = append(channels, NotificationChannelInApp)
+	}
+
+	if n.SMS {
+		channels = append(channels, NotificationChannelSMS)
+	}
+
+	return channels
+}
+
+// QuietHours is a daily period when notifications are not sent outside the
+// app. Start and End are "15:04" times in TimeZone; an End before Start
+// spans midnight.
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"timeZone"`
+}
+
+// NotificationSettings is how a user wants to be notified. Preferences has
+// an entry for every category.
+type NotificationSettings struct {
+	Preferences []NotificationPreference `json:"preferences"`
+	QuietHours  *QuietHours              `json:"quietHours"`
+}
+
+var (
+	ErrNotificationNotFound        = errors.New("notification not found")
+	ErrInvalidNotification         = errors.New("invalid notification")
+	ErrInvalidNotificationSettings = errors.New("invalid notification settings")
+)
+
+func (n *Notification) Validate() (Notification, error) {
+	if err := n.Category.Validate(); err != nil {
+		return Notification{}, fmt.Errorf("%w: %w", ErrInvalidNotification, err)
+	}
+
+	n.Title = strings.TrimSpace(n.Title)
+	if n.Title == "" || len([]rune(n.Title)) > maxNotificationTitle {
+		return Notification{}, fmt.Errorf("%w: title must be 1 to %d characters", ErrInvalidNotification, maxNotificationTitle)
+	}
+
+	n.Body = strings.TrimSpace(n.Body)
+
+	return *n, nil
+}
+
+func (q *QuietHours) Validate() (QuietHours, error) {
+	if q.TimeZone == "" {
+		q.TimeZone = DefaultTimeZone
+	}
+
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return QuietHours{}, fmt.Errorf("%w: unknown time zone %q", ErrInvalidNotificationSettings, q.TimeZone)
+	}
+
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("%w: start must be a time like 22:00", ErrInvalidNotificationSettings)
+	}
+
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("%w: end must be a time like 08:00", ErrInvalidNotificationSettings)
+	}
+
+	if start.Equal(end) {
+		return QuietHours{}, fmt.Errorf("%w: quiet hours must not start and end at once", ErrInvalidNotificationSettings)
+	}
+
+	return *q, nil
+}
+
+// EndsAt returns when the quiet hours t falls into end and false when t is
+// outside quiet hours. The quiet hours must be valid.
+func (q QuietHours) EndsAt(t time.Time) (time.Time, bool) {
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	start, _ := time.Parse(quietHoursLayout, q.Start)
+	end, _ := time.Parse(quietHoursLayout, q.End)
+
+	local := t.In(location)
+	now := clockTime(local)
+	from, until := clockTime(start), clockTime(end)
+
+	days := 0
+
+	switch {
+	case from < until && now >= from && now < until:
+	case from > until && now < until:
+	case from > until && now >= from:
+		days = 1
+	default:
+		return time.Time{}, false
+	}
+
+	return time.Date(local.Year(), local.Month(), local.Day()+days, end.Hour(), end.Minute(), 0, 0, location), true
+}
+
+// clockTime is the time of day of t.
+func clockTime(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// Preference returns the user's preference for the category.
+func (n NotificationSettings) Preference(category NotificationCategory) NotificationPreference {
+	for _, preference := range n.Preferences {
+		if preference.Category == category {
+			return preference
+		}
+	}
+
+	return DefaultNotificationPreference(category)
+}
+
+// Validate checks the settings and fills the categories left out with their
+// defaults.
+func (n *NotificationSettings) Validate() (NotificationSettings, error) {
+	seen := make(map[NotificationCategory]bool, len(n.Preferences))
+
+	for _, preference := range n.Preferences {
+		if err := preference.Category.Validate(); err != nil {
+			return NotificationSettings{}, err
+		}
+
+		if seen[preference.Category] {
+			return NotificationSettings{}, fmt.Errorf("%w: category %q is set twice",
+				ErrInvalidNotificationSettings, preference.Category)
+		}
+
+		seen[preference.Category] = true
+	}
+
+	preferences := make([]NotificationPreference, 0, len(NotificationCategories))
+
+	for _, category := range NotificationCategories {
+		preferences = append(preferences, n.Preference(category))
+	}
+
+	n.Preferences = preferences
+
+	if n.QuietHours != nil {
+		quietHours, err := n.QuietHours.Validate()
+		if err != nil {
+			return NotificationSettings{}, err
+		}
+
+		n.QuietHours = &quietHours
+	}
+
+	return *n, nil
+}
@@ -0,0 +1,246 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxCartQuantity = 20
+
+type CartItemID uuid.UUID //nolint:recvcheck
+
+func (c CartItemID) String() string {
+	return uuid.UUID(c).String()
+}
+
+func (c *CartItemID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(c), data)
+}
+
+func (c CartItemID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(c))
+}
+
+type OrderID uuid.UUID //nolint:recvcheck
+
+func (o OrderID) String() string {
+	return uuid.UUID(o).String()
+}
+
+func (o *OrderID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(o), data)
+}
+
+func (o OrderID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(o))
+}
+
+// CartItem is a product, and the size of it for products sold in sizes, a
+// user is about to buy. Name, Price and the rest describe the product as it
+// is now; prices are only fixed at checkout.
+type CartItem struct {
+	ID        CartItemID `json:"id"`
+	UserID    UserID     `json:"userId"`
+	ProductID ProductID  `json:"productId"`
+	Size      *string    `json:"size"`
+	Quantity  int        `json:"quantity"`
+	Name      string     `json:"name"`
+	Brand     *string    `json:"brand"`
+	Price     int64      `json:"price"`
+	Currency  string     `json:"currency"`
+	Available bool       `json:"available"`
+	// Categories are the product's category and its parents, for pricing.
+	Categories []string  `json:"-"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type CartItemUpdate struct {
+	Size     *string `json:"size"`
+	Quantity *int    `json:"quantity"`
+}
+
+type Cart struct {
+	Items []CartItem `json:"items"`
+	Pricing
+}
+
+// OrderStatus moves forward along orderTransitions only.
+type OrderStatus string
+
+const (
+	OrderStatusCreated   OrderStatus = "created"
+	OrderStatusPaid      OrderStatus = "paid"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusRefunded  OrderStatus = "refunded"
+)
+
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusCreated:   {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:      {OrderStatusShipped, OrderStatusRefunded},
+	OrderStatusShipped:   {OrderStatusDelivered},
+	OrderStatusDelivered: {OrderStatusRefunded},
+}
+
+func (o OrderStatus) Validate() error {
+	switch o {
+	case OrderStatusCreated, OrderStatusPaid, OrderStatusShipped,
+		OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidOrder, o)
+	}
+}
+
+// CanTransition reports whether an order in status o may be moved to status to.
+func (o OrderStatus) CanTransition(to OrderStatus) bool {
+	return slices.Contains(orderTransitions[o], to)
+}
+
+// ReleasesStock reports whether moving an order from status o to status to
+// puts its items back on sale: the order is dropped before it left the
+// warehouse.
+func (o OrderStatus) ReleasesStock(to OrderStatus) bool {
+	return (o == OrderStatusCreated || o == OrderStatusPaid) &&
+		(to == OrderStatusCancelled || to == OrderStatusRefunded)
+}
+
+// OrderItem is a snapshot of a cart item taken at checkout. It does not
+// change when the product does, and survives the product being removed.
+type OrderItem struct {
+	Position   int        `json:"position"`
+	ProductID  *ProductID `json:"productId"`
+	FeedID     string     `json:"feedId"`
+	ExternalID string     `json:"externalId"`
+	Name       string     `json:"name"`
+	Brand      *string    `json:"brand"`
+	Size       *string    `json:"size"`
+	Price      int64      `json:"price"`
+	Quantity   int        `json:"quantity"`
+}
+
+type Order struct {
+	ID     OrderID     `json:"id"`
+	UserID UserID      `json:"userId"`
+	Status OrderStatus `json:"status"`
+	Items  []OrderItem `json:"items"`
+	Pricing
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type OrderFilter struct {
+	Status *OrderStatus
+	Limit  int
+	Offset int
+}
+
+type OrderStatusUpdate struct {
+	Status OrderStatus `json:"status"`
+}
+
+var (
+	ErrCartItemNotFound       = errors.New("cart item not found")
+	ErrDuplicateCartItem      = errors.New("product size is already in the cart")
+	ErrInvalidCartItem        = errors.New("invalid cart item")
+	ErrEmptyCart              = errors.New("cart is empty")
+	ErrOutOfStock             = errors.New("product is out of stock")
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrInvalidOrder           = errors.New("invalid order")
+	ErrInvalidOrderTransition = errors.New("order status cannot be changed")
+)
+
+func validateCartQuantity(quantity int) error {
+	if quantity < 1 || quantity > maxCartQuantity {
+		return fmt.Errorf("%w: quantity must be between 1 and %d", ErrInvalidCartItem, maxCartQuantity)
+	}
+
+	return nil
+}
+
+func validateCartSize(size *string) (*string, error) {
+	if size == nil {
+		return nil, nil
+	}
+
+	trimmed := strings.TrimSpace(*size)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: size must not be empty", ErrInvalidCartItem)
+	}
+
+	return &trimmed, nil
+}
+
+func (c *CartItem) Validate() (CartItem, error) {
+	if c.ProductID == (ProductID{}) {
+		return CartItem{}, fmt.Errorf("%w: product is required", ErrInvalidCartItem)
+	}
+
+	if c.Quantity == 0 {
+		c.Quantity = 1
+	}
+
+	if err := validateCartQuantity(c.Quantity); err != nil {
+		return CartItem{}, err
+	}
+
+	size, err := validateCartSize(c.Size)
+	if err != nil {
+		return CartItem{}, err
+	}
+
+	c.Size = size
+
+	return *c, nil
+}
+
+func (cu *CartItemUpdate) Validate() (CartItemUpdate, error) {
+	if cu.Size == nil && cu.Quantity == nil {
+		return CartItemUpdate{}, fmt.Errorf("%w: nothing to update", ErrInvalidCartItem)
+	}
+
+	if cu.Quantity != nil {
+		if err := validateCartQuantity(*cu.Quantity); err != nil {
+			return CartItemUpdate{}, err
+		}
+	}
+
+	size, err := validateCartSize(cu.Size)
+	if err != nil {
+		return CartItemUpdate{}, err
+	}
+
+	cu.Size = size
+
+	return *cu, nil
+}
+
+// NewCart totals the cart items, before any shipping or discount. A cart is
+// priced in a single currency; items priced in another one make the cart
+// invalid for checkout.
+func NewCart(items []CartItem) (Cart, error) {
+	cart := Cart{Items: items, Pricing: Pricing{Currency: DefaultCurrency}}
+
+	for i, item := range items {
+		if i == 0 {
+			cart.Currency = item.Currency
+		}
+
+		if item.Currency != cart.Currency {
+			return Cart{}, fmt.Errorf("%w: cart mixes %s and %s prices", ErrInvalidOrder, cart.Currency, item.Currency)
+		}
+
+		cart.Subtotal += item.Price * int64(item.Quantity)
+	}
+
+	cart.Total = cart.Subtotal
+
+	return cart, nil
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxMessageID uuid.UUID //nolint:recvcheck
+
+func (o OutboxMessageID) String() string {
+	return uuid.UUID(o).String()
+}
+
+func (o *OutboxMessageID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(o), data)
+}
+
+func (o OutboxMessageID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(o))
+}
+
+// OutboxKind says which side effect an outbox message asks for and what its
+// payload is: an OTPPayload for otp, an SMSPayload for sms and the Event to
+// publish for event.
+type OutboxKind string
+
+const (
+	OutboxKindOTP   OutboxKind = "otp"
+	OutboxKindSMS   OutboxKind = "sms"
+	OutboxKindEvent OutboxKind = "event"
+)
+
+// OutboxStatus is pending until the side effect succeeds or runs out of
+// attempts; dead messages are kept for inspection and are not retried.
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusSent    OutboxStatus = "sent"
+	OutboxStatusDead    OutboxStatus = "dead"
+)
+
+// OutboxMessage is a side effect recorded in the transaction of the change
+// that caused it and performed after the transaction commits. Attempts
+// counts the attempts made so far, including the one in progress.
+type OutboxMessage struct {
+	ID            OutboxMessageID `json:"id"`
+	Kind          OutboxKind      `json:"kind"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        OutboxStatus    `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	LastError     *string         `json:"lastError"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+// OTPPayload asks to text a one-time password. A code that expired is
+// useless, so it is not sent after ExpiresAt.
+type OTPPayload struct {
+	Phone     string    `json:"phone"`
+	OTP       string    `json:"otp"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// SMSPayload asks to text a notification to a phone.
+type SMSPayload struct {
+	Phone string `json:"phone"`
+	Text  string `json:"text"`
+}
+
+func NewOutboxMessage(kind OutboxKind, payload any) OutboxMessage {
+	encoded, _ := json.Marshal(payload) //nolint:errchkjson
+
+	return OutboxMessage{
+		ID:      OutboxMessageID(uuid.New()),
+		Kind:    kind,
+		Payload: encoded,
+		Status:  OutboxStatusPending,
+	}
+}
@@ -0,0 +1,233 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxOutfitItems     = 12
+	maxOutfitOccasions = 10
+)
+
+type OutfitID uuid.UUID //nolint:recvcheck
+
+func (o OutfitID) String() string {
+	return uuid.UUID(o).String()
+}
+
+func (o *OutfitID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(o), data)
+}
+
+func (o OutfitID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(o))
+}
+
+type OutfitSlot string
+
+const (
+	OutfitSlotTop         OutfitSlot = "top"
+	OutfitSlotBottom      OutfitSlot = "bottom"
+	OutfitSlotShoes       OutfitSlot = "shoes"
+	OutfitSlotOuterwear   OutfitSlot = "outerwear"
+	OutfitSlotAccessories OutfitSlot = "accessories"
+)
+
+//nolint:gochecknoglobals
+var outfitSlots = []OutfitSlot{OutfitSlotTop, OutfitSlotBottom, OutfitSlotShoes, OutfitSlotOuterwear, OutfitSlotAccessories}
+
+// OutfitItem puts a wardrobe item into a slot of an outfit. Every slot but
+// accessories holds a single item.
+type OutfitItem struct {
+	Slot   OutfitSlot     `json:"slot"`
+	ItemID WardrobeItemID `json:"itemId"`
+}
+
+// Outfit is a saved look made of the owner's wardrobe items, kept in the
+// order they were given.
+type Outfit struct {
+	ID           OutfitID     `json:"id"`
+	UserID       UserID       `json:"userId"`
+	Name         string       `json:"name"`
+	Occasions    []string     `json:"occasions"`
+	Season       *Season      `json:"season"`
+	CoverPhotoID *FileID      `json:"coverPhotoId"`
+	Items        []OutfitItem `json:"items"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	UpdatedAt    time.Time    `json:"updatedAt"`
+}
+
+// OutfitUpdate changes an outfit. A nil UUID as CoverPhotoID removes the cover.
+type OutfitUpdate struct {
+	Name         *string       `json:"name"`
+	Occasions    *[]string     `json:"occasions"`
+	Season       *Season       `json:"season"`
+	CoverPhotoID *FileID       `json:"coverPhotoId"`
+	Items        *[]OutfitItem `json:"items"`
+}
+
+type OutfitFilter struct {
+	Occasion *string
+	Season   *Season
+	Limit    int
+	Offset   int
+}
+
+// SuggestionRequest describes the situation outfits are suggested for.
+// Temperatures are in degrees Celsius.
+type SuggestionRequest struct {
+	Occasion       string
+	Season         *Season
+	MinTemperature *float64
+	MaxTemperature *float64
+	Limit          int
+}
+
+// OutfitSuggestion is a ranked combination of wardrobe items.
+type OutfitSuggestion struct {
+	Items   []OutfitItem `json:"items"`
+	Score   float64      `json:"score"`
+	Reasons []string     `json:"reasons"`
+}
+
+var (
+	ErrOutfitNotFound           = errors.New("outfit not found")
+	ErrInvalidOutfit            = errors.New("invalid outfit")
+	ErrInvalidSuggestionRequest = errors.New("invalid suggestion request")
+)
+
+func (sr *SuggestionRequest) Validate() (SuggestionRequest, error) {
+	sr.Occasion = strings.ToLower(strings.TrimSpace(sr.Occasion))
+
+	if sr.Season != nil {
+		if err := sr.Season.Validate(); err != nil {
+			return SuggestionRequest{}, fmt.Errorf("%w: %w", ErrInvalidSuggestionRequest, err)
+		}
+	}
+
+	if sr.MinTemperature != nil && sr.MaxTemperature != nil && *sr.MinTemperature > *sr.MaxTemperature {
+		return SuggestionRequest{}, fmt.Errorf("%w: minimum temperature is above maximum", ErrInvalidSuggestionRequest)
+	}
+
+	return *sr, nil
+}
+
+// normalizeOccasions trims and lowercases occasion tags and drops duplicates.
+func normalizeOccasions(occasions []string) ([]string, error) {
+	if len(occasions) > maxOutfitOccasions {
+		return nil, fmt.Errorf("%w: at most %d occasions allowed", ErrInvalidOutfit, maxOutfitOccasions)
+	}
+
+	normalized := make([]string, 0, len(occasions))
+
+	for _, occasion := range occasions {
+		occasion = strings.ToLower(strings.TrimSpace(occasion))
+		if occasion == "" {
+			return nil, fmt.Errorf("%w: empty occasion", ErrInvalidOutfit)
+		}
+
+		if !slices.Contains(normalized, occasion) {
+			normalized = append(normalized, occasion)
+		}
+	}
+
+	return normalized, nil
+}
+
+func validateOutfitItems(items []OutfitItem) error {
+	if len(items) > maxOutfitItems {
+		return fmt.Errorf("%w: at most %d items allowed", ErrInvalidOutfit, maxOutfitItems)
+	}
+
+	for i, item := range items {
+		if !slices.Contains(outfitSlots, item.Slot) {
+			return fmt.Errorf("%w: unknown slot %q", ErrInvalidOutfit, item.Slot)
+		}
+
+		for _, previous := range items[:i] {
+			if previous.ItemID == item.ItemID {
+				return fmt.Errorf("%w: item %s is used twice", ErrInvalidOutfit, item.ItemID)
+			}
+
+			if previous.Slot == item.Slot && item.Slot != OutfitSlotAccessories {
+				return fmt.Errorf("%w: slot %q holds a single item", ErrInvalidOutfit, item.Slot)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *Outfit) Validate() (Outfit, error) {
+	o.Name = strings.TrimSpace(o.Name)
+
+	if o.Name == "" {
+		return Outfit{}, fmt.Errorf("%w: name is required", ErrInvalidOutfit)
+	}
+
+	occasions, err := normalizeOccasions(o.Occasions)
+	if err != nil {
+		return Outfit{}, err
+	}
+
+	o.Occasions = occasions
+
+	if o.Season != nil {
+		if err := o.Season.Validate(); err != nil {
+			return Outfit{}, fmt.Errorf("%w: %w", ErrInvalidOutfit, err)
+		}
+	}
+
+	if o.CoverPhotoID != nil && uuid.UUID(*o.CoverPhotoID) == uuid.Nil {
+		o.CoverPhotoID = nil
+	}
+
+	if o.Items == nil {
+		o.Items = []OutfitItem{}
+	}
+
+	if err := validateOutfitItems(o.Items); err != nil {
+		return Outfit{}, err
+	}
+
+	return *o, nil
+}
+
+func (ou *OutfitUpdate) Validate() (OutfitUpdate, error) {
+	if ou.Name != nil && strings.TrimSpace(*ou.Name) == "" {
+		return OutfitUpdate{}, fmt.Errorf("%w: name must not be empty", ErrInvalidOutfit)
+	}
+
+	if ou.Occasions != nil {
+		occasions, err := normalizeOccasions(*ou.Occasions)
+		if err != nil {
+			return OutfitUpdate{}, err
+		}
+
+		ou.Occasions = &occasions
+	}
+
+	if ou.Season != nil {
+		if err := ou.Season.Validate(); err != nil {
+			return OutfitUpdate{}, fmt.Errorf("%w: %w", ErrInvalidOutfit, err)
+		}
+	}
+
+	if ou.Items != nil {
+		if *ou.Items == nil {
+			ou.Items = &[]OutfitItem{}
+		}
+
+		if err := validateOutfitItems(*ou.Items); err != nil {
+			return OutfitUpdate{}, err
+		}
+	}
+
+	return *ou, nil
+}
@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentID uuid.UUID //nolint:recvcheck
+
+func (p PaymentID) String() string {
+	return uuid.UUID(p).String()
+}
+
+func (p *PaymentID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(p), data)
+}
+
+func (p PaymentID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(p))
+}
+
+// PaymentStatus follows the provider's payment lifecycle: a payment waits for
+// the customer, is held until captured, and ends succeeded, canceled or,
+// after a succeeded payment is returned, refunded.
+type PaymentStatus string
+
+const (
+	PaymentStatusPending           PaymentStatus = "pending"
+	PaymentStatusWaitingForCapture PaymentStatus = "waiting_for_capture"
+	PaymentStatusSucceeded         PaymentStatus = "succeeded"
+	PaymentStatusCanceled          PaymentStatus = "canceled"
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+)
+
+// Payment is an attempt to pay for an order. ProviderID is the payment's id
+// at the payment provider; ConfirmationURL is where the customer is sent to
+// pay.
+type Payment struct {
+	ID              PaymentID     `json:"id"`
+	OrderID         OrderID       `json:"orderId"`
+	UserID          UserID        `json:"userId"`
+	ProviderID      string        `json:"providerId"`
+	Status          PaymentStatus `json:"status"`
+	Amount          int64         `json:"amount"`
+	Currency        string        `json:"currency"`
+	ConfirmationURL *string       `json:"confirmationUrl"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+// PaymentRequest asks the provider to start a payment.
+type PaymentRequest struct {
+	ID          PaymentID
+	OrderID     OrderID
+	Amount      int64
+	Currency    string
+	Description string
+}
+
+// GatewayPayment is the provider's view of a payment.
+type GatewayPayment struct {
+	ProviderID      string
+	Status          PaymentStatus
+	Amount          int64
+	Currency        string
+	ConfirmationURL *string
+}
+
+type PaymentEventType string
+
+const (
+	PaymentEventWaitingForCapture PaymentEventType = "payment.waiting_for_capture"
+	PaymentEventSucceeded         PaymentEventType = "payment.succeeded"
+	PaymentEventCanceled          PaymentEventType = "payment.canceled"
+	PaymentEventRefundSucceeded   PaymentEventType = "refund.succeeded"
+)
+
+// PaymentEvent is a verified provider notification about a payment.
+type PaymentEvent struct {
+	Type       PaymentEventType
+	ProviderID string
+	Amount     int64
+	Currency   string
+}
+
+// PaymentUpdate is a payment after a provider event was applied to it, along
+// with the status of the payment's order. OrderChanged reports whether the
+// event moved the order to that status.
+type PaymentUpdate struct {
+	Payment      Payment
+	OrderStatus  OrderStatus
+	OrderChanged bool
+}
+
+// Status returns the payment status the event moves the payment to, if the
+// payment's current status allows it. Events that arrive late or twice leave
+// the payment as it is.
+func (e PaymentEvent) Status(current PaymentStatus) (PaymentStatus, bool) {
+	switch {
+	case e.Type == PaymentEventWaitingForCapture && current == PaymentStatusPending:
+		return PaymentStatusWaitingForCapture, true
+	case e.Type == PaymentEventSucceeded && (current == PaymentStatusPending || current == PaymentStatusWaitingForCapture):
+		return PaymentStatusSucceeded, true
+	case e.Type == PaymentEventCanceled && (current == PaymentStatusPending || current == PaymentStatusWaitingForCapture):
+		return PaymentStatusCanceled, true
+	case e.Type == PaymentEventRefundSucceeded && current == PaymentStatusSucceeded:
+		return PaymentStatusRefunded, true
+	default:
+		return current, false
+	}
+}
+
+var (
+	ErrPaymentNotFound  = errors.New("payment not found")
+	ErrInvalidPayment   = errors.New("invalid payment")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrPaymentFailed    = errors.New("payment provider request failed")
+)
@@ -0,0 +1,244 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxPercentOff = 100
+
+//nolint:gochecknoglobals
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+type PromoCodeID uuid.UUID //nolint:recvcheck
+
+func (p PromoCodeID) String() string {
+	return uuid.UUID(p).String()
+}
+
+func (p *PromoCodeID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(p), data)
+}
+
+func (p PromoCodeID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(p))
+}
+
+// PromoKind is what a promo code takes off: a percentage of the items it
+// applies to, a fixed amount of them, or the shipping fee.
+type PromoKind string
+
+const (
+	PromoKindPercent      PromoKind = "percent"
+	PromoKindFixed        PromoKind = "fixed"
+	PromoKindFreeShipping PromoKind = "free_shipping"
+)
+
+// PromoCode is a discount customers enter at checkout. Value is a percentage
+// for percent codes and an amount in Currency minor units for fixed codes.
+// MinOrder is the order subtotal the code needs. Codes restricted to
+// Categories only discount products of those categories and their
+// subcategories. Limits count redemptions by orders that were not cancelled.
+type PromoCode struct {
+	ID             PromoCodeID `json:"id"`
+	Code           string      `json:"code"`
+	Kind           PromoKind   `json:"kind"`
+	Value          int64       `json:"value"`
+	Currency       string      `json:"currency"`
+	MinOrder       int64       `json:"minOrder"`
+	Categories     []string    `json:"categories"`
+	FirstOrderOnly bool        `json:"firstOrderOnly"`
+	UsageLimit     *int        `json:"usageLimit"`
+	PerUserLimit   *int        `json:"perUserLimit"`
+	StartsAt       *time.Time  `json:"startsAt"`
+	EndsAt         *time.Time  `json:"endsAt"`
+	Active         bool        `json:"active"`
+	Redemptions    int         `json:"redemptions"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+type PromoCodeUpdate struct {
+	Active       *bool      `json:"active"`
+	UsageLimit   *int       `json:"usageLimit"`
+	PerUserLimit *int       `json:"perUserLimit"`
+	StartsAt     *time.Time `json:"startsAt"`
+	EndsAt       *time.Time `json:"endsAt"`
+}
+
+type PromoCodeFilter struct {
+	Active *bool
+	Limit  int
+	Offset int
+}
+
+// PromoUsage is how much a promo code has been used: by everybody, by the
+// customer about to use it, and how many orders that customer has placed.
+type PromoUsage struct {
+	Total      int
+	ByUser     int
+	UserOrders int
+}
+
+// PricingLine is an item being priced. Categories hold the product's
+// category and all of its parents.
+type PricingLine struct {
+	ProductID  ProductID
+	Categories []string
+	Price      int64
+	Quantity   int
+}
+
+type PricingRequest struct {
+	Lines    []PricingLine
+	Currency string
+	Promo    *PromoCode
+	Usage    PromoUsage
+	At       time.Time
+}
+
+// Pricing is what a cart or an order costs: the items' subtotal, less the
+// promo code's discount, plus shipping.
+type Pricing struct {
+	Subtotal  int64   `json:"subtotal"`
+	Discount  int64   `json:"discount"`
+	Shipping  int64   `json:"shipping"`
+	Total     int64   `json:"total"`
+	Currency  string  `json:"currency"`
+	PromoCode *string `json:"promoCode"`
+}
+
+// Pricer prices a cart being checked out.
+type Pricer func(request PricingRequest) (Pricing, error)
+
+type CheckoutRequest struct {
+	PromoCode *string `json:"promoCode"`
+}
+
+var (
+	ErrPromoCodeNotFound      = errors.New("promo code not found")
+	ErrInvalidPromoCode       = errors.New("invalid promo code")
+	ErrDuplicatePromoCode     = errors.New("promo code already exists")
+	ErrPromoCodeNotApplicable = errors.New("promo code cannot be applied")
+)
+
+// NormalizePromoCode makes codes case-insensitive.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (k PromoKind) Validate() error {
+	switch k {
+	case PromoKindPercent, PromoKindFixed, PromoKindFreeShipping:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidPromoCode, k)
+	}
+}
+
+func validatePromoLimit(name string, limit *int) error {
+	if limit != nil && *limit < 1 {
+		return fmt.Errorf("%w: %s must be positive", ErrInvalidPromoCode, name)
+	}
+
+	return nil
+}
+
+func validatePromoWindow(startsAt, endsAt *time.Time) error {
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return fmt.Errorf("%w: code must end after it starts", ErrInvalidPromoCode)
+	}
+
+	return nil
+}
+
+// Validate checks a new code. New codes are always active.
+func (p *PromoCode) Validate() (PromoCode, error) {
+	p.Code = NormalizePromoCode(p.Code)
+	if !promoCodePattern.MatchString(p.Code) {
+		return PromoCode{}, fmt.Errorf("%w: code must be 3 to 32 letters, digits, dashes or underscores", ErrInvalidPromoCode)
+	}
+
+	if err := p.Kind.Validate(); err != nil {
+		return PromoCode{}, err
+	}
+
+	switch p.Kind {
+	case PromoKindPercent:
+		if p.Value < 1 || p.Value > maxPercentOff {
+			return PromoCode{}, fmt.Errorf("%w: percent must be between 1 and %d", ErrInvalidPromoCode, maxPercentOff)
+		}
+	case PromoKindFixed:
+		if p.Value < 1 {
+			return PromoCode{}, fmt.Errorf("%w: amount must be positive", ErrInvalidPromoCode)
+		}
+	case PromoKindFreeShipping:
+		if p.Value != 0 {
+			return PromoCode{}, fmt.Errorf("%w: free shipping codes take no value", ErrInvalidPromoCode)
+		}
+	}
+
+	if p.Currency == "" {
+		p.Currency = DefaultCurrency
+	}
+
+	if p.MinOrder < 0 {
+		return PromoCode{}, fmt.Errorf("%w: minimum order must not be negative", ErrInvalidPromoCode)
+	}
+
+	if err := validatePromoLimit("usage limit", p.UsageLimit); err != nil {
+		return PromoCode{}, err
+	}
+
+	if err := validatePromoLimit("per-user limit", p.PerUserLimit); err != nil {
+		return PromoCode{}, err
+	}
+
+	if err := validatePromoWindow(p.StartsAt, p.EndsAt); err != nil {
+		return PromoCode{}, err
+	}
+
+	categories := make([]string, 0, len(p.Categories))
+
+	for _, category := range p.Categories {
+		category = strings.TrimSpace(category)
+		if category == "" {
+			return PromoCode{}, fmt.Errorf("%w: category must not be empty", ErrInvalidPromoCode)
+		}
+
+		if !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+
+	p.Categories = categories
+	p.Active = true
+
+	return *p, nil
+}
+
+func (pu *PromoCodeUpdate) Validate() (PromoCodeUpdate, error) {
+	if pu.Active == nil && pu.UsageLimit == nil && pu.PerUserLimit == nil && pu.StartsAt == nil && pu.EndsAt == nil {
+		return PromoCodeUpdate{}, fmt.Errorf("%w: nothing to update", ErrInvalidPromoCode)
+	}
+
+	if err := validatePromoLimit("usage limit", pu.UsageLimit); err != nil {
+		return PromoCodeUpdate{}, err
+	}
+
+	if err := validatePromoLimit("per-user limit", pu.PerUserLimit); err != nil {
+		return PromoCodeUpdate{}, err
+	}
+
+	if err := validatePromoWindow(pu.StartsAt, pu.EndsAt); err != nil {
+		return PromoCodeUpdate{}, err
+	}
+
+	return *pu, nil
+}
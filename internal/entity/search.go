@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxSearchTextLength  = 200
+	maxSearchFacetValues = 20
+)
+
+// SearchQuery looks for wardrobe items matching Text in their name, brand and
+// notes. Within a facet any of the values matches, different facets must all
+// match. Cursor continues a previous search with the same query.
+type SearchQuery struct {
+	Text       string
+	Categories []string
+	Colors     []string
+	Sizes      []string
+	Seasons    []Season
+	Brands     []string
+	Limit      int
+	Cursor     string
+}
+
+// FacetCount is the number of matching items having Value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets counts matches per facet value. Each facet is counted with
+// every filter applied except its own, so the counts show what selecting
+// another value would yield.
+type SearchFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Colors     []FacetCount `json:"colors"`
+	Sizes      []FacetCount `json:"sizes"`
+	Seasons    []FacetCount `json:"seasons"`
+	Brands     []FacetCount `json:"brands"`
+}
+
+// SearchResult is a page of items, best matches first. NextCursor is nil on
+// the last page.
+type SearchResult struct {
+	Items      []WardrobeItem `json:"items"`
+	Facets     SearchFacets   `json:"facets"`
+	NextCursor *string        `json:"nextCursor"`
+}
+
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+func normalizeFacetValues(facet string, values []string) ([]string, error) {
+	if len(values) > maxSearchFacetValues {
+		return nil, fmt.Errorf("%w: at most %d %s allowed", ErrInvalidSearchQuery, maxSearchFacetValues, facet)
+	}
+
+	normalized := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			normalized = append(normalized, value)
+		}
+	}
+
+	return normalized, nil
+}
+
+func (sq *SearchQuery) Validate() (SearchQuery, error) {
+	sq.Text = strings.TrimSpace(sq.Text)
+
+	if len([]rune(sq.Text)) > maxSearchTextLength {
+		return SearchQuery{}, fmt.Errorf("%w: text is longer than %d characters", ErrInvalidSearchQuery, maxSearchTextLength)
+	}
+
+	var err error
+
+	if sq.Categories, err = normalizeFacetValues("categories", sq.Categories); err != nil {
+		return SearchQuery{}, err
+	}
+
+	if sq.Colors, err = normalizeFacetValues("colors", sq.Colors); err != nil {
+		return SearchQuery{}, err
+	}
+
+	if sq.Sizes, err = normalizeFacetValues("sizes", sq.Sizes); err != nil {
+		return SearchQuery{}, err
+	}
+
+	if sq.Brands, err = normalizeFacetValues("brands", sq.Brands); err != nil {
+		return SearchQuery{}, err
+	}
+
+	if len(sq.Seasons) > maxSearchFacetValues {
+		return SearchQuery{}, fmt.Errorf("%w: at most %d seasons allowed", ErrInvalidSearchQuery, maxSearchFacetValues)
+	}
+
+	for _, season := range sq.Seasons {
+		if err := season.Validate(); err != nil {
+			return SearchQuery{}, fmt.Errorf("%w: %w", ErrInvalidSearchQuery, err)
+		}
+	}
+
+	return *sq, nil
+}
@@ -0,0 +1,212 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type SizeRegion string
+
+const (
+	SizeRegionRU  SizeRegion = "RU"
+	SizeRegionEU  SizeRegion = "EU"
+	SizeRegionUS  SizeRegion = "US"
+	SizeRegionUK  SizeRegion = "UK"
+	SizeRegionINT SizeRegion = "INT"
+
+	DefaultSizeRegion = SizeRegionRU
+)
+
+//nolint:gochecknoglobals
+var SizeRegions = []SizeRegion{SizeRegionRU, SizeRegionEU, SizeRegionUS, SizeRegionUK, SizeRegionINT}
+
+func (s SizeRegion) Validate() error {
+	if !slices.Contains(SizeRegions, s) {
+		return fmt.Errorf("%w: unknown size region %q", ErrInvalidSizeProfile, s)
+	}
+
+	return nil
+}
+
+// SizeChart selects between the men's and women's size tables.
+type SizeChart string
+
+const (
+	SizeChartMen   SizeChart = "men"
+	SizeChartWomen SizeChart = "women"
+)
+
+func (s SizeChart) Validate() error {
+	if s != SizeChartMen && s != SizeChartWomen {
+		return fmt.Errorf("%w: unknown size chart %q", ErrInvalidSizeProfile, s)
+	}
+
+	return nil
+}
+
+type Fit string
+
+const (
+	FitSlim    Fit = "slim"
+	FitRegular Fit = "regular"
+	FitLoose   Fit = "loose"
+)
+
+func (f Fit) Validate() error {
+	if f != FitSlim && f != FitRegular && f != FitLoose {
+		return fmt.Errorf("%w: unknown fit %q", ErrInvalidSizeProfile, f)
+	}
+
+	return nil
+}
+
+// Sizes is one size written the way each region labels it.
+type Sizes map[SizeRegion]string
+
+// SizeProfile holds a user's body measurements in centimeters and kilograms.
+// ShoeSize is an EU size. Sizes is computed from the measurements and maps
+// each size group (tops, bottoms, dresses, shoes) to the recommended size.
+type SizeProfile struct {
+	UserID       UserID           `json:"userId"`
+	Chart        *SizeChart       `json:"chart"`
+	Region       SizeRegion       `json:"region"`
+	HeightCM     *float64         `json:"heightCm"`
+	WeightKG     *float64         `json:"weightKg"`
+	ChestCM      *float64         `json:"chestCm"`
+	WaistCM      *float64         `json:"waistCm"`
+	HipsCM       *float64         `json:"hipsCm"`
+	ShoeSize     *float64         `json:"shoeSize"`
+	PreferredFit *Fit             `json:"preferredFit"`
+	Sizes        map[string]Sizes `json:"sizes"`
+	UpdatedAt    *time.Time       `json:"updatedAt"`
+}
+
+type SizeProfileUpdate struct {
+	Chart        *SizeChart  `json:"chart"`
+	Region       *SizeRegion `json:"region"`
+	HeightCM     *float64    `json:"heightCm"`
+	WeightKG     *float64    `json:"weightKg"`
+	ChestCM      *float64    `json:"chestCm"`
+	WaistCM      *float64    `json:"waistCm"`
+	HipsCM       *float64    `json:"hipsCm"`
+	ShoeSize     *float64    `json:"shoeSize"`
+	PreferredFit *Fit        `json:"preferredFit"`
+}
+
+// SizeHintRequest asks how a size of a category fits the user. Region tells
+// how a numeric Size is meant and defaults to the profile's region.
+type SizeHintRequest struct {
+	Category string
+	Size     string
+	Region   *SizeRegion
+}
+
+// SizeHint compares a size with the one recommended for the user. Difference
+// counts sizes from the recommended one and is positive for larger sizes.
+type SizeHint struct {
+	Category    string  `json:"category"`
+	Recommended Sizes   `json:"recommended"`
+	Size        *string `json:"size"`
+	Difference  *int    `json:"difference"`
+	Verdict     *string `json:"verdict"`
+}
+
+const (
+	SizeVerdictYourSize = "your size"
+	SizeVerdictSmaller  = "smaller than your size"
+	SizeVerdictLarger   = "larger than your size"
+)
+
+var (
+	ErrInvalidSizeProfile    = errors.New("invalid size profile")
+	ErrSizeProfileNotFound   = errors.New("size profile not found")
+	ErrIncompleteSizeProfile = errors.New("size profile lacks measurements")
+	ErrUnknownSize           = errors.New("unknown size")
+)
+
+func validateRange(name string, value *float64, low, high float64) error {
+	if value != nil && (*value < low || *value > high) {
+		return fmt.Errorf("%w: %s must be between %g and %g", ErrInvalidSizeProfile, name, low, high)
+	}
+
+	return nil
+}
+
+//nolint:mnd
+func (sp *SizeProfile) Validate() (SizeProfile, error) {
+	if sp.Region == "" {
+		sp.Region = DefaultSizeRegion
+	}
+
+	if err := sp.Region.Validate(); err != nil {
+		return SizeProfile{}, err
+	}
+
+	if sp.Chart != nil {
+		if err := sp.Chart.Validate(); err != nil {
+			return SizeProfile{}, err
+		}
+	}
+
+	if sp.PreferredFit != nil {
+		if err := sp.PreferredFit.Validate(); err != nil {
+			return SizeProfile{}, err
+		}
+	}
+
+	for _, err := range []error{
+		validateRange("height", sp.HeightCM, 50, 250),
+		validateRange("weight", sp.WeightKG, 20, 300),
+		validateRange("chest", sp.ChestCM, 40, 200),
+		validateRange("waist", sp.WaistCM, 40, 200),
+		validateRange("hips", sp.HipsCM, 40, 200),
+		validateRange("shoe size", sp.ShoeSize, 15, 55),
+	} {
+		if err != nil {
+			return SizeProfile{}, err
+		}
+	}
+
+	return *sp, nil
+}
+
+// Apply copies the fields set in the update onto the profile.
+func (sp *SizeProfile) Apply(update SizeProfileUpdate) {
+	if update.Chart != nil {
+		sp.Chart = update.Chart
+	}
+
+	if update.Region != nil {
+		sp.Region = *update.Region
+	}
+
+	if update.HeightCM != nil {
+		sp.HeightCM = update.HeightCM
+	}
+
+	if update.WeightKG != nil {
+		sp.WeightKG = update.WeightKG
+	}
+
+	if update.ChestCM != nil {
+		sp.ChestCM = update.ChestCM
+	}
+
+	if update.WaistCM != nil {
+		sp.WaistCM = update.WaistCM
+	}
+
+	if update.HipsCM != nil {
+		sp.HipsCM = update.HipsCM
+	}
+
+	if update.ShoeSize != nil {
+		sp.ShoeSize = update.ShoeSize
+	}
+
+	if update.PreferredFit != nil {
+		sp.PreferredFit = update.PreferredFit
+	}
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxPostCaptionLength = 2000
+
+type PostID uuid.UUID //nolint:recvcheck
+
+func (p PostID) String() string {
+	return uuid.UUID(p).String()
+}
+
+func (p *PostID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(p), data)
+}
+
+func (p PostID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(p))
+}
+
+type PostVisibility string
+
+const (
+	PostVisibilityPublic    PostVisibility = "public"
+	PostVisibilityFollowers PostVisibility = "followers"
+	PostVisibilityPrivate   PostVisibility = "private"
+)
+
+//nolint:gochecknoglobals
+var postVisibilities = []PostVisibility{PostVisibilityPublic, PostVisibilityFollowers, PostVisibilityPrivate}
+
+func (p PostVisibility) Validate() error {
+	if !slices.Contains(postVisibilities, p) {
+		return fmt.Errorf("%w: unknown visibility %q", ErrInvalidPost, p)
+	}
+
+	return nil
+}
+
+// Post publishes one of the author's outfits. Public posts are seen by every
+// user, followers-only posts by the author's followers and private ones by
+// the author alone. Posts of deleted users and deleted outfits are hidden.
+// Liked tells whether the viewer likes the post.
+type Post struct {
+	ID             PostID         `json:"id"`
+	AuthorID       UserID         `json:"authorId"`
+	AuthorNickName string         `json:"authorNickName"`
+	OutfitID       OutfitID       `json:"outfitId"`
+	OutfitName     string         `json:"outfitName"`
+	CoverPhotoID   *FileID        `json:"coverPhotoId"`
+	Caption        *string        `json:"caption"`
+	Visibility     PostVisibility `json:"visibility"`
+	LikesCount     int            `json:"likesCount"`
+	CommentsCount  int            `json:"commentsCount"`
+	SavesCount     int            `json:"savesCount"`
+	Liked          bool           `json:"liked"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+}
+
+type PostUpdate struct {
+	Caption    *string         `json:"caption"`
+	Visibility *PostVisibility `json:"visibility"`
+}
+
+type PostPage struct {
+	Posts      []Post  `json:"posts"`
+	NextCursor *string `json:"nextCursor"`
+}
+
+// FollowUser is a user in a follower or following list.
+type FollowUser struct {
+	UserID     UserID    `json:"userId"`
+	NickName   string    `json:"nickName"`
+	FirstName  *string   `json:"firstName"`
+	LastName   *string   `json:"lastName"`
+	FollowedAt time.Time `json:"followedAt"`
+}
+
+type FollowPage struct {
+	Users      []FollowUser `json:"users"`
+	NextCursor *string      `json:"nextCursor"`
+}
+
+var (
+	ErrPostNotFound  = errors.New("post not found")
+	ErrInvalidPost   = errors.New("invalid post")
+	ErrInvalidFollow = errors.New("invalid follow")
+)
+
+func validateCaption(caption *string) (*string, error) {
+	if caption == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	trimmed := strings.TrimSpace(*caption)
+
+	if len([]rune(trimmed)) > maxPostCaptionLength {
+		return nil, fmt.Errorf("%w: caption is longer than %d characters", ErrInvalidPost, maxPostCaptionLength)
+	}
+
+	return &trimmed, nil
+}
+
+func (p *Post) Validate() (Post, error) {
+	if uuid.UUID(p.OutfitID) == uuid.Nil {
+		return Post{}, fmt.Errorf("%w: outfit is required", ErrInvalidPost)
+	}
+
+	if p.Visibility == "" {
+		p.Visibility = PostVisibilityPublic
+	}
+
+	if err := p.Visibility.Validate(); err != nil {
+		return Post{}, err
+	}
+
+	caption, err := validateCaption(p.Caption)
+	if err != nil {
+		return Post{}, err
+	}
+
+	p.Caption = caption
+
+	return *p, nil
+}
+
+func (pu *PostUpdate) Validate() (PostUpdate, error) {
+	if pu.Visibility != nil {
+		if err := pu.Visibility.Validate(); err != nil {
+			return PostUpdate{}, err
+		}
+	}
+
+	caption, err := validateCaption(pu.Caption)
+	if err != nil {
+		return PostUpdate{}, err
+	}
+
+	pu.Caption = caption
+
+	return *pu, nil
+}
@@ -20,6 +20,13 @@ const (
 
 type UserID uuid.UUID //nolint:recvcheck
 
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
 func (u UserID) String() string {
 	return uuid.UUID(u).String()
 }
@@ -35,6 +42,7 @@ type User struct {
 	EmailVerified bool       `json:"emailVerified"`
 	Phone         string     `json:"phone"`
 	PhoneVerified bool       `json:"phoneVerified"`
+	Role          Role       `json:"role"`
 	CreatedAt     time.Time  `json:"createdAt"`
 	UpdatedAt     *time.Time `json:"updatedAt"`
 	DeletedAt     *time.Time `json:"deletedAt"`
@@ -60,6 +68,7 @@ type Claims struct {
 	UserID UserID `json:"userId"`
 	Email  string `json:"email"`
 	Phone  string `json:"phone"`
+	Role   Role   `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -73,12 +82,13 @@ var (
 	ErrInvalidUUIDFormat    = errors.New("invalid uuid format")
 	ErrInvalidOTP           = errors.New("invalid otp")
 	ErrDuplicateContact     = errors.New("duplicate contact")
+	ErrForbidden            = errors.New("forbidden")
 )
 
 type UserInfo struct {
 	UserID UserID `json:"userId"`
 	Email  string `json:"email"`
-	Role   string `json:"role"`
+	Role   Role   `json:"role"`
 }
 
 type Tokens struct {
@@ -191,8 +201,8 @@ func (u *UserID) UnmarshalText(data []byte) error {
 	return unmarshalUUID((*uuid.UUID)(u), data)
 }
 
-func (u UserID) MarshalText() ([]byte, error) {
-	data, err := json.Marshal(uuid.UUID(u).String())
+func marshalUUID(id uuid.UUID) ([]byte, error) {
+	data, err := json.Marshal(id.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal UUID: %w", err)
 	}
@@ -200,6 +210,10 @@ func (u UserID) MarshalText() ([]byte, error) {
 	return data, nil
 }
 
+func (u UserID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(u))
+}
+
 type ValidateUserRequest struct {
 	UserID UserID `json:"userId"`
 	OTP    string `json:"otp"`
@@ -0,0 +1,238 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultCurrency       = "RUB"
+	maxWardrobeItemPhotos = 10
+)
+
+type WardrobeItemID uuid.UUID //nolint:recvcheck
+
+func (w WardrobeItemID) String() string {
+	return uuid.UUID(w).String()
+}
+
+func (w *WardrobeItemID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(w), data)
+}
+
+func (w WardrobeItemID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(w))
+}
+
+type Season string
+
+const (
+	SeasonWinter    Season = "winter"
+	SeasonSpring    Season = "spring"
+	SeasonSummer    Season = "summer"
+	SeasonAutumn    Season = "autumn"
+	SeasonAllSeason Season = "all-season"
+)
+
+//nolint:gochecknoglobals
+var seasons = []Season{SeasonWinter, SeasonSpring, SeasonSummer, SeasonAutumn, SeasonAllSeason}
+
+func (s Season) Validate() error {
+	if !slices.Contains(seasons, s) {
+		return fmt.Errorf("%w: unknown season %q", ErrInvalidWardrobeItem, s)
+	}
+
+	return nil
+}
+
+// WardrobeItem is a piece of clothing owned by a user. Category and
+// Subcategory refer to the category taxonomy and Attributes are checked against
+// the attributes it defines. Price is kept in minor currency units. Items sold
+// on the marketplace are archived.
+type WardrobeItem struct {
+	ID           WardrobeItemID `json:"id"`
+	UserID       UserID         `json:"userId"`
+	Name         string         `json:"name"`
+	Category     string         `json:"category"`
+	Subcategory  *string        `json:"subcategory"`
+	Brand        *string        `json:"brand"`
+	Size         *string        `json:"size"`
+	Colors       []string       `json:"colors"`
+	Material     *string        `json:"material"`
+	Season       *Season        `json:"season"`
+	PurchaseDate *time.Time     `json:"purchaseDate"`
+	Price        *int64         `json:"price"`
+	Currency     string         `json:"currency"`
+	Notes        *string        `json:"notes"`
+	Attributes   map[string]any `json:"attributes"`
+	PhotoIDs     []FileID       `json:"photoIds"`
+	ArchivedAt   *time.Time     `json:"archivedAt"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+}
+
+type WardrobeItemUpdate struct {
+	Name         *string         `json:"name"`
+	Category     *string         `json:"category"`
+	Subcategory  *string         `json:"subcategory"`
+	Brand        *string         `json:"brand"`
+	Size         *string         `json:"size"`
+	Colors       *[]string       `json:"colors"`
+	Material     *string         `json:"material"`
+	Season       *Season         `json:"season"`
+	PurchaseDate *time.Time      `json:"purchaseDate"`
+	Price        *int64          `json:"price"`
+	Currency     *string         `json:"currency"`
+	Notes        *string         `json:"notes"`
+	Attributes   *map[string]any `json:"attributes"`
+	PhotoIDs     *[]FileID       `json:"photoIds"`
+}
+
+// WardrobeFilter narrows down a wardrobe. Archived items are left out unless
+// Archived asks for them.
+type WardrobeFilter struct {
+	Category *string
+	Season   *Season
+	Archived bool
+	Limit    int
+	Offset   int
+}
+
+var (
+	ErrWardrobeItemNotFound = errors.New("wardrobe item not found")
+	ErrInvalidWardrobeItem  = errors.New("invalid wardrobe item")
+)
+
+func validateColors(colors []string) error {
+	for _, color := range colors {
+		if strings.TrimSpace(color) == "" {
+			return fmt.Errorf("%w: empty color", ErrInvalidWardrobeItem)
+		}
+	}
+
+	return nil
+}
+
+func validatePhotoIDs(photoIDs []FileID) error {
+	if len(photoIDs) > maxWardrobeItemPhotos {
+		return fmt.Errorf("%w: at most %d photos allowed", ErrInvalidWardrobeItem, maxWardrobeItemPhotos)
+	}
+
+	for i, photoID := range photoIDs {
+		if slices.Contains(photoIDs[:i], photoID) {
+			return fmt.Errorf("%w: duplicate photo %s", ErrInvalidWardrobeItem, photoID)
+		}
+	}
+
+	return nil
+}
+
+func (w *WardrobeItem) Validate() (WardrobeItem, error) {
+	w.Name = strings.TrimSpace(w.Name)
+	w.Category = strings.TrimSpace(w.Category)
+
+	if w.Category == "" {
+		return WardrobeItem{}, fmt.Errorf("%w: category is required", ErrInvalidWardrobeItem)
+	}
+
+	if w.Name == "" {
+		w.Name = w.Category
+	}
+
+	if w.Subcategory != nil {
+		if subcategory := strings.TrimSpace(*w.Subcategory); subcategory != "" {
+			w.Subcategory = &subcategory
+		} else {
+			w.Subcategory = nil
+		}
+	}
+
+	if w.Attributes == nil {
+		w.Attributes = map[string]any{}
+	}
+
+	if w.Season != nil {
+		if err := w.Season.Validate(); err != nil {
+			return WardrobeItem{}, err
+		}
+	}
+
+	if w.Price != nil && *w.Price < 0 {
+		return WardrobeItem{}, fmt.Errorf("%w: price must not be negative", ErrInvalidWardrobeItem)
+	}
+
+	if w.Currency == "" {
+		w.Currency = DefaultCurrency
+	}
+
+	if w.Colors == nil {
+		w.Colors = []string{}
+	}
+
+	if err := validateColors(w.Colors); err != nil {
+		return WardrobeItem{}, err
+	}
+
+	if w.PhotoIDs == nil {
+		w.PhotoIDs = []FileID{}
+	}
+
+	if err := validatePhotoIDs(w.PhotoIDs); err != nil {
+		return WardrobeItem{}, err
+	}
+
+	return *w, nil
+}
+
+func (wu *WardrobeItemUpdate) Validate() (WardrobeItemUpdate, error) {
+	if wu.Category != nil && strings.TrimSpace(*wu.Category) == "" {
+		return WardrobeItemUpdate{}, fmt.Errorf("%w: category must not be empty", ErrInvalidWardrobeItem)
+	}
+
+	if wu.Name != nil && strings.TrimSpace(*wu.Name) == "" {
+		return WardrobeItemUpdate{}, fmt.Errorf("%w: name must not be empty", ErrInvalidWardrobeItem)
+	}
+
+	// An empty subcategory clears it.
+	if wu.Subcategory != nil {
+		subcategory := strings.TrimSpace(*wu.Subcategory)
+		wu.Subcategory = &subcategory
+	}
+
+	if wu.Season != nil {
+		if err := wu.Season.Validate(); err != nil {
+			return WardrobeItemUpdate{}, err
+		}
+	}
+
+	if wu.Price != nil && *wu.Price < 0 {
+		return WardrobeItemUpdate{}, fmt.Errorf("%w: price must not be negative", ErrInvalidWardrobeItem)
+	}
+
+	if wu.Currency != nil && strings.TrimSpace(*wu.Currency) == "" {
+		return WardrobeItemUpdate{}, fmt.Errorf("%w: currency must not be empty", ErrInvalidWardrobeItem)
+	}
+
+	if wu.Colors != nil {
+		if err := validateColors(*wu.Colors); err != nil {
+			return WardrobeItemUpdate{}, err
+		}
+	}
+
+	if wu.PhotoIDs != nil {
+		if err := validatePhotoIDs(*wu.PhotoIDs); err != nil {
+			return WardrobeItemUpdate{}, err
+		}
+	}
+
+	if wu.Attributes != nil && *wu.Attributes == nil {
+		wu.Attributes = &map[string]any{}
+	}
+
+	return *wu, nil
+}
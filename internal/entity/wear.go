@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxWearLogItems = 20
+	// DefaultWearLogDays is the range listed when no start date is given.
+	DefaultWearLogDays = 31
+	// MaxWearLogDays caps the range of a single listing to roughly a year.
+	MaxWearLogDays = 366
+)
+
+type WearLogEntryID uuid.UUID //nolint:recvcheck
+
+func (w WearLogEntryID) String() string {
+	return uuid.UUID(w).String()
+}
+
+func (w *WearLogEntryID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(w), data)
+}
+
+func (w WearLogEntryID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(w))
+}
+
+// WearLogEntry records what a user wore on a given day. When an outfit is
+// logged its items at the time of logging are copied into ItemIDs, so later
+// changes to the outfit do not rewrite history.
+type WearLogEntry struct {
+	ID        WearLogEntryID   `json:"id"`
+	UserID    UserID           `json:"userId"`
+	WornOn    time.Time        `json:"wornOn"`
+	OutfitID  *OutfitID        `json:"outfitId"`
+	ItemIDs   []WardrobeItemID `json:"itemIds"`
+	Notes     *string          `json:"notes"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// WearLogFilter selects entries worn between From and To, both inclusive.
+type WearLogFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+// ItemWearStats summarizes how often an item is worn. CostPerWear is the
+// purchase price split over the times worn, in minor currency units, and is
+// only known once the item has a price and was worn at least once.
+type ItemWearStats struct {
+	ItemID      WardrobeItemID `json:"itemId"`
+	TimesWorn   int            `json:"timesWorn"`
+	LastWornOn  *time.Time     `json:"lastWornOn"`
+	Price       *int64         `json:"price"`
+	CostPerWear *int64         `json:"costPerWear"`
+	Currency    string         `json:"currency"`
+}
+
+var (
+	ErrWearLogEntryNotFound = errors.New("wear log entry not found")
+	ErrInvalidWearLogEntry  = errors.New("invalid wear log entry")
+)
+
+// truncateToDate drops the time of day, keeping the calendar date as given.
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (w *WearLogEntry) Validate(now time.Time) (WearLogEntry, error) {
+	if w.WornOn.IsZero() {
+		return WearLogEntry{}, fmt.Errorf("%w: date is required", ErrInvalidWearLogEntry)
+	}
+
+	w.WornOn = truncateToDate(w.WornOn)
+
+	// A day of slack lets users ahead of UTC log today's outfit.
+	if w.WornOn.After(truncateToDate(now).AddDate(0, 0, 1)) {
+		return WearLogEntry{}, fmt.Errorf("%w: date is in the future", ErrInvalidWearLogEntry)
+	}
+
+	if w.OutfitID != nil && uuid.UUID(*w.OutfitID) == uuid.Nil {
+		w.OutfitID = nil
+	}
+
+	if w.ItemIDs == nil {
+		w.ItemIDs = []WardrobeItemID{}
+	}
+
+	if w.OutfitID == nil && len(w.ItemIDs) == 0 {
+		return WearLogEntry{}, fmt.Errorf("%w: outfit or items are required", ErrInvalidWearLogEntry)
+	}
+
+	if len(w.ItemIDs) > maxWearLogItems {
+		return WearLogEntry{}, fmt.Errorf("%w: at most %d items allowed", ErrInvalidWearLogEntry, maxWearLogItems)
+	}
+
+	for i, itemID := range w.ItemIDs {
+		if slices.Contains(w.ItemIDs[:i], itemID) {
+			return WearLogEntry{}, fmt.Errorf("%w: item %s is used twice", ErrInvalidWearLogEntry, itemID)
+		}
+	}
+
+	if w.Notes != nil && strings.TrimSpace(*w.Notes) == "" {
+		w.Notes = nil
+	}
+
+	return *w, nil
+}
+
+// Validate fills in a missing range ending today and spanning DefaultWearLogDays.
+func (wf *WearLogFilter) Validate(now time.Time) (WearLogFilter, error) {
+	if wf.To.IsZero() {
+		wf.To = now
+	}
+
+	wf.To = truncateToDate(wf.To)
+
+	if wf.From.IsZero() {
+		wf.From = wf.To.AddDate(0, 0, 1-DefaultWearLogDays)
+	}
+
+	wf.From = truncateToDate(wf.From)
+
+	if wf.From.After(wf.To) {
+		return WearLogFilter{}, fmt.Errorf("%w: start date is after end date", ErrInvalidWearLogEntry)
+	}
+
+	if wf.To.Sub(wf.From) >= MaxWearLogDays*24*time.Hour {
+		return WearLogFilter{}, fmt.Errorf("%w: at most %d days can be listed", ErrInvalidWearLogEntry, MaxWearLogDays)
+	}
+
+	return *wf, nil
+}
+
+// CalculateCostPerWear sets CostPerWear from Price and TimesWorn, rounding to
+// the nearest minor unit.
+func (s *ItemWearStats) CalculateCostPerWear() {
+	s.CostPerWear = nil
+
+	if s.Price == nil || s.TimesWorn == 0 {
+		return
+	}
+
+	times := int64(s.TimesWorn)
+	costPerWear := (*s.Price + times/2) / times //nolint:mnd
+
+	s.CostPerWear = &costPerWear
+}
@@ -0,0 +1,189 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultDropThreshold = 10
+	maxDropThreshold     = 90
+)
+
+// AlertChannel is how a user wanted to hear about price drops of a wishlist
+// item. Alerts now reach users through the channels of their price drop
+// notification preference; the channel is kept for clients that show it.
+type AlertChannel string
+
+const (
+	AlertChannelInApp AlertChannel = "in_app"
+	AlertChannelSMS   AlertChannel = "sms"
+)
+
+func (a AlertChannel) Validate() error {
+	if a != AlertChannelInApp && a != AlertChannelSMS {
+		return fmt.Errorf("%w: unknown channel %q", ErrInvalidWishlistItem, a)
+	}
+
+	return nil
+}
+
+// WishlistItem is a product a user saved. BaselinePrice is the price drops
+// are measured against: the price when the product was saved or when the
+// user was last alerted, whichever is later. DropThreshold is in percent.
+type WishlistItem struct {
+	UserID        UserID       `json:"userId"`
+	ProductID     ProductID    `json:"productId"`
+	Size          *string      `json:"size"`
+	DropThreshold int          `json:"dropThreshold"`
+	Channel       AlertChannel `json:"channel"`
+	BaselinePrice int64        `json:"baselinePrice"`
+	Product       Product      `json:"product"`
+	CreatedAt     time.Time    `json:"createdAt"`
+	UpdatedAt     time.Time    `json:"updatedAt"`
+}
+
+type WishlistItemUpdate struct {
+	Size          *string       `json:"size"`
+	DropThreshold *int          `json:"dropThreshold"`
+	Channel       *AlertChannel `json:"channel"`
+}
+
+type WishlistFilter struct {
+	Limit  int
+	Offset int
+}
+
+type PricePoint struct {
+	Price      int64     `json:"price"`
+	Currency   string    `json:"currency"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+type PriceAlertID uuid.UUID //nolint:recvcheck
+
+func (p PriceAlertID) String() string {
+	return uuid.UUID(p).String()
+}
+
+func (p *PriceAlertID) UnmarshalText(data []byte) error {
+	return unmarshalUUID((*uuid.UUID)(p), data)
+}
+
+func (p PriceAlertID) MarshalText() ([]byte, error) {
+	return marshalUUID(uuid.UUID(p))
+}
+
+type PriceAlertStatus string
+
+const (
+	PriceAlertStatusPending PriceAlertStatus = "pending"
+	PriceAlertStatusSent    PriceAlertStatus = "sent"
+	PriceAlertStatusFailed  PriceAlertStatus = "failed"
+)
+
+// PriceAlert tells a user that a wishlist product got cheaper.
+type PriceAlert struct {
+	ID          PriceAlertID     `json:"id"`
+	UserID      UserID           `json:"userId"`
+	ProductID   ProductID        `json:"productId"`
+	ProductName string           `json:"productName"`
+	OldPrice    int64            `json:"oldPrice"`
+	NewPrice    int64            `json:"newPrice"`
+	Currency    string           `json:"currency"`
+	Channel     AlertChannel     `json:"channel"`
+	Status      PriceAlertStatus `json:"status"`
+	Phone       string           `json:"-"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	SentAt      *time.Time       `json:"sentAt"`
+}
+
+type PriceAlertFilter struct {
+	Limit  int
+	Offset int
+}
+
+var (
+	ErrWishlistItemNotFound  = errors.New("wishlist item not found")
+	ErrInvalidWishlistItem   = errors.New("invalid wishlist item")
+	ErrDuplicateWishlistItem = errors.New("product is already in the wishlist")
+)
+
+func validateDropThreshold(threshold int) error {
+	if threshold < 1 || threshold > maxDropThreshold {
+		return fmt.Errorf("%w: drop threshold must be between 1 and %d percent", ErrInvalidWishlistItem, maxDropThreshold)
+	}
+
+	return nil
+}
+
+func validateWishlistSize(size *string) (*string, error) {
+	if size == nil {
+		return nil, nil
+	}
+
+	trimmed := strings.TrimSpace(*size)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: size must not be empty", ErrInvalidWishlistItem)
+	}
+
+	return &trimmed, nil
+}
+
+func (w *WishlistItem) Validate() (WishlistItem, error) {
+	if w.ProductID == (ProductID{}) {
+		return WishlistItem{}, fmt.Errorf("%w: product is required", ErrInvalidWishlistItem)
+	}
+
+	if w.DropThreshold == 0 {
+		w.DropThreshold = DefaultDropThreshold
+	}
+
+	if err := validateDropThreshold(w.DropThreshold); err != nil {
+		return WishlistItem{}, err
+	}
+
+	if w.Channel == "" {
+		w.Channel = AlertChannelInApp
+	}
+
+	if err := w.Channel.Validate(); err != nil {
+		return WishlistItem{}, err
+	}
+
+	size, err := validateWishlistSize(w.Size)
+	if err != nil {
+		return WishlistItem{}, err
+	}
+
+	w.Size = size
+
+	return *w, nil
+}
+
+func (wu *WishlistItemUpdate) Validate() (WishlistItemUpdate, error) {
+	if wu.DropThreshold != nil {
+		if err := validateDropThreshold(*wu.DropThreshold); err != nil {
+			return WishlistItemUpdate{}, err
+		}
+	}
+
+	if wu.Channel != nil {
+		if err := wu.Channel.Validate(); err != nil {
+			return WishlistItemUpdate{}, err
+		}
+	}
+
+	size, err := validateWishlistSize(wu.Size)
+	if err != nil {
+		return WishlistItemUpdate{}, err
+	}
+
+	wu.Size = size
+
+	return *wu, nil
+}
@@ -0,0 +1,124 @@
+// Package pricing works out what a cart costs: the items' subtotal, the
+// discount of a promo code and shipping.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const percent = 100
+
+var (
+	errInactive        = errors.New("code is not active")
+	errNotStarted      = errors.New("code is not valid yet")
+	errExpired         = errors.New("code has expired")
+	errUsedUp          = errors.New("code has been used up")
+	errUsedByUser      = errors.New("code has already been used")
+	errNotFirstOrder   = errors.New("code is for first orders only")
+	errWrongCurrency   = errors.New("order is in another currency")
+	errBelowMinOrder   = errors.New("order is too small")
+	errNoEligibleItems = errors.New("code does not apply to any item")
+)
+
+type Config struct {
+	// ShippingFee is charged on every order, in minor units.
+	ShippingFee int64
+}
+
+type Engine struct {
+	cfg Config
+}
+
+func New(cfg Config) *Engine {
+	return &Engine{
+		cfg: cfg,
+	}
+}
+
+// Price prices the request's lines and applies its promo code, if any. A code
+// that cannot be applied fails pricing with ErrPromoCodeNotApplicable and the
+// reason. A discount never makes an order free: at least one minor unit is
+// left to pay.
+func (e *Engine) Price(request entity.PricingRequest) (entity.Pricing, error) {
+	pricing := entity.Pricing{
+		Shipping: e.cfg.ShippingFee,
+		Currency: request.Currency,
+	}
+
+	for _, line := range request.Lines {
+		pricing.Subtotal += line.Price * int64(line.Quantity)
+	}
+
+	if promo := request.Promo; promo != nil {
+		if err := check(request, pricing.Subtotal); err != nil {
+			return entity.Pricing{}, fmt.Errorf("%w: %s: %w", entity.ErrPromoCodeNotApplicable, promo.Code, err)
+		}
+
+		eligible := eligibleSubtotal(request.Lines, promo.Categories)
+		if eligible == 0 {
+			return entity.Pricing{}, fmt.Errorf("%w: %s: %w", entity.ErrPromoCodeNotApplicable, promo.Code, errNoEligibleItems)
+		}
+
+		switch promo.Kind {
+		case entity.PromoKindPercent:
+			pricing.Discount = eligible * promo.Value / percent
+		case entity.PromoKindFixed:
+			pricing.Discount = min(promo.Value, eligible)
+		case entity.PromoKindFreeShipping:
+			pricing.Shipping = 0
+		}
+
+		pricing.Discount = max(min(pricing.Discount, pricing.Subtotal+pricing.Shipping-1), 0)
+		pricing.PromoCode = &promo.Code
+	}
+
+	pricing.Total = pricing.Subtotal - pricing.Discount + pricing.Shipping
+
+	return pricing, nil
+}
+
+// check tells why the request's promo code cannot be used, if it cannot.
+func check(request entity.PricingRequest, subtotal int64) error {
+	promo := request.Promo
+
+	switch {
+	case !promo.Active:
+		return errInactive
+	case promo.StartsAt != nil && request.At.Before(*promo.StartsAt):
+		return errNotStarted
+	case promo.EndsAt != nil && !request.At.Before(*promo.EndsAt):
+		return errExpired
+	case promo.UsageLimit != nil && request.Usage.Total >= *promo.UsageLimit:
+		return errUsedUp
+	case promo.PerUserLimit != nil && request.Usage.ByUser >= *promo.PerUserLimit:
+		return errUsedByUser
+	case promo.FirstOrderOnly && request.Usage.UserOrders > 0:
+		return errNotFirstOrder
+	case promo.Kind != entity.PromoKindPercent && promo.Currency != request.Currency:
+		return fmt.Errorf("%w: code is for %s orders", errWrongCurrency, promo.Currency)
+	case subtotal < promo.MinOrder:
+		return fmt.Errorf("%w: %d needed", errBelowMinOrder, promo.MinOrder)
+	default:
+		return nil
+	}
+}
+
+// eligibleSubtotal totals the lines a code restricted to the categories
+// applies to. Codes without categories apply to every line.
+func eligibleSubtotal(lines []entity.PricingLine, categories []string) int64 {
+	var eligible int64
+
+	for _, line := range lines {
+		if len(categories) == 0 || slices.ContainsFunc(line.Categories, func(category string) bool {
+			return slices.Contains(categories, category)
+		}) {
+			eligible += line.Price * int64(line.Quantity)
+		}
+	}
+
+	return eligible
+}
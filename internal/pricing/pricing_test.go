@@ -0,0 +1,163 @@
+package pricing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/pricing"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrice(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	lines := []entity.PricingLine{
+		{Categories: []string{"dresses"}, Price: 500000, Quantity: 1},
+		{Categories: []string{"t-shirts", "tops"}, Price: 100000, Quantity: 2},
+	}
+
+	promo := func(kind entity.PromoKind, value int64, modify func(p *entity.PromoCode)) *entity.PromoCode {
+		p := &entity.PromoCode{Code: "SALE", Kind: kind, Value: value, Currency: entity.DefaultCurrency, Active: true}
+		if modify != nil {
+			modify(p)
+		}
+
+		return p
+	}
+
+	tests := []struct {
+		name  string
+		lines []entity.PricingLine
+		promo *entity.PromoCode
+		usage entity.PromoUsage
+		want  entity.Pricing
+		err   error
+	}{
+		{
+			name:  "no code",
+			lines: lines,
+			want:  entity.Pricing{Subtotal: 700000, Shipping: 30000, Total: 730000},
+		},
+		{
+			name:  "percent off",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 15, nil),
+			want:  entity.Pricing{Subtotal: 700000, Discount: 105000, Shipping: 30000, Total: 625000},
+		},
+		{
+			name:  "percent off a parent category",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 50, func(p *entity.PromoCode) { p.Categories = []string{"tops"} }),
+			want:  entity.Pricing{Subtotal: 700000, Discount: 100000, Shipping: 30000, Total: 630000},
+		},
+		{
+			name:  "fixed amount is capped by the eligible items",
+			lines: lines,
+			promo: promo(entity.PromoKindFixed, 900000, func(p *entity.PromoCode) { p.Categories = []string{"dresses"} }),
+			want:  entity.Pricing{Subtotal: 700000, Discount: 500000, Shipping: 30000, Total: 230000},
+		},
+		{
+			name:  "discount leaves something to pay",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 100, nil),
+			want:  entity.Pricing{Subtotal: 700000, Discount: 700000, Shipping: 30000, Total: 30000},
+		},
+		{
+			name:  "free shipping",
+			lines: lines,
+			promo: promo(entity.PromoKindFreeShipping, 0, nil),
+			want:  entity.Pricing{Subtotal: 700000, Total: 700000},
+		},
+		{
+			name:  "minimum order",
+			lines: lines,
+			promo: promo(entity.PromoKindFreeShipping, 0, func(p *entity.PromoCode) { p.MinOrder = 700001 }),
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+		{
+			name:  "no eligible items",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 10, func(p *entity.PromoCode) { p.Categories = []string{"shoes"} }),
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+		{
+			name:  "inactive",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 10, func(p *entity.PromoCode) { p.Active = false }),
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+		{
+			name:  "not started",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 10, func(p *entity.PromoCode) { p.StartsAt = utils.Pointer(now.Add(time.Hour)) }),
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+		{
+			name:  "expired",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 10, func(p *entity.PromoCode) { p.EndsAt = utils.Pointer(now) }),
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+		{
+			name:  "used up",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 10, func(p *entity.PromoCode) { p.UsageLimit = utils.Pointer(3) }),
+			usage: entity.PromoUsage{Total: 3},
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+		{
+			name:  "used by the customer",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 10, func(p *entity.PromoCode) { p.PerUserLimit = utils.Pointer(1) }),
+			usage: entity.PromoUsage{Total: 1, ByUser: 1},
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+		{
+			name:  "first order only",
+			lines: lines,
+			promo: promo(entity.PromoKindPercent, 10, func(p *entity.PromoCode) { p.FirstOrderOnly = true }),
+			usage: entity.PromoUsage{UserOrders: 1},
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+		{
+			name:  "fixed amount in another currency",
+			lines: lines,
+			promo: promo(entity.PromoKindFixed, 1000, func(p *entity.PromoCode) { p.Currency = "USD" }),
+			err:   entity.ErrPromoCodeNotApplicable,
+		},
+	}
+
+	engine := pricing.New(pricing.Config{ShippingFee: 30000})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := engine.Price(entity.PricingRequest{
+				Lines:    tt.lines,
+				Currency: entity.DefaultCurrency,
+				Promo:    tt.promo,
+				Usage:    tt.usage,
+				At:       now,
+			})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			tt.want.Currency = entity.DefaultCurrency
+			if tt.promo != nil {
+				tt.want.PromoCode = &tt.promo.Code
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,697 @@
+package catalogrepo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const (
+	productColumns = `
+	p.id, p.feed_id, p.external_id, p.name, p.brand, p.description, p.url,
+	p.price, p.old_price, p.currency, p.category, p.category_id, p.available,
+	COALESCE((
+		SELECT jsonb_agg(jsonb_build_object('size', s.size, 'available', s.available, 'stock', s.stock) ORDER BY s.position)
+		FROM product_sizes s
+		WHERE s.product_id = p.id
+	), '[]'),
+	COALESCE((
+		SELECT jsonb_agg(jsonb_build_object(
+			'position', i.position, 'sourceUrl', i.source_url, 'mirrored', i.bucket_key IS NOT NULL
+		) ORDER BY i.position)
+		FROM product_images i
+		WHERE i.product_id = p.id
+	), '[]'),
+	p.created_at, p.updated_at`
+
+	importColumns = `
+	id, feed_id, format, status, offers, created, updated, unchanged, rejected, deactivated,
+	images_mirrored, images_failed, errors, started_at, finished_at`
+
+	// categoryByName matches the last segment of the partner's category path,
+	// given as $1, against the taxonomy by id or by name.
+	categoryByName = `(
+	SELECT c.id
+	FROM categories c
+	WHERE c.id = LOWER($1) OR LOWER(c.name) = LOWER($1)
+	ORDER BY c.id = LOWER($1) DESC
+	LIMIT 1
+)`
+
+	categoryPathSeparator = ">"
+)
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// productFields lists the scan destinations of productColumns.
+func productFields(product *entity.Product) []any {
+	return []any{
+		&product.ID,
+		&product.FeedID,
+		&product.ExternalID,
+		&product.Name,
+		&product.Brand,
+		&product.Description,
+		&product.URL,
+		&product.Price,
+		&product.OldPrice,
+		&product.Currency,
+		&product.Category,
+		&product.CategoryID,
+		&product.Available,
+		&product.Sizes,
+		&product.Images,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+	}
+}
+
+func scanProduct(row pgx.Row) (entity.Product, error) {
+	var product entity.Product
+
+	if err := row.Scan(productFields(&product)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Product{}, entity.ErrProductNotFound
+		}
+
+		return entity.Product{}, fmt.Errorf("failed to scan product: %w", err)
+	}
+
+	return product, nil
+}
+
+func scanImport(row pgx.Row) (entity.FeedImport, error) {
+	var imp entity.FeedImport
+
+	err := row.Scan(
+		&imp.ID,
+		&imp.FeedID,
+		&imp.Format,
+		&imp.Status,
+		&imp.Offers,
+		&imp.Created,
+		&imp.Updated,
+		&imp.Unchanged,
+		&imp.Rejected,
+		&imp.Deactivated,
+		&imp.ImagesMirrored,
+		&imp.ImagesFailed,
+		&imp.Errors,
+		&imp.StartedAt,
+		&imp.FinishedAt,
+	)
+	if err != nil {
+		return entity.FeedImport{}, fmt.Errorf("failed to scan import: %w", err)
+	}
+
+	return imp, nil
+}
+
+// StartImport registers the feed, if it is new, and a running import of it.
+func (r *Repo) StartImport(ctx context.Context, imp entity.FeedImport) (entity.FeedImport, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+INSERT INTO catalog_feeds (id, format)
+VALUES ($1, $2)
+ON CONFLICT (id) DO UPDATE
+SET format = EXCLUDED.format, updated_at = NOW()`
+
+		if _, err := tx.Exec(ctx, query, imp.FeedID, imp.Format); err != nil {
+			return fmt.Errorf("failed to upsert feed: %w", err)
+		}
+
+		query = `
+INSERT INTO catalog_imports (id, feed_id, format, status)
+VALUES ($1, $2, $3, $4)
+RETURNING ` + importColumns
+
+		var err error
+
+		imp, err = scanImport(tx.QueryRow(ctx, query, imp.ID, imp.FeedID, imp.Format, entity.FeedImportStatusRunning))
+
+		return err
+	})
+	if err != nil {
+		return entity.FeedImport{}, fmt.Errorf("failed to start import of %s: %w", imp.FeedID, err)
+	}
+
+	return imp, nil
+}
+
+// FinishImport stores the final stats of an import.
+func (r *Repo) FinishImport(ctx context.Context, imp entity.FeedImport) (entity.FeedImport, error) {
+	query := `
+UPDATE catalog_imports
+SET status = $2, offers = $3, created = $4, updated = $5, unchanged = $6, rejected = $7, deactivated = $8,
+	images_mirrored = $9, images_failed = $10, errors = $11, finished_at = NOW()
+WHERE id = $1
+RETURNING ` + importColumns
+
+	errs := imp.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+
+	finished, err := scanImport(r.db.GetTXFromContext(ctx).QueryRow(ctx, query,
+		imp.ID, imp.Status, imp.Offers, imp.Created, imp.Updated, imp.Unchanged, imp.Rejected, imp.Deactivated,
+		imp.ImagesMirrored, imp.ImagesFailed, errs))
+	if err != nil {
+		return entity.FeedImport{}, fmt.Errorf("failed to finish import %s: %w", imp.ID, err)
+	}
+
+	return finished, nil
+}
+
+// ListImports lists imports, latest first.
+func (r *Repo) ListImports(ctx context.Context, filter entity.FeedImportFilter) ([]entity.FeedImport, error) {
+	query := `
+SELECT ` + importColumns + `
+FROM catalog_imports
+WHERE $1::VARCHAR IS NULL OR feed_id = $1
+ORDER BY started_at DESC, id DESC
+LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, filter.FeedID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list imports: %w", err)
+	}
+
+	defer rows.Close()
+
+	imports := make([]entity.FeedImport, 0, filter.Limit)
+
+	for rows.Next() {
+		imp, err := scanImport(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		imports = append(imports, imp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate imports: %w", err)
+	}
+
+	return imports, nil
+}
+
+// GetProductImages returns the images the product was last imported with.
+func (r *Repo) GetProductImages(ctx context.Context, feedID, externalID string) ([]entity.ProductImage, error) {
+	query := `
+SELECT i.position, i.source_url, i.bucket_key, i.content_type
+FROM product_images i
+	JOIN products p ON p.id = i.product_id
+WHERE TRUE
+	AND p.feed_id = $1
+	AND p.external_id = $2
+ORDER BY i.position`
+
+	return r.listImages(ctx, query, feedID, externalID)
+}
+
+func (r *Repo) listImages(ctx context.Context, query string, args ...any) ([]entity.ProductImage, error) {
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product images: %w", err)
+	}
+
+	defer rows.Close()
+
+	var images []entity.ProductImage
+
+	for rows.Next() {
+		var image entity.ProductImage
+
+		if err := rows.Scan(&image.Position, &image.SourceURL, &image.Key, &image.ContentType); err != nil {
+			return nil, fmt.Errorf("failed to scan product image: %w", err)
+		}
+
+		image.Mirrored = image.Key != nil
+		images = append(images, image)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate product images: %w", err)
+	}
+
+	return images, nil
+}
+
+// UpsertProduct stores the offer as a product of the feed and marks it seen
+// by the import. It returns the keys of mirrored images the product no longer
+// uses; their blob references are the caller's to release.
+func (r *Repo) UpsertProduct(
+	ctx context.Context,
+	importID entity.FeedImportID,
+	feedID string,
+	offer entity.CatalogOffer,
+	images []entity.ProductImage,
+) (entity.ImportResult, []string, error) {
+	hash, err := contentHash(offer)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		result   entity.ImportResult
+		released []string
+	)
+
+	err = r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		var (
+			productID entity.ProductID
+			oldHash   string
+		)
+
+		query := `
+SELECT id, content_hash
+FROM products
+WHERE TRUE
+	AND feed_id = $1
+	AND external_id = $2
+FOR UPDATE`
+
+		err := tx.QueryRow(ctx, query, feedID, offer.ExternalID).Scan(&productID, &oldHash)
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			productID = entity.ProductID(uuid.New())
+			result = entity.ImportResultCreated
+
+			if err := r.insertProduct(ctx, productID, importID, feedID, offer, hash); err != nil {
+				return err
+			}
+		case err != nil:
+			return fmt.Errorf("failed to lock product: %w", err)
+		case oldHash != hash:
+			result = entity.ImportResultUpdated
+
+			if err := r.updateProduct(ctx, productID, importID, offer, hash); err != nil {
+				return err
+			}
+		default:
+			result = entity.ImportResultUnchanged
+
+			if _, err := tx.Exec(ctx, `UPDATE products SET import_id = $2 WHERE id = $1`, productID, importID); err != nil {
+				return fmt.Errorf("failed to touch product: %w", err)
+			}
+		}
+
+		if result != entity.ImportResultUnchanged {
+			if err := r.replaceSizes(ctx, productID, offer.Sizes); err != nil {
+				return err
+			}
+
+			if err := r.recordPrice(ctx, productID, offer.Price, offer.Currency); err != nil {
+				return err
+			}
+		}
+
+		changed, dropped, err := r.replaceImages(ctx, productID, images)
+		if err != nil {
+			return err
+		}
+
+		released = dropped
+
+		if changed && result == entity.ImportResultUnchanged {
+			result = entity.ImportResultUpdated
+
+			if _, err := tx.Exec(ctx, `UPDATE products SET updated_at = NOW() WHERE id = $1`, productID); err != nil {
+				return fmt.Errorf("failed to touch product: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to upsert product %s: %w", offer.ExternalID, err)
+	}
+
+	return result, released, nil
+}
+
+// contentHash fingerprints everything an offer says about a product, so that
+// unchanged offers can be skipped.
+func contentHash(offer entity.CatalogOffer) (string, error) {
+	data, err := json.Marshal(offer)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal offer: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func leafCategory(category *string) string {
+	if category == nil {
+		return ""
+	}
+
+	segments := strings.Split(*category, categoryPathSeparator)
+
+	return strings.TrimSpace(segments[len(segments)-1])
+}
+
+func (r *Repo) insertProduct(
+	ctx context.Context,
+	productID entity.ProductID,
+	importID entity.FeedImportID,
+	feedID string,
+	offer entity.CatalogOffer,
+	hash string,
+) error {
+	query := `
+INSERT INTO products (id, feed_id, external_id, name, brand, description, url, price, old_price, currency,
+	category, category_id, available, content_hash, import_id)
+VALUES ($2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, ` + categoryByName + `, $13, $14, $15)`
+
+	_, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, leafCategory(offer.Category),
+		productID, feedID, offer.ExternalID, offer.Name, offer.Brand, offer.Description, offer.URL,
+		offer.Price, offer.OldPrice, offer.Currency, offer.Category, offer.Available, hash, importID)
+	if err != nil {
+		return fmt.Errorf("failed to insert product: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) updateProduct(
+	ctx context.Context,
+	productID entity.ProductID,
+	importID entity.FeedImportID,
+	offer entity.CatalogOffer,
+	hash string,
+) error {
+	query := `
+UPDATE products
+SET name = $3, brand = $4, description = $5, url = $6, price = $7, old_price = $8, currency = $9,
+	category = $10, category_id = ` + categoryByName + `, available = $11, content_hash = $12, import_id = $13,
+	updated_at = NOW()
+WHERE id = $2`
+
+	_, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, leafCategory(offer.Category),
+		productID, offer.Name, offer.Brand, offer.Description, offer.URL, offer.Price, offer.OldPrice,
+		offer.Currency, offer.Category, offer.Available, hash, importID)
+	if err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) replaceSizes(ctx context.Context, productID entity.ProductID, sizes []entity.ProductSize) error {
+	tx := r.db.GetTXFromContext(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM product_sizes WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("failed to delete product sizes: %w", err)
+	}
+
+	for i, size := range sizes {
+		query := `
+INSERT INTO product_sizes (product_id, size, position, available, stock)
+VALUES ($1, $2, $3, $4, $5)`
+
+		if _, err := tx.Exec(ctx, query, productID, size.Size, i, size.Available, size.Stock); err != nil {
+			return fmt.Errorf("failed to insert product size: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordPrice adds the price to the product's history unless it is the
+// latest price recorded already.
+func (r *Repo) recordPrice(ctx context.Context, productID entity.ProductID, price int64, currency string) error {
+	query := `
+INSERT INTO product_price_history (product_id, price, currency)
+SELECT $1, $2, $3
+WHERE NOT EXISTS (
+	SELECT 1
+	FROM (
+		SELECT price, currency
+		FROM product_price_history
+		WHERE product_id = $1
+		ORDER BY recorded_at DESC
+		LIMIT 1
+	) latest
+	WHERE TRUE
+		AND latest.price = $2
+		AND latest.currency = $3
+)`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, productID, price, currency); err != nil {
+		return fmt.Errorf("failed to record product price: %w", err)
+	}
+
+	return nil
+}
+
+// replaceImages stores the product's images when they differ from the current
+// ones. Old mirrored images that are not carried over are returned as dropped.
+func (r *Repo) replaceImages(ctx context.Context, productID entity.ProductID, images []entity.ProductImage) (bool, []string, error) {
+	query := `
+SELECT position, source_url, bucket_key, content_type
+FROM product_images
+WHERE product_id = $1
+ORDER BY position`
+
+	current, err := r.listImages(ctx, query, productID)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if sameImages(current, images) {
+		return false, nil, nil
+	}
+
+	kept := make(map[string]bool, len(images))
+
+	for _, image := range images {
+		if image.Key != nil {
+			kept[image.SourceURL+"\x00"+*image.Key] = true
+		}
+	}
+
+	var dropped []string
+
+	for _, image := range current {
+		if image.Key != nil && !kept[image.SourceURL+"\x00"+*image.Key] {
+			dropped = append(dropped, *image.Key)
+		}
+	}
+
+	tx := r.db.GetTXFromContext(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM product_images WHERE product_id = $1`, productID); err != nil {
+		return false, nil, fmt.Errorf("failed to delete product images: %w", err)
+	}
+
+	for _, image := range images {
+		query := `
+INSERT INTO product_images (product_id, position, source_url, bucket_key, content_type)
+VALUES ($1, $2, $3, $4, $5)`
+
+		if _, err := tx.Exec(ctx, query, productID, image.Position, image.SourceURL, image.Key, image.ContentType); err != nil {
+			return false, nil, fmt.Errorf("failed to insert product image: %w", err)
+		}
+	}
+
+	return true, dropped, nil
+}
+
+func sameImages(a, b []entity.ProductImage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Position != b[i].Position || a[i].SourceURL != b[i].SourceURL || !sameKey(a[i].Key, b[i].Key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameKey(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// DeactivateMissing marks the feed's products the import did not see as
+// unavailable. Their content hash is cleared, so a product that comes back
+// in a later import is updated, and made available, again.
+func (r *Repo) DeactivateMissing(ctx context.Context, feedID string, importID entity.FeedImportID) (int, error) {
+	query := `
+WITH deactivated AS (
+	UPDATE products
+	SET available = FALSE, content_hash = '', updated_at = NOW()
+	WHERE TRUE
+		AND feed_id = $1
+		AND import_id <> $2
+		AND content_hash <> ''
+	RETURNING id
+), sizes AS (
+	UPDATE product_sizes
+	SET available = FALSE
+	WHERE product_id IN (SELECT id FROM deactivated)
+)
+SELECT COUNT(*) FROM deactivated`
+
+	var count int
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, feedID, importID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to deactivate missing products of %s: %w", feedID, err)
+	}
+
+	return count, nil
+}
+
+func (r *Repo) GetProduct(ctx context.Context, productID entity.ProductID) (entity.Product, error) {
+	query := `
+SELECT ` + productColumns + `
+FROM products p
+WHERE p.id = $1`
+
+	product, err := scanProduct(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, productID))
+	if err != nil {
+		return entity.Product{}, fmt.Errorf("failed to get product %s: %w", productID, err)
+	}
+
+	return product, nil
+}
+
+func (r *Repo) GetProductImage(ctx context.Context, productID entity.ProductID, position int) (entity.ProductImage, error) {
+	query := `
+SELECT position, source_url, bucket_key, content_type
+FROM product_images
+WHERE TRUE
+	AND product_id = $1
+	AND position = $2`
+
+	images, err := r.listImages(ctx, query, productID, position)
+	if err != nil {
+		return entity.ProductImage{}, fmt.Errorf("failed to get image %d of product %s: %w", position, productID, err)
+	}
+
+	if len(images) == 0 {
+		return entity.ProductImage{}, entity.ErrProductImageNotFound
+	}
+
+	return images[0], nil
+}
+
+// ListProducts pages through the catalog, newest products first.
+func (r *Repo) ListProducts(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, *string, error) {
+	after, err := entity.ParseCursor(filter.Page.Cursor)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	var (
+		sb     strings.Builder
+		params []any
+	)
+
+	sb.WriteString(`
+SELECT ` + productColumns + `
+FROM products p
+WHERE TRUE`)
+
+	where := func(condition string, value any) {
+		params = append(params, value)
+		sb.WriteString(fmt.Sprintf(" AND "+condition, len(params)))
+	}
+
+	if filter.FeedID != nil {
+		where("p.feed_id = $%d", *filter.FeedID)
+	}
+
+	if filter.CategoryID != nil {
+		where("p.category_id = $%d", *filter.CategoryID)
+	}
+
+	if filter.Brand != nil {
+		where("LOWER(p.brand) = LOWER($%d)", *filter.Brand)
+	}
+
+	if filter.Available != nil {
+		where("p.available = $%d", *filter.Available)
+	}
+
+	if after != nil {
+		params = append(params, after.CreatedAt, after.ID)
+		sb.WriteString(fmt.Sprintf(" AND (p.created_at, p.id) < ($%d, $%d)", len(params)-1, len(params)))
+	}
+
+	params = append(params, filter.Page.Limit+1)
+	sb.WriteString(fmt.Sprintf(" ORDER BY p.created_at DESC, p.id DESC LIMIT $%d", len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	defer rows.Close()
+
+	products := make([]entity.Product, 0, filter.Page.Limit)
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		products = append(products, product)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate products: %w", err)
+	}
+
+	if len(products) <= filter.Page.Limit {
+		return products, nil, nil
+	}
+
+	products = products[:filter.Page.Limit]
+	last := products[len(products)-1]
+	next := entity.Cursor{CreatedAt: last.CreatedAt, ID: uuid.UUID(last.ID)}.String()
+
+	return products, &next, nil
+}
@@ -0,0 +1,74 @@
+package catalogrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrImageFetchFailed = errors.New("image fetch failed")
+
+type FetcherConfig struct {
+	Timeout time.Duration
+	MaxSize int64
+}
+
+// Fetcher downloads product images from partner sites.
+type Fetcher struct {
+	cfg    FetcherConfig
+	client *http.Client
+}
+
+func NewFetcher(cfg FetcherConfig) *Fetcher {
+	return &Fetcher{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// FetchImage downloads an image and returns it with its sniffed content type.
+// Anything that does not look like an image is refused.
+func (f *Fetcher) FetchImage(ctx context.Context, sourceURL string) ([]byte, string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrImageFetchFailed, err)
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close response body")
+		}
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("%w: status %d", ErrImageFetchFailed, response.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, f.cfg.MaxSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrImageFetchFailed, err)
+	}
+
+	if int64(len(data)) > f.cfg.MaxSize {
+		return nil, "", entity.ErrFileTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, "", fmt.Errorf("%w: %s", entity.ErrUnsupportedContentType, contentType)
+	}
+
+	return data, contentType, nil
+}
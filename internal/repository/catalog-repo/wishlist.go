@@ -0,0 +1,411 @@
+package catalogrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const (
+	wishlistColumns = `
+	w.user_id, w.product_id, w.size, w.drop_threshold, w.channel, w.baseline_price, w.created_at, w.updated_at,`
+
+	alertColumns = `
+	a.id, a.user_id, a.product_id, p.name, a.old_price, a.new_price, a.currency, a.channel, a.status,
+	u.phone, a.created_at, a.sent_at`
+)
+
+func scanWishlistItem(row pgx.Row) (entity.WishlistItem, error) {
+	var item entity.WishlistItem
+
+	fields := append([]any{
+		&item.UserID,
+		&item.ProductID,
+		&item.Size,
+		&item.DropThreshold,
+		&item.Channel,
+		&item.BaselinePrice,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	}, productFields(&item.Product)...)
+
+	if err := row.Scan(fields...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.WishlistItem{}, entity.ErrWishlistItemNotFound
+		}
+
+		return entity.WishlistItem{}, fmt.Errorf("failed to scan wishlist item: %w", err)
+	}
+
+	return item, nil
+}
+
+func scanPriceAlert(row pgx.Row) (entity.PriceAlert, error) {
+	var alert entity.PriceAlert
+
+	err := row.Scan(
+		&alert.ID,
+		&alert.UserID,
+		&alert.ProductID,
+		&alert.ProductName,
+		&alert.OldPrice,
+		&alert.NewPrice,
+		&alert.Currency,
+		&alert.Channel,
+		&alert.Status,
+		&alert.Phone,
+		&alert.CreatedAt,
+		&alert.SentAt,
+	)
+	if err != nil {
+		return entity.PriceAlert{}, fmt.Errorf("failed to scan price alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// AddWishlistItem saves the product to the user's wishlist with the
+// product's current price as the baseline.
+func (r *Repo) AddWishlistItem(ctx context.Context, item entity.WishlistItem) (entity.WishlistItem, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+INSERT INTO wishlist_items (user_id, product_id, size, drop_threshold, channel, baseline_price)
+SELECT $1, p.id, $3, $4, $5, p.price
+FROM products p
+WHERE p.id = $2`
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query,
+			item.UserID, item.ProductID, item.Size, item.DropThreshold, item.Channel)
+		if err != nil {
+			var pgErr *pgconn.PgError
+
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return entity.ErrDuplicateWishlistItem
+			}
+
+			return fmt.Errorf("failed to insert wishlist item: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrProductNotFound
+		}
+
+		item, err = r.GetWishlistItem(ctx, item.UserID, item.ProductID)
+
+		return err
+	})
+	if err != nil {
+		return entity.WishlistItem{}, fmt.Errorf("failed to add wishlist item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *Repo) GetWishlistItem(ctx context.Context, userID entity.UserID, productID entity.ProductID) (entity.WishlistItem, error) {
+	query := `
+SELECT ` + wishlistColumns + productColumns + `
+FROM wishlist_items w
+	JOIN products p ON p.id = w.product_id
+WHERE TRUE
+	AND w.user_id = $1
+	AND w.product_id = $2`
+
+	item, err := scanWishlistItem(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, userID, productID))
+	if err != nil {
+		return entity.WishlistItem{}, fmt.Errorf("failed to get wishlist item %s: %w", productID, err)
+	}
+
+	return item, nil
+}
+
+// ListWishlist lists the user's wishlist, recently saved products first.
+func (r *Repo) ListWishlist(ctx context.Context, userID entity.UserID, filter entity.WishlistFilter) ([]entity.WishlistItem, error) {
+	query := `
+SELECT ` + wishlistColumns + productColumns + `
+FROM wishlist_items w
+	JOIN products p ON p.id = w.product_id
+WHERE w.user_id = $1
+ORDER BY w.created_at DESC, w.product_id
+LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, userID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wishlist: %w", err)
+	}
+
+	defer rows.Close()
+
+	items := make([]entity.WishlistItem, 0, filter.Limit)
+
+	for rows.Next() {
+		item, err := scanWishlistItem(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate wishlist: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *Repo) UpdateWishlistItem(
+	ctx context.Context,
+	userID entity.UserID,
+	productID entity.ProductID,
+	update entity.WishlistItemUpdate,
+) (entity.WishlistItem, error) {
+	var item entity.WishlistItem
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+UPDATE wishlist_items
+SET size           = COALESCE($3, size),
+	drop_threshold = COALESCE($4, drop_threshold),
+	channel        = COALESCE($5, channel),
+	updated_at     = NOW()
+WHERE TRUE
+	AND user_id = $1
+	AND product_id = $2`
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query,
+			userID, productID, update.Size, update.DropThreshold, update.Channel)
+		if err != nil {
+			return fmt.Errorf("failed to update wishlist item: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrWishlistItemNotFound
+		}
+
+		item, err = r.GetWishlistItem(ctx, userID, productID)
+
+		return err
+	})
+	if err != nil {
+		return entity.WishlistItem{}, fmt.Errorf("failed to update wishlist item %s: %w", productID, err)
+	}
+
+	return item, nil
+}
+
+func (r *Repo) DeleteWishlistItem(ctx context.Context, userID entity.UserID, productID entity.ProductID) error {
+	query := `
+DELETE FROM wishlist_items
+WHERE TRUE
+	AND user_id = $1
+	AND product_id = $2`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, userID, productID)
+	if err != nil {
+		return fmt.Errorf("failed to delete wishlist item %s: %w", productID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.ErrWishlistItemNotFound
+	}
+
+	return nil
+}
+
+// GetPriceHistory returns the product's prices, oldest first.
+func (r *Repo) GetPriceHistory(ctx context.Context, productID entity.ProductID) ([]entity.PricePoint, error) {
+	query := `
+SELECT price, currency, recorded_at
+FROM product_price_history
+WHERE product_id = $1
+ORDER BY recorded_at`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history of %s: %w", productID, err)
+	}
+
+	defer rows.Close()
+
+	history := make([]entity.PricePoint, 0)
+
+	for rows.Next() {
+		var point entity.PricePoint
+
+		if err := rows.Scan(&point.Price, &point.Currency, &point.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan price point: %w", err)
+		}
+
+		history = append(history, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate price history: %w", err)
+	}
+
+	return history, nil
+}
+
+// CreatePriceAlerts raises a pending alert for every wishlist item whose
+// product got at least drop_threshold percent cheaper than the baseline and
+// moves the baseline down to the new price, so the same drop is only
+// reported once. Items saved for a size only alert while that size is
+// available. It returns the number of alerts raised.
+func (r *Repo) CreatePriceAlerts(ctx context.Context) (int, error) {
+	var created int
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT w.user_id, w.product_id, w.baseline_price, p.price, p.currency, w.channel
+FROM wishlist_items w
+	JOIN products p ON p.id = w.product_id
+	JOIN users u ON u.id = w.user_id
+WHERE TRUE
+	AND p.available
+	AND p.price <= w.baseline_price * (100 - w.drop_threshold) / 100
+	AND (w.size IS NULL OR EXISTS (
+		SELECT 1
+		FROM product_sizes s
+		WHERE TRUE
+			AND s.product_id = p.id
+			AND s.size = w.size
+			AND s.available
+	))
+	AND u.deleted_at IS NULL
+	AND u.status = 'active'
+FOR UPDATE OF w SKIP LOCKED`
+
+		rows, err := tx.Query(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to find price drops: %w", err)
+		}
+
+		alerts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.PriceAlert, error) {
+			var alert entity.PriceAlert
+
+			err := row.Scan(&alert.UserID, &alert.ProductID, &alert.OldPrice, &alert.NewPrice, &alert.Currency, &alert.Channel)
+
+			return alert, err //nolint:wrapcheck
+		})
+		if err != nil {
+			return fmt.Errorf("failed to scan price drops: %w", err)
+		}
+
+		for _, alert := range alerts {
+			query := `
+INSERT INTO price_alerts (id, user_id, product_id, old_price, new_price, currency, channel)
+VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+			if _, err := tx.Exec(ctx, query, entity.PriceAlertID(uuid.New()), alert.UserID, alert.ProductID,
+				alert.OldPrice, alert.NewPrice, alert.Currency, alert.Channel); err != nil {
+				return fmt.Errorf("failed to insert price alert: %w", err)
+			}
+
+			query = `
+UPDATE wishlist_items
+SET baseline_price = $3
+WHERE TRUE
+	AND user_id = $1
+	AND product_id = $2`
+
+			if _, err := tx.Exec(ctx, query, alert.UserID, alert.ProductID, alert.NewPrice); err != nil {
+				return fmt.Errorf("failed to move wishlist baseline: %w", err)
+			}
+		}
+
+		created = len(alerts)
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create price alerts: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *Repo) listPriceAlerts(ctx context.Context, query string, args ...any) ([]entity.PriceAlert, error) {
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price alerts: %w", err)
+	}
+
+	defer rows.Close()
+
+	alerts := make([]entity.PriceAlert, 0)
+
+	for rows.Next() {
+		alert, err := scanPriceAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate price alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// ListPendingAlerts returns up to limit undelivered alerts, oldest first.
+func (r *Repo) ListPendingAlerts(ctx context.Context, limit int) ([]entity.PriceAlert, error) {
+	query := `
+SELECT ` + alertColumns + `
+FROM price_alerts a
+	JOIN products p ON p.id = a.product_id
+	JOIN users u ON u.id = a.user_id
+WHERE a.status = 'pending'
+ORDER BY a.created_at, a.id
+LIMIT $1`
+
+	alerts, err := r.listPriceAlerts(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending price alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// ListPriceAlerts lists the user's alerts, newest first.
+func (r *Repo) ListPriceAlerts(ctx context.Context, userID entity.UserID, filter entity.PriceAlertFilter) ([]entity.PriceAlert, error) {
+	query := `
+SELECT ` + alertColumns + `
+FROM price_alerts a
+	JOIN products p ON p.id = a.product_id
+	JOIN users u ON u.id = a.user_id
+WHERE a.user_id = $1
+ORDER BY a.created_at DESC, a.id
+LIMIT $2 OFFSET $3`
+
+	alerts, err := r.listPriceAlerts(ctx, query, userID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+func (r *Repo) SetAlertStatus(ctx context.Context, alertID entity.PriceAlertID, status entity.PriceAlertStatus) error {
+	query := `
+UPDATE price_alerts
+SET status  = $2,
+	sent_at = CASE WHEN $2::VARCHAR = 'sent' THEN NOW() END
+WHERE id = $1`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, alertID, status); err != nil {
+		return fmt.Errorf("failed to set status of price alert %s: %w", alertID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package eventsrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+	"github.com/rs/zerolog/log"
+)
+
+// channel is the Postgres notification channel events travel through, so
+// that every replica gets the events published by the others.
+const channel = "events"
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	Listen(ctx context.Context, channel string, handle func(payload string)) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Publish notifies the listeners of the event. Inside a transaction the
+// event is only delivered once the transaction commits.
+func (r *Repo) Publish(ctx context.Context, event entity.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, `SELECT pg_notify($1, $2)`, channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+// Listen calls handle with every event published until ctx is done or the
+// connection fails.
+func (r *Repo) Listen(ctx context.Context, handle func(event entity.Event)) error {
+	err := r.db.Listen(ctx, channel, func(payload string) {
+		var event entity.Event
+
+		if err := json.Unmarshal([]byte(payload), &event); err != nil {
+			log.Warn().Err(err).Msg("failed to decode event")
+
+			return
+		}
+
+		handle(event)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to listen to events: %w", err)
+	}
+
+	return nil
+}
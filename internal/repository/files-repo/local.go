@@ -0,0 +1,190 @@
+package filesrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const (
+	objectsDir = "objects"
+	metaDir    = "meta"
+	dirPerm    = 0o750
+	filePerm   = 0o600
+)
+
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Local keeps objects on the local filesystem so photo features can be
+// developed without MinIO. Content types are stored in a sidecar tree.
+type Local struct {
+	root string
+}
+
+func NewLocal(dir string) (*Local, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path for %s: %w", dir, err)
+	}
+
+	for _, sub := range []string{objectsDir, metaDir} {
+		if err := os.MkdirAll(filepath.Join(root, sub), dirPerm); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+
+	return &Local{root: root}, nil
+}
+
+func (l *Local) paths(fileName string) (string, string, error) {
+	key := filepath.FromSlash(fileName)
+	if !filepath.IsLocal(key) {
+		return "", "", fmt.Errorf("%w: %s", ErrInvalidKey, fileName)
+	}
+
+	return filepath.Join(l.root, objectsDir, key), filepath.Join(l.root, metaDir, key), nil
+}
+
+func (l *Local) UploadFile(_ context.Context, data []byte, fileName, contentType string) error {
+	objectPath, metaPath, err := l.paths(fileName)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range []string{objectPath, metaPath} {
+		if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(metaPath, []byte(contentType), filePerm); err != nil {
+		return fmt.Errorf("failed to write file metadata: %w", err)
+	}
+
+	if err := os.WriteFile(objectPath, data, filePerm); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return nil
+}
+
+func (l *Local) DownloadFile(ctx context.Context, fileName string) (io.ReadCloser, string, error) {
+	info, err := l.StatFile(ctx, fileName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	objectPath, _, err := l.paths(fileName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := os.Open(objectPath) //nolint:gosec
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download file: %w", err)
+	}
+
+	return file, info.ContentType, nil
+}
+
+func (l *Local) DeleteFiles(_ context.Context, fileNames ...string) error {
+	for _, fileName := range fileNames {
+		objectPath, metaPath, err := l.paths(fileName)
+		if err != nil {
+			return err
+		}
+
+		for _, path := range []string{objectPath, metaPath} {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to delete files: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l *Local) ListAllFiles(_ context.Context, prefix string) ([]string, error) {
+	base := filepath.Join(l.root, objectsDir)
+
+	var files []string
+
+	err := filepath.WalkDir(base, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			files = append(files, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return files, nil
+}
+
+func (l *Local) StatFile(_ context.Context, fileName string) (ObjectInfo, error) {
+	objectPath, metaPath, err := l.paths(fileName)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	stat, err := os.Stat(objectPath)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, fs.ErrNotExist):
+		return ObjectInfo{}, entity.ErrFileNotFound
+	default:
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	contentType, err := os.ReadFile(metaPath) //nolint:gosec
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return ObjectInfo{}, fmt.Errorf("failed to read file metadata: %w", err)
+	}
+
+	return ObjectInfo{
+		Key:         fileName,
+		Size:        stat.Size(),
+		ContentType: string(contentType),
+		ModifiedAt:  stat.ModTime(),
+	}, nil
+}
+
+// PresignFile returns a file:// URL, there is nothing to sign on a local disk.
+func (l *Local) PresignFile(_ context.Context, fileName, method string, _ time.Duration) (string, error) {
+	if method != http.MethodGet && method != http.MethodPut {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedVerb, method)
+	}
+
+	objectPath, _, err := l.paths(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(objectPath)}).String(), nil
+}
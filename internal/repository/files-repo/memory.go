@@ -0,0 +1,119 @@
+package filesrepo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+	modifiedAt  time.Time
+}
+
+// Memory is an in-process Storage meant for tests.
+type Memory struct {
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		objects: make(map[string]memoryObject),
+	}
+}
+
+func (m *Memory) UploadFile(_ context.Context, data []byte, fileName, contentType string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects[fileName] = memoryObject{
+		data:        bytes.Clone(data),
+		contentType: contentType,
+		modifiedAt:  time.Now(),
+	}
+
+	return nil
+}
+
+func (m *Memory) DownloadFile(_ context.Context, fileName string) (io.ReadCloser, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	object, ok := m.objects[fileName]
+	if !ok {
+		return nil, "", entity.ErrFileNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(object.data)), object.contentType, nil
+}
+
+func (m *Memory) DeleteFiles(_ context.Context, fileNames ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, fileName := range fileNames {
+		delete(m.objects, fileName)
+	}
+
+	return nil
+}
+
+func (m *Memory) ListAllFiles(_ context.Context, prefix string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	files := make([]string, 0, len(m.objects))
+
+	for key := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			files = append(files, key)
+		}
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+func (m *Memory) StatFile(_ context.Context, fileName string) (ObjectInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	object, ok := m.objects[fileName]
+	if !ok {
+		return ObjectInfo{}, entity.ErrFileNotFound
+	}
+
+	return ObjectInfo{
+		Key:         fileName,
+		Size:        int64(len(object.data)),
+		ContentType: object.contentType,
+		ModifiedAt:  object.modifiedAt,
+	}, nil
+}
+
+// PresignFile returns a memory:// URL carrying the expiry, it is only useful
+// for asserting on in tests.
+func (m *Memory) PresignFile(_ context.Context, fileName, method string, expires time.Duration) (string, error) {
+	if method != http.MethodGet && method != http.MethodPut {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedVerb, method)
+	}
+
+	query := url.Values{}
+	query.Set("method", method)
+	query.Set("expires", strconv.FormatInt(time.Now().Add(expires).Unix(), 10))
+
+	return (&url.URL{Scheme: "memory", Path: "/" + fileName, RawQuery: query.Encode()}).String(), nil
+}
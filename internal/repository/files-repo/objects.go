@@ -0,0 +1,78 @@
+package filesrepo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+// StoreObject keeps data in the bucket for content that has no file row, such
+// as mirrored catalog images, and returns its key. The caller holds a blob
+// reference until it calls ReleaseObject with the key.
+func (r *Repo) StoreObject(ctx context.Context, data []byte, contentType string) (string, error) {
+	checksum := sha256.Sum256(data)
+
+	object := entity.File{
+		Checksum:    hex.EncodeToString(checksum[:]),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+	}
+	object.Key = contentKey(object.Checksum)
+
+	err := r.withBlob(ctx, object, func(context.Context) error {
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store object: %w", err)
+	}
+
+	if err := r.storeObject(ctx, object, data); err != nil {
+		if err := r.ReleaseObject(ctx, object.Key); err != nil {
+			log.Warn().Err(err).Str("key", object.Key).Msg("failed to release object after failed upload")
+		}
+
+		return "", fmt.Errorf("failed to store object: %w", err)
+	}
+
+	return object.Key, nil
+}
+
+// ReleaseObject drops a reference taken by StoreObject, removing the object
+// once nothing refers to it any more.
+func (r *Repo) ReleaseObject(ctx context.Context, key string) error {
+	var refCount int64
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		refCount, err = r.releaseBlob(ctx, key)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release object %s: %w", key, err)
+	}
+
+	if refCount == 0 {
+		if _, err := r.purgeBlob(ctx, key); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("failed to remove unreferenced blob")
+		}
+	}
+
+	return nil
+}
+
+func (r *Repo) PresignObject(ctx context.Context, key string, expires time.Duration) (string, error) {
+	presignedURL, err := r.storage.PresignFile(ctx, key, http.MethodGet, expires)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object %s: %w", key, err)
+	}
+
+	return presignedURL, nil
+}
@@ -0,0 +1,484 @@
+package filesrepo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	contentKeyPrefix = "sha256/"
+	// legacyKeyPrefix holds the per-user objects of files uploaded before
+	// content addressing, which the blobs migration took over.
+	legacyKeyPrefix = "users/"
+	fileColumns     = "id, user_id, bucket_key, content_type, size, checksum, status, created_at, updated_at, deleted_at"
+
+	purgeWaitAttempts = 5
+	purgeWaitDelay    = 100 * time.Millisecond
+)
+
+var errBlobPurged = errors.New("blob is being purged")
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Repo keeps file metadata in Postgres in step with the objects in the bucket.
+// Objects are content addressed and shared between files through the blobs
+// table: a blob referred to by a ready file always has its object stored, a
+// blob whose ref_count dropped to zero may already have lost it.
+type Repo struct {
+	db      database
+	storage Storage
+}
+
+func NewRepo(db database, storage Storage) *Repo {
+	return &Repo{
+		db:      db,
+		storage: storage,
+	}
+}
+
+func contentKey(checksum string) string {
+	return contentKeyPrefix + checksum[:2] + "/" + checksum
+}
+
+func scanFile(row pgx.Row) (entity.File, error) {
+	var file entity.File
+
+	err := row.Scan(
+		&file.ID,
+		&file.UserID,
+		&file.Key,
+		&file.ContentType,
+		&file.Size,
+		&file.Checksum,
+		&file.Status,
+		&file.CreatedAt,
+		&file.UpdatedAt,
+		&file.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.File{}, entity.ErrFileNotFound
+		}
+
+		return entity.File{}, fmt.Errorf("failed to scan file: %w", err)
+	}
+
+	return file, nil
+}
+
+// CreateFile stores data under its SHA-256 key and links a new file to it.
+// The file is recorded pending together with its blob reference, the object
+// is uploaded outside of that transaction and only then is the file made
+// ready. Content that is already in the bucket is not uploaded again. A file
+// whose upload never completes stays pending until the reconciler expires it.
+func (r *Repo) CreateFile(ctx context.Context, file entity.File, data []byte) (entity.File, error) {
+	checksum := sha256.Sum256(data)
+
+	file.Checksum = hex.EncodeToString(checksum[:])
+	file.Key = contentKey(file.Checksum)
+	file.Size = int64(len(data))
+
+	err := r.withBlob(ctx, file, func(ctx context.Context) error {
+		var err error
+
+		file, err = r.linkFile(ctx, file)
+
+		return err
+	})
+	if err != nil {
+		return entity.File{}, fmt.Errorf("failed to create file %s: %w", file.ID, err)
+	}
+
+	if err := r.storeObject(ctx, file, data); err != nil {
+		return entity.File{}, fmt.Errorf("failed to upload file %s: %w", file.ID, err)
+	}
+
+	query := `
+UPDATE files
+SET status = $2, updated_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND status = $3
+RETURNING ` + fileColumns
+
+	file, err = scanFile(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, file.ID, entity.FileStatusReady, entity.FileStatusPending))
+	if err != nil {
+		return entity.File{}, fmt.Errorf("failed to mark file %s ready: %w", file.ID, err)
+	}
+
+	return file, nil
+}
+
+// withBlob runs fn in the transaction that takes a reference on the blob for
+// file.Key. A blob that is being purged may still lose its object, so withBlob
+// waits for the purge to finish; a purge that does not finish in time is left
+// to the reconciler and withBlob fails.
+func (r *Repo) withBlob(ctx context.Context, file entity.File, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := r.db.WithTx(ctx, func(ctx context.Context) error {
+			if err := r.acquireBlob(ctx, file); err != nil {
+				return err
+			}
+
+			return fn(ctx)
+		})
+
+		switch {
+		case err == nil:
+			return nil
+		case !errors.Is(err, errBlobPurged) || attempt == purgeWaitAttempts:
+			return fmt.Errorf("failed to reference blob %s: %w", file.Key, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to wait for blob %s to be purged: %w", file.Key, ctx.Err())
+		case <-time.After(purgeWaitDelay):
+		}
+	}
+}
+
+// acquireBlob takes a reference on the blob for file.Key unless it is being
+// purged.
+func (r *Repo) acquireBlob(ctx context.Context, file entity.File) error {
+	query := `
+INSERT INTO blobs (bucket_key, checksum, content_type, size, ref_count)
+VALUES ($1, $2, $3, $4, 1)
+ON CONFLICT (bucket_key) DO UPDATE
+SET ref_count = blobs.ref_count + 1, updated_at = NOW()
+WHERE blobs.purged_at IS NULL`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, file.Key, file.Checksum, file.ContentType, file.Size)
+	if err != nil {
+		return fmt.Errorf("failed to acquire blob: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errBlobPurged
+	}
+
+	return nil
+}
+
+// storeObject uploads the object of a file unless the bucket already has it.
+func (r *Repo) storeObject(ctx context.Context, file entity.File, data []byte) error {
+	_, err := r.storage.StatFile(ctx, file.Key)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, entity.ErrFileNotFound):
+	default:
+		return fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	if err := r.storage.UploadFile(ctx, data, file.Key, file.ContentType); err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) linkFile(ctx context.Context, file entity.File) (entity.File, error) {
+	query := `
+INSERT INTO files (id, user_id, bucket_key, content_type, size, checksum, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING ` + fileColumns
+
+	return scanFile(r.db.GetTXFromContext(ctx).QueryRow(ctx, query,
+		file.ID, file.UserID, file.Key, file.ContentType, file.Size, file.Checksum, entity.FileStatusPending))
+}
+
+func (r *Repo) GetFile(ctx context.Context, fileID entity.FileID) (entity.File, error) {
+	query := `
+SELECT ` + fileColumns + `
+FROM files
+WHERE TRUE
+	AND id = $1
+	AND status = $2`
+
+	file, err := scanFile(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, fileID, entity.FileStatusReady))
+	if err != nil {
+		return entity.File{}, fmt.Errorf("failed to get file %s: %w", fileID, err)
+	}
+
+	return file, nil
+}
+
+func (r *Repo) DownloadFile(ctx context.Context, file entity.File) (io.ReadCloser, error) {
+	body, _, err := r.storage.DownloadFile(ctx, file.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file %s: %w", file.ID, err)
+	}
+
+	return body, nil
+}
+
+func (r *Repo) PresignFile(ctx context.Context, file entity.File, expires time.Duration) (string, error) {
+	presignedURL, err := r.storage.PresignFile(ctx, file.Key, http.MethodGet, expires)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign file %s: %w", file.ID, err)
+	}
+
+	return presignedURL, nil
+}
+
+// DeleteFile marks the owner's file deleted and drops its blob reference.
+// The object itself is only removed once no other file refers to it; a failed
+// removal is left to the reconciler.
+func (r *Repo) DeleteFile(ctx context.Context, userID entity.UserID, fileID entity.FileID) error {
+	var (
+		file     entity.File
+		refCount int64 = -1
+	)
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+UPDATE files
+SET status = $3, deleted_at = NOW(), updated_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2
+	AND status <> $3
+RETURNING ` + fileColumns
+
+		var err error
+
+		if file, err = scanFile(tx.QueryRow(ctx, query, fileID, userID, entity.FileStatusDeleted)); err != nil {
+			return err
+		}
+
+		refCount, err = r.releaseBlob(ctx, file.Key)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete file %s: %w", fileID, err)
+	}
+
+	if refCount == 0 {
+		if _, err := r.purgeBlob(ctx, file.Key); err != nil {
+			log.Warn().Err(err).Str("file", fileID.String()).Msg("failed to remove unreferenced blob")
+		}
+	}
+
+	return nil
+}
+
+// releaseBlob drops a reference on the blob and returns how many are left, or
+// -1 when there is no such blob.
+func (r *Repo) releaseBlob(ctx context.Context, key string) (int64, error) {
+	query := `
+UPDATE blobs
+SET ref_count = ref_count - 1, updated_at = NOW()
+WHERE bucket_key = $1
+RETURNING ref_count`
+
+	var refCount int64
+
+	err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, key).Scan(&refCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return -1, nil
+		}
+
+		return 0, fmt.Errorf("failed to release blob: %w", err)
+	}
+
+	return refCount, nil
+}
+
+// purgeBlob removes an unreferenced blob together with its object. The blob
+// is marked purged first, which keeps new files from referring to it, then
+// its object is removed and only after that its row. A purge that stops
+// halfway leaves a purged row behind, which the reconciler purges again.
+func (r *Repo) purgeBlob(ctx context.Context, key string) (bool, error) {
+	query := `
+UPDATE blobs
+SET purged_at = COALESCE(purged_at, NOW()), updated_at = NOW()
+WHERE TRUE
+	AND bucket_key = $1
+	AND ref_count = 0`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark blob %s purged: %w", key, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := r.storage.DeleteFiles(ctx, key); err != nil {
+		return false, fmt.Errorf("failed to remove object of blob %s: %w", key, err)
+	}
+
+	query = `
+DELETE FROM blobs
+WHERE TRUE
+	AND bucket_key = $1
+	AND purged_at IS NOT NULL`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, key); err != nil {
+		return false, fmt.Errorf("failed to delete blob %s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+// ExpirePendingFiles marks files deleted whose upload never completed and
+// drops their blob references.
+func (r *Repo) ExpirePendingFiles(ctx context.Context, olderThan time.Duration) (int64, error) {
+	var expired int64
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+UPDATE files
+SET status = $1, deleted_at = NOW(), updated_at = NOW()
+WHERE TRUE
+	AND status = $2
+	AND created_at < NOW() - INTERVAL '1 SECOND'*$3
+RETURNING bucket_key`
+
+		rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, entity.FileStatusDeleted, entity.FileStatusPending, olderThan.Seconds())
+		if err != nil {
+			return fmt.Errorf("failed to update pending files: %w", err)
+		}
+
+		keys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return fmt.Errorf("failed to collect pending files: %w", err)
+		}
+
+		for _, key := range keys {
+			if _, err := r.releaseBlob(ctx, key); err != nil {
+				return err
+			}
+		}
+
+		expired = int64(len(keys))
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire pending files: %w", err)
+	}
+
+	return expired, nil
+}
+
+// PurgeUnreferencedBlobs removes blobs whose last file reference is gone,
+// including those a purge was interrupted on.
+func (r *Repo) PurgeUnreferencedBlobs(ctx context.Context) (int, error) {
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, `SELECT bucket_key FROM blobs WHERE ref_count = 0`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query unreferenced blobs: %w", err)
+	}
+
+	keys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("failed to collect unreferenced blobs: %w", err)
+	}
+
+	var purged int
+
+	for _, key := range keys {
+		ok, err := r.purgeBlob(ctx, key)
+		if err != nil {
+			return purged, err
+		}
+
+		if ok {
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// RemoveOrphanedObjects deletes bucket objects older than gracePeriod that no
+// blob row refers to and returns their keys. The grace period protects objects
+// uploaded by transactions that have not committed yet. Only the prefixes this
+// repo writes to are swept: objects elsewhere in the bucket were never tracked.
+func (r *Repo) RemoveOrphanedObjects(ctx context.Context, gracePeriod time.Duration) ([]string, error) {
+	var keys []string
+
+	for _, prefix := range []string{contentKeyPrefix, legacyKeyPrefix} {
+		prefixKeys, err := r.storage.ListAllFiles(ctx, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+		}
+
+		keys = append(keys, prefixKeys...)
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, `SELECT bucket_key FROM blobs WHERE bucket_key = ANY($1)`, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query live blobs: %w", err)
+	}
+
+	live, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect live blobs: %w", err)
+	}
+
+	liveKeys := make(map[string]struct{}, len(live))
+	for _, key := range live {
+		liveKeys[key] = struct{}{}
+	}
+
+	orphans := make([]string, 0, len(keys)-len(live))
+
+	for _, key := range keys {
+		if _, ok := liveKeys[key]; ok {
+			continue
+		}
+
+		info, err := r.storage.StatFile(ctx, key)
+		if err != nil {
+			if errors.Is(err, entity.ErrFileNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to stat object: %w", err)
+		}
+
+		if time.Since(info.ModifiedAt) > gracePeriod {
+			orphans = append(orphans, key)
+		}
+	}
+
+	if len(orphans) == 0 {
+		return nil, nil
+	}
+
+	if err := r.storage.DeleteFiles(ctx, orphans...); err != nil {
+		return nil, fmt.Errorf("failed to remove orphaned objects: %w", err)
+	}
+
+	return orphans, nil
+}
@@ -8,8 +8,10 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -42,7 +44,7 @@ type S3 struct {
 	client *s3.Client
 }
 
-func New(cfg S3Config) (*S3, error) {
+func NewS3(cfg S3Config) (*S3, error) {
 	endpoint, err := url.Parse(cfg.Address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse S3 address: %w", err)
@@ -62,8 +64,8 @@ func New(cfg S3Config) (*S3, error) {
 	return &s3Repo, nil
 }
 
-func (s *S3) UploadFile(data []byte, fileName, contentType string) error {
-	_, err := s.client.PutObject(context.Background(), &s3.PutObjectInput{
+func (s *S3) UploadFile(ctx context.Context, data []byte, fileName, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      &s.cfg.Bucket,
 		Key:         aws.String(fileName),
 		Body:        bytes.NewReader(data),
@@ -76,32 +78,30 @@ func (s *S3) UploadFile(data []byte, fileName, contentType string) error {
 	return nil
 }
 
-func (s *S3) DownloadFile(fileName string) (io.ReadCloser, string, error) {
-	result, err := s.client.GetObject(context.Background(), &s3.GetObjectInput{
+func (s *S3) DownloadFile(ctx context.Context, fileName string) (io.ReadCloser, string, error) {
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: &s.cfg.Bucket,
 		Key:    aws.String(fileName),
 	})
 
-	var responseError *awshttp.ResponseError
-
 	switch {
 	case err == nil:
-	case errors.As(err, &responseError) && responseError.HTTPStatusCode() == http.StatusNotFound:
+	case isNotFound(err):
 		return nil, "", entity.ErrFileNotFound
 	default:
 		return nil, "", fmt.Errorf("failed to download file: %w", err)
 	}
 
-	return result.Body, *result.ContentType, nil
+	return result.Body, aws.ToString(result.ContentType), nil
 }
 
-func (s *S3) DeleteFiles(fileNames ...string) error {
+func (s *S3) DeleteFiles(ctx context.Context, fileNames ...string) error {
 	toRemove := make([]types.ObjectIdentifier, 0, len(fileNames))
 	for _, fileName := range fileNames {
 		toRemove = append(toRemove, types.ObjectIdentifier{Key: aws.String(fileName)})
 	}
 
-	_, err := s.client.DeleteObjects(context.Background(), &s3.DeleteObjectsInput{
+	_, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 		Bucket: &s.cfg.Bucket,
 		Delete: &types.Delete{Objects: toRemove},
 	})
@@ -112,19 +112,82 @@ func (s *S3) DeleteFiles(fileNames ...string) error {
 	return nil
 }
 
-func (s *S3) ListAllFiles(prefix string) ([]string, error) {
-	result, err := s.client.ListObjectsV2(context.Background(), &s3.ListObjectsV2Input{
+func (s *S3) ListAllFiles(ctx context.Context, prefix string) ([]string, error) {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
 		Bucket: &s.cfg.Bucket,
 		Prefix: aws.String(prefix),
 	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list files: %w", err)
-	}
 
-	files := make([]string, 0, len(result.Contents))
-	for _, object := range result.Contents {
-		files = append(files, *object.Key)
+	var files []string
+
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			files = append(files, *object.Key)
+		}
 	}
 
 	return files, nil
 }
+
+func (s *S3) StatFile(ctx context.Context, fileName string) (ObjectInfo, error) {
+	result, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &s.cfg.Bucket,
+		Key:    aws.String(fileName),
+	})
+
+	switch {
+	case err == nil:
+	case isNotFound(err):
+		return ObjectInfo{}, entity.ErrFileNotFound
+	default:
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return ObjectInfo{
+		Key:         fileName,
+		Size:        aws.ToInt64(result.ContentLength),
+		ContentType: aws.ToString(result.ContentType),
+		ModifiedAt:  aws.ToTime(result.LastModified),
+	}, nil
+}
+
+func (s *S3) PresignFile(ctx context.Context, fileName, method string, expires time.Duration) (string, error) {
+	presignClient := s3.NewPresignClient(s.client, s3.WithPresignExpires(expires))
+
+	var (
+		request *v4.PresignedHTTPRequest
+		err     error
+	)
+
+	switch method {
+	case http.MethodGet:
+		request, err = presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: &s.cfg.Bucket,
+			Key:    aws.String(fileName),
+		})
+	case http.MethodPut:
+		request, err = presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: &s.cfg.Bucket,
+			Key:    aws.String(fileName),
+		})
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedVerb, method)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to presign file: %w", err)
+	}
+
+	return request.URL, nil
+}
+
+func isNotFound(err error) bool {
+	var responseError *awshttp.ResponseError
+
+	return errors.As(err, &responseError) && responseError.HTTPStatusCode() == http.StatusNotFound
+}
@@ -0,0 +1,57 @@
+package filesrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	BackendS3     = "s3"
+	BackendLocal  = "local"
+	BackendMemory = "memory"
+)
+
+var (
+	ErrUnknownBackend  = errors.New("unknown storage backend")
+	ErrUnsupportedVerb = errors.New("unsupported presign method")
+)
+
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModifiedAt  time.Time
+}
+
+// Storage is a blob store the files repository keeps its objects in.
+// Missing objects are reported with entity.ErrFileNotFound.
+type Storage interface {
+	UploadFile(ctx context.Context, data []byte, fileName, contentType string) error
+	DownloadFile(ctx context.Context, fileName string) (io.ReadCloser, string, error)
+	DeleteFiles(ctx context.Context, fileNames ...string) error
+	ListAllFiles(ctx context.Context, prefix string) ([]string, error)
+	StatFile(ctx context.Context, fileName string) (ObjectInfo, error)
+	PresignFile(ctx context.Context, fileName, method string, expires time.Duration) (string, error)
+}
+
+type StorageConfig struct {
+	Backend  string
+	S3       S3Config
+	LocalDir string
+}
+
+func NewStorage(cfg StorageConfig) (Storage, error) {
+	switch cfg.Backend {
+	case BackendS3:
+		return NewS3(cfg.S3)
+	case BackendLocal:
+		return NewLocal(cfg.LocalDir)
+	case BackendMemory:
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, cfg.Backend)
+	}
+}
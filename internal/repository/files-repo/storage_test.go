@@ -0,0 +1,81 @@
+package filesrepo_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	filesrepo "github.com/romanpitatelev/clothing-service/internal/repository/files-repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageBackends(t *testing.T) {
+	local, err := filesrepo.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	backends := map[string]filesrepo.Storage{
+		filesrepo.BackendMemory: filesrepo.NewMemory(),
+		filesrepo.BackendLocal:  local,
+	}
+
+	for name, storage := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+
+			_, _, err := storage.DownloadFile(ctx, "users/a/missing")
+			require.ErrorIs(t, err, entity.ErrFileNotFound)
+
+			_, err = storage.StatFile(ctx, "users/a/missing")
+			require.ErrorIs(t, err, entity.ErrFileNotFound)
+
+			require.NoError(t, storage.UploadFile(ctx, []byte("first"), "users/a/1", "image/png"))
+			require.NoError(t, storage.UploadFile(ctx, []byte("second"), "users/b/2", "image/jpeg"))
+
+			body, contentType, err := storage.DownloadFile(ctx, "users/a/1")
+			require.NoError(t, err)
+
+			data, err := io.ReadAll(body)
+			require.NoError(t, err)
+			require.NoError(t, body.Close())
+			require.Equal(t, "first", string(data))
+			require.Equal(t, "image/png", contentType)
+
+			info, err := storage.StatFile(ctx, "users/b/2")
+			require.NoError(t, err)
+			require.Equal(t, int64(len("second")), info.Size)
+			require.Equal(t, "image/jpeg", info.ContentType)
+
+			files, err := storage.ListAllFiles(ctx, "users/a/")
+			require.NoError(t, err)
+			require.Equal(t, []string{"users/a/1"}, files)
+
+			presignedURL, err := storage.PresignFile(ctx, "users/a/1", http.MethodGet, time.Minute)
+			require.NoError(t, err)
+			require.NotEmpty(t, presignedURL)
+
+			_, err = storage.PresignFile(ctx, "users/a/1", http.MethodPatch, time.Minute)
+			require.ErrorIs(t, err, filesrepo.ErrUnsupportedVerb)
+
+			require.NoError(t, storage.DeleteFiles(ctx, "users/a/1", "users/a/missing"))
+
+			files, err = storage.ListAllFiles(ctx, "users/")
+			require.NoError(t, err)
+			require.Equal(t, []string{"users/b/2"}, files)
+		})
+	}
+}
+
+func TestLocalRejectsKeysOutsideRoot(t *testing.T) {
+	local, err := filesrepo.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	err = local.UploadFile(t.Context(), []byte("data"), "../escape", "text/plain")
+	require.ErrorIs(t, err, filesrepo.ErrInvalidKey)
+}
+
+func TestNewStorageUnknownBackend(t *testing.T) {
+	_, err := filesrepo.NewStorage(filesrepo.StorageConfig{Backend: "ftp"})
+	require.ErrorIs(t, err, filesrepo.ErrUnknownBackend)
+}
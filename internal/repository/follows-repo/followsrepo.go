@@ -0,0 +1,155 @@
+package followsrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Follow makes the follower follow the followee and reports whether the
+// follow is new. Following someone twice is not an error.
+func (r *Repo) Follow(ctx context.Context, followerID, followeeID entity.UserID) (bool, error) {
+	tx := r.db.GetTXFromContext(ctx)
+
+	query := `
+INSERT INTO follows (follower_id, followee_id)
+SELECT $1, id
+FROM users
+WHERE TRUE
+	AND id = $2
+	AND deleted_at IS NULL
+ON CONFLICT DO NOTHING`
+
+	tag, err := tx.Exec(ctx, query, followerID, followeeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to follow user %s: %w", followeeID, err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		return true, nil
+	}
+
+	var exists bool
+
+	query = `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)`
+
+	if err := tx.QueryRow(ctx, query, followeeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check user %s: %w", followeeID, err)
+	}
+
+	if !exists {
+		return false, entity.ErrUserNotFound
+	}
+
+	return false, nil
+}
+
+// Unfollow is a no-op when the follower did not follow the followee.
+func (r *Repo) Unfollow(ctx context.Context, followerID, followeeID entity.UserID) error {
+	query := `
+DELETE FROM follows
+WHERE TRUE
+	AND follower_id = $1
+	AND followee_id = $2`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, followerID, followeeID); err != nil {
+		return fmt.Errorf("failed to unfollow user %s: %w", followeeID, err)
+	}
+
+	return nil
+}
+
+// ListFollowers lists who follows the user, latest first.
+func (r *Repo) ListFollowers(ctx context.Context, userID entity.UserID, page entity.Page) ([]entity.FollowUser, *string, error) {
+	return r.list(ctx, "f.followee_id", "f.follower_id", userID, page)
+}
+
+// ListFollowing lists whom the user follows, latest first.
+func (r *Repo) ListFollowing(ctx context.Context, userID entity.UserID, page entity.Page) ([]entity.FollowUser, *string, error) {
+	return r.list(ctx, "f.follower_id", "f.followee_id", userID, page)
+}
+
+// list pages through the follows matching userID in the by column and returns
+// the live users in the other column.
+func (r *Repo) list(
+	ctx context.Context,
+	by, other string,
+	userID entity.UserID,
+	page entity.Page,
+) ([]entity.FollowUser, *string, error) {
+	after, err := entity.ParseCursor(page.Cursor)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	params := []any{userID}
+
+	query := `
+SELECT u.id, u.nick_name, u.first_name, u.last_name, f.created_at
+FROM follows f
+	JOIN users u ON u.id = ` + other + ` AND u.deleted_at IS NULL
+WHERE ` + by + ` = $1`
+
+	if after != nil {
+		params = append(params, after.CreatedAt, after.ID)
+		query += fmt.Sprintf(" AND (f.created_at, %s) < ($%d, $%d)", other, len(params)-1, len(params))
+	}
+
+	params = append(params, page.Limit+1)
+	query += fmt.Sprintf(" ORDER BY f.created_at DESC, %s DESC LIMIT $%d", other, len(params))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+
+	defer rows.Close()
+
+	users := make([]entity.FollowUser, 0, page.Limit)
+
+	for rows.Next() {
+		var user entity.FollowUser
+
+		if err := rows.Scan(&user.UserID, &user.NickName, &user.FirstName, &user.LastName, &user.FollowedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan follow: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate follows: %w", err)
+	}
+
+	if len(users) <= page.Limit {
+		return users, nil, nil
+	}
+
+	users = users[:page.Limit]
+	last := users[len(users)-1]
+	next := entity.Cursor{CreatedAt: last.FollowedAt, ID: uuid.UUID(last.UserID)}.String()
+
+	return users, &next, nil
+}
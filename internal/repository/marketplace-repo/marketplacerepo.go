@@ -0,0 +1,491 @@
+package marketplacerepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const listingColumns = `
+	l.id, l.seller_id, l.item_id, l.title, l.description, l.price, l.currency, l.condition,
+	l.category, l.subcategory, l.brand, l.size,
+	ARRAY(
+		SELECT p.file_id
+		FROM listing_photos p
+			JOIN files f ON f.id = p.file_id AND f.status = 'ready'
+		WHERE p.listing_id = l.id
+		ORDER BY p.position
+	),
+	l.status, l.buyer_id, l.published_at, l.sold_at, l.created_at, l.updated_at`
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanListing(row pgx.Row) (entity.Listing, error) {
+	var (
+		listing  entity.Listing
+		photoIDs []uuid.UUID
+	)
+
+	err := row.Scan(
+		&listing.ID,
+		&listing.SellerID,
+		&listing.ItemID,
+		&listing.Title,
+		&listing.Description,
+		&listing.Price,
+		&listing.Currency,
+		&listing.Condition,
+		&listing.Category,
+		&listing.Subcategory,
+		&listing.Brand,
+		&listing.Size,
+		&photoIDs,
+		&listing.Status,
+		&listing.BuyerID,
+		&listing.PublishedAt,
+		&listing.SoldAt,
+		&listing.CreatedAt,
+		&listing.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Listing{}, entity.ErrListingNotFound
+		}
+
+		return entity.Listing{}, fmt.Errorf("failed to scan listing: %w", err)
+	}
+
+	listing.PhotoIDs = make([]entity.FileID, 0, len(photoIDs))
+	for _, photoID := range photoIDs {
+		listing.PhotoIDs = append(listing.PhotoIDs, entity.FileID(photoID))
+	}
+
+	return listing, nil
+}
+
+// CreateListing lists a wardrobe item of the seller that is neither deleted
+// nor archived. The listing copies the item's name, categories, brand and
+// size, and its photos when the listing comes without any.
+func (r *Repo) CreateListing(ctx context.Context, listing entity.Listing) (entity.Listing, error) { //nolint:funlen
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT i.name, i.category, i.subcategory, i.brand, i.size,
+	ARRAY(
+		SELECT p.file_id
+		FROM wardrobe_item_photos p
+			JOIN files f ON f.id = p.file_id AND f.status = 'ready'
+		WHERE p.item_id = i.id
+		ORDER BY p.position
+	)
+FROM wardrobe_items i
+WHERE TRUE
+	AND i.id = $1
+	AND i.user_id = $2
+	AND i.deleted_at IS NULL
+	AND i.archived_at IS NULL
+FOR SHARE OF i`
+
+		var (
+			name     string
+			photoIDs []uuid.UUID
+		)
+
+		err := tx.QueryRow(ctx, query, listing.ItemID, listing.SellerID).Scan(&name, &listing.Category,
+			&listing.Subcategory, &listing.Brand, &listing.Size, &photoIDs)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrWardrobeItemNotFound
+			}
+
+			return fmt.Errorf("failed to get wardrobe item %s: %w", listing.ItemID, err)
+		}
+
+		if listing.Title == "" {
+			listing.Title = name
+		}
+
+		if len(listing.PhotoIDs) == 0 {
+			for _, photoID := range photoIDs {
+				listing.PhotoIDs = append(listing.PhotoIDs, entity.FileID(photoID))
+			}
+		}
+
+		query = `
+INSERT INTO listings (id, seller_id, item_id, title, description, price, currency, condition,
+	category, subcategory, brand, size, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+		_, err = tx.Exec(ctx, query, listing.ID, listing.SellerID, listing.ItemID, listing.Title, listing.Description,
+			listing.Price, listing.Currency, listing.Condition, listing.Category, listing.Subcategory, listing.Brand,
+			listing.Size, listing.Status)
+		if err != nil {
+			var pgErr *pgconn.PgError
+
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return entity.ErrDuplicateListing
+			}
+
+			return fmt.Errorf("failed to insert listing: %w", err)
+		}
+
+		if err := r.setPhotos(ctx, listing.SellerID, listing.ID, listing.PhotoIDs); err != nil {
+			return err
+		}
+
+		listing, err = r.GetListing(ctx, listing.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to create listing: %w", err)
+	}
+
+	return listing, nil
+}
+
+// setPhotos replaces the listing's photos, making sure every file is a ready
+// file of the seller.
+func (r *Repo) setPhotos(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID, photoIDs []entity.FileID) error {
+	tx := r.db.GetTXFromContext(ctx)
+
+	ids := make([]uuid.UUID, 0, len(photoIDs))
+	for _, photoID := range photoIDs {
+		ids = append(ids, uuid.UUID(photoID))
+	}
+
+	var owned int
+
+	query := `
+SELECT COUNT(*)
+FROM files
+WHERE TRUE
+	AND id = ANY($1)
+	AND user_id = $2
+	AND status = $3`
+
+	if err := tx.QueryRow(ctx, query, ids, sellerID, entity.FileStatusReady).Scan(&owned); err != nil {
+		return fmt.Errorf("failed to check photos: %w", err)
+	}
+
+	if owned != len(ids) {
+		return fmt.Errorf("%w: unknown photo", entity.ErrInvalidListing)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM listing_photos WHERE listing_id = $1`, listingID); err != nil {
+		return fmt.Errorf("failed to clear photos: %w", err)
+	}
+
+	query = `
+INSERT INTO listing_photos (listing_id, file_id, position)
+SELECT $1, photo.id, photo.position
+FROM UNNEST($2::uuid[]) WITH ORDINALITY AS photo(id, position)`
+
+	if _, err := tx.Exec(ctx, query, listingID, ids); err != nil {
+		return fmt.Errorf("failed to set photos: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetListing(ctx context.Context, listingID entity.ListingID) (entity.Listing, error) {
+	query := `
+SELECT ` + listingColumns + `
+FROM listings l
+WHERE l.id = $1`
+
+	listing, err := scanListing(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, listingID))
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to get listing %s: %w", listingID, err)
+	}
+
+	return listing, nil
+}
+
+// lockListing returns the seller's listing locked until the end of the
+// transaction.
+func (r *Repo) lockListing(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID) (entity.Listing, error) {
+	query := `
+SELECT ` + listingColumns + `
+FROM listings l
+WHERE TRUE
+	AND l.id = $1
+	AND l.seller_id = $2
+FOR UPDATE OF l`
+
+	listing, err := scanListing(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, listingID, sellerID))
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to lock listing %s: %w", listingID, err)
+	}
+
+	return listing, nil
+}
+
+func (r *Repo) ListListings(ctx context.Context, filter entity.ListingFilter) ([]entity.Listing, error) { //nolint:funlen,cyclop
+	var (
+		sb    strings.Builder
+		where strings.Builder
+	)
+
+	params := []any{}
+	rank := "0::real"
+
+	add := func(value any) int {
+		params = append(params, value)
+
+		return len(params)
+	}
+
+	where.WriteString("TRUE")
+
+	if filter.SellerID != nil {
+		where.WriteString(fmt.Sprintf(" AND l.seller_id = $%d", add(*filter.SellerID)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+
+		where.WriteString(fmt.Sprintf(" AND l.status = ANY($%d)", add(statuses)))
+	}
+
+	if filter.Text != "" {
+		n := add(filter.Text)
+		tsQuery := fmt.Sprintf("(websearch_to_tsquery('russian', $%d) || websearch_to_tsquery('english', $%d))", n, n)
+
+		where.WriteString(" AND l.search_vector @@ " + tsQuery)
+		rank = "ts_rank(l.search_vector, " + tsQuery + ")"
+	}
+
+	if filter.Category != nil {
+		n := add(*filter.Category)
+		where.WriteString(fmt.Sprintf(" AND (l.category = $%d OR l.subcategory = $%d)", n, n))
+	}
+
+	if filter.Brand != nil {
+		where.WriteString(fmt.Sprintf(" AND LOWER(l.brand) = LOWER($%d)", add(*filter.Brand)))
+	}
+
+	if filter.Size != nil {
+		where.WriteString(fmt.Sprintf(" AND l.size = $%d", add(*filter.Size)))
+	}
+
+	if filter.Condition != nil {
+		where.WriteString(fmt.Sprintf(" AND l.condition = $%d", add(*filter.Condition)))
+	}
+
+	if filter.MinPrice != nil {
+		where.WriteString(fmt.Sprintf(" AND l.price >= $%d", add(*filter.MinPrice)))
+	}
+
+	if filter.MaxPrice != nil {
+		where.WriteString(fmt.Sprintf(" AND l.price <= $%d", add(*filter.MaxPrice)))
+	}
+
+	sb.WriteString(`
+SELECT ` + listingColumns + `
+FROM listings l
+WHERE ` + where.String())
+
+	sb.WriteString(fmt.Sprintf(" ORDER BY %s DESC, COALESCE(l.published_at, l.created_at) DESC, l.id DESC", rank))
+	sb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", add(filter.Limit), add(filter.Offset)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listings: %w", err)
+	}
+
+	defer rows.Close()
+
+	listings := make([]entity.Listing, 0, filter.Limit)
+
+	for rows.Next() {
+		listing, err := scanListing(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		listings = append(listings, listing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate listings: %w", err)
+	}
+
+	return listings, nil
+}
+
+// UpdateListing changes a listing that is still a draft or on sale.
+func (r *Repo) UpdateListing(
+	ctx context.Context,
+	sellerID entity.UserID,
+	listingID entity.ListingID,
+	update entity.ListingUpdate,
+) (entity.Listing, error) {
+	var listing entity.Listing
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		current, err := r.lockListing(ctx, sellerID, listingID)
+		if err != nil {
+			return err
+		}
+
+		if !current.Status.Editable() {
+			return fmt.Errorf("%w: %s listings cannot be changed", entity.ErrInvalidListingTransition, current.Status)
+		}
+
+		query := `
+UPDATE listings
+SET title       = COALESCE($2, title),
+	description = CASE WHEN $3::varchar IS NULL THEN description ELSE NULLIF($3, '') END,
+	price       = COALESCE($4, price),
+	condition   = COALESCE($5, condition),
+	updated_at  = NOW()
+WHERE id = $1`
+
+		if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, listingID, update.Title, update.Description,
+			update.Price, update.Condition); err != nil {
+			return fmt.Errorf("failed to update listing: %w", err)
+		}
+
+		if update.PhotoIDs != nil {
+			if err := r.setPhotos(ctx, sellerID, listingID, *update.PhotoIDs); err != nil {
+				return err
+			}
+		}
+
+		listing, err = r.GetListing(ctx, listingID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to update listing %s: %w", listingID, err)
+	}
+
+	return listing, nil
+}
+
+// TransitionListing moves the seller's listing to the status. Publishing
+// stamps the listing; selling it archives the wardrobe item and declines the
+// offers still pending; withdrawing it cancels its open offers. Listings are
+// only reserved by accepting an offer.
+func (r *Repo) TransitionListing( //nolint:funlen
+	ctx context.Context,
+	sellerID entity.UserID,
+	listingID entity.ListingID,
+	status entity.ListingStatus,
+) (entity.Listing, error) {
+	var listing entity.Listing
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		current, err := r.lockListing(ctx, sellerID, listingID)
+		if err != nil {
+			return err
+		}
+
+		if !current.Status.CanTransition(status) {
+			return fmt.Errorf("%w: %s to %s", entity.ErrInvalidListingTransition, current.Status, status)
+		}
+
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+UPDATE listings
+SET status       = $2,
+	published_at = CASE WHEN $2 = 'active' THEN NOW() ELSE published_at END,
+	sold_at      = CASE WHEN $2 = 'sold' THEN NOW() ELSE sold_at END,
+	buyer_id     = CASE WHEN $2 = 'withdrawn' THEN NULL ELSE buyer_id END,
+	updated_at   = NOW()
+WHERE id = $1`
+
+		if _, err := tx.Exec(ctx, query, listingID, status); err != nil {
+			return fmt.Errorf("failed to update listing status: %w", err)
+		}
+
+		switch status {
+		case entity.ListingStatusSold:
+			query = `
+UPDATE wardrobe_items
+SET archived_at = NOW(),
+	updated_at  = NOW()
+WHERE TRUE
+	AND id = $1
+	AND archived_at IS NULL`
+
+			if _, err := tx.Exec(ctx, query, current.ItemID); err != nil {
+				return fmt.Errorf("failed to archive wardrobe item: %w", err)
+			}
+
+			if err := r.closeOffers(ctx, listingID, entity.ListingOfferStatusDeclined,
+				entity.ListingOfferStatusPending); err != nil {
+				return err
+			}
+		case entity.ListingStatusWithdrawn:
+			if err := r.closeOffers(ctx, listingID, entity.ListingOfferStatusCancelled,
+				entity.ListingOfferStatusPending, entity.ListingOfferStatusAccepted); err != nil {
+				return err
+			}
+		default:
+		}
+
+		listing, err = r.GetListing(ctx, listingID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to move listing %s to %s: %w", listingID, status, err)
+	}
+
+	return listing, nil
+}
+
+// GetListingPhotoKey returns the storage key of the listing's photo at the
+// position, counting from 1.
+func (r *Repo) GetListingPhotoKey(ctx context.Context, listingID entity.ListingID, position int) (string, error) {
+	query := `
+SELECT f.bucket_key
+FROM listing_photos p
+	JOIN files f ON f.id = p.file_id AND f.status = 'ready'
+WHERE TRUE
+	AND p.listing_id = $1
+	AND p.position = $2`
+
+	var key string
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, listingID, position).Scan(&key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", entity.ErrFileNotFound
+		}
+
+		return "", fmt.Errorf("failed to get photo %d of listing %s: %w", position, listingID, err)
+	}
+
+	return key, nil
+}
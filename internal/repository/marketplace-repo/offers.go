@@ -0,0 +1,262 @@
+package marketplacerepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const offerColumns = `o.id, o.listing_id, o.buyer_id, o.amount, o.message, o.status, o.created_at, o.updated_at`
+
+func scanOffer(row pgx.Row) (entity.ListingOffer, error) {
+	var offer entity.ListingOffer
+
+	err := row.Scan(
+		&offer.ID,
+		&offer.ListingID,
+		&offer.BuyerID,
+		&offer.Amount,
+		&offer.Message,
+		&offer.Status,
+		&offer.CreatedAt,
+		&offer.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ListingOffer{}, entity.ErrListingOfferNotFound
+		}
+
+		return entity.ListingOffer{}, fmt.Errorf("failed to scan offer: %w", err)
+	}
+
+	return offer, nil
+}
+
+// CreateOffer makes an offer on a listing on sale. Sellers cannot make offers
+// on their own listings.
+func (r *Repo) CreateOffer(ctx context.Context, offer entity.ListingOffer) (entity.ListingOffer, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT seller_id, status
+FROM listings
+WHERE id = $1
+FOR SHARE`
+
+		var (
+			sellerID entity.UserID
+			status   entity.ListingStatus
+		)
+
+		if err := tx.QueryRow(ctx, query, offer.ListingID).Scan(&sellerID, &status); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrListingNotFound
+			}
+
+			return fmt.Errorf("failed to get listing %s: %w", offer.ListingID, err)
+		}
+
+		switch {
+		case status == entity.ListingStatusDraft:
+			return entity.ErrListingNotFound
+		case status != entity.ListingStatusActive:
+			return fmt.Errorf("%w: listing is %s", entity.ErrInvalidListingTransition, status)
+		case sellerID == offer.BuyerID:
+			return fmt.Errorf("%w: sellers cannot make offers on their own listings", entity.ErrInvalidListingOffer)
+		}
+
+		query = `
+INSERT INTO listing_offers AS o (id, listing_id, buyer_id, amount, message, status)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING ` + offerColumns
+
+		var err error
+
+		offer, err = scanOffer(tx.QueryRow(ctx, query, offer.ID, offer.ListingID, offer.BuyerID, offer.Amount,
+			offer.Message, offer.Status))
+		if err != nil {
+			var pgErr *pgconn.PgError
+
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return entity.ErrDuplicateListingOffer
+			}
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.ListingOffer{}, fmt.Errorf("failed to create offer: %w", err)
+	}
+
+	return offer, nil
+}
+
+// ListOffers lists offers, newest first: the offers on a listing, those of a
+// buyer, or both.
+func (r *Repo) ListOffers(
+	ctx context.Context,
+	listingID *entity.ListingID,
+	buyerID *entity.UserID,
+	filter entity.ListingOfferFilter,
+) ([]entity.ListingOffer, error) {
+	var sb strings.Builder
+
+	params := []any{}
+
+	sb.WriteString(`
+SELECT ` + offerColumns + `
+FROM listing_offers o
+WHERE TRUE`)
+
+	if listingID != nil {
+		params = append(params, *listingID)
+		sb.WriteString(fmt.Sprintf(" AND o.listing_id = $%d", len(params)))
+	}
+
+	if buyerID != nil {
+		params = append(params, *buyerID)
+		sb.WriteString(fmt.Sprintf(" AND o.buyer_id = $%d", len(params)))
+	}
+
+	params = append(params, filter.Limit, filter.Offset)
+	sb.WriteString(fmt.Sprintf(" ORDER BY o.created_at DESC, o.id DESC LIMIT $%d OFFSET $%d", len(params)-1, len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offers: %w", err)
+	}
+
+	defer rows.Close()
+
+	offers := make([]entity.ListingOffer, 0, filter.Limit)
+
+	for rows.Next() {
+		offer, err := scanOffer(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		offers = append(offers, offer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate offers: %w", err)
+	}
+
+	return offers, nil
+}
+
+// RespondToOffer accepts or declines a pending offer on the seller's listing.
+// Accepting an offer reserves the listing for the offer's buyer, which is
+// only possible while the listing is on sale.
+func (r *Repo) RespondToOffer( //nolint:funlen
+	ctx context.Context,
+	sellerID entity.UserID,
+	listingID entity.ListingID,
+	offerID entity.ListingOfferID,
+	status entity.ListingOfferStatus,
+) (entity.ListingOffer, error) {
+	var offer entity.ListingOffer
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		listing, err := r.lockListing(ctx, sellerID, listingID)
+		if err != nil {
+			return err
+		}
+
+		if status == entity.ListingOfferStatusAccepted && !listing.Status.CanTransition(entity.ListingStatusReserved) {
+			return fmt.Errorf("%w: %s to %s", entity.ErrInvalidListingTransition, listing.Status, entity.ListingStatusReserved)
+		}
+
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+UPDATE listing_offers o
+SET status     = $3,
+	updated_at = NOW()
+WHERE TRUE
+	AND o.id = $1
+	AND o.listing_id = $2
+	AND o.status = 'pending'
+RETURNING ` + offerColumns
+
+		offer, err = scanOffer(tx.QueryRow(ctx, query, offerID, listingID, status))
+		if err != nil {
+			if !errors.Is(err, entity.ErrListingOfferNotFound) {
+				return err
+			}
+
+			var exists bool
+
+			query = `SELECT EXISTS (SELECT 1 FROM listing_offers WHERE id = $1 AND listing_id = $2)`
+
+			if err := tx.QueryRow(ctx, query, offerID, listingID).Scan(&exists); err != nil {
+				return fmt.Errorf("failed to check offer: %w", err)
+			}
+
+			if exists {
+				return fmt.Errorf("%w: offer is no longer pending", entity.ErrInvalidListingTransition)
+			}
+
+			return entity.ErrListingOfferNotFound
+		}
+
+		if status != entity.ListingOfferStatusAccepted {
+			return nil
+		}
+
+		query = `
+UPDATE listings
+SET status     = 'reserved',
+	buyer_id   = $2,
+	updated_at = NOW()
+WHERE id = $1`
+
+		if _, err := tx.Exec(ctx, query, listingID, offer.BuyerID); err != nil {
+			return fmt.Errorf("failed to reserve listing: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.ListingOffer{}, fmt.Errorf("failed to respond to offer %s: %w", offerID, err)
+	}
+
+	return offer, nil
+}
+
+// closeOffers moves the listing's offers in one of the statuses to status.
+func (r *Repo) closeOffers(
+	ctx context.Context,
+	listingID entity.ListingID,
+	status entity.ListingOfferStatus,
+	from ...entity.ListingOfferStatus,
+) error {
+	statuses := make([]string, 0, len(from))
+	for _, status := range from {
+		statuses = append(statuses, string(status))
+	}
+
+	query := `
+UPDATE listing_offers
+SET status     = $2,
+	updated_at = NOW()
+WHERE TRUE
+	AND listing_id = $1
+	AND status = ANY($3)`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, listingID, status, statuses); err != nil {
+		return fmt.Errorf("failed to close offers: %w", err)
+	}
+
+	return nil
+}
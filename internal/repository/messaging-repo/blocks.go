@@ -0,0 +1,135 @@
+package messagingrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const blockColumns = `b.blocker_id, b.blocked_id, b.created_at`
+
+func scanBlock(row pgx.Row) (entity.Block, error) {
+	var block entity.Block
+
+	if err := row.Scan(&block.BlockerID, &block.BlockedID, &block.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Block{}, entity.ErrBlockNotFound
+		}
+
+		return entity.Block{}, fmt.Errorf("failed to scan block: %w", err)
+	}
+
+	return block, nil
+}
+
+// checkBlocked fails when either user blocked the other.
+func (r *Repo) checkBlocked(ctx context.Context, userID, otherID entity.UserID) error {
+	query := `
+SELECT EXISTS (
+	SELECT 1
+	FROM user_blocks
+	WHERE (blocker_id, blocked_id) IN (($1, $2), ($2, $1))
+)`
+
+	var blocked bool
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, userID, otherID).Scan(&blocked); err != nil {
+		return fmt.Errorf("failed to check blocks: %w", err)
+	}
+
+	if blocked {
+		return entity.ErrUserBlocked
+	}
+
+	return nil
+}
+
+// BlockUser blocks a user. Blocking a user again keeps the original block.
+func (r *Repo) BlockUser(ctx context.Context, block entity.Block) (entity.Block, error) {
+	query := `
+WITH blocked AS (
+	SELECT id
+	FROM users
+	WHERE TRUE
+		AND id = $2
+		AND deleted_at IS NULL
+), inserted AS (
+	INSERT INTO user_blocks AS b (blocker_id, blocked_id)
+	SELECT $1, id
+	FROM blocked
+	ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	RETURNING ` + blockColumns + `
+)
+SELECT ` + blockColumns + ` FROM inserted b
+UNION ALL
+SELECT ` + blockColumns + `
+FROM user_blocks b
+	JOIN blocked u ON u.id = b.blocked_id
+WHERE b.blocker_id = $1`
+
+	block, err := scanBlock(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, block.BlockerID, block.BlockedID))
+	if err != nil {
+		if errors.Is(err, entity.ErrBlockNotFound) {
+			err = entity.ErrUserNotFound
+		}
+
+		return entity.Block{}, fmt.Errorf("failed to block user %s: %w", block.BlockedID, err)
+	}
+
+	return block, nil
+}
+
+func (r *Repo) UnblockUser(ctx context.Context, blockerID, blockedID entity.UserID) error {
+	query := `
+DELETE FROM user_blocks
+WHERE TRUE
+	AND blocker_id = $1
+	AND blocked_id = $2`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user %s: %w", blockedID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to unblock user %s: %w", blockedID, entity.ErrBlockNotFound)
+	}
+
+	return nil
+}
+
+// ListBlocks lists the users the blocker blocked, most recent first.
+func (r *Repo) ListBlocks(ctx context.Context, blockerID entity.UserID) ([]entity.Block, error) {
+	query := `
+SELECT ` + blockColumns + `
+FROM user_blocks b
+WHERE b.blocker_id = $1
+ORDER BY b.created_at DESC, b.blocked_id`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocks: %w", err)
+	}
+
+	defer rows.Close()
+
+	blocks := []entity.Block{}
+
+	for rows.Next() {
+		block, err := scanBlock(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate blocks: %w", err)
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,258 @@
+package messagingrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const messageColumns = `
+	m.id, m.conversation_id, m.sender_id, m.body,
+	ARRAY(
+		SELECT p.file_id
+		FROM message_photos p
+			JOIN files f ON f.id = p.file_id AND f.status = 'ready'
+		WHERE p.message_id = m.id
+		ORDER BY p.position
+	),
+	m.read_at, m.created_at`
+
+func scanMessage(row pgx.Row) (entity.Message, error) {
+	var (
+		message  entity.Message
+		photoIDs []uuid.UUID
+	)
+
+	err := row.Scan(
+		&message.ID,
+		&message.ConversationID,
+		&message.SenderID,
+		&message.Body,
+		&photoIDs,
+		&message.ReadAt,
+		&message.CreatedAt,
+	)
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("failed to scan message: %w", err)
+	}
+
+	message.PhotoIDs = make([]entity.FileID, 0, len(photoIDs))
+	for _, photoID := range photoIDs {
+		message.PhotoIDs = append(message.PhotoIDs, entity.FileID(photoID))
+	}
+
+	return message, nil
+}
+
+// SendMessage adds a message to a conversation the sender takes part in,
+// unless either participant blocked the other.
+func (r *Repo) SendMessage(ctx context.Context, message entity.Message) (entity.Message, error) { //nolint:funlen
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT seller_id, buyer_id
+FROM conversations
+WHERE TRUE
+	AND id = $1
+	AND $2 IN (seller_id, buyer_id)
+FOR UPDATE`
+
+		var sellerID, buyerID entity.UserID
+
+		if err := tx.QueryRow(ctx, query, message.ConversationID, message.SenderID).Scan(&sellerID, &buyerID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrConversationNotFound
+			}
+
+			return fmt.Errorf("failed to lock conversation %s: %w", message.ConversationID, err)
+		}
+
+		if err := r.checkBlocked(ctx, sellerID, buyerID); err != nil {
+			return err
+		}
+
+		query = `
+INSERT INTO messages (id, conversation_id, sender_id, body)
+VALUES ($1, $2, $3, $4)
+RETURNING created_at`
+
+		err := tx.QueryRow(ctx, query, message.ID, message.ConversationID, message.SenderID, message.Body).
+			Scan(&message.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert message: %w", err)
+		}
+
+		if len(message.PhotoIDs) > 0 {
+			if err := r.addPhotos(ctx, message); err != nil {
+				return err
+			}
+		}
+
+		query = `
+UPDATE conversations
+SET last_message_at = $2,
+	updated_at      = NOW()
+WHERE id = $1`
+
+		if _, err := tx.Exec(ctx, query, message.ConversationID, message.CreatedAt); err != nil {
+			return fmt.Errorf("failed to update conversation: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	if message.PhotoIDs == nil {
+		message.PhotoIDs = []entity.FileID{}
+	}
+
+	return message, nil
+}
+
+// addPhotos attaches photos the sender uploaded to the message.
+func (r *Repo) addPhotos(ctx context.Context, message entity.Message) error {
+	tx := r.db.GetTXFromContext(ctx)
+
+	ids := make([]uuid.UUID, 0, len(message.PhotoIDs))
+	for _, photoID := range message.PhotoIDs {
+		ids = append(ids, uuid.UUID(photoID))
+	}
+
+	var owned int
+
+	query := `
+SELECT COUNT(*)
+FROM files
+WHERE TRUE
+	AND id = ANY($1)
+	AND user_id = $2
+	AND status = $3`
+
+	if err := tx.QueryRow(ctx, query, ids, message.SenderID, entity.FileStatusReady).Scan(&owned); err != nil {
+		return fmt.Errorf("failed to check photos: %w", err)
+	}
+
+	if owned != len(ids) {
+		return fmt.Errorf("%w: unknown photo", entity.ErrInvalidMessage)
+	}
+
+	query = `
+INSERT INTO message_photos (message_id, file_id, position)
+SELECT $1, photo.id, photo.position
+FROM UNNEST($2::uuid[]) WITH ORDINALITY AS photo(id, position)`
+
+	if _, err := tx.Exec(ctx, query, message.ID, ids); err != nil {
+		return fmt.Errorf("failed to add photos: %w", err)
+	}
+
+	return nil
+}
+
+// ListMessages pages through a conversation the user takes part in, newest
+// messages first.
+func (r *Repo) ListMessages(
+	ctx context.Context,
+	userID entity.UserID,
+	conversationID entity.ConversationID,
+	page entity.Page,
+) ([]entity.Message, *string, error) {
+	before, err := entity.ParseCursor(page.Cursor)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	if _, err := r.GetConversation(ctx, userID, conversationID); err != nil {
+		return nil, nil, err
+	}
+
+	var sb strings.Builder
+
+	params := []any{conversationID}
+
+	sb.WriteString(`
+SELECT ` + messageColumns + `
+FROM messages m
+WHERE m.conversation_id = $1`)
+
+	if before != nil {
+		params = append(params, before.CreatedAt, before.ID)
+		sb.WriteString(fmt.Sprintf(" AND (m.created_at, m.id) < ($%d, $%d)", len(params)-1, len(params)))
+	}
+
+	params = append(params, page.Limit+1)
+	sb.WriteString(fmt.Sprintf(" ORDER BY m.created_at DESC, m.id DESC LIMIT $%d", len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	defer rows.Close()
+
+	messages := make([]entity.Message, 0, page.Limit)
+
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate messages: %w", err)
+	}
+
+	if len(messages) <= page.Limit {
+		return messages, nil, nil
+	}
+
+	messages = messages[:page.Limit]
+	last := messages[len(messages)-1]
+	next := entity.Cursor{CreatedAt: last.CreatedAt, ID: uuid.UUID(last.ID)}.String()
+
+	return messages, &next, nil
+}
+
+// GetMessagePhotoKey returns the bucket key of a message's photo, counting
+// from 1, in a conversation the user takes part in.
+func (r *Repo) GetMessagePhotoKey(
+	ctx context.Context,
+	userID entity.UserID,
+	conversationID entity.ConversationID,
+	messageID entity.MessageID,
+	position int,
+) (string, error) {
+	query := `
+SELECT f.bucket_key
+FROM message_photos p
+	JOIN messages m ON m.id = p.message_id
+	JOIN conversations c ON c.id = m.conversation_id
+	JOIN files f ON f.id = p.file_id AND f.status = 'ready'
+WHERE TRUE
+	AND p.message_id = $1
+	AND p.position = $2
+	AND m.conversation_id = $3
+	AND $4 IN (c.seller_id, c.buyer_id)`
+
+	var key string
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, messageID, position, conversationID, userID).Scan(&key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", entity.ErrFileNotFound
+		}
+
+		return "", fmt.Errorf("failed to get message photo: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,248 @@
+package messagingrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+// conversationColumns counts unread messages for the viewer, which queries
+// pass as $1.
+const conversationColumns = `
+	c.id, c.listing_id, c.seller_id, c.buyer_id, c.last_message_at,
+	(
+		SELECT COUNT(*)
+		FROM messages m
+		WHERE TRUE
+			AND m.conversation_id = c.id
+			AND m.sender_id <> $1
+			AND m.read_at IS NULL
+	),
+	c.created_at, c.updated_at`
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanConversation(row pgx.Row) (entity.Conversation, error) {
+	var conversation entity.Conversation
+
+	err := row.Scan(
+		&conversation.ID,
+		&conversation.ListingID,
+		&conversation.SellerID,
+		&conversation.BuyerID,
+		&conversation.LastMessageAt,
+		&conversation.UnreadCount,
+		&conversation.CreatedAt,
+		&conversation.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Conversation{}, entity.ErrConversationNotFound
+		}
+
+		return entity.Conversation{}, fmt.Errorf("failed to scan conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// StartConversation opens the buyer's conversation with the seller of a
+// published listing, or returns the one they already have.
+func (r *Repo) StartConversation(ctx context.Context, conversation entity.Conversation) (entity.Conversation, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT seller_id, status
+FROM listings
+WHERE id = $1
+FOR SHARE`
+
+		var status entity.ListingStatus
+
+		if err := tx.QueryRow(ctx, query, conversation.ListingID).Scan(&conversation.SellerID, &status); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrListingNotFound
+			}
+
+			return fmt.Errorf("failed to get listing %s: %w", conversation.ListingID, err)
+		}
+
+		switch {
+		case status == entity.ListingStatusDraft:
+			return entity.ErrListingNotFound
+		case conversation.SellerID == conversation.BuyerID:
+			return fmt.Errorf("%w: sellers cannot message themselves", entity.ErrInvalidConversation)
+		}
+
+		if err := r.checkBlocked(ctx, conversation.SellerID, conversation.BuyerID); err != nil {
+			return err
+		}
+
+		query = `
+INSERT INTO conversations (id, listing_id, seller_id, buyer_id)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (listing_id, buyer_id) DO NOTHING`
+
+		_, err := tx.Exec(ctx, query, conversation.ID, conversation.ListingID, conversation.SellerID, conversation.BuyerID)
+		if err != nil {
+			return fmt.Errorf("failed to insert conversation: %w", err)
+		}
+
+		query = `
+SELECT ` + conversationColumns + `
+FROM conversations c
+WHERE TRUE
+	AND c.listing_id = $2
+	AND c.buyer_id = $1`
+
+		conversation, err = scanConversation(tx.QueryRow(ctx, query, conversation.BuyerID, conversation.ListingID))
+
+		return err
+	})
+	if err != nil {
+		return entity.Conversation{}, fmt.Errorf("failed to start conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// GetConversation returns a conversation the user takes part in.
+func (r *Repo) GetConversation(
+	ctx context.Context,
+	userID entity.UserID,
+	conversationID entity.ConversationID,
+) (entity.Conversation, error) {
+	query := `
+SELECT ` + conversationColumns + `
+FROM conversations c
+WHERE TRUE
+	AND c.id = $2
+	AND $1 IN (c.seller_id, c.buyer_id)`
+
+	conversation, err := scanConversation(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, userID, conversationID))
+	if err != nil {
+		return entity.Conversation{}, fmt.Errorf("failed to get conversation %s: %w", conversationID, err)
+	}
+
+	return conversation, nil
+}
+
+// ListConversations lists the user's conversations, the most recently
+// active first.
+func (r *Repo) ListConversations(
+	ctx context.Context,
+	userID entity.UserID,
+	filter entity.ConversationFilter,
+) ([]entity.Conversation, error) {
+	query := `
+SELECT ` + conversationColumns + `
+FROM conversations c
+WHERE $1 IN (c.seller_id, c.buyer_id)
+ORDER BY COALESCE(c.last_message_at, c.created_at) DESC, c.id DESC
+LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, userID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+
+	defer rows.Close()
+
+	conversations := make([]entity.Conversation, 0, filter.Limit)
+
+	for rows.Next() {
+		conversation, err := scanConversation(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		conversations = append(conversations, conversation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate conversations: %w", err)
+	}
+
+	return conversations, nil
+}
+
+// MarkConversationRead marks every message the user received in the
+// conversation as read.
+func (r *Repo) MarkConversationRead(
+	ctx context.Context,
+	userID entity.UserID,
+	conversationID entity.ConversationID,
+) (entity.Conversation, error) {
+	var conversation entity.Conversation
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+UPDATE messages m
+SET read_at = NOW()
+FROM conversations c
+WHERE TRUE
+	AND c.id = m.conversation_id
+	AND m.conversation_id = $2
+	AND $1 IN (c.seller_id, c.buyer_id)
+	AND m.sender_id <> $1
+	AND m.read_at IS NULL`
+
+		if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, userID, conversationID); err != nil {
+			return fmt.Errorf("failed to mark messages read: %w", err)
+		}
+
+		var err error
+
+		conversation, err = r.GetConversation(ctx, userID, conversationID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Conversation{}, fmt.Errorf("failed to read conversation %s: %w", conversationID, err)
+	}
+
+	return conversation, nil
+}
+
+// GetUnreadCount counts the messages the user has not read and the
+// conversations they are in.
+func (r *Repo) GetUnreadCount(ctx context.Context, userID entity.UserID) (entity.UnreadCount, error) {
+	query := `
+SELECT COUNT(DISTINCT m.conversation_id), COUNT(*)
+FROM messages m
+	JOIN conversations c ON c.id = m.conversation_id
+WHERE TRUE
+	AND $1 IN (c.seller_id, c.buyer_id)
+	AND m.sender_id <> $1
+	AND m.read_at IS NULL`
+
+	var count entity.UnreadCount
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, userID).Scan(&count.Conversations, &count.Messages); err != nil {
+		return entity.UnreadCount{}, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,510 @@
+package moderationrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const reportColumns = `
+	id, reporter_id, target_type, target_id, reason, details, status, created_at, resolved_at, resolved_by`
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanReport(row pgx.Row) (entity.Report, error) {
+	var report entity.Report
+
+	err := row.Scan(
+		&report.ID,
+		&report.ReporterID,
+		&report.TargetType,
+		&report.TargetID,
+		&report.Reason,
+		&report.Details,
+		&report.Status,
+		&report.CreatedAt,
+		&report.ResolvedAt,
+		&report.ResolvedBy,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Report{}, entity.ErrReportNotFound
+		}
+
+		return entity.Report{}, fmt.Errorf("failed to scan report: %w", err)
+	}
+
+	return report, nil
+}
+
+func (r *Repo) getReport(ctx context.Context, reportID entity.ReportID) (entity.Report, error) {
+	query := `SELECT ` + reportColumns + ` FROM reports WHERE id = $1`
+
+	return scanReport(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, reportID))
+}
+
+// checkTarget fails unless the reported post, comment or user is live.
+func (r *Repo) checkTarget(ctx context.Context, targetType entity.ReportTargetType, targetID uuid.UUID) error {
+	var query string
+
+	switch targetType {
+	case entity.ReportTargetPost:
+		query = `
+SELECT EXISTS (
+	SELECT 1
+	FROM posts p
+		JOIN users u ON u.id = p.user_id AND u.deleted_at IS NULL
+	WHERE p.id = $1
+		AND p.deleted_at IS NULL
+		AND p.hidden_at IS NULL
+)`
+	case entity.ReportTargetComment:
+		query = `
+SELECT EXISTS (
+	SELECT 1
+	FROM comments c
+		JOIN posts p ON p.id = c.post_id AND p.deleted_at IS NULL AND p.hidden_at IS NULL
+	WHERE c.id = $1
+		AND c.deleted_at IS NULL
+		AND c.hidden_at IS NULL
+)`
+	case entity.ReportTargetUser:
+		query = `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)`
+	default:
+		return fmt.Errorf("%w: unknown target type %q", entity.ErrInvalidReport, targetType)
+	}
+
+	var exists bool
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, targetID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check reported %s: %w", targetType, err)
+	}
+
+	if !exists {
+		return fmt.Errorf("%w: unknown %s", entity.ErrInvalidReport, targetType)
+	}
+
+	return nil
+}
+
+// CreateReport fails with ErrDuplicateReport while the reporter's previous
+// report on the same target is open.
+func (r *Repo) CreateReport(ctx context.Context, report entity.Report) (entity.Report, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.checkTarget(ctx, report.TargetType, report.TargetID); err != nil {
+			return err
+		}
+
+		query := `
+INSERT INTO reports (id, reporter_id, target_type, target_id, reason, details)
+VALUES ($1, $2, $3, $4, $5, $6)`
+
+		if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, report.ID, report.ReporterID,
+			report.TargetType, report.TargetID, report.Reason, report.Details); err != nil {
+			var pgErr *pgconn.PgError
+
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return entity.ErrDuplicateReport
+			}
+
+			return fmt.Errorf("failed to insert report: %w", err)
+		}
+
+		var err error
+
+		report, err = r.getReport(ctx, report.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	return report, nil
+}
+
+// ListReports pages through the moderation queue, oldest first.
+func (r *Repo) ListReports(ctx context.Context, filter entity.ReportFilter) ([]entity.Report, *string, error) {
+	after, err := entity.ParseCursor(filter.Page.Cursor)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	var sb strings.Builder
+
+	params := []any{filter.Status}
+
+	sb.WriteString(`SELECT ` + reportColumns + ` FROM reports WHERE status = $1`)
+
+	if filter.TargetType != nil {
+		params = append(params, *filter.TargetType)
+		sb.WriteString(fmt.Sprintf(" AND target_type = $%d", len(params)))
+	}
+
+	if after != nil {
+		params = append(params, after.CreatedAt, after.ID)
+		sb.WriteString(fmt.Sprintf(" AND (created_at, id) > ($%d, $%d)", len(params)-1, len(params)))
+	}
+
+	params = append(params, filter.Page.Limit+1)
+	sb.WriteString(fmt.Sprintf(" ORDER BY created_at, id LIMIT $%d", len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	defer rows.Close()
+
+	reports := make([]entity.Report, 0, filter.Page.Limit)
+
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate reports: %w", err)
+	}
+
+	if len(reports) <= filter.Page.Limit {
+		return reports, nil, nil
+	}
+
+	reports = reports[:filter.Page.Limit]
+	last := reports[len(reports)-1]
+	next := entity.Cursor{CreatedAt: last.CreatedAt, ID: uuid.UUID(last.ID)}.String()
+
+	return reports, &next, nil
+}
+
+// audit records the moderation action in the transaction that performed it.
+func (r *Repo) audit(
+	ctx context.Context,
+	moderatorID entity.UserID,
+	action entity.ModerationAction,
+	targetType string,
+	targetID uuid.UUID,
+	note *string,
+) error {
+	query := `
+INSERT INTO moderation_audit_log (id, moderator_id, action, target_type, target_id, note)
+VALUES ($1, $2, $3, $4, $5, $6)`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query,
+		uuid.New(), moderatorID, action, targetType, targetID, note); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// resolveReports closes the open reports on a target a moderator acted on.
+func (r *Repo) resolveReports(
+	ctx context.Context,
+	moderatorID entity.UserID,
+	targetType entity.ReportTargetType,
+	targetID uuid.UUID,
+) error {
+	query := `
+UPDATE reports
+SET status      = 'resolved',
+	resolved_at = NOW(),
+	resolved_by = $3
+WHERE TRUE
+	AND target_type = $1
+	AND target_id = $2
+	AND status = 'open'`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, targetType, targetID, moderatorID); err != nil {
+		return fmt.Errorf("failed to resolve reports: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) DismissReport(
+	ctx context.Context,
+	moderatorID entity.UserID,
+	reportID entity.ReportID,
+	request entity.ModerationRequest,
+) (entity.Report, error) {
+	var report entity.Report
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+UPDATE reports
+SET status      = 'dismissed',
+	resolved_at = NOW(),
+	resolved_by = $2
+WHERE TRUE
+	AND id = $1
+	AND status = 'open'`
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, reportID, moderatorID)
+		if err != nil {
+			return fmt.Errorf("failed to dismiss report: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrReportNotFound
+		}
+
+		if err := r.audit(ctx, moderatorID, entity.ModerationActionDismissReport,
+			"report", uuid.UUID(reportID), request.Note); err != nil {
+			return err
+		}
+
+		report, err = r.getReport(ctx, reportID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("failed to dismiss report %s: %w", reportID, err)
+	}
+
+	return report, nil
+}
+
+// HidePost hides the post from everyone, its author included.
+func (r *Repo) HidePost(ctx context.Context, moderatorID entity.UserID, postID entity.PostID, request entity.ModerationRequest) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+UPDATE posts
+SET hidden_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND deleted_at IS NULL
+	AND hidden_at IS NULL`
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, postID)
+		if err != nil {
+			return fmt.Errorf("failed to hide post: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrPostNotFound
+		}
+
+		if err := r.resolveReports(ctx, moderatorID, entity.ReportTargetPost, uuid.UUID(postID)); err != nil {
+			return err
+		}
+
+		return r.audit(ctx, moderatorID, entity.ModerationActionHidePost,
+			string(entity.ReportTargetPost), uuid.UUID(postID), request.Note)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hide post %s: %w", postID, err)
+	}
+
+	return nil
+}
+
+// HideComment hides the comment like a deletion does and takes it off the
+// post's comment counter.
+func (r *Repo) HideComment(
+	ctx context.Context,
+	moderatorID entity.UserID,
+	commentID entity.CommentID,
+	request entity.ModerationRequest,
+) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+UPDATE comments
+SET hidden_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND deleted_at IS NULL
+	AND hidden_at IS NULL
+RETURNING post_id`
+
+		var postID entity.PostID
+
+		if err := tx.QueryRow(ctx, query, commentID).Scan(&postID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrCommentNotFound
+			}
+
+			return fmt.Errorf("failed to hide comment: %w", err)
+		}
+
+		query = `
+UPDATE posts
+SET comments_count = comments_count - 1
+WHERE id = $1`
+
+		if _, err := tx.Exec(ctx, query, postID); err != nil {
+			return fmt.Errorf("failed to count comments: %w", err)
+		}
+
+		if err := r.resolveReports(ctx, moderatorID, entity.ReportTargetComment, uuid.UUID(commentID)); err != nil {
+			return err
+		}
+
+		return r.audit(ctx, moderatorID, entity.ModerationActionHideComment,
+			string(entity.ReportTargetComment), uuid.UUID(commentID), request.Note)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hide comment %s: %w", commentID, err)
+	}
+
+	return nil
+}
+
+// SetUserStatus suspends or reinstates a user. Admins cannot be suspended.
+// Suspending resolves the open reports on the user.
+func (r *Repo) SetUserStatus(
+	ctx context.Context,
+	moderatorID, userID entity.UserID,
+	status entity.UserStatus,
+	request entity.ModerationRequest,
+) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT role
+FROM users
+WHERE TRUE
+	AND id = $1
+	AND deleted_at IS NULL
+FOR UPDATE`
+
+		var role entity.Role
+
+		if err := tx.QueryRow(ctx, query, userID).Scan(&role); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrUserNotFound
+			}
+
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		action := entity.ModerationActionUnsuspendUser
+
+		if status == entity.UserStatusSuspended {
+			if role == entity.RoleAdmin {
+				return fmt.Errorf("%w: admins cannot be suspended", entity.ErrForbidden)
+			}
+
+			if err := r.resolveReports(ctx, moderatorID, entity.ReportTargetUser, uuid.UUID(userID)); err != nil {
+				return err
+			}
+
+			action = entity.ModerationActionSuspendUser
+		}
+
+		query = `
+UPDATE users
+SET status     = $2,
+	updated_at = NOW()
+WHERE id = $1`
+
+		if _, err := tx.Exec(ctx, query, userID, status); err != nil {
+			return fmt.Errorf("failed to update user status: %w", err)
+		}
+
+		return r.audit(ctx, moderatorID, action, string(entity.ReportTargetUser), uuid.UUID(userID), request.Note)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set status of user %s: %w", userID, err)
+	}
+
+	return nil
+}
+
+// ListAuditLog pages through moderation actions, newest first.
+func (r *Repo) ListAuditLog(ctx context.Context, page entity.Page) ([]entity.AuditLogEntry, *string, error) {
+	after, err := entity.ParseCursor(page.Cursor)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	var sb strings.Builder
+
+	params := make([]any, 0, 3) //nolint:mnd
+
+	sb.WriteString(`
+SELECT id, moderator_id, action, target_type, target_id, note, created_at
+FROM moderation_audit_log
+WHERE TRUE`)
+
+	if after != nil {
+		params = append(params, after.CreatedAt, after.ID)
+		sb.WriteString(" AND (created_at, id) < ($1, $2)")
+	}
+
+	params = append(params, page.Limit+1)
+	sb.WriteString(fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	defer rows.Close()
+
+	entries := make([]entity.AuditLogEntry, 0, page.Limit)
+
+	for rows.Next() {
+		var entry entity.AuditLogEntry
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ModeratorID,
+			&entry.Action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&entry.Note,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan audit log entry: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate audit log: %w", err)
+	}
+
+	if len(entries) <= page.Limit {
+		return entries, nil, nil
+	}
+
+	entries = entries[:page.Limit]
+	last := entries[len(entries)-1]
+	next := entity.Cursor{CreatedAt: last.CreatedAt, ID: uuid.UUID(last.ID)}.String()
+
+	return entries, &next, nil
+}
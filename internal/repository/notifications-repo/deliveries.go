@@ -0,0 +1,174 @@
+package notificationsrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// ClaimDueDeliveries takes up to limit pending deliveries that may be sent
+// now, oldest first, with the recipient's phone and quiet hours, and leases
+// them until leaseUntil: if they are neither finished nor retried by then,
+// they are due again. Dispatchers running side by side never claim the same
+// delivery.
+func (r *Repo) ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]entity.NotificationDelivery, error) {
+	query := `
+WITH due AS (
+	SELECT notification_id, channel
+	FROM notification_deliveries
+	WHERE TRUE
+		AND status = 'pending'
+		AND deliver_after <= NOW()
+	ORDER BY deliver_after, notification_id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED
+)
+UPDATE notification_deliveries d
+SET attempts      = d.attempts + 1,
+	deliver_after = $2
+FROM due
+	JOIN notifications n ON n.id = due.notification_id
+	JOIN users u ON u.id = n.user_id
+	LEFT JOIN quiet_hours q ON q.user_id = n.user_id
+WHERE TRUE
+	AND d.notification_id = due.notification_id
+	AND d.channel = due.channel
+RETURNING ` + notificationColumns + `, d.channel, d.attempts, u.phone,
+	to_char(q.start_time, 'HH24:MI'), to_char(q.end_time, 'HH24:MI'), q.time_zone`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, limit, leaseUntil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notification deliveries: %w", err)
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]entity.NotificationDelivery, 0, limit)
+
+	for rows.Next() {
+		var (
+			delivery             entity.NotificationDelivery
+			start, end, timeZone *string
+		)
+
+		err := rows.Scan(
+			&delivery.Notification.ID,
+			&delivery.Notification.UserID,
+			&delivery.Notification.Category,
+			&delivery.Notification.Title,
+			&delivery.Notification.Body,
+			&delivery.Notification.ReadAt,
+			&delivery.Notification.CreatedAt,
+			&delivery.Channel,
+			&delivery.Attempts,
+			&delivery.Phone,
+			&start,
+			&end,
+			&timeZone,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification delivery: %w", err)
+		}
+
+		if start != nil && end != nil && timeZone != nil {
+			delivery.QuietHours = &entity.QuietHours{Start: *start, End: *end, TimeZone: *timeZone}
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repo) MarkDeliverySent(ctx context.Context, notificationID entity.NotificationID, channel entity.NotificationChannel) error {
+	query := `
+UPDATE notification_deliveries
+SET status     = 'sent',
+	last_error = NULL,
+	sent_at    = NOW()
+WHERE TRUE
+	AND notification_id = $1
+	AND channel = $2`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, notificationID, channel); err != nil {
+		return fmt.Errorf("failed to mark notification %s delivery sent: %w", notificationID, err)
+	}
+
+	return nil
+}
+
+// RetryDelivery records a failed attempt and makes the delivery due again at
+// nextAttemptAt.
+func (r *Repo) RetryDelivery(
+	ctx context.Context,
+	notificationID entity.NotificationID,
+	channel entity.NotificationChannel,
+	lastError string,
+	nextAttemptAt time.Time,
+) error {
+	query := `
+UPDATE notification_deliveries
+SET last_error    = $3,
+	deliver_after = $4
+WHERE TRUE
+	AND notification_id = $1
+	AND channel = $2`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, notificationID, channel, lastError, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to retry notification %s delivery: %w", notificationID, err)
+	}
+
+	return nil
+}
+
+// MarkDeliveryFailed records the last failed attempt and stops retrying the
+// delivery.
+func (r *Repo) MarkDeliveryFailed(
+	ctx context.Context,
+	notificationID entity.NotificationID,
+	channel entity.NotificationChannel,
+	lastError string,
+) error {
+	query := `
+UPDATE notification_deliveries
+SET status     = 'failed',
+	last_error = $3
+WHERE TRUE
+	AND notification_id = $1
+	AND channel = $2`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, notificationID, channel, lastError); err != nil {
+		return fmt.Errorf("failed to mark notification %s delivery failed: %w", notificationID, err)
+	}
+
+	return nil
+}
+
+// PostponeDelivery keeps a delivery pending until the given time. Claiming it
+// did not count as an attempt.
+func (r *Repo) PostponeDelivery(
+	ctx context.Context,
+	notificationID entity.NotificationID,
+	channel entity.NotificationChannel,
+	until time.Time,
+) error {
+	query := `
+UPDATE notification_deliveries
+SET deliver_after = $3,
+	attempts      = attempts - 1
+WHERE TRUE
+	AND notification_id = $1
+	AND channel = $2`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, notificationID, channel, until); err != nil {
+		return fmt.Errorf("failed to postpone notification %s delivery: %w", notificationID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package notificationsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const notificationColumns = `n.id, n.user_id, n.category, n.title, n.body, n.read_at, n.created_at`
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanNotification(row pgx.Row) (entity.Notification, error) {
+	var notification entity.Notification
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Category,
+		&notification.Title,
+		&notification.Body,
+		&notification.ReadAt,
+		&notification.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Notification{}, entity.ErrNotificationNotFound
+		}
+
+		return entity.Notification{}, fmt.Errorf("failed to scan notification: %w", err)
+	}
+
+	return notification, nil
+}
+
+// CreateNotification stores a notification and queues its delivery through
+// every channel but the app. It is listed in the app only when channels
+// include the app.
+func (r *Repo) CreateNotification(
+	ctx context.Context,
+	notification entity.Notification,
+	channels []entity.NotificationChannel,
+) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		var (
+			inApp    bool
+			external []string
+		)
+
+		for _, channel := range channels {
+			if channel == entity.NotificationChannelInApp {
+				inApp = true
+			} else {
+				external = append(external, string(channel))
+			}
+		}
+
+		query := `
+INSERT INTO notifications (id, user_id, category, title, body, in_app)
+VALUES ($1, $2, $3, $4, $5, $6)`
+
+		_, err := tx.Exec(ctx, query, notification.ID, notification.UserID, notification.Category,
+			notification.Title, notification.Body, inApp)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+				return entity.ErrUserNotFound
+			}
+
+			return fmt.Errorf("failed to insert notification: %w", err)
+		}
+
+		if len(external) == 0 {
+			return nil
+		}
+
+		query = `
+INSERT INTO notification_deliveries (notification_id, channel)
+SELECT $1, channel
+FROM UNNEST($2::varchar[]) AS channel`
+
+		if _, err := tx.Exec(ctx, query, notification.ID, external); err != nil {
+			return fmt.Errorf("failed to insert notification deliveries: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// ListNotifications lists the user's in-app notifications, newest first.
+func (r *Repo) ListNotifications(
+	ctx context.Context,
+	userID entity.UserID,
+	filter entity.NotificationFilter,
+) ([]entity.Notification, error) {
+	query := `
+SELECT ` + notificationColumns + `
+FROM notifications n
+WHERE TRUE
+	AND n.user_id = $1
+	AND n.in_app
+	AND (NOT $2 OR n.read_at IS NULL)
+ORDER BY n.created_at DESC, n.id
+LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, userID, filter.Unread, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	defer rows.Close()
+
+	notifications := make([]entity.Notification, 0, filter.Limit)
+
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// MarkNotificationRead marks one of the user's in-app notifications as read.
+// Reading a notification twice keeps the first read time.
+func (r *Repo) MarkNotificationRead(
+	ctx context.Context,
+	userID entity.UserID,
+	notificationID entity.NotificationID,
+) (entity.Notification, error) {
+	query := `
+UPDATE notifications n
+SET read_at = COALESCE(n.read_at, NOW())
+WHERE TRUE
+	AND n.id = $2
+	AND n.user_id = $1
+	AND n.in_app
+RETURNING ` + notificationColumns
+
+	notification, err := scanNotification(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, userID, notificationID))
+	if err != nil {
+		return entity.Notification{}, fmt.Errorf("failed to mark notification %s read: %w", notificationID, err)
+	}
+
+	return notification, nil
+}
+
+// MarkAllNotificationsRead marks every unread in-app notification of the
+// user as read.
+func (r *Repo) MarkAllNotificationsRead(ctx context.Context, userID entity.UserID) error {
+	query := `
+UPDATE notifications
+SET read_at = NOW()
+WHERE TRUE
+	AND user_id = $1
+	AND in_app
+	AND read_at IS NULL`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package notificationsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// GetNotificationSettings returns the user's settings with defaults for the
+// categories the user has not set up.
+func (r *Repo) GetNotificationSettings(ctx context.Context, userID entity.UserID) (entity.NotificationSettings, error) {
+	tx := r.db.GetTXFromContext(ctx)
+
+	query := `
+SELECT category, in_app, sms
+FROM notification_preferences
+WHERE user_id = $1`
+
+	rows, err := tx.Query(ctx, query, userID)
+	if err != nil {
+		return entity.NotificationSettings{}, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	defer rows.Close()
+
+	var settings entity.NotificationSettings
+
+	for rows.Next() {
+		var preference entity.NotificationPreference
+
+		if err := rows.Scan(&preference.Category, &preference.InApp, &preference.SMS); err != nil {
+			return entity.NotificationSettings{}, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+
+		settings.Preferences = append(settings.Preferences, preference)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entity.NotificationSettings{}, fmt.Errorf("failed to iterate notification preferences: %w", err)
+	}
+
+	query = `
+SELECT to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), time_zone
+FROM quiet_hours
+WHERE user_id = $1`
+
+	var quietHours entity.QuietHours
+
+	err = tx.QueryRow(ctx, query, userID).Scan(&quietHours.Start, &quietHours.End, &quietHours.TimeZone)
+
+	switch {
+	case err == nil:
+		settings.QuietHours = &quietHours
+	case !errors.Is(err, pgx.ErrNoRows):
+		return entity.NotificationSettings{}, fmt.Errorf("failed to get quiet hours: %w", err)
+	}
+
+	settings, err = settings.Validate()
+	if err != nil {
+		return entity.NotificationSettings{}, fmt.Errorf("failed to read notification settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateNotificationSettings replaces the user's settings. Settings must be
+// validated and have a preference for every category.
+func (r *Repo) UpdateNotificationSettings(
+	ctx context.Context,
+	userID entity.UserID,
+	settings entity.NotificationSettings,
+) (entity.NotificationSettings, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		var exists bool
+
+		query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)`
+
+		if err := tx.QueryRow(ctx, query, userID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check user %s: %w", userID, err)
+		}
+
+		if !exists {
+			return entity.ErrUserNotFound
+		}
+
+		categories := make([]string, 0, len(settings.Preferences))
+		inApp := make([]bool, 0, len(settings.Preferences))
+		sms := make([]bool, 0, len(settings.Preferences))
+
+		for _, preference := range settings.Preferences {
+			categories = append(categories, string(preference.Category))
+			inApp = append(inApp, preference.InApp)
+			sms = append(sms, preference.SMS)
+		}
+
+		query = `
+INSERT INTO notification_preferences (user_id, category, in_app, sms)
+SELECT $1, preference.category, preference.in_app, preference.sms
+FROM UNNEST($2::varchar[], $3::boolean[], $4::boolean[]) AS preference(category, in_app, sms)
+ON CONFLICT (user_id, category) DO UPDATE
+SET in_app = EXCLUDED.in_app,
+	sms    = EXCLUDED.sms`
+
+		if _, err := tx.Exec(ctx, query, userID, categories, inApp, sms); err != nil {
+			return fmt.Errorf("failed to upsert notification preferences: %w", err)
+		}
+
+		if settings.QuietHours == nil {
+			if _, err := tx.Exec(ctx, `DELETE FROM quiet_hours WHERE user_id = $1`, userID); err != nil {
+				return fmt.Errorf("failed to delete quiet hours: %w", err)
+			}
+
+			return nil
+		}
+
+		query = `
+INSERT INTO quiet_hours (user_id, start_time, end_time, time_zone)
+VALUES ($1, $2::time, $3::time, $4)
+ON CONFLICT (user_id) DO UPDATE
+SET start_time = EXCLUDED.start_time,
+	end_time   = EXCLUDED.end_time,
+	time_zone  = EXCLUDED.time_zone`
+
+		_, err := tx.Exec(ctx, query, userID, settings.QuietHours.Start, settings.QuietHours.End, settings.QuietHours.TimeZone)
+		if err != nil {
+			return fmt.Errorf("failed to upsert quiet hours: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.NotificationSettings{}, fmt.Errorf("failed to update notification settings: %w", err)
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,268 @@
+package ordersrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const cartItemColumns = `
+	c.id, c.user_id, c.product_id, c.size, c.quantity,
+	p.name, p.brand, p.price, p.currency,
+	p.available AND (c.size IS NULL OR EXISTS (
+		SELECT 1
+		FROM product_sizes s
+		WHERE TRUE
+			AND s.product_id = p.id
+			AND s.size = c.size
+			AND s.available
+			AND (s.stock IS NULL OR s.stock >= c.quantity)
+	)),
+	` + categoryPath + `,
+	c.created_at, c.updated_at`
+
+func scanCartItem(row pgx.Row) (entity.CartItem, error) {
+	var item entity.CartItem
+
+	err := row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.ProductID,
+		&item.Size,
+		&item.Quantity,
+		&item.Name,
+		&item.Brand,
+		&item.Price,
+		&item.Currency,
+		&item.Available,
+		&item.Categories,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.CartItem{}, entity.ErrCartItemNotFound
+		}
+
+		return entity.CartItem{}, fmt.Errorf("failed to scan cart item: %w", err)
+	}
+
+	return item, nil
+}
+
+func convertCartItemError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return entity.ErrDuplicateCartItem
+		case pgerrcode.CheckViolation:
+			return fmt.Errorf("%w: too many items of the product", entity.ErrInvalidCartItem)
+		}
+	}
+
+	return fmt.Errorf("failed to save cart item: %w", err)
+}
+
+// checkVariant makes sure the size picked fits the product: products sold in
+// sizes need one of their sizes, other products no size at all. Products
+// that are off sale cannot be picked.
+func (r *Repo) checkVariant(ctx context.Context, productID entity.ProductID, size *string) error {
+	query := `
+SELECT p.available,
+	EXISTS (SELECT 1 FROM product_sizes s WHERE s.product_id = p.id),
+	EXISTS (SELECT 1 FROM product_sizes s WHERE s.product_id = p.id AND s.size = $2)
+FROM products p
+WHERE p.id = $1`
+
+	var available, sized, known bool
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, productID, size).Scan(&available, &sized, &known); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrProductNotFound
+		}
+
+		return fmt.Errorf("failed to get product: %w", err)
+	}
+
+	switch {
+	case !available:
+		return entity.ErrOutOfStock
+	case sized && size == nil:
+		return fmt.Errorf("%w: size is required", entity.ErrInvalidCartItem)
+	case !sized && size != nil:
+		return fmt.Errorf("%w: product is not sold in sizes", entity.ErrInvalidCartItem)
+	case sized && !known:
+		return fmt.Errorf("%w: unknown size %q", entity.ErrInvalidCartItem, *size)
+	}
+
+	return nil
+}
+
+// AddCartItem puts the product size into the cart, or adds to its quantity
+// if it is in the cart already.
+func (r *Repo) AddCartItem(ctx context.Context, item entity.CartItem) (entity.CartItem, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.checkVariant(ctx, item.ProductID, item.Size); err != nil {
+			return err
+		}
+
+		query := `
+INSERT INTO cart_items (id, user_id, product_id, size, quantity)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, product_id, COALESCE(size, '')) DO UPDATE
+SET quantity   = cart_items.quantity + EXCLUDED.quantity,
+	updated_at = NOW()
+RETURNING id`
+
+		err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query,
+			item.ID, item.UserID, item.ProductID, item.Size, item.Quantity).Scan(&item.ID)
+		if err != nil {
+			return convertCartItemError(err)
+		}
+
+		item, err = r.GetCartItem(ctx, item.UserID, item.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.CartItem{}, fmt.Errorf("failed to add cart item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *Repo) GetCartItem(ctx context.Context, userID entity.UserID, itemID entity.CartItemID) (entity.CartItem, error) {
+	query := `
+SELECT ` + cartItemColumns + `
+FROM cart_items c
+	JOIN products p ON p.id = c.product_id
+WHERE TRUE
+	AND c.id = $1
+	AND c.user_id = $2`
+
+	item, err := scanCartItem(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, itemID, userID))
+	if err != nil {
+		return entity.CartItem{}, fmt.Errorf("failed to get cart item %s: %w", itemID, err)
+	}
+
+	return item, nil
+}
+
+// ListCartItems returns the whole cart in the order items were added.
+func (r *Repo) ListCartItems(ctx context.Context, userID entity.UserID) ([]entity.CartItem, error) {
+	query := `
+SELECT ` + cartItemColumns + `
+FROM cart_items c
+	JOIN products p ON p.id = c.product_id
+WHERE c.user_id = $1
+ORDER BY c.created_at, c.id`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cart items: %w", err)
+	}
+
+	defer rows.Close()
+
+	items := make([]entity.CartItem, 0)
+
+	for rows.Next() {
+		item, err := scanCartItem(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate cart items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *Repo) UpdateCartItem(
+	ctx context.Context,
+	userID entity.UserID,
+	itemID entity.CartItemID,
+	update entity.CartItemUpdate,
+) (entity.CartItem, error) {
+	var item entity.CartItem
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT product_id, size
+FROM cart_items
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2
+FOR UPDATE`
+
+		if err := tx.QueryRow(ctx, query, itemID, userID).Scan(&item.ProductID, &item.Size); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrCartItemNotFound
+			}
+
+			return fmt.Errorf("failed to lock cart item: %w", err)
+		}
+
+		if update.Size != nil {
+			if err := r.checkVariant(ctx, item.ProductID, update.Size); err != nil {
+				return err
+			}
+		}
+
+		query = `
+UPDATE cart_items
+SET size       = COALESCE($3, size),
+	quantity   = COALESCE($4, quantity),
+	updated_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2`
+
+		if _, err := tx.Exec(ctx, query, itemID, userID, update.Size, update.Quantity); err != nil {
+			return convertCartItemError(err)
+		}
+
+		var err error
+
+		item, err = r.GetCartItem(ctx, userID, itemID)
+
+		return err
+	})
+	if err != nil {
+		return entity.CartItem{}, fmt.Errorf("failed to update cart item %s: %w", itemID, err)
+	}
+
+	return item, nil
+}
+
+func (r *Repo) DeleteCartItem(ctx context.Context, userID entity.UserID, itemID entity.CartItemID) error {
+	query := `
+DELETE FROM cart_items
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, itemID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete cart item %s: %w", itemID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.ErrCartItemNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,441 @@
+package ordersrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const orderColumns = `
+	o.id, o.user_id, o.status,
+	COALESCE((
+		SELECT jsonb_agg(jsonb_build_object(
+			'position', i.position, 'productId', i.product_id, 'feedId', i.feed_id, 'externalId', i.external_id,
+			'name', i.name, 'brand', i.brand, 'size', i.size, 'price', i.price, 'quantity', i.quantity
+		) ORDER BY i.position)
+		FROM order_items i
+		WHERE i.order_id = o.id
+	), '[]'),
+	o.subtotal, o.discount, o.shipping, o.total, o.currency,
+	(
+		SELECT pc.code
+		FROM promo_redemptions r
+			JOIN promo_codes pc ON pc.id = r.promo_code_id
+		WHERE r.order_id = o.id
+	),
+	o.created_at, o.updated_at`
+
+// categoryPath lists the category of product p and all of its parents.
+const categoryPath = `
+	(
+		WITH RECURSIVE path AS (
+			SELECT id, parent_id
+			FROM categories
+			WHERE id = p.category_id
+			UNION ALL
+			SELECT parent.id, parent.parent_id
+			FROM categories parent
+				JOIN path ON parent.id = path.parent_id
+		)
+		SELECT COALESCE(array_agg(path.id), '{}')
+		FROM path
+	)`
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanOrder(row pgx.Row) (entity.Order, error) {
+	var order entity.Order
+
+	err := row.Scan(
+		&order.ID,
+		&order.UserID,
+		&order.Status,
+		&order.Items,
+		&order.Subtotal,
+		&order.Discount,
+		&order.Shipping,
+		&order.Total,
+		&order.Currency,
+		&order.PromoCode,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Order{}, entity.ErrOrderNotFound
+		}
+
+		return entity.Order{}, fmt.Errorf("failed to scan order: %w", err)
+	}
+
+	return order, nil
+}
+
+// Checkout turns the user's cart into an order. Cart items, products and
+// sizes are locked, always in the same order, for the whole checkout, so that
+// concurrent checkouts of the last items in stock cannot both succeed: the
+// one that comes second finds the stock already reserved and fails with
+// ErrOutOfStock. A promo code is locked too, so that concurrent checkouts
+// redeeming it count each other's redemptions against its limits.
+func (r *Repo) Checkout(
+	ctx context.Context,
+	orderID entity.OrderID,
+	userID entity.UserID,
+	promoCode *string,
+	price entity.Pricer,
+) (entity.Order, error) {
+	var order entity.Order
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT c.id, c.product_id, c.size, c.quantity
+FROM cart_items c
+WHERE c.user_id = $1
+ORDER BY c.product_id, c.size NULLS FIRST
+FOR UPDATE`
+
+		rows, err := tx.Query(ctx, query, userID)
+		if err != nil {
+			return fmt.Errorf("failed to lock cart: %w", err)
+		}
+
+		cart, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.CartItem, error) {
+			var item entity.CartItem
+
+			err := row.Scan(&item.ID, &item.ProductID, &item.Size, &item.Quantity)
+
+			return item, err //nolint:wrapcheck
+		})
+		if err != nil {
+			return fmt.Errorf("failed to scan cart: %w", err)
+		}
+
+		if len(cart) == 0 {
+			return entity.ErrEmptyCart
+		}
+
+		items := make([]entity.OrderItem, 0, len(cart))
+		lines := make([]entity.PricingLine, 0, len(cart))
+		priced := make([]entity.CartItem, 0, len(cart))
+
+		for _, cartItem := range cart {
+			item, err := r.reserve(ctx, &cartItem)
+			if err != nil {
+				return err
+			}
+
+			items = append(items, item)
+			priced = append(priced, cartItem)
+			lines = append(lines, entity.PricingLine{
+				ProductID:  cartItem.ProductID,
+				Categories: cartItem.Categories,
+				Price:      cartItem.Price,
+				Quantity:   cartItem.Quantity,
+			})
+		}
+
+		total, err := entity.NewCart(priced)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		request := entity.PricingRequest{
+			Lines:    lines,
+			Currency: total.Currency,
+		}
+
+		if promoCode != nil {
+			promo, err := r.lockPromoCode(ctx, *promoCode)
+			if err != nil {
+				return err
+			}
+
+			request.Promo = &promo
+
+			if request.Usage, err = r.promoUsage(ctx, promo.ID, userID); err != nil {
+				return err
+			}
+		}
+
+		pricing, err := price(request)
+		if err != nil {
+			return err
+		}
+
+		query = `
+INSERT INTO orders (id, user_id, subtotal, discount, shipping, total, currency)
+VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+		if _, err := tx.Exec(ctx, query, orderID, userID, pricing.Subtotal, pricing.Discount, pricing.Shipping,
+			pricing.Total, pricing.Currency); err != nil {
+			return fmt.Errorf("failed to insert order: %w", err)
+		}
+
+		if request.Promo != nil {
+			query := `
+INSERT INTO promo_redemptions (order_id, promo_code_id, user_id, discount)
+VALUES ($1, $2, $3, $4)`
+
+			if _, err := tx.Exec(ctx, query, orderID, request.Promo.ID, userID, pricing.Discount); err != nil {
+				return fmt.Errorf("failed to redeem promo code: %w", err)
+			}
+		}
+
+		for position, item := range items {
+			query := `
+INSERT INTO order_items (order_id, position, product_id, feed_id, external_id, name, brand, size, price, quantity)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+			if _, err := tx.Exec(ctx, query, orderID, position, item.ProductID, item.FeedID, item.ExternalID,
+				item.Name, item.Brand, item.Size, item.Price, item.Quantity); err != nil {
+				return fmt.Errorf("failed to insert order item: %w", err)
+			}
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM cart_items WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to empty cart: %w", err)
+		}
+
+		order, err = r.GetOrder(ctx, userID, orderID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("failed to check out: %w", err)
+	}
+
+	return order, nil
+}
+
+// reserve locks the cart item's product, takes the item's quantity off the
+// stock of its size and returns the order item snapshot. The cart item gets
+// the product's current price. Sizes of unknown stock are only checked for
+// availability.
+func (r *Repo) reserve(ctx context.Context, cartItem *entity.CartItem) (entity.OrderItem, error) {
+	tx := r.db.GetTXFromContext(ctx)
+
+	item := entity.OrderItem{
+		ProductID: &cartItem.ProductID,
+		Size:      cartItem.Size,
+		Quantity:  cartItem.Quantity,
+	}
+
+	var available bool
+
+	query := `
+SELECT p.feed_id, p.external_id, p.name, p.brand, p.price, p.currency, p.available, ` + categoryPath + `
+FROM products p
+WHERE p.id = $1
+FOR SHARE OF p`
+
+	err := tx.QueryRow(ctx, query, cartItem.ProductID).Scan(&item.FeedID, &item.ExternalID, &item.Name, &item.Brand,
+		&item.Price, &cartItem.Currency, &available, &cartItem.Categories)
+	if err != nil {
+		return entity.OrderItem{}, fmt.Errorf("failed to lock product %s: %w", cartItem.ProductID, err)
+	}
+
+	cartItem.Price = item.Price
+
+	if !available {
+		return entity.OrderItem{}, fmt.Errorf("%w: %s", entity.ErrOutOfStock, item.Name)
+	}
+
+	if cartItem.Size == nil {
+		return item, nil
+	}
+
+	query = `
+UPDATE product_sizes
+SET stock     = stock - $3,
+	available = stock IS NULL OR stock > $3
+WHERE TRUE
+	AND product_id = $1
+	AND size = $2
+	AND available
+	AND (stock IS NULL OR stock >= $3)`
+
+	tag, err := tx.Exec(ctx, query, cartItem.ProductID, *cartItem.Size, cartItem.Quantity)
+	if err != nil {
+		return entity.OrderItem{}, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.OrderItem{}, fmt.Errorf("%w: %s, size %s", entity.ErrOutOfStock, item.Name, *cartItem.Size)
+	}
+
+	return item, nil
+}
+
+// release puts the order's reserved stock back on sale.
+func (r *Repo) release(ctx context.Context, orderID entity.OrderID) error {
+	query := `
+UPDATE product_sizes s
+SET stock     = s.stock + i.quantity,
+	available = TRUE
+FROM order_items i
+WHERE TRUE
+	AND i.order_id = $1
+	AND s.product_id = i.product_id
+	AND s.size = i.size
+	AND s.stock IS NOT NULL`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, orderID); err != nil {
+		return fmt.Errorf("failed to release stock: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetOrder(ctx context.Context, userID entity.UserID, orderID entity.OrderID) (entity.Order, error) {
+	query := `
+SELECT ` + orderColumns + `
+FROM orders o
+WHERE TRUE
+	AND o.id = $1
+	AND o.user_id = $2`
+
+	order, err := scanOrder(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, orderID, userID))
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("failed to get order %s: %w", orderID, err)
+	}
+
+	return order, nil
+}
+
+// ListOrders lists the user's orders, newest first.
+func (r *Repo) ListOrders(ctx context.Context, userID entity.UserID, filter entity.OrderFilter) ([]entity.Order, error) {
+	var sb strings.Builder
+
+	params := []any{userID}
+
+	sb.WriteString(`
+SELECT ` + orderColumns + `
+FROM orders o
+WHERE o.user_id = $1`)
+
+	if filter.Status != nil {
+		params = append(params, *filter.Status)
+		sb.WriteString(fmt.Sprintf(" AND o.status = $%d", len(params)))
+	}
+
+	params = append(params, filter.Limit, filter.Offset)
+	sb.WriteString(fmt.Sprintf(" ORDER BY o.created_at DESC, o.id LIMIT $%d OFFSET $%d", len(params)-1, len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	defer rows.Close()
+
+	orders := make([]entity.Order, 0, filter.Limit)
+
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// TransitionOrder moves the order to the status if the order's state machine
+// allows it, returning stock to sale when the order is dropped before it is
+// shipped. The order row stays locked until the transition is committed, so
+// concurrent transitions of the same order are applied one after another.
+// A nil userID lets the transition apply to anybody's order.
+func (r *Repo) TransitionOrder(
+	ctx context.Context,
+	userID *entity.UserID,
+	orderID entity.OrderID,
+	status entity.OrderStatus,
+) (entity.Order, error) {
+	var order entity.Order
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT user_id, status
+FROM orders
+WHERE TRUE
+	AND id = $1
+	AND ($2::UUID IS NULL OR user_id = $2)
+FOR UPDATE`
+
+		var (
+			ownerID entity.UserID
+			current entity.OrderStatus
+		)
+
+		if err := tx.QueryRow(ctx, query, orderID, userID).Scan(&ownerID, &current); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrOrderNotFound
+			}
+
+			return fmt.Errorf("failed to lock order: %w", err)
+		}
+
+		if !current.CanTransition(status) {
+			return fmt.Errorf("%w: %s order cannot become %s", entity.ErrInvalidOrderTransition, current, status)
+		}
+
+		query = `
+UPDATE orders
+SET status     = $2,
+	updated_at = NOW()
+WHERE id = $1`
+
+		if _, err := tx.Exec(ctx, query, orderID, status); err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+
+		if current.ReleasesStock(status) {
+			if err := r.release(ctx, orderID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+
+		order, err = r.GetOrder(ctx, ownerID, orderID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("failed to move order %s to %s: %w", orderID, status, err)
+	}
+
+	return order, nil
+}
@@ -0,0 +1,310 @@
+package ordersrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const paymentColumns = `
+	p.id, p.order_id, p.user_id, COALESCE(p.provider_id, ''), p.status, p.amount, p.currency,
+	p.confirmation_url, p.created_at, p.updated_at`
+
+func scanPayment(row pgx.Row) (entity.Payment, error) {
+	var payment entity.Payment
+
+	err := row.Scan(
+		&payment.ID,
+		&payment.OrderID,
+		&payment.UserID,
+		&payment.ProviderID,
+		&payment.Status,
+		&payment.Amount,
+		&payment.Currency,
+		&payment.ConfirmationURL,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Payment{}, entity.ErrPaymentNotFound
+		}
+
+		return entity.Payment{}, fmt.Errorf("failed to scan payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// StartPayment stores a payment for the whole total of the user's order,
+// which must be waiting for payment. If the order already has a payment in
+// progress, that payment is returned instead, so paying twice does not charge
+// the customer twice.
+func (r *Repo) StartPayment(ctx context.Context, payment entity.Payment) (entity.Payment, error) {
+	var started entity.Payment
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT status, total, currency
+FROM orders
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2
+FOR UPDATE`
+
+		var status entity.OrderStatus
+
+		err := tx.QueryRow(ctx, query, payment.OrderID, payment.UserID).Scan(&status, &payment.Amount, &payment.Currency)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrOrderNotFound
+			}
+
+			return fmt.Errorf("failed to lock order: %w", err)
+		}
+
+		if !status.CanTransition(entity.OrderStatusPaid) {
+			return fmt.Errorf("%w: %s order cannot be paid", entity.ErrInvalidOrderTransition, status)
+		}
+
+		query = `
+SELECT ` + paymentColumns + `
+FROM payments p
+WHERE TRUE
+	AND p.order_id = $1
+	AND p.status IN ('pending', 'waiting_for_capture')
+ORDER BY p.created_at DESC
+LIMIT 1`
+
+		started, err = scanPayment(tx.QueryRow(ctx, query, payment.OrderID))
+		if !errors.Is(err, entity.ErrPaymentNotFound) {
+			return err
+		}
+
+		query = `
+INSERT INTO payments (id, order_id, user_id, amount, currency)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING ` + paymentColumns
+
+		started, err = scanPayment(tx.QueryRow(ctx, query, payment.ID, payment.OrderID, payment.UserID,
+			payment.Amount, payment.Currency))
+
+		return err
+	})
+	if err != nil {
+		return entity.Payment{}, fmt.Errorf("failed to start payment for order %s: %w", payment.OrderID, err)
+	}
+
+	return started, nil
+}
+
+// SetPaymentProvider records the provider's side of a started payment.
+func (r *Repo) SetPaymentProvider(
+	ctx context.Context,
+	paymentID entity.PaymentID,
+	gatewayPayment entity.GatewayPayment,
+) (entity.Payment, error) {
+	query := `
+UPDATE payments p
+SET provider_id      = $2,
+	status           = $3,
+	confirmation_url = $4,
+	updated_at       = NOW()
+WHERE p.id = $1
+RETURNING ` + paymentColumns
+
+	payment, err := scanPayment(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, paymentID,
+		gatewayPayment.ProviderID, gatewayPayment.Status, gatewayPayment.ConfirmationURL))
+	if err != nil {
+		return entity.Payment{}, fmt.Errorf("failed to set provider of payment %s: %w", paymentID, err)
+	}
+
+	return payment, nil
+}
+
+// CancelPayment drops a payment the provider never accepted.
+func (r *Repo) CancelPayment(ctx context.Context, paymentID entity.PaymentID) error {
+	query := `
+UPDATE payments
+SET status     = 'canceled',
+	updated_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND provider_id IS NULL`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, paymentID); err != nil {
+		return fmt.Errorf("failed to cancel payment %s: %w", paymentID, err)
+	}
+
+	return nil
+}
+
+// ListPayments lists the payments of the user's order, newest first.
+func (r *Repo) ListPayments(ctx context.Context, userID entity.UserID, orderID entity.OrderID) ([]entity.Payment, error) {
+	query := `
+SELECT ` + paymentColumns + `
+FROM payments p
+WHERE TRUE
+	AND p.order_id = $1
+	AND p.user_id = $2
+ORDER BY p.created_at DESC, p.id`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, orderID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	defer rows.Close()
+
+	payments := make([]entity.Payment, 0)
+
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// GetRefundablePayment returns the succeeded payment of an order that can
+// still be refunded.
+func (r *Repo) GetRefundablePayment(ctx context.Context, orderID entity.OrderID) (entity.Payment, error) {
+	var status entity.OrderStatus
+
+	query := `
+SELECT o.status
+FROM orders o
+WHERE o.id = $1`
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, orderID).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Payment{}, entity.ErrOrderNotFound
+		}
+
+		return entity.Payment{}, fmt.Errorf("failed to get order %s: %w", orderID, err)
+	}
+
+	if !status.CanTransition(entity.OrderStatusRefunded) {
+		return entity.Payment{}, fmt.Errorf("%w: %s order cannot be refunded", entity.ErrInvalidOrderTransition, status)
+	}
+
+	query = `
+SELECT ` + paymentColumns + `
+FROM payments p
+WHERE TRUE
+	AND p.order_id = $1
+	AND p.status = 'succeeded'`
+
+	payment, err := scanPayment(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, orderID))
+	if err != nil {
+		return entity.Payment{}, fmt.Errorf("failed to get payment of order %s: %w", orderID, err)
+	}
+
+	return payment, nil
+}
+
+// ApplyPaymentEvent moves the payment along with a provider event and pays or
+// refunds its order accordingly. Every event is recorded, so an event that is
+// delivered again leaves the payment and the order untouched.
+func (r *Repo) ApplyPaymentEvent(ctx context.Context, event entity.PaymentEvent) (entity.PaymentUpdate, error) {
+	var update entity.PaymentUpdate
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+INSERT INTO payment_events (provider_id, event)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING`
+
+		tag, err := tx.Exec(ctx, query, event.ProviderID, event.Type)
+		if err != nil {
+			return fmt.Errorf("failed to record payment event: %w", err)
+		}
+
+		query = `
+SELECT ` + paymentColumns + `
+FROM payments p
+WHERE p.provider_id = $1
+FOR UPDATE`
+
+		update.Payment, err = scanPayment(tx.QueryRow(ctx, query, event.ProviderID))
+		if err != nil {
+			return err
+		}
+
+		if err := tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1`, update.Payment.OrderID).
+			Scan(&update.OrderStatus); err != nil {
+			return fmt.Errorf("failed to get order status: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		if event.Amount != update.Payment.Amount || event.Currency != update.Payment.Currency {
+			return fmt.Errorf("%w: event amount %d %s does not match payment amount %d %s", entity.ErrInvalidPayment,
+				event.Amount, event.Currency, update.Payment.Amount, update.Payment.Currency)
+		}
+
+		status, ok := event.Status(update.Payment.Status)
+		if !ok {
+			return nil
+		}
+
+		query = `
+UPDATE payments p
+SET status     = $2,
+	updated_at = NOW()
+WHERE p.id = $1
+RETURNING ` + paymentColumns
+
+		update.Payment, err = scanPayment(tx.QueryRow(ctx, query, update.Payment.ID, status))
+		if err != nil {
+			return err
+		}
+
+		orderStatus := update.OrderStatus
+
+		switch status {
+		case entity.PaymentStatusSucceeded:
+			orderStatus = entity.OrderStatusPaid
+		case entity.PaymentStatusRefunded:
+			orderStatus = entity.OrderStatusRefunded
+		default:
+			return nil
+		}
+
+		if !update.OrderStatus.CanTransition(orderStatus) {
+			return nil
+		}
+
+		order, err := r.TransitionOrder(ctx, nil, update.Payment.OrderID, orderStatus)
+		if err != nil {
+			return err
+		}
+
+		update.OrderStatus = order.Status
+		update.OrderChanged = true
+
+		return nil
+	})
+	if err != nil {
+		return entity.PaymentUpdate{}, fmt.Errorf("failed to apply %s event to payment %s: %w", event.Type, event.ProviderID, err)
+	}
+
+	return update, nil
+}
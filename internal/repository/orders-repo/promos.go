@@ -0,0 +1,254 @@
+package ordersrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const promoCodeColumns = `
+	pc.id, pc.code, pc.kind, pc.value, pc.currency, pc.min_order, pc.categories, pc.first_order_only,
+	pc.usage_limit, pc.per_user_limit, pc.starts_at, pc.ends_at, pc.active,
+	(
+		SELECT COUNT(*)
+		FROM promo_redemptions r
+			JOIN orders o ON o.id = r.order_id
+		WHERE TRUE
+			AND r.promo_code_id = pc.id
+			AND o.status <> 'cancelled'
+	),
+	pc.created_at, pc.updated_at`
+
+func scanPromoCode(row pgx.Row) (entity.PromoCode, error) {
+	var promo entity.PromoCode
+
+	err := row.Scan(
+		&promo.ID,
+		&promo.Code,
+		&promo.Kind,
+		&promo.Value,
+		&promo.Currency,
+		&promo.MinOrder,
+		&promo.Categories,
+		&promo.FirstOrderOnly,
+		&promo.UsageLimit,
+		&promo.PerUserLimit,
+		&promo.StartsAt,
+		&promo.EndsAt,
+		&promo.Active,
+		&promo.Redemptions,
+		&promo.CreatedAt,
+		&promo.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.PromoCode{}, entity.ErrPromoCodeNotFound
+		}
+
+		return entity.PromoCode{}, fmt.Errorf("failed to scan promo code: %w", err)
+	}
+
+	return promo, nil
+}
+
+func convertPromoCodeError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return entity.ErrDuplicatePromoCode
+		case pgerrcode.CheckViolation:
+			return fmt.Errorf("%w: code must end after it starts", entity.ErrInvalidPromoCode)
+		}
+	}
+
+	return fmt.Errorf("failed to save promo code: %w", err)
+}
+
+func (r *Repo) CreatePromoCode(ctx context.Context, promo entity.PromoCode) (entity.PromoCode, error) {
+	tx := r.db.GetTXFromContext(ctx)
+
+	var known int
+
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM categories WHERE id = ANY($1)`, promo.Categories).Scan(&known); err != nil {
+		return entity.PromoCode{}, fmt.Errorf("failed to check categories: %w", err)
+	}
+
+	if known != len(promo.Categories) {
+		return entity.PromoCode{}, fmt.Errorf("%w: unknown category", entity.ErrInvalidPromoCode)
+	}
+
+	query := `
+INSERT INTO promo_codes AS pc (id, code, kind, value, currency, min_order, categories, first_order_only,
+	usage_limit, per_user_limit, starts_at, ends_at, active)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING ` + promoCodeColumns
+
+	createdPromo, err := scanPromoCode(tx.QueryRow(ctx, query, promo.ID, promo.Code, promo.Kind, promo.Value,
+		promo.Currency, promo.MinOrder, promo.Categories, promo.FirstOrderOnly, promo.UsageLimit, promo.PerUserLimit,
+		promo.StartsAt, promo.EndsAt, promo.Active))
+	if err != nil {
+		return entity.PromoCode{}, convertPromoCodeError(err)
+	}
+
+	return createdPromo, nil
+}
+
+func (r *Repo) GetPromoCode(ctx context.Context, promoID entity.PromoCodeID) (entity.PromoCode, error) {
+	query := `
+SELECT ` + promoCodeColumns + `
+FROM promo_codes pc
+WHERE pc.id = $1`
+
+	promo, err := scanPromoCode(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, promoID))
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("failed to get promo code %s: %w", promoID, err)
+	}
+
+	return promo, nil
+}
+
+// ListPromoCodes lists promo codes, newest first.
+func (r *Repo) ListPromoCodes(ctx context.Context, filter entity.PromoCodeFilter) ([]entity.PromoCode, error) {
+	var sb strings.Builder
+
+	params := []any{}
+
+	sb.WriteString(`
+SELECT ` + promoCodeColumns + `
+FROM promo_codes pc
+WHERE TRUE`)
+
+	if filter.Active != nil {
+		params = append(params, *filter.Active)
+		sb.WriteString(fmt.Sprintf(" AND pc.active = $%d", len(params)))
+	}
+
+	params = append(params, filter.Limit, filter.Offset)
+	sb.WriteString(fmt.Sprintf(" ORDER BY pc.created_at DESC, pc.id LIMIT $%d OFFSET $%d", len(params)-1, len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promo codes: %w", err)
+	}
+
+	defer rows.Close()
+
+	promos := make([]entity.PromoCode, 0, filter.Limit)
+
+	for rows.Next() {
+		promo, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		promos = append(promos, promo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate promo codes: %w", err)
+	}
+
+	return promos, nil
+}
+
+func (r *Repo) UpdatePromoCode(
+	ctx context.Context,
+	promoID entity.PromoCodeID,
+	update entity.PromoCodeUpdate,
+) (entity.PromoCode, error) {
+	query := `
+UPDATE promo_codes pc
+SET active         = COALESCE($2, pc.active),
+	usage_limit    = COALESCE($3, pc.usage_limit),
+	per_user_limit = COALESCE($4, pc.per_user_limit),
+	starts_at      = COALESCE($5, pc.starts_at),
+	ends_at        = COALESCE($6, pc.ends_at),
+	updated_at     = NOW()
+WHERE pc.id = $1
+RETURNING ` + promoCodeColumns
+
+	promo, err := scanPromoCode(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, promoID, update.Active,
+		update.UsageLimit, update.PerUserLimit, update.StartsAt, update.EndsAt))
+	if err != nil {
+		return entity.PromoCode{}, convertPromoCodeError(err)
+	}
+
+	return promo, nil
+}
+
+// GetPromoCodeUsage returns a code by its text along with how much it has
+// been used, as far as the user is concerned.
+func (r *Repo) GetPromoCodeUsage(
+	ctx context.Context,
+	code string,
+	userID entity.UserID,
+) (entity.PromoCode, entity.PromoUsage, error) {
+	query := `
+SELECT ` + promoCodeColumns + `
+FROM promo_codes pc
+WHERE pc.code = $1`
+
+	promo, err := scanPromoCode(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, entity.NormalizePromoCode(code)))
+	if err != nil {
+		return entity.PromoCode{}, entity.PromoUsage{}, fmt.Errorf("failed to get promo code %s: %w", code, err)
+	}
+
+	usage, err := r.promoUsage(ctx, promo.ID, userID)
+	if err != nil {
+		return entity.PromoCode{}, entity.PromoUsage{}, err
+	}
+
+	return promo, usage, nil
+}
+
+// lockPromoCode returns the code locked until the end of the transaction.
+func (r *Repo) lockPromoCode(ctx context.Context, code string) (entity.PromoCode, error) {
+	query := `
+SELECT ` + promoCodeColumns + `
+FROM promo_codes pc
+WHERE pc.code = $1
+FOR UPDATE OF pc`
+
+	promo, err := scanPromoCode(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, entity.NormalizePromoCode(code)))
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("failed to lock promo code %s: %w", code, err)
+	}
+
+	return promo, nil
+}
+
+func (r *Repo) promoUsage(ctx context.Context, promoID entity.PromoCodeID, userID entity.UserID) (entity.PromoUsage, error) {
+	query := `
+SELECT
+	COUNT(r.order_id),
+	COUNT(r.order_id) FILTER (WHERE r.user_id = $2),
+	(
+		SELECT COUNT(*)
+		FROM orders
+		WHERE TRUE
+			AND user_id = $2
+			AND status <> 'cancelled'
+	)
+FROM promo_redemptions r
+	JOIN orders o ON o.id = r.order_id
+WHERE TRUE
+	AND r.promo_code_id = $1
+	AND o.status <> 'cancelled'`
+
+	var usage entity.PromoUsage
+
+	err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, promoID, userID).Scan(&usage.Total, &usage.ByUser, &usage.UserOrders)
+	if err != nil {
+		return entity.PromoUsage{}, fmt.Errorf("failed to count promo code usage: %w", err)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,168 @@
+package outboxrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	GetTXFromContext(ctx context.Context) store.Transaction
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Enqueue records a message in the transaction of the context, so it is
+// only relayed if that transaction commits.
+func (r *Repo) Enqueue(ctx context.Context, message entity.OutboxMessage) error {
+	query := `
+INSERT INTO outbox_messages (id, kind, payload)
+VALUES ($1, $2, $3)`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, message.ID, message.Kind, message.Payload); err != nil {
+		return fmt.Errorf("failed to enqueue outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue takes up to limit messages that are due, oldest first, and
+// leases them until leaseUntil: if they are neither sent nor retried by
+// then, they are due again. Relays running side by side never claim the
+// same message.
+func (r *Repo) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]entity.OutboxMessage, error) {
+	query := `
+WITH due AS (
+	SELECT id
+	FROM outbox_messages
+	WHERE TRUE
+		AND status = 'pending'
+		AND next_attempt_at <= NOW()
+	ORDER BY next_attempt_at, id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED
+)
+UPDATE outbox_messages o
+SET attempts        = o.attempts + 1,
+	next_attempt_at = $2
+FROM due
+WHERE o.id = due.id
+RETURNING o.id, o.kind, o.payload, o.status, o.attempts, o.next_attempt_at, o.last_error, o.created_at`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, limit, leaseUntil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	defer rows.Close()
+
+	messages := make([]entity.OutboxMessage, 0, limit)
+
+	for rows.Next() {
+		var message entity.OutboxMessage
+
+		err := rows.Scan(
+			&message.ID,
+			&message.Kind,
+			&message.Payload,
+			&message.Status,
+			&message.Attempts,
+			&message.NextAttemptAt,
+			&message.LastError,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (r *Repo) MarkSent(ctx context.Context, messageID entity.OutboxMessageID) error {
+	query := `
+UPDATE outbox_messages
+SET status     = 'sent',
+	payload    = '{}',
+	last_error = NULL,
+	sent_at    = NOW()
+WHERE id = $1`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, messageID); err != nil {
+		return fmt.Errorf("failed to mark outbox message %s sent: %w", messageID, err)
+	}
+
+	return nil
+}
+
+// Retry records a failed attempt and makes the message due again at
+// nextAttemptAt.
+func (r *Repo) Retry(ctx context.Context, messageID entity.OutboxMessageID, lastError string, nextAttemptAt time.Time) error {
+	query := `
+UPDATE outbox_messages
+SET last_error      = $2,
+	next_attempt_at = $3
+WHERE id = $1`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, messageID, lastError, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to retry outbox message %s: %w", messageID, err)
+	}
+
+	return nil
+}
+
+// MarkDead records the last failed attempt and stops retrying the message.
+// Like MarkSent, it clears the payload, which may hold secrets such as
+// one-time passwords that must not outlive the message.
+func (r *Repo) MarkDead(ctx context.Context, messageID entity.OutboxMessageID, lastError string) error {
+	query := `
+UPDATE outbox_messages
+SET status     = 'dead',
+	payload    = '{}',
+	last_error = $2
+WHERE id = $1`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, messageID, lastError); err != nil {
+		return fmt.Errorf("failed to mark outbox message %s dead: %w", messageID, err)
+	}
+
+	return nil
+}
+
+// DeleteFinished deletes sent and dead messages created before the given time
+// and returns how many were deleted.
+func (r *Repo) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+DELETE FROM outbox_messages
+WHERE TRUE
+	AND status IN ('sent', 'dead')
+	AND created_at < $1`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished outbox messages: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
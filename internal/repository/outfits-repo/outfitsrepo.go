@@ -0,0 +1,366 @@
+package outfitsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const (
+	maxUpdates = 5
+
+	outfitColumns = `
+	o.id, o.user_id, o.name, o.occasions, o.season,
+	(SELECT f.id FROM files f WHERE f.id = o.cover_photo_id AND f.status = 'ready'),
+	ARRAY(
+		SELECT oi.slot
+		FROM outfit_items oi
+		WHERE oi.outfit_id = o.id
+		ORDER BY oi.position
+	),
+	ARRAY(
+		SELECT oi.item_id
+		FROM outfit_items oi
+		WHERE oi.outfit_id = o.id
+		ORDER BY oi.position
+	),
+	o.created_at, o.updated_at`
+)
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanOutfit(row pgx.Row) (entity.Outfit, error) {
+	var (
+		outfit  entity.Outfit
+		slots   []string
+		itemIDs []uuid.UUID
+	)
+
+	err := row.Scan(
+		&outfit.ID,
+		&outfit.UserID,
+		&outfit.Name,
+		&outfit.Occasions,
+		&outfit.Season,
+		&outfit.CoverPhotoID,
+		&slots,
+		&itemIDs,
+		&outfit.CreatedAt,
+		&outfit.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Outfit{}, entity.ErrOutfitNotFound
+		}
+
+		return entity.Outfit{}, fmt.Errorf("failed to scan outfit: %w", err)
+	}
+
+	outfit.Items = make([]entity.OutfitItem, 0, len(itemIDs))
+	for i, itemID := range itemIDs {
+		outfit.Items = append(outfit.Items, entity.OutfitItem{
+			Slot:   entity.OutfitSlot(slots[i]),
+			ItemID: entity.WardrobeItemID(itemID),
+		})
+	}
+
+	return outfit, nil
+}
+
+func (r *Repo) CreateOutfit(ctx context.Context, outfit entity.Outfit) (entity.Outfit, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.checkCoverPhoto(ctx, outfit.UserID, outfit.CoverPhotoID); err != nil {
+			return err
+		}
+
+		query := `
+INSERT INTO outfits (id, user_id, name, occasions, season, cover_photo_id)
+VALUES ($1, $2, $3, $4, $5, $6)`
+
+		_, err := r.db.GetTXFromContext(ctx).Exec(ctx, query,
+			outfit.ID, outfit.UserID, outfit.Name, outfit.Occasions, outfit.Season, outfit.CoverPhotoID)
+		if err != nil {
+			return fmt.Errorf("failed to insert outfit: %w", err)
+		}
+
+		if err := r.setItems(ctx, outfit.UserID, outfit.ID, outfit.Items); err != nil {
+			return err
+		}
+
+		outfit, err = r.GetOutfit(ctx, outfit.UserID, outfit.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("failed to create outfit: %w", err)
+	}
+
+	return outfit, nil
+}
+
+// checkCoverPhoto makes sure the cover is a ready file of the outfit's owner.
+func (r *Repo) checkCoverPhoto(ctx context.Context, userID entity.UserID, photoID *entity.FileID) error {
+	if photoID == nil {
+		return nil
+	}
+
+	query := `
+SELECT EXISTS (
+	SELECT 1
+	FROM files
+	WHERE TRUE
+		AND id = $1
+		AND user_id = $2
+		AND status = $3
+)`
+
+	var exists bool
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, *photoID, userID, entity.FileStatusReady).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check cover photo: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("%w: unknown cover photo", entity.ErrInvalidOutfit)
+	}
+
+	return nil
+}
+
+// setItems replaces the outfit's items, making sure every item belongs to the
+// outfit's owner and is not deleted.
+func (r *Repo) setItems(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID, items []entity.OutfitItem) error {
+	tx := r.db.GetTXFromContext(ctx)
+
+	ids := make([]uuid.UUID, 0, len(items))
+	slots := make([]string, 0, len(items))
+
+	for _, item := range items {
+		ids = append(ids, uuid.UUID(item.ItemID))
+		slots = append(slots, string(item.Slot))
+	}
+
+	var owned int
+
+	query := `
+SELECT COUNT(*)
+FROM wardrobe_items
+WHERE TRUE
+	AND id = ANY($1)
+	AND user_id = $2
+	AND deleted_at IS NULL`
+
+	if err := tx.QueryRow(ctx, query, ids, userID).Scan(&owned); err != nil {
+		return fmt.Errorf("failed to check outfit items: %w", err)
+	}
+
+	if owned != len(ids) {
+		return fmt.Errorf("%w: unknown wardrobe item", entity.ErrInvalidOutfit)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM outfit_items WHERE outfit_id = $1`, outfitID); err != nil {
+		return fmt.Errorf("failed to clear outfit items: %w", err)
+	}
+
+	query = `
+INSERT INTO outfit_items (outfit_id, item_id, slot, position)
+SELECT $1, item.id, item.slot, item.position
+FROM UNNEST($2::uuid[], $3::varchar[]) WITH ORDINALITY AS item(id, slot, position)`
+
+	if _, err := tx.Exec(ctx, query, outfitID, ids, slots); err != nil {
+		return fmt.Errorf("failed to set outfit items: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) (entity.Outfit, error) {
+	query := `
+SELECT ` + outfitColumns + `
+FROM outfits o
+WHERE TRUE
+	AND o.id = $1
+	AND o.user_id = $2
+	AND o.deleted_at IS NULL`
+
+	outfit, err := scanOutfit(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, outfitID, userID))
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("failed to get outfit %s: %w", outfitID, err)
+	}
+
+	return outfit, nil
+}
+
+func (r *Repo) ListOutfits(ctx context.Context, userID entity.UserID, filter entity.OutfitFilter) ([]entity.Outfit, error) {
+	var sb strings.Builder
+
+	params := []any{userID}
+
+	sb.WriteString(`
+SELECT ` + outfitColumns + `
+FROM outfits o
+WHERE TRUE
+	AND o.user_id = $1
+	AND o.deleted_at IS NULL`)
+
+	if filter.Occasion != nil {
+		params = append(params, *filter.Occasion)
+		sb.WriteString(fmt.Sprintf(" AND $%d = ANY(o.occasions)", len(params)))
+	}
+
+	if filter.Season != nil {
+		params = append(params, *filter.Season)
+		sb.WriteString(fmt.Sprintf(" AND o.season = $%d", len(params)))
+	}
+
+	params = append(params, filter.Limit, filter.Offset)
+	sb.WriteString(fmt.Sprintf(" ORDER BY o.created_at DESC, o.id LIMIT $%d OFFSET $%d", len(params)-1, len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outfits: %w", err)
+	}
+
+	defer rows.Close()
+
+	outfits := make([]entity.Outfit, 0, filter.Limit)
+
+	for rows.Next() {
+		outfit, err := scanOutfit(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		outfits = append(outfits, outfit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outfits: %w", err)
+	}
+
+	return outfits, nil
+}
+
+func (r *Repo) UpdateOutfit(
+	ctx context.Context,
+	userID entity.UserID,
+	outfitID entity.OutfitID,
+	update entity.OutfitUpdate,
+) (entity.Outfit, error) {
+	var outfit entity.Outfit
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		var (
+			sb     strings.Builder
+			params []any
+		)
+
+		updates := make([]string, 0, maxUpdates)
+
+		set := func(column string, value any) {
+			params = append(params, value)
+			updates = append(updates, fmt.Sprintf("%s = $%d", column, len(params)))
+		}
+
+		if update.Name != nil {
+			set("name", strings.TrimSpace(*update.Name))
+		}
+
+		if update.Occasions != nil {
+			set("occasions", *update.Occasions)
+		}
+
+		if update.Season != nil {
+			set("season", *update.Season)
+		}
+
+		if update.CoverPhotoID != nil {
+			if uuid.UUID(*update.CoverPhotoID) == uuid.Nil {
+				set("cover_photo_id", nil)
+			} else {
+				if err := r.checkCoverPhoto(ctx, userID, update.CoverPhotoID); err != nil {
+					return err
+				}
+
+				set("cover_photo_id", *update.CoverPhotoID)
+			}
+		}
+
+		set("updated_at", time.Now())
+
+		sb.WriteString("UPDATE outfits SET ")
+		sb.WriteString(strings.Join(updates, ", "))
+
+		params = append(params, outfitID, userID)
+		sb.WriteString(fmt.Sprintf(" WHERE id = $%d AND user_id = $%d AND deleted_at IS NULL", len(params)-1, len(params)))
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, sb.String(), params...)
+		if err != nil {
+			return fmt.Errorf("failed to update outfit: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrOutfitNotFound
+		}
+
+		if update.Items != nil {
+			if err := r.setItems(ctx, userID, outfitID, *update.Items); err != nil {
+				return err
+			}
+		}
+
+		outfit, err = r.GetOutfit(ctx, userID, outfitID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("failed to update outfit %s: %w", outfitID, err)
+	}
+
+	return outfit, nil
+}
+
+func (r *Repo) DeleteOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) error {
+	query := `
+UPDATE outfits
+SET deleted_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2
+	AND deleted_at IS NULL`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, outfitID, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting outfit %s: %w", outfitID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.ErrOutfitNotFound
+	}
+
+	return nil
+}
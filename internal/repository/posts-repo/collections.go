@@ -0,0 +1,310 @@
+package postsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// collectionColumns count only the saved posts that are still live.
+const collectionColumns = `
+	c.id, c.user_id, c.name,
+	(SELECT COUNT(*)` + livePosts + `
+		AND p.id IN (SELECT cp.post_id FROM collection_posts cp WHERE cp.collection_id = c.id)),
+	c.created_at, c.updated_at`
+
+func scanCollection(row pgx.Row) (entity.Collection, error) {
+	var collection entity.Collection
+
+	err := row.Scan(
+		&collection.ID,
+		&collection.UserID,
+		&collection.Name,
+		&collection.PostsCount,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Collection{}, entity.ErrCollectionNotFound
+		}
+
+		return entity.Collection{}, fmt.Errorf("failed to scan collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+func convertCollectionError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return entity.ErrDuplicateCollection
+	}
+
+	return fmt.Errorf("failed to save collection: %w", err)
+}
+
+func (r *Repo) CreateCollection(ctx context.Context, collection entity.Collection) (entity.Collection, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+INSERT INTO collections (id, user_id, name)
+VALUES ($1, $2, $3)`
+
+		if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query,
+			collection.ID, collection.UserID, collection.Name); err != nil {
+			return convertCollectionError(err)
+		}
+
+		var err error
+
+		collection, err = r.GetCollection(ctx, collection.UserID, collection.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+func (r *Repo) GetCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) (entity.Collection, error) {
+	query := `
+SELECT ` + collectionColumns + `
+FROM collections c
+WHERE TRUE
+	AND c.id = $1
+	AND c.user_id = $2`
+
+	collection, err := scanCollection(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, collectionID, userID))
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("failed to get collection %s: %w", collectionID, err)
+	}
+
+	return collection, nil
+}
+
+// ListCollections lists the user's collections, recently changed first.
+func (r *Repo) ListCollections(ctx context.Context, userID entity.UserID, filter entity.CollectionFilter) ([]entity.Collection, error) {
+	query := `
+SELECT ` + collectionColumns + `
+FROM collections c
+WHERE c.user_id = $1
+ORDER BY c.updated_at DESC, c.id
+LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, userID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	defer rows.Close()
+
+	collections := make([]entity.Collection, 0, filter.Limit)
+
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		collections = append(collections, collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate collections: %w", err)
+	}
+
+	return collections, nil
+}
+
+func (r *Repo) UpdateCollection(
+	ctx context.Context,
+	userID entity.UserID,
+	collectionID entity.CollectionID,
+	update entity.CollectionUpdate,
+) (entity.Collection, error) {
+	var collection entity.Collection
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if update.Name != nil {
+			query := `
+UPDATE collections
+SET name       = $3,
+	updated_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2`
+
+			tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, collectionID, userID, *update.Name)
+			if err != nil {
+				return convertCollectionError(err)
+			}
+
+			if tag.RowsAffected() == 0 {
+				return entity.ErrCollectionNotFound
+			}
+		}
+
+		var err error
+
+		collection, err = r.GetCollection(ctx, userID, collectionID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("failed to update collection %s: %w", collectionID, err)
+	}
+
+	return collection, nil
+}
+
+// lockCollection fails with ErrCollectionNotFound unless the user owns the
+// collection.
+func (r *Repo) lockCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) error {
+	query := `
+SELECT id
+FROM collections
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2
+FOR UPDATE`
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, collectionID, userID).Scan(&collectionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrCollectionNotFound
+		}
+
+		return fmt.Errorf("failed to get collection: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCollection removes the collection and takes its saves off the posts'
+// counters.
+func (r *Repo) DeleteCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.lockCollection(ctx, userID, collectionID); err != nil {
+			return err
+		}
+
+		tx := r.db.GetTXFromContext(ctx)
+
+		for _, query := range []string{
+			`
+UPDATE posts p
+SET saves_count = p.saves_count - 1
+FROM collection_posts cp
+WHERE TRUE
+	AND cp.collection_id = $1
+	AND cp.post_id = p.id`,
+			`DELETE FROM collection_posts WHERE collection_id = $1`,
+			`DELETE FROM collections WHERE id = $1`,
+		} {
+			if _, err := tx.Exec(ctx, query, collectionID); err != nil {
+				return fmt.Errorf("failed to delete collection: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete collection %s: %w", collectionID, err)
+	}
+
+	return nil
+}
+
+// SavePost is a no-op when the post is already in the collection.
+func (r *Repo) SavePost(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error {
+	return r.toggleSave(ctx, userID, collectionID, postID, true)
+}
+
+// UnsavePost is a no-op when the post is not in the collection.
+func (r *Repo) UnsavePost(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error {
+	return r.toggleSave(ctx, userID, collectionID, postID, false)
+}
+
+func (r *Repo) toggleSave(
+	ctx context.Context,
+	userID entity.UserID,
+	collectionID entity.CollectionID,
+	postID entity.PostID,
+	save bool,
+) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.lockCollection(ctx, userID, collectionID); err != nil {
+			return err
+		}
+
+		tx := r.db.GetTXFromContext(ctx)
+
+		query, delta := `
+INSERT INTO collection_posts (collection_id, post_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING`, 1
+
+		if save {
+			if err := r.checkVisible(ctx, userID, nil, postID); err != nil {
+				return err
+			}
+		} else {
+			query, delta = `
+DELETE FROM collection_posts
+WHERE TRUE
+	AND collection_id = $1
+	AND post_id = $2`, -1
+		}
+
+		tag, err := tx.Exec(ctx, query, collectionID, postID)
+		if err != nil {
+			return fmt.Errorf("failed to save post: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		query = `
+UPDATE posts
+SET saves_count = saves_count + $2
+WHERE id = $1`
+
+		if _, err := tx.Exec(ctx, query, postID, delta); err != nil {
+			return fmt.Errorf("failed to count saves: %w", err)
+		}
+
+		query = `UPDATE collections SET updated_at = NOW() WHERE id = $1`
+
+		if _, err := tx.Exec(ctx, query, collectionID); err != nil {
+			return fmt.Errorf("failed to touch collection: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save post %s: %w", postID, err)
+	}
+
+	return nil
+}
+
+// ListCollectionPosts pages through the posts saved in the collection that
+// the user may still see, newest first.
+func (r *Repo) ListCollectionPosts(
+	ctx context.Context,
+	userID entity.UserID,
+	collectionID entity.CollectionID,
+	page entity.Page,
+) ([]entity.Post, *string, error) {
+	condition := `p.id IN (SELECT cp.post_id FROM collection_posts cp WHERE cp.collection_id = $2) AND ` + visibleTo(1)
+
+	return r.list(ctx, condition, []any{userID, collectionID}, page)
+}
@@ -0,0 +1,325 @@
+package postsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// commentColumns hide the body of deleted comments, comments hidden by
+// moderators and comments by deleted users.
+const commentColumns = `
+	c.id, c.post_id, c.user_id,
+	CASE WHEN u.deleted_at IS NULL THEN u.nick_name ELSE '' END,
+	c.parent_id,
+	CASE WHEN c.deleted_at IS NULL AND c.hidden_at IS NULL AND u.deleted_at IS NULL THEN c.body END,
+	c.deleted_at IS NOT NULL OR c.hidden_at IS NOT NULL OR u.deleted_at IS NOT NULL,
+	(SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id AND r.deleted_at IS NULL AND r.hidden_at IS NULL),
+	c.created_at, c.updated_at
+FROM comments c
+	JOIN users u ON u.id = c.user_id`
+
+func scanComment(row pgx.Row) (entity.Comment, error) {
+	var comment entity.Comment
+
+	err := row.Scan(
+		&comment.ID,
+		&comment.PostID,
+		&comment.AuthorID,
+		&comment.AuthorNickName,
+		&comment.ParentID,
+		&comment.Body,
+		&comment.Deleted,
+		&comment.RepliesCount,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Comment{}, entity.ErrCommentNotFound
+		}
+
+		return entity.Comment{}, fmt.Errorf("failed to scan comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *Repo) getComment(ctx context.Context, commentID entity.CommentID) (entity.Comment, error) {
+	query := `SELECT ` + commentColumns + ` WHERE c.id = $1`
+
+	return scanComment(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, commentID))
+}
+
+// CreateComment adds the viewer's comment to the author's post. A reply to a
+// reply is attached to the top-level comment of its thread.
+func (r *Repo) CreateComment(ctx context.Context, authorID entity.UserID, comment entity.Comment) (entity.Comment, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.checkVisible(ctx, comment.AuthorID, &authorID, comment.PostID); err != nil {
+			return err
+		}
+
+		tx := r.db.GetTXFromContext(ctx)
+
+		if comment.ParentID != nil {
+			query := `
+SELECT COALESCE(parent_id, id)
+FROM comments
+WHERE TRUE
+	AND id = $1
+	AND post_id = $2
+	AND deleted_at IS NULL
+	AND hidden_at IS NULL`
+
+			var rootID entity.CommentID
+
+			err := tx.QueryRow(ctx, query, *comment.ParentID, comment.PostID).Scan(&rootID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return fmt.Errorf("%w: unknown parent comment", entity.ErrInvalidComment)
+				}
+
+				return fmt.Errorf("failed to get parent comment: %w", err)
+			}
+
+			comment.ParentID = &rootID
+		}
+
+		query := `
+INSERT INTO comments (id, post_id, user_id, parent_id, body)
+VALUES ($1, $2, $3, $4, $5)`
+
+		if _, err := tx.Exec(ctx, query,
+			comment.ID, comment.PostID, comment.AuthorID, comment.ParentID, comment.Body); err != nil {
+			return fmt.Errorf("failed to insert comment: %w", err)
+		}
+
+		if err := r.countComments(ctx, comment.PostID, 1); err != nil {
+			return err
+		}
+
+		var err error
+
+		comment, err = r.getComment(ctx, comment.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Comment{}, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *Repo) countComments(ctx context.Context, postID entity.PostID, delta int) error {
+	query := `
+UPDATE posts
+SET comments_count = comments_count + $2
+WHERE id = $1`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, postID, delta); err != nil {
+		return fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	return nil
+}
+
+// ListComments pages through the top-level comments of the post, or the
+// replies to parentID, oldest first.
+func (r *Repo) ListComments(
+	ctx context.Context,
+	viewerID, authorID entity.UserID,
+	postID entity.PostID,
+	parentID *entity.CommentID,
+	page entity.Page,
+) ([]entity.Comment, *string, error) {
+	after, err := entity.ParseCursor(page.Cursor)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	if err := r.checkVisible(ctx, viewerID, &authorID, postID); err != nil {
+		return nil, nil, err
+	}
+
+	var sb strings.Builder
+
+	params := []any{postID}
+
+	sb.WriteString(`SELECT ` + commentColumns + `
+WHERE c.post_id = $1`)
+
+	if parentID != nil {
+		query := `SELECT EXISTS (SELECT 1 FROM comments WHERE id = $1 AND post_id = $2 AND parent_id IS NULL)`
+
+		var exists bool
+
+		if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, *parentID, postID).Scan(&exists); err != nil {
+			return nil, nil, fmt.Errorf("failed to check comment %s: %w", *parentID, err)
+		}
+
+		if !exists {
+			return nil, nil, entity.ErrCommentNotFound
+		}
+
+		params = append(params, *parentID)
+		sb.WriteString(fmt.Sprintf(" AND c.parent_id = $%d", len(params)))
+	} else {
+		sb.WriteString(" AND c.parent_id IS NULL")
+	}
+
+	if after != nil {
+		params = append(params, after.CreatedAt, after.ID)
+		sb.WriteString(fmt.Sprintf(" AND (c.created_at, c.id) > ($%d, $%d)", len(params)-1, len(params)))
+	}
+
+	params = append(params, page.Limit+1)
+	sb.WriteString(fmt.Sprintf(" ORDER BY c.created_at, c.id LIMIT $%d", len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	defer rows.Close()
+
+	comments := make([]entity.Comment, 0, page.Limit)
+
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate comments: %w", err)
+	}
+
+	if len(comments) <= page.Limit {
+		return comments, nil, nil
+	}
+
+	comments = comments[:page.Limit]
+	last := comments[len(comments)-1]
+	next := entity.Cursor{CreatedAt: last.CreatedAt, ID: uuid.UUID(last.ID)}.String()
+
+	return comments, &next, nil
+}
+
+// lockComment returns the commenter of a live comment on a live post of the
+// author.
+func (r *Repo) lockComment(
+	ctx context.Context,
+	authorID entity.UserID,
+	postID entity.PostID,
+	commentID entity.CommentID,
+) (entity.UserID, error) {
+	query := `
+SELECT c.user_id
+FROM comments c
+	JOIN posts p ON p.id = c.post_id AND p.deleted_at IS NULL
+WHERE TRUE
+	AND c.id = $1
+	AND c.post_id = $2
+	AND p.user_id = $3
+	AND c.deleted_at IS NULL
+	AND c.hidden_at IS NULL
+FOR UPDATE OF c`
+
+	var commenterID entity.UserID
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, commentID, postID, authorID).Scan(&commenterID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.UserID{}, entity.ErrCommentNotFound
+		}
+
+		return entity.UserID{}, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return commenterID, nil
+}
+
+// UpdateComment lets only the commenter edit the comment.
+func (r *Repo) UpdateComment(
+	ctx context.Context,
+	userID, authorID entity.UserID,
+	postID entity.PostID,
+	commentID entity.CommentID,
+	update entity.CommentUpdate,
+) (entity.Comment, error) {
+	var comment entity.Comment
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		commenterID, err := r.lockComment(ctx, authorID, postID, commentID)
+		if err != nil {
+			return err
+		}
+
+		if commenterID != userID {
+			return entity.ErrForbidden
+		}
+
+		query := `
+UPDATE comments
+SET body       = $2,
+	updated_at = NOW()
+WHERE id = $1`
+
+		if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, commentID, update.Body); err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+
+		comment, err = r.getComment(ctx, commentID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Comment{}, fmt.Errorf("failed to update comment %s: %w", commentID, err)
+	}
+
+	return comment, nil
+}
+
+// DeleteComment soft-deletes the comment. The commenter and the author of the
+// post may delete it. Replies stay in the thread.
+func (r *Repo) DeleteComment(
+	ctx context.Context,
+	userID, authorID entity.UserID,
+	postID entity.PostID,
+	commentID entity.CommentID,
+) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		commenterID, err := r.lockComment(ctx, authorID, postID, commentID)
+		if err != nil {
+			return err
+		}
+
+		if commenterID != userID && authorID != userID {
+			return entity.ErrForbidden
+		}
+
+		query := `
+UPDATE comments
+SET deleted_at = NOW()
+WHERE id = $1`
+
+		if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, commentID); err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+
+		return r.countComments(ctx, postID, -1)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete comment %s: %w", commentID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package postsrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// checkVisible fails with ErrPostNotFound unless the post is live and the
+// viewer may see it. A nil author matches a post of any author.
+func (r *Repo) checkVisible(ctx context.Context, viewerID entity.UserID, authorID *entity.UserID, postID entity.PostID) error {
+	query := `
+SELECT EXISTS (SELECT 1` + livePosts + `
+	AND p.id = $2
+	AND ($3::UUID IS NULL OR p.user_id = $3)
+	AND ` + visibleTo(1) + `
+)`
+
+	var exists bool
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, viewerID, postID, authorID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check post %s: %w", postID, err)
+	}
+
+	if !exists {
+		return entity.ErrPostNotFound
+	}
+
+	return nil
+}
+
+// Like is a no-op when the viewer already likes the post.
+func (r *Repo) Like(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error) {
+	return r.toggleLike(ctx, viewerID, authorID, postID, true)
+}
+
+// Unlike is a no-op when the viewer does not like the post.
+func (r *Repo) Unlike(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error) {
+	return r.toggleLike(ctx, viewerID, authorID, postID, false)
+}
+
+// toggleLike changes likes_count in the same transaction as post_likes and
+// only when a like was actually added or removed, so repeated requests keep
+// the counter in step with the likes.
+func (r *Repo) toggleLike(
+	ctx context.Context,
+	viewerID, authorID entity.UserID,
+	postID entity.PostID,
+	like bool,
+) (entity.LikeState, error) {
+	state := entity.LikeState{Liked: like}
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.checkVisible(ctx, viewerID, &authorID, postID); err != nil {
+			return err
+		}
+
+		tx := r.db.GetTXFromContext(ctx)
+
+		query, delta := `
+INSERT INTO post_likes (post_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING`, 1
+
+		if !like {
+			query, delta = `
+DELETE FROM post_likes
+WHERE TRUE
+	AND post_id = $1
+	AND user_id = $2`, -1
+		}
+
+		tag, err := tx.Exec(ctx, query, postID, viewerID)
+		if err != nil {
+			return fmt.Errorf("failed to save like: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			delta = 0
+		}
+
+		query = `
+UPDATE posts
+SET likes_count = likes_count + $2
+WHERE id = $1
+RETURNING likes_count`
+
+		if err := tx.QueryRow(ctx, query, postID, delta).Scan(&state.LikesCount); err != nil {
+			return fmt.Errorf("failed to count likes: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.LikeState{}, fmt.Errorf("failed to like post %s: %w", postID, err)
+	}
+
+	return state, nil
+}
@@ -0,0 +1,281 @@
+package postsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const (
+	maxUpdates = 3
+
+	// postColumns expects the viewer as the first parameter.
+	postColumns = `
+	p.id, p.user_id, u.nick_name, p.outfit_id, o.name,
+	(SELECT f.id FROM files f WHERE f.id = o.cover_photo_id AND f.status = 'ready'),
+	p.caption, p.visibility, p.likes_count, p.comments_count, p.saves_count,
+	EXISTS (SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = $1),
+	p.created_at, p.updated_at`
+
+	// livePosts leaves out posts of deleted users and deleted outfits, and
+	// posts hidden by moderators.
+	livePosts = `
+FROM posts p
+	JOIN users u ON u.id = p.user_id AND u.deleted_at IS NULL
+	JOIN outfits o ON o.id = p.outfit_id AND o.deleted_at IS NULL
+WHERE p.deleted_at IS NULL
+	AND p.hidden_at IS NULL`
+)
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// visibleTo is the condition for posts the viewer, passed as parameter n, may
+// see.
+func visibleTo(n int) string {
+	return fmt.Sprintf(`(p.user_id = $%[1]d OR p.visibility = 'public' OR (p.visibility = 'followers' AND EXISTS (
+	SELECT 1 FROM follows fl WHERE fl.follower_id = $%[1]d AND fl.followee_id = p.user_id
+)))`, n)
+}
+
+func scanPost(row pgx.Row) (entity.Post, error) {
+	var post entity.Post
+
+	err := row.Scan(
+		&post.ID,
+		&post.AuthorID,
+		&post.AuthorNickName,
+		&post.OutfitID,
+		&post.OutfitName,
+		&post.CoverPhotoID,
+		&post.Caption,
+		&post.Visibility,
+		&post.LikesCount,
+		&post.CommentsCount,
+		&post.SavesCount,
+		&post.Liked,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Post{}, entity.ErrPostNotFound
+		}
+
+		return entity.Post{}, fmt.Errorf("failed to scan post: %w", err)
+	}
+
+	return post, nil
+}
+
+func (r *Repo) CreatePost(ctx context.Context, post entity.Post) (entity.Post, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+INSERT INTO posts (id, user_id, outfit_id, caption, visibility)
+SELECT $1, o.user_id, o.id, $4, $5
+FROM outfits o
+WHERE TRUE
+	AND o.id = $2
+	AND o.user_id = $3
+	AND o.deleted_at IS NULL`
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query,
+			post.ID, post.OutfitID, post.AuthorID, post.Caption, post.Visibility)
+		if err != nil {
+			return fmt.Errorf("failed to insert post: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("%w: unknown outfit", entity.ErrInvalidPost)
+		}
+
+		post, err = r.GetPost(ctx, post.AuthorID, post.AuthorID, post.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Post{}, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	return post, nil
+}
+
+// GetPost returns the author's post if the viewer may see it.
+func (r *Repo) GetPost(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.Post, error) {
+	query := `
+SELECT ` + postColumns + livePosts + `
+	AND p.id = $2
+	AND p.user_id = $3
+	AND ` + visibleTo(1)
+
+	post, err := scanPost(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, viewerID, postID, authorID))
+	if err != nil {
+		return entity.Post{}, fmt.Errorf("failed to get post %s: %w", postID, err)
+	}
+
+	return post, nil
+}
+
+// ListPosts pages through the author's posts the viewer may see.
+func (r *Repo) ListPosts(ctx context.Context, viewerID, authorID entity.UserID, page entity.Page) ([]entity.Post, *string, error) {
+	return r.list(ctx, "p.user_id = $2 AND "+visibleTo(1), []any{viewerID, authorID}, page)
+}
+
+// Feed pages through the user's own posts and the posts of everyone the user
+// follows, newest first.
+func (r *Repo) Feed(ctx context.Context, userID entity.UserID, page entity.Page) ([]entity.Post, *string, error) {
+	condition := `(p.user_id = $1 OR (p.visibility <> 'private' AND EXISTS (
+	SELECT 1 FROM follows fl WHERE fl.follower_id = $1 AND fl.followee_id = p.user_id
+)))`
+
+	return r.list(ctx, condition, []any{userID}, page)
+}
+
+func (r *Repo) list(ctx context.Context, condition string, params []any, page entity.Page) ([]entity.Post, *string, error) {
+	after, err := entity.ParseCursor(page.Cursor)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(`
+SELECT ` + postColumns + livePosts + `
+	AND ` + condition)
+
+	if after != nil {
+		params = append(params, after.CreatedAt, after.ID)
+		sb.WriteString(fmt.Sprintf(" AND (p.created_at, p.id) < ($%d, $%d)", len(params)-1, len(params)))
+	}
+
+	params = append(params, page.Limit+1)
+	sb.WriteString(fmt.Sprintf(" ORDER BY p.created_at DESC, p.id DESC LIMIT $%d", len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list posts: %w", err)
+	}
+
+	defer rows.Close()
+
+	posts := make([]entity.Post, 0, page.Limit)
+
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate posts: %w", err)
+	}
+
+	if len(posts) <= page.Limit {
+		return posts, nil, nil
+	}
+
+	posts = posts[:page.Limit]
+	last := posts[len(posts)-1]
+	next := entity.Cursor{CreatedAt: last.CreatedAt, ID: uuid.UUID(last.ID)}.String()
+
+	return posts, &next, nil
+}
+
+func (r *Repo) UpdatePost(ctx context.Context, userID entity.UserID, postID entity.PostID, update entity.PostUpdate) (entity.Post, error) {
+	var post entity.Post
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		var (
+			sb     strings.Builder
+			params []any
+		)
+
+		updates := make([]string, 0, maxUpdates)
+
+		set := func(column string, value any) {
+			params = append(params, value)
+			updates = append(updates, fmt.Sprintf("%s = $%d", column, len(params)))
+		}
+
+		if update.Caption != nil {
+			set("caption", *update.Caption)
+		}
+
+		if update.Visibility != nil {
+			set("visibility", *update.Visibility)
+		}
+
+		set("updated_at", time.Now())
+
+		sb.WriteString("UPDATE posts SET ")
+		sb.WriteString(strings.Join(updates, ", "))
+
+		params = append(params, postID, userID)
+		sb.WriteString(fmt.Sprintf(" WHERE id = $%d AND user_id = $%d AND deleted_at IS NULL", len(params)-1, len(params)))
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, sb.String(), params...)
+		if err != nil {
+			return fmt.Errorf("failed to update post: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrPostNotFound
+		}
+
+		post, err = r.GetPost(ctx, userID, userID, postID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Post{}, fmt.Errorf("failed to update post %s: %w", postID, err)
+	}
+
+	return post, nil
+}
+
+func (r *Repo) DeletePost(ctx context.Context, userID entity.UserID, postID entity.PostID) error {
+	query := `
+UPDATE posts
+SET deleted_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2
+	AND deleted_at IS NULL`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, postID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete post %s: %w", postID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.ErrPostNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,374 @@
+package searchrepo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const itemColumns = `
+	i.id, i.user_id, i.name, i.category, i.subcategory, i.brand, i.size, i.colors, i.material, i.season,
+	i.purchase_date, i.price, i.currency, i.notes, i.attributes,
+	ARRAY(
+		SELECT p.file_id
+		FROM wardrobe_item_photos p
+			JOIN files f ON f.id = p.file_id AND f.status = 'ready'
+		WHERE p.item_id = i.id
+		ORDER BY p.position
+	),
+	i.archived_at, i.created_at, i.updated_at`
+
+type facet string
+
+const (
+	facetCategory facet = "category"
+	facetColor    facet = "color"
+	facetSize     facet = "size"
+	facetSeason   facet = "season"
+	facetBrand    facet = "brand"
+)
+
+//nolint:gochecknoglobals
+var facets = []facet{facetCategory, facetColor, facetSize, facetSeason, facetBrand}
+
+type database interface {
+	Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error)
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// cursor points at the last item of a page. Text searches are ordered by rank,
+// the others by creation time, and the id breaks ties in both.
+type cursor struct {
+	Rank      *float32   `json:"rank,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	ID        uuid.UUID  `json:"id"`
+}
+
+func encodeCursor(c cursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(value string, ranked bool) (*cursor, error) {
+	if value == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", entity.ErrInvalidSearchQuery)
+	}
+
+	var c cursor
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", entity.ErrInvalidSearchQuery)
+	}
+
+	if (ranked && c.Rank == nil) || (!ranked && c.CreatedAt == nil) {
+		return nil, fmt.Errorf("%w: cursor belongs to another query", entity.ErrInvalidSearchQuery)
+	}
+
+	return &c, nil
+}
+
+// filters keeps the conditions of a search apart per facet, so every facet can
+// be counted without its own filter.
+type filters struct {
+	params  []any
+	base    []string
+	byFacet map[facet]string
+	rank    string
+}
+
+func newFilters(userID entity.UserID, query entity.SearchQuery) *filters {
+	f := &filters{
+		params:  []any{userID},
+		base:    []string{"i.user_id = $1", "i.deleted_at IS NULL", "i.archived_at IS NULL"},
+		byFacet: make(map[facet]string),
+		rank:    "0::real",
+	}
+
+	if query.Text != "" {
+		n := f.add(query.Text)
+		tsQuery := fmt.Sprintf("(websearch_to_tsquery('russian', $%d) || websearch_to_tsquery('english', $%d))", n, n)
+
+		f.base = append(f.base, "i.search_vector @@ "+tsQuery)
+		f.rank = "ts_rank(i.search_vector, " + tsQuery + ")"
+	}
+
+	if len(query.Categories) > 0 {
+		n := f.add(query.Categories)
+		f.byFacet[facetCategory] = fmt.Sprintf("(i.category = ANY($%d) OR i.subcategory = ANY($%d))", n, n)
+	}
+
+	if len(query.Colors) > 0 {
+		f.byFacet[facetColor] = fmt.Sprintf("i.colors && $%d::varchar[]", f.add(query.Colors))
+	}
+
+	if len(query.Sizes) > 0 {
+		f.byFacet[facetSize] = fmt.Sprintf("i.size = ANY($%d)", f.add(query.Sizes))
+	}
+
+	if len(query.Seasons) > 0 {
+		seasons := make([]string, 0, len(query.Seasons))
+		for _, season := range query.Seasons {
+			seasons = append(seasons, string(season))
+		}
+
+		f.byFacet[facetSeason] = fmt.Sprintf("i.season = ANY($%d)", f.add(seasons))
+	}
+
+	if len(query.Brands) > 0 {
+		f.byFacet[facetBrand] = fmt.Sprintf("i.brand = ANY($%d)", f.add(query.Brands))
+	}
+
+	return f
+}
+
+func (f *filters) add(value any) int {
+	f.params = append(f.params, value)
+
+	return len(f.params)
+}
+
+// where joins the conditions of all facets but except.
+func (f *filters) where(except facet) string {
+	conditions := append([]string{}, f.base...)
+
+	for _, fc := range facets {
+		if condition, ok := f.byFacet[fc]; ok && fc != except {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// SearchItems returns a page of matching items and the cursor of the next
+// page, if there is one.
+func (r *Repo) SearchItems(ctx context.Context, userID entity.UserID, query entity.SearchQuery) ([]entity.WardrobeItem, *string, error) {
+	ranked := query.Text != ""
+
+	after, err := decodeCursor(query.Cursor, ranked)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f := newFilters(userID, query)
+	where := f.where("")
+
+	var orderBy string
+
+	switch {
+	case ranked:
+		orderBy = f.rank + " DESC, i.id DESC"
+
+		if after != nil {
+			where += fmt.Sprintf(" AND (%s, i.id) < ($%d::real, $%d)", f.rank, f.add(*after.Rank), f.add(after.ID))
+		}
+	default:
+		orderBy = "i.created_at DESC, i.id DESC"
+
+		if after != nil {
+			where += fmt.Sprintf(" AND (i.created_at, i.id) < ($%d, $%d)", f.add(*after.CreatedAt), f.add(after.ID))
+		}
+	}
+
+	sql := fmt.Sprintf(`
+SELECT %s, %s
+FROM wardrobe_items i
+WHERE %s
+ORDER BY %s
+LIMIT $%d`, itemColumns, f.rank, where, orderBy, f.add(query.Limit+1))
+
+	rows, err := r.db.Query(ctx, sql, f.params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search wardrobe items: %w", err)
+	}
+
+	defer rows.Close()
+
+	var (
+		items = make([]entity.WardrobeItem, 0, query.Limit)
+		ranks = make([]float32, 0, query.Limit)
+	)
+
+	for rows.Next() {
+		item, rank, err := scanItem(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items = append(items, item)
+		ranks = append(ranks, rank)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate wardrobe items: %w", err)
+	}
+
+	if len(items) <= query.Limit {
+		return items, nil, nil
+	}
+
+	items = items[:query.Limit]
+	last := items[len(items)-1]
+
+	next := cursor{ID: uuid.UUID(last.ID)}
+	if ranked {
+		next.Rank = &ranks[len(items)-1]
+	} else {
+		next.CreatedAt = &last.CreatedAt
+	}
+
+	nextCursor, err := encodeCursor(next)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, &nextCursor, nil
+}
+
+// CountFacets counts the items matching the query per facet value, ignoring
+// the cursor and the facet's own filter.
+func (r *Repo) CountFacets(ctx context.Context, userID entity.UserID, query entity.SearchQuery) (entity.SearchFacets, error) {
+	f := newFilters(userID, query)
+
+	sql := fmt.Sprintf(`
+SELECT '%s', i.category, COUNT(*)
+FROM wardrobe_items i
+WHERE %s
+GROUP BY i.category
+UNION ALL
+SELECT '%s', color, COUNT(DISTINCT i.id)
+FROM wardrobe_items i
+	CROSS JOIN UNNEST(i.colors) AS color
+WHERE %s
+GROUP BY color
+UNION ALL
+SELECT '%s', i.size, COUNT(*)
+FROM wardrobe_items i
+WHERE %s AND i.size IS NOT NULL
+GROUP BY i.size
+UNION ALL
+SELECT '%s', i.season, COUNT(*)
+FROM wardrobe_items i
+WHERE %s AND i.season IS NOT NULL
+GROUP BY i.season
+UNION ALL
+SELECT '%s', i.brand, COUNT(*)
+FROM wardrobe_items i
+WHERE %s AND i.brand IS NOT NULL
+GROUP BY i.brand
+ORDER BY 1, 3 DESC, 2`,
+		facetCategory, f.where(facetCategory),
+		facetColor, f.where(facetColor),
+		facetSize, f.where(facetSize),
+		facetSeason, f.where(facetSeason),
+		facetBrand, f.where(facetBrand),
+	)
+
+	rows, err := r.db.Query(ctx, sql, f.params...)
+	if err != nil {
+		return entity.SearchFacets{}, fmt.Errorf("failed to count search facets: %w", err)
+	}
+
+	defer rows.Close()
+
+	result := entity.SearchFacets{
+		Categories: []entity.FacetCount{},
+		Colors:     []entity.FacetCount{},
+		Sizes:      []entity.FacetCount{},
+		Seasons:    []entity.FacetCount{},
+		Brands:     []entity.FacetCount{},
+	}
+
+	byFacet := map[facet]*[]entity.FacetCount{
+		facetCategory: &result.Categories,
+		facetColor:    &result.Colors,
+		facetSize:     &result.Sizes,
+		facetSeason:   &result.Seasons,
+		facetBrand:    &result.Brands,
+	}
+
+	for rows.Next() {
+		var (
+			name  facet
+			count entity.FacetCount
+		)
+
+		if err := rows.Scan(&name, &count.Value, &count.Count); err != nil {
+			return entity.SearchFacets{}, fmt.Errorf("failed to scan facet count: %w", err)
+		}
+
+		*byFacet[name] = append(*byFacet[name], count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entity.SearchFacets{}, fmt.Errorf("failed to iterate facet counts: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanItem(row pgx.Row) (entity.WardrobeItem, float32, error) {
+	var (
+		item     entity.WardrobeItem
+		photoIDs []uuid.UUID
+		rank     float32
+	)
+
+	err := row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.Name,
+		&item.Category,
+		&item.Subcategory,
+		&item.Brand,
+		&item.Size,
+		&item.Colors,
+		&item.Material,
+		&item.Season,
+		&item.PurchaseDate,
+		&item.Price,
+		&item.Currency,
+		&item.Notes,
+		&item.Attributes,
+		&photoIDs,
+		&item.ArchivedAt,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+		&rank,
+	)
+	if err != nil {
+		return entity.WardrobeItem{}, 0, fmt.Errorf("failed to scan wardrobe item: %w", err)
+	}
+
+	item.PhotoIDs = make([]entity.FileID, 0, len(photoIDs))
+	for _, photoID := range photoIDs {
+		item.PhotoIDs = append(item.PhotoIDs, entity.FileID(photoID))
+	}
+
+	return item, rank, nil
+}
@@ -0,0 +1,101 @@
+package sizesrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const profileColumns = `
+	user_id, chart, region, height_cm, weight_kg, chest_cm, waist_cm, hips_cm, shoe_size, preferred_fit, updated_at`
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanProfile(row pgx.Row) (entity.SizeProfile, error) {
+	var profile entity.SizeProfile
+
+	err := row.Scan(
+		&profile.UserID,
+		&profile.Chart,
+		&profile.Region,
+		&profile.HeightCM,
+		&profile.WeightKG,
+		&profile.ChestCM,
+		&profile.WaistCM,
+		&profile.HipsCM,
+		&profile.ShoeSize,
+		&profile.PreferredFit,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.SizeProfile{}, entity.ErrSizeProfileNotFound
+		}
+
+		return entity.SizeProfile{}, fmt.Errorf("failed to scan size profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+func (r *Repo) GetProfile(ctx context.Context, userID entity.UserID) (entity.SizeProfile, error) {
+	query := `
+SELECT ` + profileColumns + `
+FROM size_profiles
+WHERE user_id = $1`
+
+	profile, err := scanProfile(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, userID))
+	if err != nil {
+		return entity.SizeProfile{}, fmt.Errorf("failed to get size profile of user %s: %w", userID, err)
+	}
+
+	return profile, nil
+}
+
+// SaveProfile creates the user's profile or replaces every field of it.
+func (r *Repo) SaveProfile(ctx context.Context, profile entity.SizeProfile) (entity.SizeProfile, error) {
+	query := `
+INSERT INTO size_profiles (user_id, chart, region, height_cm, weight_kg, chest_cm, waist_cm, hips_cm, shoe_size,
+	preferred_fit)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (user_id) DO UPDATE
+SET chart = EXCLUDED.chart,
+	region = EXCLUDED.region,
+	height_cm = EXCLUDED.height_cm,
+	weight_kg = EXCLUDED.weight_kg,
+	chest_cm = EXCLUDED.chest_cm,
+	waist_cm = EXCLUDED.waist_cm,
+	hips_cm = EXCLUDED.hips_cm,
+	shoe_size = EXCLUDED.shoe_size,
+	preferred_fit = EXCLUDED.preferred_fit,
+	updated_at = NOW()
+RETURNING ` + profileColumns
+
+	saved, err := scanProfile(r.db.GetTXFromContext(ctx).QueryRow(ctx, query,
+		profile.UserID, profile.Chart, profile.Region, profile.HeightCM, profile.WeightKG, profile.ChestCM,
+		profile.WaistCM, profile.HipsCM, profile.ShoeSize, profile.PreferredFit))
+	if err != nil {
+		return entity.SizeProfile{}, fmt.Errorf("failed to save size profile of user %s: %w", profile.UserID, err)
+	}
+
+	return saved, nil
+}
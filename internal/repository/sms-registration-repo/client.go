@@ -51,10 +51,16 @@ type Response struct {
 }
 
 func (s *SMSService) SendOTP(ctx context.Context, phone string, otp string) error {
+	return s.SendSMS(ctx, phone, EnrichOTP(otp))
+}
+
+// SendSMS sends an arbitrary text message through the same gateway as the
+// verification codes.
+func (s *SMSService) SendSMS(ctx context.Context, phone string, text string) error {
 	data := url.Values{}
 	data.Set("number", phone)
 	data.Set("sign", s.cfg.Sender)
-	data.Set("text", EnrichOTP(otp))
+	data.Set("text", text)
 
 	endpoint := "send"
 	if s.cfg.TestMode {
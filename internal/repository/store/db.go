@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -19,12 +20,16 @@ import (
 var migrations embed.FS
 
 type DataStore struct {
-	pool *pgxpool.Pool
-	dsn  string
+	pool      *pgxpool.Pool
+	dsn       string
+	txManager *TxManager
 }
 
 type Config struct {
-	Dsn string
+	Dsn          string
+	TxIsoLevel   pgx.TxIsoLevel
+	TxMaxRetries int
+	TxRetryDelay time.Duration
 }
 
 func New(ctx context.Context, cfg Config) (*DataStore, error) {
@@ -42,6 +47,11 @@ func New(ctx context.Context, cfg Config) (*DataStore, error) {
 	return &DataStore{
 		pool: pool,
 		dsn:  cfg.Dsn,
+		txManager: NewTxManager(pool, TxConfig{
+			IsoLevel:   cfg.TxIsoLevel,
+			MaxRetries: cfg.TxMaxRetries,
+			RetryDelay: cfg.TxRetryDelay,
+		}),
 	}, nil
 }
 
@@ -142,6 +152,37 @@ func (d *DataStore) QueryRow(ctx context.Context, sql string, arguments ...any)
 	return d.pool.QueryRow(ctx, sql, arguments...)
 }
 
+// Listen calls handle with the payload of every notification sent to the
+// channel until ctx is done or the connection fails. The connection is taken
+// out of the pool for good, so that it does not go back still listening.
+func (d *DataStore) Listen(ctx context.Context, channel string, handle func(payload string)) error {
+	pooled, err := d.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	conn := pooled.Hijack()
+
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			log.Warn().Err(err).Msg("failed to close listening connection")
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen to %s: %w", channel, err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+
+		handle(notification.Payload)
+	}
+}
+
 type Transaction interface {
 	Exec(ctx context.Context, query string, arguments ...any) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error)
@@ -161,3 +202,11 @@ func (d *DataStore) GetTXFromContext(ctx context.Context) Transaction {
 
 	return tx
 }
+
+// WithTx runs fn inside a transaction stored in the context, so repositories
+// picking it up with GetTXFromContext share it. Transactions use the
+// configured isolation level and are retried when they fail to serialize;
+// a transaction already in the context is continued in a savepoint.
+func (d *DataStore) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return d.txManager.Do(ctx, fn)
+}
@@ -0,0 +1,31 @@
+-- +migrate Up
+CREATE TABLE files
+(
+    id           UUID PRIMARY KEY,
+    user_id      UUID                     NOT NULL REFERENCES users (id),
+    bucket_key   VARCHAR                  NOT NULL,
+    content_type VARCHAR                  NOT NULL,
+    size         BIGINT                   NOT NULL DEFAULT 0,
+    checksum     VARCHAR                  NOT NULL DEFAULT '',
+    status       VARCHAR                  NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'ready', 'deleted')),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX files_unique_bucket_key_idx
+    ON files (bucket_key);
+
+CREATE INDEX files_user_id_idx
+    ON files (user_id)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX files_status_updated_at_idx
+    ON files (status, updated_at);
+
+-- +migrate Down
+DROP INDEX files_status_updated_at_idx;
+DROP INDEX files_user_id_idx;
+DROP INDEX files_unique_bucket_key_idx;
+DROP TABLE files;
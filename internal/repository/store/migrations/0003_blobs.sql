@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE blobs
+(
+    bucket_key   VARCHAR PRIMARY KEY,
+    checksum     VARCHAR                  NOT NULL,
+    content_type VARCHAR                  NOT NULL,
+    size         BIGINT                   NOT NULL,
+    ref_count    BIGINT                   NOT NULL DEFAULT 0 CHECK (ref_count >= 0),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX blobs_unreferenced_idx
+    ON blobs (bucket_key)
+    WHERE ref_count = 0;
+
+INSERT INTO blobs (bucket_key, checksum, content_type, size, ref_count)
+SELECT bucket_key, checksum, content_type, size, 1
+FROM files
+WHERE status = 'ready';
+
+DROP INDEX files_unique_bucket_key_idx;
+
+CREATE INDEX files_bucket_key_idx
+    ON files (bucket_key);
+
+-- +migrate Down
+DROP INDEX files_bucket_key_idx;
+
+CREATE UNIQUE INDEX files_unique_bucket_key_idx
+    ON files (bucket_key);
+
+DROP INDEX blobs_unreferenced_idx;
+DROP TABLE blobs;
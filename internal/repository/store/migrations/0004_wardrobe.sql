@@ -0,0 +1,43 @@
+-- +migrate Up
+CREATE TABLE wardrobe_items
+(
+    id            UUID PRIMARY KEY,
+    user_id       UUID                     NOT NULL REFERENCES users (id),
+    name          VARCHAR                  NOT NULL,
+    category      VARCHAR                  NOT NULL,
+    subcategory   VARCHAR,
+    brand         VARCHAR,
+    size          VARCHAR,
+    colors        VARCHAR[]                NOT NULL DEFAULT '{}',
+    material      VARCHAR,
+    season        VARCHAR
+        CHECK (season IN ('winter', 'spring', 'summer', 'autumn', 'all-season')),
+    purchase_date DATE,
+    price         BIGINT CHECK (price >= 0),
+    currency      VARCHAR                  NOT NULL DEFAULT 'RUB',
+    notes         VARCHAR,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX wardrobe_items_user_id_created_at_idx
+    ON wardrobe_items (user_id, created_at DESC)
+    WHERE deleted_at IS NULL;
+
+CREATE TABLE wardrobe_item_photos
+(
+    item_id  UUID    NOT NULL REFERENCES wardrobe_items (id),
+    file_id  UUID    NOT NULL REFERENCES files (id),
+    position INTEGER NOT NULL,
+    PRIMARY KEY (item_id, file_id)
+);
+
+CREATE INDEX wardrobe_item_photos_file_id_idx
+    ON wardrobe_item_photos (file_id);
+
+-- +migrate Down
+DROP INDEX wardrobe_item_photos_file_id_idx;
+DROP TABLE wardrobe_item_photos;
+DROP INDEX wardrobe_items_user_id_created_at_idx;
+DROP TABLE wardrobe_items;
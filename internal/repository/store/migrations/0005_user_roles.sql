@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE users
+    ADD COLUMN role VARCHAR NOT NULL DEFAULT 'user'
+        CHECK (role IN ('user', 'admin'));
+
+-- +migrate Down
+ALTER TABLE users
+    DROP COLUMN role;
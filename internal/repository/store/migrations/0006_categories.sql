@@ -0,0 +1,81 @@
+-- +migrate Up
+CREATE TABLE categories
+(
+    id         VARCHAR PRIMARY KEY,
+    parent_id  VARCHAR REFERENCES categories (id),
+    name       VARCHAR                  NOT NULL,
+    position   INTEGER                  NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX categories_parent_id_idx
+    ON categories (parent_id);
+
+CREATE TABLE category_attributes
+(
+    category_id    VARCHAR   NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    name           VARCHAR   NOT NULL,
+    type           VARCHAR   NOT NULL
+        CHECK (type IN ('enum', 'string', 'number', 'boolean')),
+    allowed_values VARCHAR[] NOT NULL DEFAULT '{}',
+    required       BOOLEAN   NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (category_id, name)
+);
+
+ALTER TABLE wardrobe_items
+    ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+INSERT INTO categories (id, parent_id, name, position)
+VALUES ('tops', NULL, 'Tops', 1),
+       ('t-shirts', 'tops', 'T-shirts', 1),
+       ('shirts', 'tops', 'Shirts', 2),
+       ('oxford', 'shirts', 'Oxford', 1),
+       ('flannel', 'shirts', 'Flannel', 2),
+       ('dress-shirts', 'shirts', 'Dress shirts', 3),
+       ('polos', 'tops', 'Polos', 3),
+       ('sweaters', 'tops', 'Sweaters', 4),
+       ('hoodies', 'tops', 'Hoodies', 5),
+       ('bottoms', NULL, 'Bottoms', 2),
+       ('jeans', 'bottoms', 'Jeans', 1),
+       ('trousers', 'bottoms', 'Trousers', 2),
+       ('shorts', 'bottoms', 'Shorts', 3),
+       ('skirts', 'bottoms', 'Skirts', 4),
+       ('dresses', NULL, 'Dresses', 3),
+       ('outerwear', NULL, 'Outerwear', 4),
+       ('jackets', 'outerwear', 'Jackets', 1),
+       ('coats', 'outerwear', 'Coats', 2),
+       ('parkas', 'outerwear', 'Parkas', 3),
+       ('shoes', NULL, 'Shoes', 5),
+       ('sneakers', 'shoes', 'Sneakers', 1),
+       ('boots', 'shoes', 'Boots', 2),
+       ('loafers', 'shoes', 'Loafers', 3),
+       ('sandals', 'shoes', 'Sandals', 4),
+       ('accessories', NULL, 'Accessories', 6),
+       ('bags', 'accessories', 'Bags', 1),
+       ('belts', 'accessories', 'Belts', 2),
+       ('hats', 'accessories', 'Hats', 3),
+       ('scarves', 'accessories', 'Scarves', 4),
+       ('jewelry', 'accessories', 'Jewelry', 5);
+
+INSERT INTO category_attributes (category_id, name, type, allowed_values, required)
+VALUES ('tops', 'sleeveLength', 'enum', '{sleeveless,short,three-quarter,long}', FALSE),
+       ('tops', 'fit', 'enum', '{slim,regular,relaxed,oversized}', FALSE),
+       ('shirts', 'collar', 'enum', '{button-down,spread,point,mandarin}', FALSE),
+       ('bottoms', 'fit', 'enum', '{skinny,slim,straight,relaxed,wide}', FALSE),
+       ('bottoms', 'rise', 'enum', '{low,mid,high}', FALSE),
+       ('bottoms', 'inseam', 'number', '{}', FALSE),
+       ('dresses', 'length', 'enum', '{mini,midi,maxi}', FALSE),
+       ('dresses', 'sleeveLength', 'enum', '{sleeveless,short,three-quarter,long}', FALSE),
+       ('outerwear', 'insulation', 'enum', '{none,light,medium,heavy}', FALSE),
+       ('outerwear', 'waterproof', 'boolean', '{}', FALSE),
+       ('shoes', 'closure', 'enum', '{laces,velcro,slip-on,zip,buckle}', FALSE),
+       ('shoes', 'heelHeight', 'number', '{}', FALSE);
+
+-- +migrate Down
+ALTER TABLE wardrobe_items
+    DROP COLUMN attributes;
+
+DROP TABLE category_attributes;
+DROP INDEX categories_parent_id_idx;
+DROP TABLE categories;
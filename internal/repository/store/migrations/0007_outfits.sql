@@ -0,0 +1,37 @@
+-- +migrate Up
+CREATE TABLE outfits
+(
+    id             UUID PRIMARY KEY,
+    user_id        UUID                     NOT NULL REFERENCES users (id),
+    name           VARCHAR                  NOT NULL,
+    occasions      VARCHAR[]                NOT NULL DEFAULT '{}',
+    season         VARCHAR
+        CHECK (season IN ('winter', 'spring', 'summer', 'autumn', 'all-season')),
+    cover_photo_id UUID REFERENCES files (id),
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at     TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX outfits_user_id_created_at_idx
+    ON outfits (user_id, created_at DESC)
+    WHERE deleted_at IS NULL;
+
+CREATE TABLE outfit_items
+(
+    outfit_id UUID    NOT NULL REFERENCES outfits (id),
+    item_id   UUID    NOT NULL REFERENCES wardrobe_items (id),
+    slot      VARCHAR NOT NULL
+        CHECK (slot IN ('top', 'bottom', 'shoes', 'outerwear', 'accessories')),
+    position  INTEGER NOT NULL,
+    PRIMARY KEY (outfit_id, item_id)
+);
+
+CREATE INDEX outfit_items_item_id_idx
+    ON outfit_items (item_id);
+
+-- +migrate Down
+DROP INDEX outfit_items_item_id_idx;
+DROP TABLE outfit_items;
+DROP INDEX outfits_user_id_created_at_idx;
+DROP TABLE outfits;
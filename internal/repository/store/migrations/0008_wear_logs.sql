@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE wear_logs
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    worn_on    DATE                     NOT NULL,
+    outfit_id  UUID REFERENCES outfits (id),
+    notes      VARCHAR,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX wear_logs_user_id_worn_on_idx
+    ON wear_logs (user_id, worn_on);
+
+CREATE TABLE wear_log_items
+(
+    log_id   UUID    NOT NULL REFERENCES wear_logs (id) ON DELETE CASCADE,
+    item_id  UUID    NOT NULL REFERENCES wardrobe_items (id),
+    position INTEGER NOT NULL,
+    PRIMARY KEY (log_id, item_id)
+);
+
+CREATE INDEX wear_log_items_item_id_idx
+    ON wear_log_items (item_id);
+
+-- +migrate Down
+DROP INDEX wear_log_items_item_id_idx;
+DROP TABLE wear_log_items;
+DROP INDEX wear_logs_user_id_worn_on_idx;
+DROP TABLE wear_logs;
@@ -0,0 +1,18 @@
+-- +migrate Up
+ALTER TABLE wardrobe_items
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian'::regconfig, COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('russian'::regconfig, COALESCE(brand, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(brand, '')), 'B') ||
+        setweight(to_tsvector('russian'::regconfig, COALESCE(notes, '')), 'C') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(notes, '')), 'C')
+    ) STORED;
+
+CREATE INDEX wardrobe_items_search_vector_idx
+    ON wardrobe_items USING GIN (search_vector);
+
+-- +migrate Down
+DROP INDEX wardrobe_items_search_vector_idx;
+ALTER TABLE wardrobe_items
+    DROP COLUMN search_vector;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE size_profiles
+(
+    user_id       UUID PRIMARY KEY REFERENCES users (id),
+    chart         VARCHAR CHECK (chart IN ('men', 'women')),
+    region        VARCHAR                  NOT NULL DEFAULT 'RU'
+        CHECK (region IN ('RU', 'EU', 'US', 'UK', 'INT')),
+    height_cm     NUMERIC(5, 1),
+    weight_kg     NUMERIC(5, 1),
+    chest_cm      NUMERIC(5, 1),
+    waist_cm      NUMERIC(5, 1),
+    hips_cm       NUMERIC(5, 1),
+    shoe_size     NUMERIC(3, 1),
+    preferred_fit VARCHAR CHECK (preferred_fit IN ('slim', 'regular', 'loose')),
+    updated_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate Down
+DROP TABLE size_profiles;
@@ -0,0 +1,35 @@
+-- +migrate Up
+CREATE TABLE follows
+(
+    follower_id UUID                     NOT NULL REFERENCES users (id),
+    followee_id UUID                     NOT NULL REFERENCES users (id),
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX follows_followee_id_created_at_idx
+    ON follows (followee_id, created_at DESC);
+
+CREATE TABLE posts
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    outfit_id  UUID                     NOT NULL REFERENCES outfits (id),
+    caption    VARCHAR,
+    visibility VARCHAR                  NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'followers', 'private')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX posts_user_id_created_at_idx
+    ON posts (user_id, created_at DESC, id DESC)
+    WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX posts_user_id_created_at_idx;
+DROP TABLE posts;
+DROP INDEX follows_followee_id_created_at_idx;
+DROP TABLE follows;
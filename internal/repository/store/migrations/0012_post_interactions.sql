@@ -0,0 +1,70 @@
+-- +migrate Up
+ALTER TABLE posts
+    ADD COLUMN likes_count    INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN comments_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN saves_count    INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE post_likes
+(
+    post_id    UUID                     NOT NULL REFERENCES posts (id),
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE TABLE collections
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    name       VARCHAR                  NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX collections_user_id_name_idx
+    ON collections (user_id, LOWER(name));
+
+CREATE TABLE collection_posts
+(
+    collection_id UUID                     NOT NULL REFERENCES collections (id),
+    post_id       UUID                     NOT NULL REFERENCES posts (id),
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (collection_id, post_id)
+);
+
+CREATE INDEX collection_posts_post_id_idx
+    ON collection_posts (post_id);
+
+CREATE TABLE comments
+(
+    id         UUID PRIMARY KEY,
+    post_id    UUID                     NOT NULL REFERENCES posts (id),
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    parent_id  UUID REFERENCES comments (id),
+    body       VARCHAR                  NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX comments_post_id_created_at_idx
+    ON comments (post_id, created_at, id)
+    WHERE parent_id IS NULL;
+
+CREATE INDEX comments_parent_id_created_at_idx
+    ON comments (parent_id, created_at, id)
+    WHERE parent_id IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX comments_parent_id_created_at_idx;
+DROP INDEX comments_post_id_created_at_idx;
+DROP TABLE comments;
+DROP INDEX collection_posts_post_id_idx;
+DROP TABLE collection_posts;
+DROP INDEX collections_user_id_name_idx;
+DROP TABLE collections;
+DROP TABLE post_likes;
+ALTER TABLE posts
+    DROP COLUMN saves_count,
+    DROP COLUMN comments_count,
+    DROP COLUMN likes_count;
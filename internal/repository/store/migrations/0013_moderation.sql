@@ -0,0 +1,68 @@
+-- +migrate Up
+ALTER TABLE users
+    ADD COLUMN status VARCHAR NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'suspended'));
+
+ALTER TABLE posts
+    ADD COLUMN hidden_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE comments
+    ADD COLUMN hidden_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE reports
+(
+    id          UUID PRIMARY KEY,
+    reporter_id UUID                     NOT NULL REFERENCES users (id),
+    target_type VARCHAR                  NOT NULL
+        CHECK (target_type IN ('post', 'comment', 'user')),
+    target_id   UUID                     NOT NULL,
+    reason      VARCHAR                  NOT NULL
+        CHECK (reason IN ('spam', 'harassment', 'hate', 'nudity', 'violence', 'scam', 'counterfeit', 'other')),
+    details     VARCHAR,
+    status      VARCHAR                  NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'resolved', 'dismissed')),
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    resolved_by UUID REFERENCES users (id)
+);
+
+CREATE UNIQUE INDEX reports_open_reporter_target_idx
+    ON reports (reporter_id, target_type, target_id)
+    WHERE status = 'open';
+
+CREATE INDEX reports_status_created_at_idx
+    ON reports (status, created_at, id);
+
+CREATE INDEX reports_target_idx
+    ON reports (target_type, target_id)
+    WHERE status = 'open';
+
+CREATE TABLE moderation_audit_log
+(
+    id           UUID PRIMARY KEY,
+    moderator_id UUID                     NOT NULL REFERENCES users (id),
+    action       VARCHAR                  NOT NULL
+        CHECK (action IN ('hide_post', 'hide_comment', 'dismiss_report', 'suspend_user', 'unsuspend_user')),
+    target_type  VARCHAR                  NOT NULL
+        CHECK (target_type IN ('post', 'comment', 'user', 'report')),
+    target_id    UUID                     NOT NULL,
+    note         VARCHAR,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX moderation_audit_log_created_at_idx
+    ON moderation_audit_log (created_at DESC, id DESC);
+
+-- +migrate Down
+DROP INDEX moderation_audit_log_created_at_idx;
+DROP TABLE moderation_audit_log;
+DROP INDEX reports_target_idx;
+DROP INDEX reports_status_created_at_idx;
+DROP INDEX reports_open_reporter_target_idx;
+DROP TABLE reports;
+ALTER TABLE comments
+    DROP COLUMN hidden_at;
+ALTER TABLE posts
+    DROP COLUMN hidden_at;
+ALTER TABLE users
+    DROP COLUMN status;
@@ -0,0 +1,95 @@
+-- +migrate Up
+CREATE TABLE catalog_feeds
+(
+    id         VARCHAR PRIMARY KEY,
+    format     VARCHAR                  NOT NULL
+        CHECK (format IN ('yml', 'csv')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE catalog_imports
+(
+    id              UUID PRIMARY KEY,
+    feed_id         VARCHAR                  NOT NULL REFERENCES catalog_feeds (id),
+    format          VARCHAR                  NOT NULL
+        CHECK (format IN ('yml', 'csv')),
+    status          VARCHAR                  NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'succeeded', 'failed')),
+    offers          INTEGER                  NOT NULL DEFAULT 0,
+    created         INTEGER                  NOT NULL DEFAULT 0,
+    updated         INTEGER                  NOT NULL DEFAULT 0,
+    unchanged       INTEGER                  NOT NULL DEFAULT 0,
+    rejected        INTEGER                  NOT NULL DEFAULT 0,
+    deactivated     INTEGER                  NOT NULL DEFAULT 0,
+    images_mirrored INTEGER                  NOT NULL DEFAULT 0,
+    images_failed   INTEGER                  NOT NULL DEFAULT 0,
+    errors          JSONB                    NOT NULL DEFAULT '[]',
+    started_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at     TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX catalog_imports_feed_id_started_at_idx
+    ON catalog_imports (feed_id, started_at DESC);
+
+CREATE TABLE products
+(
+    id           UUID PRIMARY KEY,
+    feed_id      VARCHAR                  NOT NULL REFERENCES catalog_feeds (id),
+    external_id  VARCHAR                  NOT NULL,
+    name         VARCHAR                  NOT NULL,
+    brand        VARCHAR,
+    description  VARCHAR,
+    url          VARCHAR,
+    price        BIGINT                   NOT NULL CHECK (price > 0),
+    old_price    BIGINT CHECK (old_price > price),
+    currency     VARCHAR                  NOT NULL DEFAULT 'RUB',
+    category     VARCHAR,
+    category_id  VARCHAR REFERENCES categories (id) ON DELETE SET NULL,
+    available    BOOLEAN                  NOT NULL DEFAULT TRUE,
+    content_hash VARCHAR                  NOT NULL,
+    import_id    UUID                     NOT NULL REFERENCES catalog_imports (id),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX products_feed_id_external_id_idx
+    ON products (feed_id, external_id);
+
+CREATE INDEX products_created_at_idx
+    ON products (created_at DESC, id DESC);
+
+CREATE INDEX products_category_id_idx
+    ON products (category_id);
+
+CREATE TABLE product_sizes
+(
+    product_id UUID    NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    size       VARCHAR NOT NULL,
+    position   INTEGER NOT NULL,
+    available  BOOLEAN NOT NULL DEFAULT TRUE,
+    stock      INTEGER CHECK (stock >= 0),
+    PRIMARY KEY (product_id, size)
+);
+
+-- Mirrored images hold a reference on the blob stored under bucket_key.
+CREATE TABLE product_images
+(
+    product_id   UUID    NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    position     INTEGER NOT NULL,
+    source_url   VARCHAR NOT NULL,
+    bucket_key   VARCHAR,
+    content_type VARCHAR,
+    PRIMARY KEY (product_id, position)
+);
+
+-- +migrate Down
+DROP TABLE product_images;
+DROP TABLE product_sizes;
+DROP INDEX products_category_id_idx;
+DROP INDEX products_created_at_idx;
+DROP INDEX products_feed_id_external_id_idx;
+DROP TABLE products;
+DROP INDEX catalog_imports_feed_id_started_at_idx;
+DROP TABLE catalog_imports;
+DROP TABLE catalog_feeds;
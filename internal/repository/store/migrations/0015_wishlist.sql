@@ -0,0 +1,59 @@
+-- +migrate Up
+-- Catalog imports add a row whenever a product's price changes.
+CREATE TABLE product_price_history
+(
+    product_id  UUID                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    price       BIGINT                   NOT NULL CHECK (price > 0),
+    currency    VARCHAR                  NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX product_price_history_product_id_recorded_at_idx
+    ON product_price_history (product_id, recorded_at DESC);
+
+-- Price drops are measured against baseline_price: the price the product was
+-- saved at, lowered to the alerted price every time the user is alerted.
+CREATE TABLE wishlist_items
+(
+    user_id        UUID                     NOT NULL REFERENCES users (id),
+    product_id     UUID                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    size           VARCHAR,
+    drop_threshold INTEGER                  NOT NULL
+        CHECK (drop_threshold BETWEEN 1 AND 90),
+    channel        VARCHAR                  NOT NULL
+        CHECK (channel IN ('in_app', 'sms')),
+    baseline_price BIGINT                   NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, product_id)
+);
+
+CREATE INDEX wishlist_items_product_id_idx ON wishlist_items (product_id);
+
+CREATE TABLE price_alerts
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    product_id UUID                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    old_price  BIGINT                   NOT NULL,
+    new_price  BIGINT                   NOT NULL,
+    currency   VARCHAR                  NOT NULL,
+    channel    VARCHAR                  NOT NULL
+        CHECK (channel IN ('in_app', 'sms')),
+    status     VARCHAR                  NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'failed')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX price_alerts_user_id_created_at_idx ON price_alerts (user_id, created_at DESC);
+CREATE INDEX price_alerts_pending_idx ON price_alerts (created_at) WHERE status = 'pending';
+
+-- +migrate Down
+DROP INDEX price_alerts_pending_idx;
+DROP INDEX price_alerts_user_id_created_at_idx;
+DROP TABLE price_alerts;
+DROP INDEX wishlist_items_product_id_idx;
+DROP TABLE wishlist_items;
+DROP INDEX product_price_history_product_id_recorded_at_idx;
+DROP TABLE product_price_history;
@@ -0,0 +1,53 @@
+-- +migrate Up
+CREATE TABLE cart_items
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    product_id UUID                     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    size       VARCHAR,
+    quantity   INTEGER                  NOT NULL
+        CHECK (quantity BETWEEN 1 AND 20),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX cart_items_user_id_product_id_size_idx
+    ON cart_items (user_id, product_id, COALESCE(size, ''));
+
+CREATE TABLE orders
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    status     VARCHAR                  NOT NULL DEFAULT 'created'
+        CHECK (status IN ('created', 'paid', 'shipped', 'delivered', 'cancelled', 'refunded')),
+    total      BIGINT                   NOT NULL CHECK (total > 0),
+    currency   VARCHAR                  NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX orders_user_id_created_at_idx ON orders (user_id, created_at DESC);
+
+-- Order items copy what the customer saw at checkout; the product itself may
+-- change or go away afterwards.
+CREATE TABLE order_items
+(
+    order_id    UUID    NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    position    INTEGER NOT NULL,
+    product_id  UUID    REFERENCES products (id) ON DELETE SET NULL,
+    feed_id     VARCHAR NOT NULL,
+    external_id VARCHAR NOT NULL,
+    name        VARCHAR NOT NULL,
+    brand       VARCHAR,
+    size        VARCHAR,
+    price       BIGINT  NOT NULL CHECK (price > 0),
+    quantity    INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (order_id, position)
+);
+
+-- +migrate Down
+DROP TABLE order_items;
+DROP INDEX orders_user_id_created_at_idx;
+DROP TABLE orders;
+DROP INDEX cart_items_user_id_product_id_size_idx;
+DROP TABLE cart_items;
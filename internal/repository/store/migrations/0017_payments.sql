@@ -0,0 +1,32 @@
+-- +migrate Up
+-- provider_id is set once the payment provider has accepted the payment.
+CREATE TABLE payments
+(
+    id               UUID PRIMARY KEY,
+    order_id         UUID                     NOT NULL REFERENCES orders (id),
+    user_id          UUID                     NOT NULL REFERENCES users (id),
+    provider_id      VARCHAR UNIQUE,
+    status           VARCHAR                  NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'waiting_for_capture', 'succeeded', 'canceled', 'refunded')),
+    amount           BIGINT                   NOT NULL CHECK (amount > 0),
+    currency         VARCHAR                  NOT NULL,
+    confirmation_url VARCHAR,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX payments_order_id_idx ON payments (order_id);
+
+-- Every provider event is applied once; redelivered webhooks find their row.
+CREATE TABLE payment_events
+(
+    provider_id VARCHAR                  NOT NULL,
+    event       VARCHAR                  NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider_id, event)
+);
+
+-- +migrate Down
+DROP TABLE payment_events;
+DROP INDEX payments_order_id_idx;
+DROP TABLE payments;
@@ -0,0 +1,55 @@
+-- +migrate Up
+CREATE TABLE promo_codes
+(
+    id               UUID PRIMARY KEY,
+    code             VARCHAR                  NOT NULL UNIQUE,
+    kind             VARCHAR                  NOT NULL
+        CHECK (kind IN ('percent', 'fixed', 'free_shipping')),
+    value            BIGINT                   NOT NULL CHECK (value >= 0),
+    currency         VARCHAR                  NOT NULL,
+    min_order        BIGINT                   NOT NULL DEFAULT 0 CHECK (min_order >= 0),
+    categories       VARCHAR[]                NOT NULL DEFAULT '{}',
+    first_order_only BOOLEAN                  NOT NULL DEFAULT FALSE,
+    usage_limit      INTEGER CHECK (usage_limit > 0),
+    per_user_limit   INTEGER CHECK (per_user_limit > 0),
+    starts_at        TIMESTAMP WITH TIME ZONE,
+    ends_at          TIMESTAMP WITH TIME ZONE,
+    active           BOOLEAN                  NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+ALTER TABLE orders
+    ADD COLUMN subtotal BIGINT,
+    ADD COLUMN discount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN shipping BIGINT NOT NULL DEFAULT 0;
+
+UPDATE orders
+SET subtotal = total;
+
+ALTER TABLE orders
+    ALTER COLUMN subtotal SET NOT NULL;
+
+-- An order redeems at most one code. Redemptions of cancelled orders do not
+-- count towards the code's limits.
+CREATE TABLE promo_redemptions
+(
+    order_id      UUID PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    promo_code_id UUID                     NOT NULL REFERENCES promo_codes (id),
+    user_id       UUID                     NOT NULL REFERENCES users (id),
+    discount      BIGINT                   NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX promo_redemptions_promo_code_id_user_id_idx
+    ON promo_redemptions (promo_code_id, user_id);
+
+-- +migrate Down
+DROP INDEX promo_redemptions_promo_code_id_user_id_idx;
+DROP TABLE promo_redemptions;
+ALTER TABLE orders
+    DROP COLUMN shipping,
+    DROP COLUMN discount,
+    DROP COLUMN subtotal;
+DROP TABLE promo_codes;
@@ -0,0 +1,36 @@
+-- +migrate Up
+CREATE TABLE addresses
+(
+    id          UUID PRIMARY KEY,
+    user_id     UUID                     NOT NULL REFERENCES users (id),
+    label       VARCHAR                  NOT NULL,
+    recipient   VARCHAR                  NOT NULL,
+    phone       VARCHAR                  NOT NULL,
+    country     VARCHAR(2)               NOT NULL,
+    region      VARCHAR,
+    city        VARCHAR                  NOT NULL,
+    street      VARCHAR                  NOT NULL,
+    house       VARCHAR                  NOT NULL,
+    apartment   VARCHAR,
+    postal_code VARCHAR,
+    latitude    DOUBLE PRECISION
+        CHECK (latitude BETWEEN -90 AND 90),
+    longitude   DOUBLE PRECISION
+        CHECK (longitude BETWEEN -180 AND 180),
+    is_default  BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+CREATE INDEX addresses_user_id_created_at_idx ON addresses (user_id, created_at);
+
+-- A user has at most one default address.
+CREATE UNIQUE INDEX addresses_user_id_default_idx
+    ON addresses (user_id)
+    WHERE is_default;
+
+-- +migrate Down
+DROP INDEX addresses_user_id_default_idx;
+DROP INDEX addresses_user_id_created_at_idx;
+DROP TABLE addresses;
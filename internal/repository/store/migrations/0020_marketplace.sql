@@ -0,0 +1,93 @@
+-- +migrate Up
+-- Items sold on the marketplace are archived: they leave the wardrobe's lists
+-- but keep their history.
+ALTER TABLE wardrobe_items
+    ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE listings
+(
+    id            UUID PRIMARY KEY,
+    seller_id     UUID                     NOT NULL REFERENCES users (id),
+    item_id       UUID                     NOT NULL REFERENCES wardrobe_items (id),
+    title         VARCHAR                  NOT NULL,
+    description   VARCHAR,
+    price         BIGINT                   NOT NULL CHECK (price > 0),
+    currency      VARCHAR                  NOT NULL,
+    condition     VARCHAR                  NOT NULL
+        CHECK (condition IN ('new_with_tags', 'like_new', 'good', 'fair')),
+    category      VARCHAR                  NOT NULL,
+    subcategory   VARCHAR,
+    brand         VARCHAR,
+    size          VARCHAR,
+    status        VARCHAR                  NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'active', 'reserved', 'sold', 'withdrawn')),
+    buyer_id      UUID REFERENCES users (id),
+    published_at  TIMESTAMP WITH TIME ZONE,
+    sold_at       TIMESTAMP WITH TIME ZONE,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian'::regconfig, COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('russian'::regconfig, COALESCE(brand, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(brand, '')), 'B') ||
+        setweight(to_tsvector('russian'::regconfig, COALESCE(description, '')), 'C') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(description, '')), 'C')
+    ) STORED
+);
+
+-- An item is on sale at most once at a time.
+CREATE UNIQUE INDEX listings_item_id_open_idx
+    ON listings (item_id)
+    WHERE status IN ('draft', 'active', 'reserved');
+
+CREATE INDEX listings_seller_id_created_at_idx ON listings (seller_id, created_at DESC);
+
+CREATE INDEX listings_status_published_at_idx ON listings (status, published_at DESC);
+
+CREATE INDEX listings_search_vector_idx
+    ON listings USING GIN (search_vector);
+
+CREATE TABLE listing_photos
+(
+    listing_id UUID    NOT NULL REFERENCES listings (id) ON DELETE CASCADE,
+    file_id    UUID    NOT NULL REFERENCES files (id),
+    position   INTEGER NOT NULL,
+    PRIMARY KEY (listing_id, position)
+);
+
+CREATE TABLE listing_offers
+(
+    id         UUID PRIMARY KEY,
+    listing_id UUID                     NOT NULL REFERENCES listings (id),
+    buyer_id   UUID                     NOT NULL REFERENCES users (id),
+    amount     BIGINT                   NOT NULL CHECK (amount > 0),
+    message    VARCHAR,
+    status     VARCHAR                  NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX listing_offers_listing_id_created_at_idx ON listing_offers (listing_id, created_at DESC);
+
+CREATE INDEX listing_offers_buyer_id_created_at_idx ON listing_offers (buyer_id, created_at DESC);
+
+-- A buyer has at most one pending offer per listing.
+CREATE UNIQUE INDEX listing_offers_listing_id_buyer_id_pending_idx
+    ON listing_offers (listing_id, buyer_id)
+    WHERE status = 'pending';
+
+-- +migrate Down
+DROP INDEX listing_offers_listing_id_buyer_id_pending_idx;
+DROP INDEX listing_offers_buyer_id_created_at_idx;
+DROP INDEX listing_offers_listing_id_created_at_idx;
+DROP TABLE listing_offers;
+DROP TABLE listing_photos;
+DROP INDEX listings_search_vector_idx;
+DROP INDEX listings_status_published_at_idx;
+DROP INDEX listings_seller_id_created_at_idx;
+DROP INDEX listings_item_id_open_idx;
+DROP TABLE listings;
+ALTER TABLE wardrobe_items
+    DROP COLUMN archived_at;
@@ -0,0 +1,64 @@
+-- +migrate Up
+CREATE TABLE conversations
+(
+    id              UUID PRIMARY KEY,
+    listing_id      UUID                     NOT NULL REFERENCES listings (id),
+    seller_id       UUID                     NOT NULL REFERENCES users (id),
+    buyer_id        UUID                     NOT NULL REFERENCES users (id),
+    last_message_at TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (seller_id <> buyer_id),
+    UNIQUE (listing_id, buyer_id)
+);
+
+CREATE INDEX conversations_seller_id_idx ON conversations (seller_id);
+
+CREATE INDEX conversations_buyer_id_idx ON conversations (buyer_id);
+
+CREATE TABLE messages
+(
+    id              UUID PRIMARY KEY,
+    conversation_id UUID                     NOT NULL REFERENCES conversations (id),
+    sender_id       UUID                     NOT NULL REFERENCES users (id),
+    body            VARCHAR,
+    read_at         TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX messages_conversation_id_created_at_idx ON messages (conversation_id, created_at DESC, id DESC);
+
+-- Unread counters only look at the few messages nobody has read yet.
+CREATE INDEX messages_conversation_id_unread_idx
+    ON messages (conversation_id, sender_id)
+    WHERE read_at IS NULL;
+
+CREATE TABLE message_photos
+(
+    message_id UUID    NOT NULL REFERENCES messages (id) ON DELETE CASCADE,
+    file_id    UUID    NOT NULL REFERENCES files (id),
+    position   INTEGER NOT NULL,
+    PRIMARY KEY (message_id, position)
+);
+
+CREATE TABLE user_blocks
+(
+    blocker_id UUID                     NOT NULL REFERENCES users (id),
+    blocked_id UUID                     NOT NULL REFERENCES users (id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX user_blocks_blocked_id_idx ON user_blocks (blocked_id);
+
+-- +migrate Down
+DROP INDEX user_blocks_blocked_id_idx;
+DROP TABLE user_blocks;
+DROP TABLE message_photos;
+DROP INDEX messages_conversation_id_unread_idx;
+DROP INDEX messages_conversation_id_created_at_idx;
+DROP TABLE messages;
+DROP INDEX conversations_buyer_id_idx;
+DROP INDEX conversations_seller_id_idx;
+DROP TABLE conversations;
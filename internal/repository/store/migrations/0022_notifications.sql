@@ -0,0 +1,62 @@
+-- +migrate Up
+-- Notifications nobody opted in to see in the app are kept for their
+-- deliveries only.
+CREATE TABLE notifications
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID                     NOT NULL REFERENCES users (id),
+    category   VARCHAR                  NOT NULL
+        CHECK (category IN ('social', 'orders', 'price_drops', 'marketing')),
+    title      VARCHAR                  NOT NULL,
+    body       VARCHAR                  NOT NULL,
+    in_app     BOOLEAN                  NOT NULL,
+    read_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX notifications_user_id_created_at_idx
+    ON notifications (user_id, created_at DESC, id)
+    WHERE in_app;
+
+CREATE TABLE notification_deliveries
+(
+    notification_id UUID                     NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+    channel         VARCHAR                  NOT NULL
+        CHECK (channel IN ('sms')),
+    status          VARCHAR                  NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'failed')),
+    deliver_after   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at         TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (notification_id, channel)
+);
+
+CREATE INDEX notification_deliveries_pending_idx
+    ON notification_deliveries (deliver_after)
+    WHERE status = 'pending';
+
+CREATE TABLE notification_preferences
+(
+    user_id  UUID    NOT NULL REFERENCES users (id),
+    category VARCHAR NOT NULL
+        CHECK (category IN ('social', 'orders', 'price_drops', 'marketing')),
+    in_app   BOOLEAN NOT NULL,
+    sms      BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, category)
+);
+
+CREATE TABLE quiet_hours
+(
+    user_id    UUID PRIMARY KEY REFERENCES users (id),
+    start_time TIME    NOT NULL,
+    end_time   TIME    NOT NULL,
+    time_zone  VARCHAR NOT NULL,
+    CHECK (start_time <> end_time)
+);
+
+-- +migrate Down
+DROP TABLE quiet_hours;
+DROP TABLE notification_preferences;
+DROP INDEX notification_deliveries_pending_idx;
+DROP TABLE notification_deliveries;
+DROP INDEX notifications_user_id_created_at_idx;
+DROP TABLE notifications;
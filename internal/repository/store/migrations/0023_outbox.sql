@@ -0,0 +1,25 @@
+-- +migrate Up
+-- Side effects are written here in the transaction of the change that
+-- causes them and performed by the relay once it commits. next_attempt_at
+-- doubles as the lease of a message the relay is working on.
+CREATE TABLE outbox_messages
+(
+    id              UUID PRIMARY KEY,
+    kind            VARCHAR                  NOT NULL,
+    payload         JSONB                    NOT NULL,
+    status          VARCHAR                  NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'dead')),
+    attempts        INTEGER                  NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error      VARCHAR,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at         TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX outbox_messages_pending_idx
+    ON outbox_messages (next_attempt_at)
+    WHERE status = 'pending';
+
+-- +migrate Down
+DROP INDEX outbox_messages_pending_idx;
+DROP TABLE outbox_messages;
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- A blob is marked purged before its object is removed, so no file takes a
+-- new reference on an object that is about to disappear.
+ALTER TABLE blobs
+    ADD COLUMN purged_at TIMESTAMP WITH TIME ZONE;
+
+-- +migrate Down
+ALTER TABLE blobs
+    DROP COLUMN purged_at;
@@ -0,0 +1,11 @@
+-- +migrate Up
+-- deliver_after doubles as the lease of a delivery a dispatcher is working
+-- on, like next_attempt_at of outbox messages.
+ALTER TABLE notification_deliveries
+    ADD COLUMN attempts   INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN last_error VARCHAR;
+
+-- +migrate Down
+ALTER TABLE notification_deliveries
+    DROP COLUMN last_error,
+    DROP COLUMN attempts;
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type TxConfig struct {
+	IsoLevel   pgx.TxIsoLevel
+	MaxRetries int
+	RetryDelay time.Duration
+}
+
+// TxManager runs functions in transactions that repositories pick up with
+// GetTXFromContext. A transaction started inside another one runs in a
+// savepoint, so its failure only undoes its own changes. DataStore.WithTx
+// runs through the manager built from the store config.
+type TxManager struct {
+	pool *pgxpool.Pool
+	cfg  TxConfig
+}
+
+func NewTxManager(pool *pgxpool.Pool, cfg TxConfig) *TxManager {
+	return &TxManager{
+		pool: pool,
+		cfg:  cfg,
+	}
+}
+
+// Do runs fn in a transaction and commits it if fn succeeds. A transaction
+// that fails to serialize with a concurrent one is rolled back and fn runs
+// again, up to MaxRetries times, so fn must not have effects outside the
+// transaction that cannot be repeated. Deadlocks are retried the same way.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(ctxKey).(pgx.Tx); ok {
+		return m.run(ctx, tx.Begin, fn)
+	}
+
+	begin := func(ctx context.Context) (pgx.Tx, error) {
+		return m.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: m.cfg.IsoLevel})
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := m.run(ctx, begin, fn)
+		if err == nil || attempt > m.cfg.MaxRetries || !isSerializationFailure(err) {
+			return err
+		}
+
+		log.Debug().Err(err).Int("attempt", attempt).Msg("retrying transaction")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to retry transaction: %w", ctx.Err())
+		case <-time.After(time.Duration(attempt) * m.cfg.RetryDelay):
+		}
+	}
+}
+
+func (m *TxManager) run(
+	ctx context.Context,
+	begin func(ctx context.Context) (pgx.Tx, error),
+	fn func(ctx context.Context) error,
+) error {
+	tx, err := begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Warn().Err(err).Msg("failed to rollback transaction")
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, ctxKey, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == pgerrcode.SerializationFailure || pgErr.Code == pgerrcode.DeadlockDetected
+}
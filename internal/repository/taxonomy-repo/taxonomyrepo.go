@@ -0,0 +1,446 @@
+package taxonomyrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const (
+	maxUpdates = 4
+
+	categoryColumns  = "id, parent_id, name, position, created_at, updated_at"
+	attributeColumns = "category_id, name, type, allowed_values, required"
+)
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanCategory(row pgx.Row) (entity.Category, error) {
+	var category entity.Category
+
+	err := row.Scan(
+		&category.ID,
+		&category.ParentID,
+		&category.Name,
+		&category.Position,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Category{}, entity.ErrCategoryNotFound
+		}
+
+		return entity.Category{}, fmt.Errorf("failed to scan category: %w", err)
+	}
+
+	category.Attributes = []entity.CategoryAttribute{}
+
+	return category, nil
+}
+
+func (r *Repo) queryCategories(ctx context.Context, query string, arguments ...any) ([]entity.Category, error) {
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, arguments...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+
+	defer rows.Close()
+
+	var categories []entity.Category
+
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate categories: %w", err)
+	}
+
+	return categories, r.attachAttributes(ctx, categories)
+}
+
+// attachAttributes loads the attributes defined directly on each category.
+func (r *Repo) attachAttributes(ctx context.Context, categories []entity.Category) error {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(categories))
+	ids := make([]string, 0, len(categories))
+
+	for i, category := range categories {
+		index[category.ID] = i
+		ids = append(ids, category.ID)
+	}
+
+	query := `
+SELECT ` + attributeColumns + `
+FROM category_attributes
+WHERE category_id = ANY($1)
+ORDER BY category_id, name`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to query category attributes: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			categoryID string
+			attribute  entity.CategoryAttribute
+		)
+
+		err := rows.Scan(&categoryID, &attribute.Name, &attribute.Type, &attribute.AllowedValues, &attribute.Required)
+		if err != nil {
+			return fmt.Errorf("failed to scan category attribute: %w", err)
+		}
+
+		i := index[categoryID]
+		categories[i].Attributes = append(categories[i].Attributes, attribute)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate category attributes: %w", err)
+	}
+
+	return nil
+}
+
+// ListCategories returns all categories, parents before their children.
+func (r *Repo) ListCategories(ctx context.Context) ([]entity.Category, error) {
+	query := `
+WITH RECURSIVE tree AS (
+	SELECT ` + categoryColumns + `, 0 AS depth
+	FROM categories
+	WHERE parent_id IS NULL
+	UNION ALL
+	SELECT c.id, c.parent_id, c.name, c.position, c.created_at, c.updated_at, t.depth + 1
+	FROM categories c
+		JOIN tree t ON c.parent_id = t.id
+)
+SELECT ` + categoryColumns + `
+FROM tree
+ORDER BY depth, position, id`
+
+	categories, err := r.queryCategories(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *Repo) GetCategory(ctx context.Context, categoryID string) (entity.Category, error) {
+	query := `
+SELECT ` + categoryColumns + `
+FROM categories
+WHERE id = $1`
+
+	category, err := scanCategory(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, categoryID))
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to get category %s: %w", categoryID, err)
+	}
+
+	categories := []entity.Category{category}
+
+	if err := r.attachAttributes(ctx, categories); err != nil {
+		return entity.Category{}, fmt.Errorf("failed to get category %s: %w", categoryID, err)
+	}
+
+	return categories[0], nil
+}
+
+// GetCategoryPath returns the category together with its ancestors, root first.
+// The categories stay share-locked until the transaction of the context ends,
+// so they cannot be deleted while an item is being validated against them.
+func (r *Repo) GetCategoryPath(ctx context.Context, categoryID string) (entity.CategoryPath, error) {
+	query := `
+WITH RECURSIVE path AS (
+	SELECT ` + categoryColumns + `, 0 AS depth
+	FROM categories
+	WHERE id = $1
+	UNION ALL
+	SELECT c.id, c.parent_id, c.name, c.position, c.created_at, c.updated_at, p.depth + 1
+	FROM categories c
+		JOIN path p ON c.id = p.parent_id
+)
+SELECT c.id, c.parent_id, c.name, c.position, c.created_at, c.updated_at
+FROM path p
+	JOIN categories c ON c.id = p.id
+ORDER BY p.depth DESC
+FOR SHARE OF c`
+
+	categories, err := r.queryCategories(ctx, query, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category path %s: %w", categoryID, err)
+	}
+
+	if len(categories) == 0 {
+		return nil, fmt.Errorf("failed to get category path %s: %w", categoryID, entity.ErrCategoryNotFound)
+	}
+
+	return categories, nil
+}
+
+func (r *Repo) CreateCategory(ctx context.Context, category entity.Category) (entity.Category, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+INSERT INTO categories (id, parent_id, name, position)
+VALUES ($1, $2, $3, $4)`
+
+		_, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, category.ID, category.ParentID, category.Name, category.Position)
+		if err != nil {
+			return convertError(err)
+		}
+
+		for _, attribute := range category.Attributes {
+			if err := r.upsertAttribute(ctx, category.ID, attribute); err != nil {
+				return err
+			}
+		}
+
+		category, err = r.GetCategory(ctx, category.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to create category %s: %w", category.ID, err)
+	}
+
+	return category, nil
+}
+
+func (r *Repo) UpdateCategory(ctx context.Context, categoryID string, update entity.CategoryUpdate) (entity.Category, error) {
+	var category entity.Category
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		var (
+			sb     strings.Builder
+			params []any
+		)
+
+		updates := make([]string, 0, maxUpdates)
+
+		set := func(column string, value any) {
+			params = append(params, value)
+			updates = append(updates, fmt.Sprintf("%s = $%d", column, len(params)))
+		}
+
+		if update.ParentID != nil {
+			if *update.ParentID == "" {
+				set("parent_id", nil)
+			} else {
+				if err := r.checkParent(ctx, categoryID, *update.ParentID); err != nil {
+					return err
+				}
+
+				set("parent_id", *update.ParentID)
+			}
+		}
+
+		if update.Name != nil {
+			set("name", strings.TrimSpace(*update.Name))
+		}
+
+		if update.Position != nil {
+			set("position", *update.Position)
+		}
+
+		set("updated_at", time.Now())
+
+		sb.WriteString("UPDATE categories SET ")
+		sb.WriteString(strings.Join(updates, ", "))
+
+		params = append(params, categoryID)
+		sb.WriteString(fmt.Sprintf(" WHERE id = $%d", len(params)))
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, sb.String(), params...)
+		if err != nil {
+			return convertError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrCategoryNotFound
+		}
+
+		category, err = r.GetCategory(ctx, categoryID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to update category %s: %w", categoryID, err)
+	}
+
+	return category, nil
+}
+
+// checkParent makes sure moving the category under parentID keeps the taxonomy a tree.
+func (r *Repo) checkParent(ctx context.Context, categoryID, parentID string) error {
+	path, err := r.GetCategoryPath(ctx, parentID)
+	if err != nil {
+		return err
+	}
+
+	for _, ancestor := range path {
+		if ancestor.ID == categoryID {
+			return fmt.Errorf("%w: category cannot be moved under itself", entity.ErrInvalidCategory)
+		}
+	}
+
+	return nil
+}
+
+// DeleteCategory removes a category that has no subcategories and no items.
+func (r *Repo) DeleteCategory(ctx context.Context, categoryID string) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = $1)
+	OR EXISTS (
+		SELECT 1
+		FROM wardrobe_items
+		WHERE TRUE
+			AND (category = $1 OR subcategory = $1)
+			AND deleted_at IS NULL
+	)`
+
+		var inUse bool
+
+		// Lock the category first, so items validated against it in
+		// transactions still running are counted below.
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM categories WHERE id = $1 FOR UPDATE`, categoryID); err != nil {
+			return fmt.Errorf("failed to lock category: %w", err)
+		}
+
+		if err := tx.QueryRow(ctx, query, categoryID).Scan(&inUse); err != nil {
+			return fmt.Errorf("failed to check category usage: %w", err)
+		}
+
+		if inUse {
+			return entity.ErrCategoryInUse
+		}
+
+		tag, err := tx.Exec(ctx, `DELETE FROM categories WHERE id = $1`, categoryID)
+		if err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrCategoryNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete category %s: %w", categoryID, err)
+	}
+
+	return nil
+}
+
+func (r *Repo) UpsertAttribute(ctx context.Context, categoryID string, attribute entity.CategoryAttribute) (entity.Category, error) {
+	var category entity.Category
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.upsertAttribute(ctx, categoryID, attribute); err != nil {
+			return err
+		}
+
+		_, err := r.db.GetTXFromContext(ctx).Exec(ctx, `UPDATE categories SET updated_at = NOW() WHERE id = $1`, categoryID)
+		if err != nil {
+			return fmt.Errorf("failed to touch category: %w", err)
+		}
+
+		category, err = r.GetCategory(ctx, categoryID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to save attribute %s of category %s: %w", attribute.Name, categoryID, err)
+	}
+
+	return category, nil
+}
+
+func (r *Repo) upsertAttribute(ctx context.Context, categoryID string, attribute entity.CategoryAttribute) error {
+	query := `
+INSERT INTO category_attributes (` + attributeColumns + `)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (category_id, name) DO UPDATE
+SET type = EXCLUDED.type, allowed_values = EXCLUDED.allowed_values, required = EXCLUDED.required`
+
+	_, err := r.db.GetTXFromContext(ctx).Exec(ctx, query,
+		categoryID, attribute.Name, attribute.Type, attribute.AllowedValues, attribute.Required)
+	if err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteAttribute(ctx context.Context, categoryID, name string) error {
+	query := `
+DELETE FROM category_attributes
+WHERE TRUE
+	AND category_id = $1
+	AND name = $2`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, categoryID, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete attribute %s of category %s: %w", name, categoryID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.ErrCategoryAttributeNotFound
+	}
+
+	return nil
+}
+
+func convertError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return entity.ErrDuplicateCategory
+		case pgerrcode.ForeignKeyViolation:
+			return entity.ErrCategoryNotFound
+		}
+	}
+
+	return fmt.Errorf("failed to save category: %w", err)
+}
@@ -0,0 +1,310 @@
+package usersrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const addressColumns = `
+	a.id, a.user_id, a.label, a.recipient, a.phone, a.country, a.region, a.city, a.street, a.house,
+	a.apartment, a.postal_code, a.latitude, a.longitude, a.is_default, a.created_at, a.updated_at`
+
+func scanAddress(row pgx.Row) (entity.Address, error) {
+	var address entity.Address
+
+	err := row.Scan(
+		&address.ID,
+		&address.UserID,
+		&address.Label,
+		&address.Recipient,
+		&address.Phone,
+		&address.Country,
+		&address.Region,
+		&address.City,
+		&address.Street,
+		&address.House,
+		&address.Apartment,
+		&address.PostalCode,
+		&address.Latitude,
+		&address.Longitude,
+		&address.IsDefault,
+		&address.CreatedAt,
+		&address.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Address{}, entity.ErrAddressNotFound
+		}
+
+		return entity.Address{}, fmt.Errorf("failed to scan address: %w", err)
+	}
+
+	return address, nil
+}
+
+// lockUser serializes changes to the user's address book until the end of
+// the transaction, so that only one of them picks the default address.
+func (r *Repo) lockUser(ctx context.Context, userID entity.UserID) error {
+	query := `
+SELECT id
+FROM users
+WHERE TRUE
+	AND id = $1
+	AND deleted_at IS NULL
+FOR NO KEY UPDATE`
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, userID).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrUserNotFound
+		}
+
+		return fmt.Errorf("failed to lock user %s: %w", uuid.UUID(userID), err)
+	}
+
+	return nil
+}
+
+func (r *Repo) unsetDefaultAddress(ctx context.Context, userID entity.UserID) error {
+	query := `
+UPDATE addresses
+SET is_default = FALSE,
+	updated_at = NOW()
+WHERE TRUE
+	AND user_id = $1
+	AND is_default`
+
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to unset default address: %w", err)
+	}
+
+	return nil
+}
+
+// CreateAddress saves an address. The user's first address becomes the
+// default one.
+func (r *Repo) CreateAddress(ctx context.Context, address entity.Address) (entity.Address, error) {
+	var createdAddress entity.Address
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.lockUser(ctx, address.UserID); err != nil {
+			return err
+		}
+
+		tx := r.db.GetTXFromContext(ctx)
+
+		if address.IsDefault {
+			if err := r.unsetDefaultAddress(ctx, address.UserID); err != nil {
+				return err
+			}
+		} else {
+			query := `SELECT NOT EXISTS (SELECT 1 FROM addresses WHERE user_id = $1)`
+
+			if err := tx.QueryRow(ctx, query, address.UserID).Scan(&address.IsDefault); err != nil {
+				return fmt.Errorf("failed to check addresses: %w", err)
+			}
+		}
+
+		query := `
+INSERT INTO addresses AS a (id, user_id, label, recipient, phone, country, region, city, street, house,
+	apartment, postal_code, latitude, longitude, is_default)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING ` + addressColumns
+
+		var err error
+
+		createdAddress, err = scanAddress(tx.QueryRow(ctx, query, address.ID, address.UserID, address.Label,
+			address.Recipient, address.Phone, address.Country, address.Region, address.City, address.Street,
+			address.House, address.Apartment, address.PostalCode, address.Latitude, address.Longitude, address.IsDefault))
+
+		return err
+	})
+	if err != nil {
+		return entity.Address{}, fmt.Errorf("failed to create address: %w", err)
+	}
+
+	return createdAddress, nil
+}
+
+// GetAddress returns an address of the user. Addresses of deleted users are
+// not found.
+func (r *Repo) GetAddress(ctx context.Context, userID entity.UserID, addressID entity.AddressID) (entity.Address, error) {
+	query := `
+SELECT ` + addressColumns + `
+FROM addresses a
+	JOIN users u ON u.id = a.user_id
+WHERE TRUE
+	AND a.id = $1
+	AND a.user_id = $2
+	AND u.deleted_at IS NULL`
+
+	address, err := scanAddress(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, addressID, userID))
+	if err != nil {
+		return entity.Address{}, fmt.Errorf("failed to get address %s: %w", addressID, err)
+	}
+
+	return address, nil
+}
+
+// ListAddresses lists the user's addresses, the default one first.
+func (r *Repo) ListAddresses(ctx context.Context, userID entity.UserID) ([]entity.Address, error) {
+	query := `
+SELECT ` + addressColumns + `
+FROM addresses a
+	JOIN users u ON u.id = a.user_id
+WHERE TRUE
+	AND a.user_id = $1
+	AND u.deleted_at IS NULL
+ORDER BY a.is_default DESC, a.created_at, a.id`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list addresses: %w", err)
+	}
+
+	defer rows.Close()
+
+	addresses := []entity.Address{}
+
+	for rows.Next() {
+		address, err := scanAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+func (r *Repo) UpdateAddress( //nolint:funlen
+	ctx context.Context,
+	userID entity.UserID,
+	addressID entity.AddressID,
+	update entity.AddressUpdate,
+) (entity.Address, error) {
+	var updatedAddress entity.Address
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.lockUser(ctx, userID); err != nil {
+			return err
+		}
+
+		if update.IsDefault != nil && *update.IsDefault {
+			if err := r.unsetDefaultAddress(ctx, userID); err != nil {
+				return err
+			}
+		}
+
+		var sb strings.Builder
+
+		params := []any{addressID, userID}
+		updates := []string{"updated_at = NOW()"}
+
+		for _, field := range []struct {
+			column string
+			value  any
+			isSet  bool
+		}{
+			{"label", update.Label, update.Label != nil},
+			{"recipient", update.Recipient, update.Recipient != nil},
+			{"phone", update.Phone, update.Phone != nil},
+			{"country", update.Country, update.Country != nil},
+			{"region", update.Region, update.Region != nil},
+			{"city", update.City, update.City != nil},
+			{"street", update.Street, update.Street != nil},
+			{"house", update.House, update.House != nil},
+			{"apartment", update.Apartment, update.Apartment != nil},
+			{"postal_code", update.PostalCode, update.PostalCode != nil},
+			{"latitude", update.Latitude, update.Latitude != nil},
+			{"longitude", update.Longitude, update.Longitude != nil},
+			{"is_default", update.IsDefault, update.IsDefault != nil},
+		} {
+			if field.isSet {
+				params = append(params, field.value)
+				updates = append(updates, fmt.Sprintf("%s = $%d", field.column, len(params)))
+			}
+		}
+
+		sb.WriteString("UPDATE addresses a SET ")
+		sb.WriteString(strings.Join(updates, ", "))
+		sb.WriteString(" WHERE a.id = $1 AND a.user_id = $2 RETURNING " + addressColumns)
+
+		var err error
+
+		updatedAddress, err = scanAddress(r.db.GetTXFromContext(ctx).QueryRow(ctx, sb.String(), params...))
+
+		return err
+	})
+	if err != nil {
+		return entity.Address{}, fmt.Errorf("failed to update address %s: %w", addressID, err)
+	}
+
+	return updatedAddress, nil
+}
+
+// DeleteAddress deletes an address. When it was the default one, the most
+// recently added of the remaining addresses becomes the default.
+func (r *Repo) DeleteAddress(ctx context.Context, userID entity.UserID, addressID entity.AddressID) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.lockUser(ctx, userID); err != nil {
+			return err
+		}
+
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+DELETE FROM addresses
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2
+RETURNING is_default`
+
+		var wasDefault bool
+
+		if err := tx.QueryRow(ctx, query, addressID, userID).Scan(&wasDefault); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrAddressNotFound
+			}
+
+			return err
+		}
+
+		if !wasDefault {
+			return nil
+		}
+
+		query = `
+UPDATE addresses
+SET is_default = TRUE,
+	updated_at = NOW()
+WHERE id = (
+	SELECT id
+	FROM addresses
+	WHERE user_id = $1
+	ORDER BY created_at DESC, id DESC
+	LIMIT 1
+)`
+
+		if _, err := tx.Exec(ctx, query, userID); err != nil {
+			return fmt.Errorf("failed to pick default address: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete address %s: %w", addressID, err)
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ type database interface {
 	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
 	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Repo struct {
@@ -105,7 +106,7 @@ func (r *Repo) CreateUnverifiedUser(ctx context.Context, user entity.User, otp s
 	sb.WriteString(") VALUES (")
 	sb.WriteString(strings.Join(values, ", "))
 	sb.WriteString(`)
-RETURNING id, first_name, last_name, nick_name, gender, birth_date, email, email_verified, phone, phone_verified, created_at, updated_at`)
+RETURNING id, first_name, last_name, nick_name, gender, birth_date, email, email_verified, phone, phone_verified, role, created_at, updated_at`)
 
 	row := tx.QueryRow(ctx, sb.String(), args...)
 
@@ -120,6 +121,7 @@ RETURNING id, first_name, last_name, nick_name, gender, birth_date, email, email
 		&user.EmailVerified,
 		&user.Phone,
 		&user.PhoneVerified,
+		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	); err != nil {
@@ -145,7 +147,7 @@ WHERE TRUE
 	AND id = $1
 	AND otp = $2
 	AND otp_created_at > NOW() - INTERVAL '1 SECOND'*$3
-	RETURNING id, first_name, last_name, nick_name, gender, birth_date, email, email_verified, phone, phone_verified, created_at, updated_at`
+	RETURNING id, first_name, last_name, nick_name, gender, birth_date, email, email_verified, phone, phone_verified, role, created_at, updated_at`
 
 	row := tx.QueryRow(ctx, query, validateUserRequest.UserID.String(), validateUserRequest.OTP, otpLifetime.Seconds())
 
@@ -162,6 +164,7 @@ WHERE TRUE
 		&user.EmailVerified,
 		&user.Phone,
 		&user.PhoneVerified,
+		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -180,7 +183,7 @@ func (r *Repo) GetUser(ctx context.Context, userID entity.UserID) (entity.User,
 	var user entity.User
 
 	query := `
-SELECT id, first_name, last_name, nick_name, gender, birth_date, email, email_verified, phone, phone_verified, created_at, updated_at
+SELECT id, first_name, last_name, nick_name, gender, birth_date, email, email_verified, phone, phone_verified, role, created_at, updated_at
 FROM users
 WHERE TRUE
 	AND id = $1
@@ -205,6 +208,7 @@ WHERE TRUE
 		&user.EmailVerified,
 		&user.Phone,
 		&user.PhoneVerified,
+		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -298,7 +302,7 @@ func (r *Repo) UpdateUser(ctx context.Context, userID entity.UserID, updatedUser
 	params = append(params, userID)
 	sb.WriteString(fmt.Sprintf(" WHERE id = $%d AND deleted_at IS NULL", len(params)))
 
-	sb.WriteString(" RETURNING id, first_name, last_name, nick_name, gender, birth_date, email, email_verified, phone, phone_verified, created_at, updated_at")
+	sb.WriteString(" RETURNING id, first_name, last_name, nick_name, gender, birth_date, email, email_verified, phone, phone_verified, role, created_at, updated_at")
 
 	row := tx.QueryRow(ctx, sb.String(), params...)
 
@@ -315,6 +319,7 @@ func (r *Repo) UpdateUser(ctx context.Context, userID entity.UserID, updatedUser
 		&user.EmailVerified,
 		&user.Phone,
 		&user.PhoneVerified,
+		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -336,15 +341,6 @@ func (r *Repo) UpdateUser(ctx context.Context, userID entity.UserID, updatedUser
 }
 
 func (r *Repo) DeleteUser(ctx context.Context, userID entity.UserID) error {
-	_, err := r.GetUser(ctx, userID)
-	if err != nil {
-		if errors.Is(err, entity.ErrUserNotFound) {
-			return entity.ErrUserNotFound
-		}
-
-		return fmt.Errorf("failed to fetch user in DeleteUser() function: %w", err)
-	}
-
 	query := `
 UPDATE users
 SET deleted_at = NOW()
@@ -352,10 +348,35 @@ WHERE TRUE
 	AND id = $1
 	AND deleted_at IS NULL`
 
-	_, err = r.db.Exec(ctx, query, userID)
-	if err != nil {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.GetUser(ctx, userID); err != nil {
+			return err
+		}
+
+		_, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, userID)
+
+		return err
+	})
+
+	switch {
+	case errors.Is(err, entity.ErrUserNotFound):
+		return entity.ErrUserNotFound
+	case err != nil:
 		return fmt.Errorf("error deleting user %s: %w", uuid.UUID(userID), err)
 	}
 
 	return nil
 }
+
+// IsUserSuspended reports whether moderators suspended the user.
+func (r *Repo) IsUserSuspended(ctx context.Context, userID entity.UserID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND status = 'suspended')`
+
+	var suspended bool
+
+	if err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, userID).Scan(&suspended); err != nil {
+		return false, fmt.Errorf("failed to check status of user %s: %w", uuid.UUID(userID), err)
+	}
+
+	return suspended, nil
+}
@@ -0,0 +1,393 @@
+package wardroberepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+const (
+	maxUpdates = 14
+
+	itemColumns = `
+	i.id, i.user_id, i.name, i.category, i.subcategory, i.brand, i.size, i.colors, i.material, i.season,
+	i.purchase_date, i.price, i.currency, i.notes, i.attributes,
+	ARRAY(
+		SELECT p.file_id
+		FROM wardrobe_item_photos p
+			JOIN files f ON f.id = p.file_id AND f.status = 'ready'
+		WHERE p.item_id = i.id
+		ORDER BY p.position
+	),
+	i.archived_at, i.created_at, i.updated_at`
+)
+
+type database interface {
+	Exec(ctx context.Context, sq string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sq string, arguments ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	GetTXFromContext(ctx context.Context) store.Transaction
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Repo struct {
+	db database
+}
+
+func New(db database) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func scanItem(row pgx.Row) (entity.WardrobeItem, error) {
+	var (
+		item     entity.WardrobeItem
+		photoIDs []uuid.UUID
+	)
+
+	err := row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.Name,
+		&item.Category,
+		&item.Subcategory,
+		&item.Brand,
+		&item.Size,
+		&item.Colors,
+		&item.Material,
+		&item.Season,
+		&item.PurchaseDate,
+		&item.Price,
+		&item.Currency,
+		&item.Notes,
+		&item.Attributes,
+		&photoIDs,
+		&item.ArchivedAt,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.WardrobeItem{}, entity.ErrWardrobeItemNotFound
+		}
+
+		return entity.WardrobeItem{}, fmt.Errorf("failed to scan wardrobe item: %w", err)
+	}
+
+	item.PhotoIDs = make([]entity.FileID, 0, len(photoIDs))
+	for _, photoID := range photoIDs {
+		item.PhotoIDs = append(item.PhotoIDs, entity.FileID(photoID))
+	}
+
+	return item, nil
+}
+
+func (r *Repo) CreateItem(ctx context.Context, item entity.WardrobeItem) (entity.WardrobeItem, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+INSERT INTO wardrobe_items (id, user_id, name, category, subcategory, brand, size, colors, material, season,
+	purchase_date, price, currency, notes, attributes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+		_, err := r.db.GetTXFromContext(ctx).Exec(ctx, query,
+			item.ID, item.UserID, item.Name, item.Category, item.Subcategory, item.Brand, item.Size, item.Colors,
+			item.Material, item.Season, item.PurchaseDate, item.Price, item.Currency, item.Notes, item.Attributes)
+		if err != nil {
+			return fmt.Errorf("failed to insert wardrobe item: %w", err)
+		}
+
+		if err := r.setPhotos(ctx, item.UserID, item.ID, item.PhotoIDs); err != nil {
+			return err
+		}
+
+		item, err = r.GetItem(ctx, item.UserID, item.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.WardrobeItem{}, fmt.Errorf("failed to create wardrobe item: %w", err)
+	}
+
+	return item, nil
+}
+
+// setPhotos replaces the item's photos, making sure every file is a ready
+// file of the item's owner.
+func (r *Repo) setPhotos(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID, photoIDs []entity.FileID) error {
+	tx := r.db.GetTXFromContext(ctx)
+
+	ids := make([]uuid.UUID, 0, len(photoIDs))
+	for _, photoID := range photoIDs {
+		ids = append(ids, uuid.UUID(photoID))
+	}
+
+	var owned int
+
+	query := `
+SELECT COUNT(*)
+FROM files
+WHERE TRUE
+	AND id = ANY($1)
+	AND user_id = $2
+	AND status = $3`
+
+	if err := tx.QueryRow(ctx, query, ids, userID, entity.FileStatusReady).Scan(&owned); err != nil {
+		return fmt.Errorf("failed to check photos: %w", err)
+	}
+
+	if owned != len(ids) {
+		return fmt.Errorf("%w: unknown photo", entity.ErrInvalidWardrobeItem)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM wardrobe_item_photos WHERE item_id = $1`, itemID); err != nil {
+		return fmt.Errorf("failed to clear photos: %w", err)
+	}
+
+	query = `
+INSERT INTO wardrobe_item_photos (item_id, file_id, position)
+SELECT $1, photo.id, photo.position
+FROM UNNEST($2::uuid[]) WITH ORDINALITY AS photo(id, position)`
+
+	if _, err := tx.Exec(ctx, query, itemID, ids); err != nil {
+		return fmt.Errorf("failed to set photos: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) (entity.WardrobeItem, error) {
+	query := `
+SELECT ` + itemColumns + `
+FROM wardrobe_items i
+WHERE TRUE
+	AND i.id = $1
+	AND i.user_id = $2
+	AND i.deleted_at IS NULL`
+
+	item, err := scanItem(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, itemID, userID))
+	if err != nil {
+		return entity.WardrobeItem{}, fmt.Errorf("failed to get wardrobe item %s: %w", itemID, err)
+	}
+
+	return item, nil
+}
+
+func (r *Repo) ListItems(ctx context.Context, userID entity.UserID, filter entity.WardrobeFilter) ([]entity.WardrobeItem, error) {
+	var sb strings.Builder
+
+	params := []any{userID}
+
+	sb.WriteString(`
+SELECT ` + itemColumns + `
+FROM wardrobe_items i
+WHERE TRUE
+	AND i.user_id = $1
+	AND i.deleted_at IS NULL`)
+
+	if !filter.Archived {
+		sb.WriteString(" AND i.archived_at IS NULL")
+	}
+
+	if filter.Category != nil {
+		params = append(params, *filter.Category)
+		sb.WriteString(fmt.Sprintf(" AND (i.category = $%d OR i.subcategory = $%d)", len(params), len(params)))
+	}
+
+	if filter.Season != nil {
+		params = append(params, *filter.Season)
+		sb.WriteString(fmt.Sprintf(" AND i.season = $%d", len(params)))
+	}
+
+	params = append(params, filter.Limit, filter.Offset)
+	sb.WriteString(fmt.Sprintf(" ORDER BY i.created_at DESC, i.id LIMIT $%d OFFSET $%d", len(params)-1, len(params)))
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, sb.String(), params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wardrobe items: %w", err)
+	}
+
+	defer rows.Close()
+
+	items := make([]entity.WardrobeItem, 0, filter.Limit)
+
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate wardrobe items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *Repo) UpdateItem( //nolint:funlen,cyclop
+	ctx context.Context,
+	userID entity.UserID,
+	itemID entity.WardrobeItemID,
+	update entity.WardrobeItemUpdate,
+) (entity.WardrobeItem, error) {
+	var item entity.WardrobeItem
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		var (
+			sb     strings.Builder
+			params []any
+		)
+
+		updates := make([]string, 0, maxUpdates)
+
+		set := func(column string, value any) {
+			params = append(params, value)
+			updates = append(updates, fmt.Sprintf("%s = $%d", column, len(params)))
+		}
+
+		if update.Name != nil {
+			set("name", strings.TrimSpace(*update.Name))
+		}
+
+		if update.Category != nil {
+			set("category", strings.TrimSpace(*update.Category))
+		}
+
+		if update.Subcategory != nil {
+			var subcategory *string
+			if *update.Subcategory != "" {
+				subcategory = update.Subcategory
+			}
+
+			set("subcategory", subcategory)
+		}
+
+		if update.Brand != nil {
+			set("brand", *update.Brand)
+		}
+
+		if update.Size != nil {
+			set("size", *update.Size)
+		}
+
+		if update.Colors != nil {
+			set("colors", *update.Colors)
+		}
+
+		if update.Material != nil {
+			set("material", *update.Material)
+		}
+
+		if update.Season != nil {
+			set("season", *update.Season)
+		}
+
+		if update.PurchaseDate != nil {
+			set("purchase_date", *update.PurchaseDate)
+		}
+
+		if update.Price != nil {
+			set("price", *update.Price)
+		}
+
+		if update.Currency != nil {
+			set("currency", *update.Currency)
+		}
+
+		if update.Notes != nil {
+			set("notes", *update.Notes)
+		}
+
+		if update.Attributes != nil {
+			set("attributes", *update.Attributes)
+		}
+
+		set("updated_at", time.Now())
+
+		sb.WriteString("UPDATE wardrobe_items SET ")
+		sb.WriteString(strings.Join(updates, ", "))
+
+		params = append(params, itemID, userID)
+		sb.WriteString(fmt.Sprintf(" WHERE id = $%d AND user_id = $%d AND deleted_at IS NULL", len(params)-1, len(params)))
+
+		tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, sb.String(), params...)
+		if err != nil {
+			return fmt.Errorf("failed to update wardrobe item: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrWardrobeItemNotFound
+		}
+
+		if update.PhotoIDs != nil {
+			if err := r.setPhotos(ctx, userID, itemID, *update.PhotoIDs); err != nil {
+				return err
+			}
+		}
+
+		item, err = r.GetItem(ctx, userID, itemID)
+
+		return err
+	})
+	if err != nil {
+		return entity.WardrobeItem{}, fmt.Errorf("failed to update wardrobe item %s: %w", itemID, err)
+	}
+
+	return item, nil
+}
+
+// DeleteItem soft deletes the item and detaches it from every outfit it was
+// part of.
+func (r *Repo) DeleteItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		query := `
+UPDATE wardrobe_items
+SET deleted_at = NOW()
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2
+	AND deleted_at IS NULL`
+
+		tag, err := tx.Exec(ctx, query, itemID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to mark wardrobe item deleted: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrWardrobeItemNotFound
+		}
+
+		query = `
+UPDATE outfits
+SET updated_at = NOW()
+WHERE id IN (SELECT outfit_id FROM outfit_items WHERE item_id = $1)`
+
+		if _, err := tx.Exec(ctx, query, itemID); err != nil {
+			return fmt.Errorf("failed to touch outfits: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM outfit_items WHERE item_id = $1`, itemID); err != nil {
+			return fmt.Errorf("failed to detach wardrobe item from outfits: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting wardrobe item %s: %w", itemID, err)
+	}
+
+	return nil
+}
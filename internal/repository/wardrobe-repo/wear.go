@@ -0,0 +1,246 @@
+package wardroberepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const wearLogColumns = `
+	l.id, l.user_id, l.worn_on, l.outfit_id, l.notes,
+	ARRAY(
+		SELECT w.item_id
+		FROM wear_log_items w
+		WHERE w.log_id = l.id
+		ORDER BY w.position
+	),
+	l.created_at`
+
+func scanWearLogEntry(row pgx.Row) (entity.WearLogEntry, error) {
+	var (
+		entry   entity.WearLogEntry
+		itemIDs []uuid.UUID
+	)
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.WornOn,
+		&entry.OutfitID,
+		&entry.Notes,
+		&itemIDs,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.WearLogEntry{}, entity.ErrWearLogEntryNotFound
+		}
+
+		return entity.WearLogEntry{}, fmt.Errorf("failed to scan wear log entry: %w", err)
+	}
+
+	entry.ItemIDs = make([]entity.WardrobeItemID, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		entry.ItemIDs = append(entry.ItemIDs, entity.WardrobeItemID(itemID))
+	}
+
+	return entry, nil
+}
+
+// CreateWearLogEntry logs the entry's items together with the current items of
+// its outfit, making sure all of them belong to the user and are not deleted.
+func (r *Repo) CreateWearLogEntry(ctx context.Context, entry entity.WearLogEntry) (entity.WearLogEntry, error) {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.db.GetTXFromContext(ctx)
+
+		ids := make([]uuid.UUID, 0, len(entry.ItemIDs))
+
+		if entry.OutfitID != nil {
+			query := `
+SELECT ARRAY(
+	SELECT oi.item_id
+	FROM outfit_items oi
+	WHERE oi.outfit_id = o.id
+	ORDER BY oi.position
+)
+FROM outfits o
+WHERE TRUE
+	AND o.id = $1
+	AND o.user_id = $2
+	AND o.deleted_at IS NULL`
+
+			err := tx.QueryRow(ctx, query, *entry.OutfitID, entry.UserID).Scan(&ids)
+
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				return fmt.Errorf("%w: unknown outfit", entity.ErrInvalidWearLogEntry)
+			case err != nil:
+				return fmt.Errorf("failed to get outfit items: %w", err)
+			}
+		}
+
+		for _, itemID := range entry.ItemIDs {
+			if !slices.Contains(ids, uuid.UUID(itemID)) {
+				ids = append(ids, uuid.UUID(itemID))
+			}
+		}
+
+		if len(ids) == 0 {
+			return fmt.Errorf("%w: no items worn", entity.ErrInvalidWearLogEntry)
+		}
+
+		var owned int
+
+		query := `
+SELECT COUNT(*)
+FROM wardrobe_items
+WHERE TRUE
+	AND id = ANY($1)
+	AND user_id = $2
+	AND deleted_at IS NULL`
+
+		if err := tx.QueryRow(ctx, query, ids, entry.UserID).Scan(&owned); err != nil {
+			return fmt.Errorf("failed to check worn items: %w", err)
+		}
+
+		if owned != len(ids) {
+			return fmt.Errorf("%w: unknown wardrobe item", entity.ErrInvalidWearLogEntry)
+		}
+
+		query = `
+INSERT INTO wear_logs (id, user_id, worn_on, outfit_id, notes)
+VALUES ($1, $2, $3, $4, $5)`
+
+		if _, err := tx.Exec(ctx, query, entry.ID, entry.UserID, entry.WornOn, entry.OutfitID, entry.Notes); err != nil {
+			return fmt.Errorf("failed to insert wear log entry: %w", err)
+		}
+
+		query = `
+INSERT INTO wear_log_items (log_id, item_id, position)
+SELECT $1, item.id, item.position
+FROM UNNEST($2::uuid[]) WITH ORDINALITY AS item(id, position)`
+
+		if _, err := tx.Exec(ctx, query, entry.ID, ids); err != nil {
+			return fmt.Errorf("failed to insert worn items: %w", err)
+		}
+
+		var err error
+
+		entry, err = r.GetWearLogEntry(ctx, entry.UserID, entry.ID)
+
+		return err
+	})
+	if err != nil {
+		return entity.WearLogEntry{}, fmt.Errorf("failed to create wear log entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *Repo) GetWearLogEntry(ctx context.Context, userID entity.UserID, entryID entity.WearLogEntryID) (entity.WearLogEntry, error) {
+	query := `
+SELECT ` + wearLogColumns + `
+FROM wear_logs l
+WHERE TRUE
+	AND l.id = $1
+	AND l.user_id = $2`
+
+	entry, err := scanWearLogEntry(r.db.GetTXFromContext(ctx).QueryRow(ctx, query, entryID, userID))
+	if err != nil {
+		return entity.WearLogEntry{}, fmt.Errorf("failed to get wear log entry %s: %w", entryID, err)
+	}
+
+	return entry, nil
+}
+
+func (r *Repo) ListWearLog(ctx context.Context, userID entity.UserID, filter entity.WearLogFilter) ([]entity.WearLogEntry, error) {
+	query := `
+SELECT ` + wearLogColumns + `
+FROM wear_logs l
+WHERE TRUE
+	AND l.user_id = $1
+	AND l.worn_on BETWEEN $2 AND $3
+ORDER BY l.worn_on, l.created_at, l.id`
+
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, query, userID, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wear log: %w", err)
+	}
+
+	defer rows.Close()
+
+	entries := make([]entity.WearLogEntry, 0)
+
+	for rows.Next() {
+		entry, err := scanWearLogEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate wear log: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *Repo) DeleteWearLogEntry(ctx context.Context, userID entity.UserID, entryID entity.WearLogEntryID) error {
+	query := `
+DELETE FROM wear_logs
+WHERE TRUE
+	AND id = $1
+	AND user_id = $2`
+
+	tag, err := r.db.GetTXFromContext(ctx).Exec(ctx, query, entryID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete wear log entry %s: %w", entryID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.ErrWearLogEntryNotFound
+	}
+
+	return nil
+}
+
+// GetItemWearStats counts the wear log entries of a live item. Cost per wear is
+// left to the caller.
+func (r *Repo) GetItemWearStats(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) (entity.ItemWearStats, error) {
+	query := `
+SELECT i.id, COUNT(l.id), MAX(l.worn_on), i.price, i.currency
+FROM wardrobe_items i
+	LEFT JOIN wear_log_items w ON w.item_id = i.id
+	LEFT JOIN wear_logs l ON l.id = w.log_id
+WHERE TRUE
+	AND i.id = $1
+	AND i.user_id = $2
+	AND i.deleted_at IS NULL
+GROUP BY i.id`
+
+	var stats entity.ItemWearStats
+
+	err := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, itemID, userID).Scan(
+		&stats.ItemID,
+		&stats.TimesWorn,
+		&stats.LastWornOn,
+		&stats.Price,
+		&stats.Currency,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ItemWearStats{}, fmt.Errorf("failed to get wear stats of item %s: %w", itemID, entity.ErrWardrobeItemNotFound)
+		}
+
+		return entity.ItemWearStats{}, fmt.Errorf("failed to get wear stats of item %s: %w", itemID, err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,321 @@
+package yookassarepo
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	minorUnits    = 100
+	minorDigits   = 2
+	orderIDKey    = "order_id"
+	paymentIDKey  = "payment_id"
+	redirectType  = "redirect"
+	refundSuccess = "succeeded"
+	refundFailure = "canceled"
+)
+
+type Config struct {
+	Host          string
+	Schema        string
+	ShopID        string
+	SecretKey     string
+	ReturnURL     string
+	WebhookSecret string
+	Timeout       time.Duration
+}
+
+// Client talks to a YooKassa-compatible payment API. Payments are created
+// for two-stage capture: the customer's money is held first and taken once
+// the order is confirmed to still be payable.
+type Client struct {
+	cfg    Config
+	client *http.Client
+}
+
+func New(cfg Config) *Client {
+	return &Client{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+type Amount struct {
+	Value    string `json:"value"`
+	Currency string `json:"currency"`
+}
+
+type Confirmation struct {
+	Type            string `json:"type"`
+	ReturnURL       string `json:"return_url,omitempty"`
+	ConfirmationURL string `json:"confirmation_url,omitempty"`
+}
+
+type PaymentRequest struct {
+	Amount       Amount            `json:"amount"`
+	Capture      bool              `json:"capture"`
+	Confirmation Confirmation      `json:"confirmation"`
+	Description  string            `json:"description,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+type Payment struct {
+	ID           string            `json:"id"`
+	Status       string            `json:"status"`
+	Paid         bool              `json:"paid"`
+	Amount       Amount            `json:"amount"`
+	Confirmation *Confirmation     `json:"confirmation,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+type CaptureRequest struct {
+	Amount Amount `json:"amount"`
+}
+
+type RefundRequest struct {
+	PaymentID string `json:"payment_id"`
+	Amount    Amount `json:"amount"`
+}
+
+type Refund struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	PaymentID string `json:"payment_id"`
+	Amount    Amount `json:"amount"`
+}
+
+// Notification is the webhook body. Object is a payment for payment events
+// and a refund for refund events.
+type Notification struct {
+	Type   string          `json:"type"`
+	Event  string          `json:"event"`
+	Object json.RawMessage `json:"object"`
+}
+
+func (c *Client) CreatePayment(ctx context.Context, request entity.PaymentRequest) (entity.GatewayPayment, error) {
+	body := PaymentRequest{
+		Amount:  formatAmount(request.Amount, request.Currency),
+		Capture: false,
+		Confirmation: Confirmation{
+			Type:      redirectType,
+			ReturnURL: c.cfg.ReturnURL,
+		},
+		Description: request.Description,
+		Metadata: map[string]string{
+			orderIDKey:   request.OrderID.String(),
+			paymentIDKey: request.ID.String(),
+		},
+	}
+
+	var payment Payment
+
+	if err := c.do(ctx, "/v3/payments", request.ID.String(), body, &payment); err != nil {
+		return entity.GatewayPayment{}, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	return convertPayment(payment)
+}
+
+// CapturePayment takes the money held for the payment.
+func (c *Client) CapturePayment(ctx context.Context, providerID string, amount int64, currency string) (entity.GatewayPayment, error) {
+	var payment Payment
+
+	path := "/v3/payments/" + url.PathEscape(providerID) + "/capture"
+
+	if err := c.do(ctx, path, "capture-"+providerID, CaptureRequest{Amount: formatAmount(amount, currency)}, &payment); err != nil {
+		return entity.GatewayPayment{}, fmt.Errorf("failed to capture payment %s: %w", providerID, err)
+	}
+
+	return convertPayment(payment)
+}
+
+// CancelPayment releases the money held for a payment that is not captured.
+func (c *Client) CancelPayment(ctx context.Context, providerID string) (entity.GatewayPayment, error) {
+	var payment Payment
+
+	path := "/v3/payments/" + url.PathEscape(providerID) + "/cancel"
+
+	if err := c.do(ctx, path, "cancel-"+providerID, struct{}{}, &payment); err != nil {
+		return entity.GatewayPayment{}, fmt.Errorf("failed to cancel payment %s: %w", providerID, err)
+	}
+
+	return convertPayment(payment)
+}
+
+// RefundPayment returns the payment's money to the customer. It reports
+// whether the refund is already done; a refund that is not is finished by a
+// refund.succeeded notification.
+func (c *Client) RefundPayment(ctx context.Context, providerID string, amount int64, currency string) (bool, error) {
+	var refund Refund
+
+	body := RefundRequest{
+		PaymentID: providerID,
+		Amount:    formatAmount(amount, currency),
+	}
+
+	if err := c.do(ctx, "/v3/refunds", "refund-"+providerID, body, &refund); err != nil {
+		return false, fmt.Errorf("failed to refund payment %s: %w", providerID, err)
+	}
+
+	if refund.Status == refundFailure {
+		return false, fmt.Errorf("%w: refund of %s was canceled", entity.ErrPaymentFailed, providerID)
+	}
+
+	return refund.Status == refundSuccess, nil
+}
+
+// ParseWebhook checks the notification's signature, a hex HMAC-SHA256 of the
+// body keyed with the webhook secret, and decodes it.
+func (c *Client) ParseWebhook(body []byte, signature string) (entity.PaymentEvent, error) {
+	expected := Sign(c.cfg.WebhookSecret, body)
+
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return entity.PaymentEvent{}, entity.ErrInvalidSignature
+	}
+
+	var notification Notification
+
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return entity.PaymentEvent{}, fmt.Errorf("%w: %w", entity.ErrInvalidPayment, err)
+	}
+
+	event := entity.PaymentEvent{Type: entity.PaymentEventType(notification.Event)}
+
+	var amount Amount
+
+	switch event.Type {
+	case entity.PaymentEventWaitingForCapture, entity.PaymentEventSucceeded, entity.PaymentEventCanceled:
+		var payment Payment
+
+		if err := json.Unmarshal(notification.Object, &payment); err != nil {
+			return entity.PaymentEvent{}, fmt.Errorf("%w: %w", entity.ErrInvalidPayment, err)
+		}
+
+		event.ProviderID, amount = payment.ID, payment.Amount
+	case entity.PaymentEventRefundSucceeded:
+		var refund Refund
+
+		if err := json.Unmarshal(notification.Object, &refund); err != nil {
+			return entity.PaymentEvent{}, fmt.Errorf("%w: %w", entity.ErrInvalidPayment, err)
+		}
+
+		event.ProviderID, amount = refund.PaymentID, refund.Amount
+	default:
+		return entity.PaymentEvent{}, fmt.Errorf("%w: unknown event %q", entity.ErrInvalidPayment, notification.Event)
+	}
+
+	value, err := parseAmount(amount.Value)
+	if err != nil {
+		return entity.PaymentEvent{}, err
+	}
+
+	event.Amount, event.Currency = value, amount.Currency
+
+	return event, nil
+}
+
+// Sign returns the signature of a webhook body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *Client) do(ctx context.Context, path, idempotenceKey string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	requestURL := url.URL{
+		Scheme: c.cfg.Schema,
+		Host:   c.cfg.Host,
+		Path:   path,
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.SetBasicAuth(c.cfg.ShopID, c.cfg.SecretKey)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Idempotence-Key", idempotenceKey)
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("%w: %w", entity.ErrPaymentFailed, err)
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close response body")
+		}
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w with status: %s", entity.ErrPaymentFailed, response.Status)
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func convertPayment(payment Payment) (entity.GatewayPayment, error) {
+	amount, err := parseAmount(payment.Amount.Value)
+	if err != nil {
+		return entity.GatewayPayment{}, err
+	}
+
+	converted := entity.GatewayPayment{
+		ProviderID: payment.ID,
+		Status:     entity.PaymentStatus(payment.Status),
+		Amount:     amount,
+		Currency:   payment.Amount.Currency,
+	}
+
+	if payment.Confirmation != nil && payment.Confirmation.ConfirmationURL != "" {
+		converted.ConfirmationURL = &payment.Confirmation.ConfirmationURL
+	}
+
+	return converted, nil
+}
+
+func formatAmount(amount int64, currency string) Amount {
+	return Amount{
+		Value:    fmt.Sprintf("%d.%02d", amount/minorUnits, amount%minorUnits),
+		Currency: currency,
+	}
+}
+
+func parseAmount(value string) (int64, error) {
+	units, cents, _ := strings.Cut(value, ".")
+
+	if len(cents) > minorDigits {
+		return 0, fmt.Errorf("%w: bad amount %q", entity.ErrInvalidPayment, value)
+	}
+
+	amount, err := strconv.ParseInt(units+cents+strings.Repeat("0", minorDigits-len(cents)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad amount %q", entity.ErrInvalidPayment, value)
+	}
+
+	return amount, nil
+}
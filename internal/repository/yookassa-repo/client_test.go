@@ -0,0 +1,44 @@
+package yookassarepo_test
+
+import (
+	"testing"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	yookassarepo "github.com/romanpitatelev/clothing-service/internal/repository/yookassa-repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhook(t *testing.T) {
+	client := yookassarepo.New(yookassarepo.Config{WebhookSecret: "secret"})
+
+	payment := []byte(`{"type":"notification","event":"payment.succeeded",` +
+		`"object":{"id":"pay-1","status":"succeeded","amount":{"value":"1500.5","currency":"RUB"}}}`)
+
+	event, err := client.ParseWebhook(payment, yookassarepo.Sign("secret", payment))
+	require.NoError(t, err)
+	require.Equal(t, entity.PaymentEvent{
+		Type:       entity.PaymentEventSucceeded,
+		ProviderID: "pay-1",
+		Amount:     150050,
+		Currency:   "RUB",
+	}, event)
+
+	_, err = client.ParseWebhook(payment, yookassarepo.Sign("other", payment))
+	require.ErrorIs(t, err, entity.ErrInvalidSignature)
+
+	refund := []byte(`{"type":"notification","event":"refund.succeeded",` +
+		`"object":{"id":"ref-1","payment_id":"pay-1","status":"succeeded","amount":{"value":"20","currency":"RUB"}}}`)
+
+	event, err = client.ParseWebhook(refund, yookassarepo.Sign("secret", refund))
+	require.NoError(t, err)
+	require.Equal(t, "pay-1", event.ProviderID)
+	require.Equal(t, int64(2000), event.Amount)
+
+	for _, body := range []string{
+		`{"event":"payout.succeeded","object":{}}`,
+		`{"event":"payment.succeeded","object":{"id":"pay-1","amount":{"value":"1.005","currency":"RUB"}}}`,
+	} {
+		_, err = client.ParseWebhook([]byte(body), yookassarepo.Sign("secret", []byte(body)))
+		require.ErrorIs(t, err, entity.ErrInvalidPayment)
+	}
+}
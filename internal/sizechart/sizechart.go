@@ -0,0 +1,333 @@
+// Package sizechart converts clothing and shoe sizes between regions and
+// picks the size that fits a set of body measurements.
+package sizechart
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// Group is a set of categories sharing a size table.
+type Group string
+
+const (
+	GroupTops    Group = "tops"
+	GroupBottoms Group = "bottoms"
+	GroupDresses Group = "dresses"
+	GroupShoes   Group = "shoes"
+)
+
+//nolint:gochecknoglobals
+var Groups = []Group{GroupTops, GroupBottoms, GroupDresses, GroupShoes}
+
+var (
+	ErrNoSizes         = errors.New("category has no sizes")
+	ErrOutOfChart      = errors.New("measurements are out of the size chart")
+	ErrNotEnoughInputs = errors.New("not enough measurements")
+)
+
+// GroupOf maps a root category of the taxonomy to its size group.
+func GroupOf(category string) (Group, error) {
+	switch category {
+	case "tops", "outerwear":
+		return GroupTops, nil
+	case "bottoms":
+		return GroupBottoms, nil
+	case "dresses":
+		return GroupDresses, nil
+	case "shoes":
+		return GroupShoes, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrNoSizes, category)
+	}
+}
+
+// Measurements are body measurements in centimeters and an EU shoe size.
+type Measurements struct {
+	Chest    *float64
+	Waist    *float64
+	Hips     *float64
+	ShoeSize *float64
+	Fit      *entity.Fit
+}
+
+// row is one clothing size. The girths are the largest ones the size fits,
+// zero when the chart does not use them.
+type row struct {
+	letter, ru, eu, us, uk string
+	chest, waist, hips     float64
+}
+
+func (r row) sizes() entity.Sizes {
+	return entity.Sizes{
+		entity.SizeRegionINT: r.letter,
+		entity.SizeRegionRU:  r.ru,
+		entity.SizeRegionEU:  r.eu,
+		entity.SizeRegionUS:  r.us,
+		entity.SizeRegionUK:  r.uk,
+	}
+}
+
+func (r row) label(region entity.SizeRegion) string {
+	return r.sizes()[region]
+}
+
+//nolint:gochecknoglobals
+var clothing = map[entity.SizeChart][]row{
+	entity.SizeChartWomen: {
+		{"XXS", "40", "32", "0", "4", 80, 62, 88},
+		{"XS", "42", "34", "2", "6", 84, 66, 92},
+		{"S", "44", "36", "4", "8", 88, 70, 96},
+		{"M", "46", "38", "6", "10", 92, 74, 100},
+		{"L", "48", "40", "8", "12", 96, 78, 104},
+		{"XL", "50", "42", "10", "14", 100, 82, 108},
+		{"XXL", "52", "44", "12", "16", 104, 86, 112},
+		{"3XL", "54", "46", "14", "18", 110, 92, 118},
+	},
+	// Men's US and UK sizes are the chest girth in inches.
+	entity.SizeChartMen: {
+		{"XS", "44", "44", "34", "34", 88, 76, 0},
+		{"S", "46", "46", "36", "36", 92, 80, 0},
+		{"M", "48", "48", "38", "38", 96, 84, 0},
+		{"L", "50", "50", "40", "40", 100, 88, 0},
+		{"XL", "52", "52", "42", "42", 104, 92, 0},
+		{"XXL", "54", "54", "44", "44", 108, 96, 0},
+		{"3XL", "56", "56", "46", "46", 112, 100, 0},
+	},
+}
+
+// letterAliases spells the extended letter sizes the way the chart does.
+//
+//nolint:gochecknoglobals
+var letterAliases = map[string]string{
+	"2XS":  "XXS",
+	"2XL":  "XXL",
+	"XXXL": "3XL",
+}
+
+// Recommend returns the smallest size whose girths fit every measurement
+// relevant to the group. A loose fit goes one size up.
+func Recommend(chart entity.SizeChart, group Group, m Measurements) (entity.Sizes, error) {
+	if group == GroupShoes {
+		if m.ShoeSize == nil {
+			return nil, ErrNotEnoughInputs
+		}
+
+		return shoeSizes(chart, *m.ShoeSize), nil
+	}
+
+	index, err := recommendIndex(chart, group, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return clothing[chart][index].sizes(), nil
+}
+
+func recommendIndex(chart entity.SizeChart, group Group, m Measurements) (int, error) {
+	rows, ok := clothing[chart]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrNoSizes, chart)
+	}
+
+	var girths []girth
+
+	switch group {
+	case GroupTops:
+		girths = []girth{{m.Chest, chestOf}}
+	case GroupBottoms:
+		girths = []girth{{m.Waist, waistOf}, {m.Hips, hipsOf}}
+	case GroupDresses:
+		girths = []girth{{m.Chest, chestOf}, {m.Waist, waistOf}, {m.Hips, hipsOf}}
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrNoSizes, group)
+	}
+
+	index, used := 0, false
+
+	for _, g := range girths {
+		if g.value == nil || g.bound(rows[0]) == 0 {
+			continue
+		}
+
+		i := fitting(rows, *g.value, g.bound)
+		if i < 0 {
+			return 0, ErrOutOfChart
+		}
+
+		index, used = max(index, i), true
+	}
+
+	if !used {
+		return 0, ErrNotEnoughInputs
+	}
+
+	if m.Fit != nil && *m.Fit == entity.FitLoose && index < len(rows)-1 {
+		index++
+	}
+
+	return index, nil
+}
+
+// girth pairs a measurement with the chart column it is checked against.
+type girth struct {
+	value *float64
+	bound func(r row) float64
+}
+
+func chestOf(r row) float64 { return r.chest }
+func waistOf(r row) float64 { return r.waist }
+func hipsOf(r row) float64  { return r.hips }
+
+// fitting returns the first size the girth fits into, or -1.
+func fitting(rows []row, value float64, girth func(r row) float64) int {
+	for i, r := range rows {
+		if value <= girth(r) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Convert writes a size of the group given in one region as in another.
+func Convert(chart entity.SizeChart, group Group, size string, from, to entity.SizeRegion) (string, error) {
+	if group == GroupShoes {
+		eu, err := parseShoeSize(chart, size, from)
+		if err != nil {
+			return "", err
+		}
+
+		label, ok := shoeSizes(chart, eu)[to]
+		if !ok {
+			return "", fmt.Errorf("%w: no %s shoe sizes", entity.ErrUnknownSize, to)
+		}
+
+		return label, nil
+	}
+
+	index, err := parseClothingSize(chart, size, from)
+	if err != nil {
+		return "", err
+	}
+
+	return clothing[chart][index].label(to), nil
+}
+
+// Difference counts the sizes between the recommended size and the given one,
+// positive when the given size is larger. Shoe sizes are compared in whole EU
+// sizes.
+func Difference(chart entity.SizeChart, group Group, m Measurements, size string, region entity.SizeRegion) (int, error) {
+	if group == GroupShoes {
+		if m.ShoeSize == nil {
+			return 0, ErrNotEnoughInputs
+		}
+
+		eu, err := parseShoeSize(chart, size, region)
+		if err != nil {
+			return 0, err
+		}
+
+		return int(math.Round(eu - roundToHalf(*m.ShoeSize))), nil
+	}
+
+	recommended, err := recommendIndex(chart, group, m)
+	if err != nil {
+		return 0, err
+	}
+
+	index, err := parseClothingSize(chart, size, region)
+	if err != nil {
+		return 0, err
+	}
+
+	return index - recommended, nil
+}
+
+// parseClothingSize finds the size in the chart. Letter sizes are understood
+// whatever the region.
+func parseClothingSize(chart entity.SizeChart, size string, region entity.SizeRegion) (int, error) {
+	rows, ok := clothing[chart]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrNoSizes, chart)
+	}
+
+	size = strings.ToUpper(strings.TrimSpace(size))
+	if alias, ok := letterAliases[size]; ok {
+		size = alias
+	}
+
+	for i, r := range rows {
+		if r.letter == size || r.label(region) == size {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q in %s", entity.ErrUnknownSize, size, region)
+}
+
+// UK adult shoe sizes count barleycorns (a third of an inch) from 25, EU sizes
+// count Paris points (two thirds of a centimeter), so
+// UK = EU * 2/3 cm / (2.54/3 cm) - 25. Russian sizes run one below EU.
+const (
+	euToUK         = 2.0 / 2.54
+	ukOffset       = 25.0
+	ruOffset       = 1.0
+	usMenOffset    = 1.0
+	usWomenOffset  = 2.0
+	halfSizeFactor = 2.0
+)
+
+func shoeSizes(chart entity.SizeChart, eu float64) entity.Sizes {
+	uk := eu*euToUK - ukOffset
+
+	us := uk + usMenOffset
+	if chart == entity.SizeChartWomen {
+		us = uk + usWomenOffset
+	}
+
+	return entity.Sizes{
+		entity.SizeRegionRU: formatShoeSize(eu - ruOffset),
+		entity.SizeRegionEU: formatShoeSize(eu),
+		entity.SizeRegionUS: formatShoeSize(us),
+		entity.SizeRegionUK: formatShoeSize(uk),
+	}
+}
+
+// parseShoeSize returns the EU size.
+func parseShoeSize(chart entity.SizeChart, size string, region entity.SizeRegion) (float64, error) {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(size), ",", "."), 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("%w: %q", entity.ErrUnknownSize, size)
+	}
+
+	switch region {
+	case entity.SizeRegionEU:
+		return value, nil
+	case entity.SizeRegionRU:
+		return value + ruOffset, nil
+	case entity.SizeRegionUK:
+		return (value + ukOffset) / euToUK, nil
+	case entity.SizeRegionUS:
+		if chart == entity.SizeChartWomen {
+			return (value - usWomenOffset + ukOffset) / euToUK, nil
+		}
+
+		return (value - usMenOffset + ukOffset) / euToUK, nil
+	default:
+		return 0, fmt.Errorf("%w: no %s shoe sizes", entity.ErrUnknownSize, region)
+	}
+}
+
+func roundToHalf(value float64) float64 {
+	return math.Round(value*halfSizeFactor) / halfSizeFactor
+}
+
+func formatShoeSize(value float64) string {
+	return strconv.FormatFloat(roundToHalf(value), 'f', -1, 64)
+}
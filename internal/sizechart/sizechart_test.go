@@ -0,0 +1,177 @@
+package sizechart_test
+
+import (
+	"testing"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/sizechart"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommend(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		chart entity.SizeChart
+		group sizechart.Group
+		m     sizechart.Measurements
+		want  entity.Sizes
+		err   error
+	}{
+		{
+			name:  "men's top by chest",
+			chart: entity.SizeChartMen,
+			group: sizechart.GroupTops,
+			m:     sizechart.Measurements{Chest: utils.Pointer(95.0)},
+			want:  entity.Sizes{"INT": "M", "RU": "48", "EU": "48", "US": "38", "UK": "38"},
+		},
+		{
+			name:  "loose fit goes a size up",
+			chart: entity.SizeChartMen,
+			group: sizechart.GroupTops,
+			m:     sizechart.Measurements{Chest: utils.Pointer(95.0), Fit: utils.Pointer(entity.FitLoose)},
+			want:  entity.Sizes{"INT": "L", "RU": "50", "EU": "50", "US": "40", "UK": "40"},
+		},
+		{
+			name:  "women's bottoms take the larger of waist and hips",
+			chart: entity.SizeChartWomen,
+			group: sizechart.GroupBottoms,
+			m:     sizechart.Measurements{Waist: utils.Pointer(68.0), Hips: utils.Pointer(99.0)},
+			want:  entity.Sizes{"INT": "M", "RU": "46", "EU": "38", "US": "6", "UK": "10"},
+		},
+		{
+			name:  "men's chart ignores hips",
+			chart: entity.SizeChartMen,
+			group: sizechart.GroupBottoms,
+			m:     sizechart.Measurements{Hips: utils.Pointer(99.0)},
+			err:   sizechart.ErrNotEnoughInputs,
+		},
+		{
+			name:  "smaller than the chart fits the smallest size",
+			chart: entity.SizeChartWomen,
+			group: sizechart.GroupDresses,
+			m:     sizechart.Measurements{Chest: utils.Pointer(70.0)},
+			want:  entity.Sizes{"INT": "XXS", "RU": "40", "EU": "32", "US": "0", "UK": "4"},
+		},
+		{
+			name:  "larger than the chart",
+			chart: entity.SizeChartWomen,
+			group: sizechart.GroupTops,
+			m:     sizechart.Measurements{Chest: utils.Pointer(130.0)},
+			err:   sizechart.ErrOutOfChart,
+		},
+		{
+			name:  "men's shoes",
+			chart: entity.SizeChartMen,
+			group: sizechart.GroupShoes,
+			m:     sizechart.Measurements{ShoeSize: utils.Pointer(42.0)},
+			want:  entity.Sizes{"RU": "41", "EU": "42", "US": "9", "UK": "8"},
+		},
+		{
+			name:  "women's shoes",
+			chart: entity.SizeChartWomen,
+			group: sizechart.GroupShoes,
+			m:     sizechart.Measurements{ShoeSize: utils.Pointer(38.0)},
+			want:  entity.Sizes{"RU": "37", "EU": "38", "US": "7", "UK": "5"},
+		},
+		{
+			name:  "shoes without shoe size",
+			chart: entity.SizeChartWomen,
+			group: sizechart.GroupShoes,
+			m:     sizechart.Measurements{Chest: utils.Pointer(90.0)},
+			err:   sizechart.ErrNotEnoughInputs,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			sizes, err := sizechart.Recommend(test.chart, test.group, test.m)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, sizes)
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		chart    entity.SizeChart
+		group    sizechart.Group
+		size     string
+		from, to entity.SizeRegion
+		want     string
+		err      error
+	}{
+		{entity.SizeChartWomen, sizechart.GroupTops, "44", "RU", "EU", "36", nil},
+		{entity.SizeChartWomen, sizechart.GroupTops, "44", "EU", "RU", "52", nil},
+		{entity.SizeChartWomen, sizechart.GroupTops, "10", "UK", "US", "6", nil},
+		{entity.SizeChartWomen, sizechart.GroupTops, " xxxl ", "EU", "RU", "54", nil},
+		{entity.SizeChartMen, sizechart.GroupBottoms, "2xl", "INT", "US", "44", nil},
+		{entity.SizeChartMen, sizechart.GroupTops, "48", "RU", "INT", "M", nil},
+		{entity.SizeChartMen, sizechart.GroupTops, "47", "RU", "INT", "", entity.ErrUnknownSize},
+		{entity.SizeChartMen, sizechart.GroupShoes, "9", "US", "EU", "42", nil},
+		{entity.SizeChartWomen, sizechart.GroupShoes, "37", "RU", "UK", "5", nil},
+		{entity.SizeChartWomen, sizechart.GroupShoes, "38,5", "EU", "US", "7.5", nil},
+		{entity.SizeChartWomen, sizechart.GroupShoes, "38", "EU", "INT", "", entity.ErrUnknownSize},
+		{entity.SizeChartWomen, sizechart.GroupShoes, "big", "EU", "RU", "", entity.ErrUnknownSize},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.chart)+" "+test.size+" "+string(test.from)+" to "+string(test.to), func(t *testing.T) {
+			t.Parallel()
+
+			size, err := sizechart.Convert(test.chart, test.group, test.size, test.from, test.to)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, size)
+		})
+	}
+}
+
+func TestDifference(t *testing.T) {
+	t.Parallel()
+
+	m := sizechart.Measurements{Chest: utils.Pointer(95.0), ShoeSize: utils.Pointer(42.0)}
+
+	difference, err := sizechart.Difference(entity.SizeChartMen, sizechart.GroupTops, m, "M", entity.SizeRegionEU)
+	require.NoError(t, err)
+	require.Equal(t, 0, difference)
+
+	difference, err = sizechart.Difference(entity.SizeChartMen, sizechart.GroupTops, m, "52", entity.SizeRegionRU)
+	require.NoError(t, err)
+	require.Equal(t, 2, difference)
+
+	difference, err = sizechart.Difference(entity.SizeChartMen, sizechart.GroupShoes, m, "7", entity.SizeRegionUK)
+	require.NoError(t, err)
+	require.Equal(t, -1, difference)
+
+	_, err = sizechart.Difference(entity.SizeChartMen, sizechart.GroupBottoms, m, "M", entity.SizeRegionEU)
+	require.ErrorIs(t, err, sizechart.ErrNotEnoughInputs)
+}
+
+func TestGroupOf(t *testing.T) {
+	t.Parallel()
+
+	group, err := sizechart.GroupOf("outerwear")
+	require.NoError(t, err)
+	require.Equal(t, sizechart.GroupTops, group)
+
+	_, err = sizechart.GroupOf("accessories")
+	require.ErrorIs(t, err, sizechart.ErrNoSizes)
+}
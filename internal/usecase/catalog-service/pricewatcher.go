@@ -0,0 +1,158 @@
+package catalogservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+const minorUnits = 100
+
+type priceWatcherStore interface {
+	CreatePriceAlerts(ctx context.Context) (int, error)
+	ListPendingAlerts(ctx context.Context, limit int) ([]entity.PriceAlert, error)
+	SetAlertStatus(ctx context.Context, alertID entity.PriceAlertID, status entity.PriceAlertStatus) error
+}
+
+type notifier interface {
+	Notify(ctx context.Context, notification entity.Notification) error
+}
+
+type outboxStore interface {
+	Enqueue(ctx context.Context, message entity.OutboxMessage) error
+}
+
+type txManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type PriceWatcherConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// PriceWatcher periodically raises alerts for wishlist products that got
+// cheaper and delivers them as price drop notifications, which reach the
+// user through the channels and outside the quiet hours they chose. Connected
+// clients get every alert in real time. An alert is marked sent in the
+// transaction that records its notification and event, so it is delivered
+// exactly once.
+type PriceWatcher struct {
+	cfg         PriceWatcherConfig
+	store       priceWatcherStore
+	notifier    notifier
+	outboxStore outboxStore
+	txManager   txManager
+}
+
+func NewPriceWatcher(
+	cfg PriceWatcherConfig,
+	store priceWatcherStore,
+	notifier notifier,
+	outboxStore outboxStore,
+	txManager txManager,
+) *PriceWatcher {
+	return &PriceWatcher{
+		cfg:         cfg,
+		store:       store,
+		notifier:    notifier,
+		outboxStore: outboxStore,
+		txManager:   txManager,
+	}
+}
+
+func (p *PriceWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Check(ctx); err != nil {
+				log.Warn().Err(err).Msg("failed to check price drops")
+			}
+		}
+	}
+}
+
+func (p *PriceWatcher) Check(ctx context.Context) error {
+	created, err := p.store.CreatePriceAlerts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create price alerts: %w", err)
+	}
+
+	alerts, err := p.store.ListPendingAlerts(ctx, p.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list pending price alerts: %w", err)
+	}
+
+	var sent, failed int
+
+	for _, alert := range alerts {
+		err := p.deliver(ctx, alert)
+		if err == nil {
+			sent++
+
+			continue
+		}
+
+		log.Warn().Err(err).Str("alert", alert.ID.String()).Msg("failed to deliver price alert")
+
+		if err := p.store.SetAlertStatus(ctx, alert.ID, entity.PriceAlertStatusFailed); err != nil {
+			return fmt.Errorf("failed to record price alert failure: %w", err)
+		}
+
+		failed++
+	}
+
+	if created > 0 || len(alerts) > 0 {
+		log.Info().Int("created", created).Int("sent", sent).Int("failed", failed).Msg("price alerts processed")
+	}
+
+	return nil
+}
+
+func (p *PriceWatcher) deliver(ctx context.Context, alert entity.PriceAlert) error {
+	event := entity.NewEvent(alert.UserID, entity.EventPriceDropped, entity.PriceDroppedData{
+		ProductID: alert.ProductID,
+		OldPrice:  alert.OldPrice,
+		NewPrice:  alert.NewPrice,
+		Currency:  alert.Currency,
+	})
+
+	return p.txManager.WithTx(ctx, func(ctx context.Context) error { //nolint:wrapcheck
+		if err := p.outboxStore.Enqueue(ctx, entity.NewOutboxMessage(entity.OutboxKindEvent, event)); err != nil {
+			return fmt.Errorf("failed to enqueue price alert event: %w", err)
+		}
+
+		err := p.notifier.Notify(ctx, entity.Notification{
+			UserID:   alert.UserID,
+			Category: entity.NotificationCategoryPriceDrops,
+			Title:    "Цена снизилась",
+			Body: fmt.Sprintf("%s — %s вместо %s",
+				alert.ProductName, formatPrice(alert.NewPrice, alert.Currency), formatPrice(alert.OldPrice, alert.Currency)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to notify about price alert: %w", err)
+		}
+
+		if err := p.store.SetAlertStatus(ctx, alert.ID, entity.PriceAlertStatusSent); err != nil {
+			return fmt.Errorf("failed to record price alert delivery: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func formatPrice(price int64, currency string) string {
+	if price%minorUnits == 0 {
+		return fmt.Sprintf("%d %s", price/minorUnits, currency)
+	}
+
+	return fmt.Sprintf("%d.%02d %s", price/minorUnits, price%minorUnits, currency)
+}
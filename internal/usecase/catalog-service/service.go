@@ -0,0 +1,281 @@
+package catalogservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/catalogfeed"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+type catalogStore interface {
+	StartImport(ctx context.Context, imp entity.FeedImport) (entity.FeedImport, error)
+	FinishImport(ctx context.Context, imp entity.FeedImport) (entity.FeedImport, error)
+	ListImports(ctx context.Context, filter entity.FeedImportFilter) ([]entity.FeedImport, error)
+	GetProductImages(ctx context.Context, feedID, externalID string) ([]entity.ProductImage, error)
+	UpsertProduct(ctx context.Context, importID entity.FeedImportID, feedID string, offer entity.CatalogOffer,
+		images []entity.ProductImage) (entity.ImportResult, []string, error)
+	DeactivateMissing(ctx context.Context, feedID string, importID entity.FeedImportID) (int, error)
+	GetProduct(ctx context.Context, productID entity.ProductID) (entity.Product, error)
+	GetProductImage(ctx context.Context, productID entity.ProductID, position int) (entity.ProductImage, error)
+	ListProducts(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, *string, error)
+	AddWishlistItem(ctx context.Context, item entity.WishlistItem) (entity.WishlistItem, error)
+	ListWishlist(ctx context.Context, userID entity.UserID, filter entity.WishlistFilter) ([]entity.WishlistItem, error)
+	UpdateWishlistItem(ctx context.Context, userID entity.UserID, productID entity.ProductID,
+		update entity.WishlistItemUpdate) (entity.WishlistItem, error)
+	DeleteWishlistItem(ctx context.Context, userID entity.UserID, productID entity.ProductID) error
+	GetPriceHistory(ctx context.Context, productID entity.ProductID) ([]entity.PricePoint, error)
+	ListPriceAlerts(ctx context.Context, userID entity.UserID, filter entity.PriceAlertFilter) ([]entity.PriceAlert, error)
+}
+
+type objectsStore interface {
+	StoreObject(ctx context.Context, data []byte, contentType string) (string, error)
+	ReleaseObject(ctx context.Context, key string) error
+	PresignObject(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+type imageFetcher interface {
+	FetchImage(ctx context.Context, sourceURL string) ([]byte, string, error)
+}
+
+type Config struct {
+	URLLifetime time.Duration
+}
+
+type Service struct {
+	cfg          Config
+	catalogStore catalogStore
+	objectsStore objectsStore
+	imageFetcher imageFetcher
+}
+
+func New(cfg Config, catalogStore catalogStore, objectsStore objectsStore, imageFetcher imageFetcher) *Service {
+	return &Service{
+		cfg:          cfg,
+		catalogStore: catalogStore,
+		objectsStore: objectsStore,
+		imageFetcher: imageFetcher,
+	}
+}
+
+// Import reads a partner feed and brings the feed's products in line with it.
+// Offers that fail to import are counted and reported in the import's errors
+// instead of failing the whole import; products missing from the feed are
+// marked unavailable.
+func (s *Service) Import(ctx context.Context, feedID string, format entity.FeedFormat, r io.Reader) (entity.FeedImport, error) {
+	if err := entity.ValidateFeedID(feedID); err != nil {
+		return entity.FeedImport{}, fmt.Errorf("feed validation failed: %w", err)
+	}
+
+	if err := format.Validate(); err != nil {
+		return entity.FeedImport{}, fmt.Errorf("feed validation failed: %w", err)
+	}
+
+	imp, err := s.catalogStore.StartImport(ctx, entity.FeedImport{
+		ID:     entity.FeedImportID(uuid.New()),
+		FeedID: feedID,
+		Format: format,
+	})
+	if err != nil {
+		return entity.FeedImport{}, fmt.Errorf("failed to start import: %w", err)
+	}
+
+	if err := s.importFeed(ctx, &imp, r); err != nil {
+		imp.Status = entity.FeedImportStatusFailed
+		imp.AddError("%v", err)
+
+		if _, finishErr := s.catalogStore.FinishImport(context.WithoutCancel(ctx), imp); finishErr != nil {
+			log.Warn().Err(finishErr).Str("import", imp.ID.String()).Msg("failed to record failed import")
+		}
+
+		return entity.FeedImport{}, fmt.Errorf("failed to import feed %s: %w", feedID, err)
+	}
+
+	imp.Status = entity.FeedImportStatusSucceeded
+
+	finished, err := s.catalogStore.FinishImport(ctx, imp)
+	if err != nil {
+		return entity.FeedImport{}, fmt.Errorf("failed to finish import: %w", err)
+	}
+
+	return finished, nil
+}
+
+func (s *Service) importFeed(ctx context.Context, imp *entity.FeedImport, r io.Reader) error {
+	result, err := catalogfeed.Parse(imp.Format, r)
+	if err != nil {
+		return fmt.Errorf("failed to parse feed: %w", err)
+	}
+
+	imp.Offers = len(result.Offers)
+
+	for _, rejection := range result.Rejected {
+		imp.Rejected++
+		imp.AddError("offer %s: %v", rejection.ExternalID, rejection.Err)
+	}
+
+	for _, offer := range result.Offers {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("import interrupted: %w", err)
+		}
+
+		importResult, err := s.importOffer(ctx, imp, offer)
+		if err != nil {
+			imp.Rejected++
+			imp.AddError("offer %s: %v", offer.ExternalID, err)
+
+			continue
+		}
+
+		switch importResult {
+		case entity.ImportResultCreated:
+			imp.Created++
+		case entity.ImportResultUpdated:
+			imp.Updated++
+		case entity.ImportResultUnchanged:
+			imp.Unchanged++
+		}
+	}
+
+	if imp.Deactivated, err = s.catalogStore.DeactivateMissing(ctx, imp.FeedID, imp.ID); err != nil {
+		return fmt.Errorf("failed to deactivate missing products: %w", err)
+	}
+
+	return nil
+}
+
+// importOffer mirrors the offer's pictures that are not in the bucket yet and
+// stores the product. Pictures that fail to mirror are kept by their source
+// URL and retried on the next import.
+func (s *Service) importOffer(ctx context.Context, imp *entity.FeedImport, offer entity.CatalogOffer) (entity.ImportResult, error) {
+	current, err := s.catalogStore.GetProductImages(ctx, imp.FeedID, offer.ExternalID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get product images: %w", err)
+	}
+
+	mirrored := make(map[string]entity.ProductImage, len(current))
+
+	for _, image := range current {
+		if image.Mirrored {
+			mirrored[image.SourceURL] = image
+		}
+	}
+
+	images := make([]entity.ProductImage, 0, len(offer.Pictures))
+
+	var acquired []string
+
+	for position, sourceURL := range offer.Pictures {
+		image, ok := mirrored[sourceURL]
+		if ok {
+			image.Position = position
+			images = append(images, image)
+
+			continue
+		}
+
+		image = entity.ProductImage{Position: position, SourceURL: sourceURL}
+
+		if key, contentType, err := s.mirrorImage(ctx, sourceURL); err != nil {
+			imp.ImagesFailed++
+			imp.AddError("offer %s: image %s: %v", offer.ExternalID, sourceURL, err)
+		} else {
+			imp.ImagesMirrored++
+			image.Key, image.ContentType, image.Mirrored = &key, &contentType, true
+			acquired = append(acquired, key)
+		}
+
+		images = append(images, image)
+	}
+
+	result, released, err := s.catalogStore.UpsertProduct(ctx, imp.ID, imp.FeedID, offer, images)
+	if err != nil {
+		s.releaseObjects(ctx, acquired)
+
+		return "", fmt.Errorf("failed to store product: %w", err)
+	}
+
+	s.releaseObjects(ctx, released)
+
+	return result, nil
+}
+
+func (s *Service) mirrorImage(ctx context.Context, sourceURL string) (string, string, error) {
+	data, contentType, err := s.imageFetcher.FetchImage(ctx, sourceURL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch image: %w", err)
+	}
+
+	key, err := s.objectsStore.StoreObject(ctx, data, contentType)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store image: %w", err)
+	}
+
+	return key, contentType, nil
+}
+
+// releaseObjects drops image references. A reference that cannot be dropped
+// only keeps its object in the bucket longer than needed, so failures are
+// logged rather than failing the import.
+func (s *Service) releaseObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.objectsStore.ReleaseObject(ctx, key); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("failed to release product image")
+		}
+	}
+}
+
+func (s *Service) ListImports(ctx context.Context, filter entity.FeedImportFilter) ([]entity.FeedImport, error) {
+	imports, err := s.catalogStore.ListImports(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list imports: %w", err)
+	}
+
+	return imports, nil
+}
+
+func (s *Service) GetProduct(ctx context.Context, productID entity.ProductID) (entity.Product, error) {
+	product, err := s.catalogStore.GetProduct(ctx, productID)
+	if err != nil {
+		return entity.Product{}, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return product, nil
+}
+
+func (s *Service) ListProducts(ctx context.Context, filter entity.ProductFilter) (entity.ProductPage, error) {
+	products, nextCursor, err := s.catalogStore.ListProducts(ctx, filter)
+	if err != nil {
+		return entity.ProductPage{}, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	return entity.ProductPage{Products: products, NextCursor: nextCursor}, nil
+}
+
+// GetProductImageURL returns a temporary link to a mirrored product image.
+func (s *Service) GetProductImageURL(ctx context.Context, productID entity.ProductID, position int) (entity.FileURL, error) {
+	image, err := s.catalogStore.GetProductImage(ctx, productID, position)
+	if err != nil {
+		return entity.FileURL{}, fmt.Errorf("failed to get product image: %w", err)
+	}
+
+	if image.Key == nil {
+		return entity.FileURL{}, fmt.Errorf("%w: image is not mirrored yet", entity.ErrProductImageNotFound)
+	}
+
+	expiresAt := time.Now().Add(s.cfg.URLLifetime)
+
+	presignedURL, err := s.objectsStore.PresignObject(ctx, *image.Key, s.cfg.URLLifetime)
+	if err != nil {
+		return entity.FileURL{}, fmt.Errorf("failed to presign product image: %w", err)
+	}
+
+	return entity.FileURL{
+		URL:       presignedURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
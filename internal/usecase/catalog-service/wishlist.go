@@ -0,0 +1,82 @@
+package catalogservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (s *Service) AddWishlistItem(ctx context.Context, userID entity.UserID, item entity.WishlistItem) (entity.WishlistItem, error) {
+	validatedItem, err := item.Validate()
+	if err != nil {
+		return entity.WishlistItem{}, fmt.Errorf("wishlist item validation failed: %w", err)
+	}
+
+	validatedItem.UserID = userID
+
+	addedItem, err := s.catalogStore.AddWishlistItem(ctx, validatedItem)
+	if err != nil {
+		return entity.WishlistItem{}, fmt.Errorf("failed to add wishlist item: %w", err)
+	}
+
+	return addedItem, nil
+}
+
+func (s *Service) ListWishlist(ctx context.Context, userID entity.UserID, filter entity.WishlistFilter) ([]entity.WishlistItem, error) {
+	items, err := s.catalogStore.ListWishlist(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wishlist: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *Service) UpdateWishlistItem(
+	ctx context.Context,
+	userID entity.UserID,
+	productID entity.ProductID,
+	update entity.WishlistItemUpdate,
+) (entity.WishlistItem, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.WishlistItem{}, fmt.Errorf("wishlist item validation failed: %w", err)
+	}
+
+	updatedItem, err := s.catalogStore.UpdateWishlistItem(ctx, userID, productID, validatedUpdate)
+	if err != nil {
+		return entity.WishlistItem{}, fmt.Errorf("failed to update wishlist item: %w", err)
+	}
+
+	return updatedItem, nil
+}
+
+func (s *Service) DeleteWishlistItem(ctx context.Context, userID entity.UserID, productID entity.ProductID) error {
+	if err := s.catalogStore.DeleteWishlistItem(ctx, userID, productID); err != nil {
+		return fmt.Errorf("failed to delete wishlist item: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetPriceHistory(ctx context.Context, productID entity.ProductID) ([]entity.PricePoint, error) {
+	if _, err := s.catalogStore.GetProduct(ctx, productID); err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	history, err := s.catalogStore.GetPriceHistory(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	return history, nil
+}
+
+func (s *Service) ListPriceAlerts(ctx context.Context, userID entity.UserID, filter entity.PriceAlertFilter) ([]entity.PriceAlert, error) {
+	alerts, err := s.catalogStore.ListPriceAlerts(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price alerts: %w", err)
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,74 @@
+package filesservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type reconcilerStore interface {
+	ExpirePendingFiles(ctx context.Context, olderThan time.Duration) (int64, error)
+	PurgeUnreferencedBlobs(ctx context.Context) (int, error)
+	RemoveOrphanedObjects(ctx context.Context, gracePeriod time.Duration) ([]string, error)
+}
+
+type ReconcilerConfig struct {
+	Interval          time.Duration
+	PendingTTL        time.Duration
+	OrphanGracePeriod time.Duration
+}
+
+// Reconciler periodically drops uploads that never completed, blobs that lost
+// their last reference and bucket objects no blob refers to anymore.
+type Reconciler struct {
+	cfg   ReconcilerConfig
+	store reconcilerStore
+}
+
+func NewReconciler(cfg ReconcilerConfig, store reconcilerStore) *Reconciler {
+	return &Reconciler{
+		cfg:   cfg,
+		store: store,
+	}
+}
+
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reconcile(ctx); err != nil {
+				log.Warn().Err(err).Msg("failed to reconcile files")
+			}
+		}
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	expired, err := r.store.ExpirePendingFiles(ctx, r.cfg.PendingTTL)
+	if err != nil {
+		return fmt.Errorf("failed to expire pending files: %w", err)
+	}
+
+	purged, err := r.store.PurgeUnreferencedBlobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge unreferenced blobs: %w", err)
+	}
+
+	removed, err := r.store.RemoveOrphanedObjects(ctx, r.cfg.OrphanGracePeriod)
+	if err != nil {
+		return fmt.Errorf("failed to remove orphaned objects: %w", err)
+	}
+
+	if expired > 0 || purged > 0 || len(removed) > 0 {
+		log.Info().Int64("expired", expired).Int("purged", purged).Int("removed", len(removed)).Msg("files reconciled")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package filesservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type filesStore interface {
+	CreateFile(ctx context.Context, file entity.File, data []byte) (entity.File, error)
+	GetFile(ctx context.Context, fileID entity.FileID) (entity.File, error)
+	DownloadFile(ctx context.Context, file entity.File) (io.ReadCloser, error)
+	PresignFile(ctx context.Context, file entity.File, expires time.Duration) (string, error)
+	DeleteFile(ctx context.Context, userID entity.UserID, fileID entity.FileID) error
+}
+
+type Config struct {
+	MaxFileSize int64
+	URLLifetime time.Duration
+}
+
+type Service struct {
+	cfg        Config
+	filesStore filesStore
+}
+
+func New(cfg Config, filesStore filesStore) *Service {
+	return &Service{
+		cfg:        cfg,
+		filesStore: filesStore,
+	}
+}
+
+func (s *Service) UploadFile(ctx context.Context, userID entity.UserID, data []byte) (entity.File, error) {
+	if int64(len(data)) > s.cfg.MaxFileSize {
+		return entity.File{}, entity.ErrFileTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return entity.File{}, fmt.Errorf("%w: %s", entity.ErrUnsupportedContentType, contentType)
+	}
+
+	file, err := s.filesStore.CreateFile(ctx, entity.File{
+		ID:          entity.FileID(uuid.New()),
+		UserID:      userID,
+		ContentType: contentType,
+	}, data)
+	if err != nil {
+		return entity.File{}, fmt.Errorf("failed to create file: %w", err)
+	}
+
+	return file, nil
+}
+
+func (s *Service) GetFile(ctx context.Context, userID entity.UserID, fileID entity.FileID) (entity.File, error) {
+	file, err := s.filesStore.GetFile(ctx, fileID)
+	if err != nil {
+		return entity.File{}, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	if file.UserID != userID {
+		return entity.File{}, entity.ErrFileNotFound
+	}
+
+	return file, nil
+}
+
+func (s *Service) DownloadFile(ctx context.Context, userID entity.UserID, fileID entity.FileID) (io.ReadCloser, entity.File, error) {
+	file, err := s.GetFile(ctx, userID, fileID)
+	if err != nil {
+		return nil, entity.File{}, err
+	}
+
+	body, err := s.filesStore.DownloadFile(ctx, file)
+	if err != nil {
+		return nil, entity.File{}, fmt.Errorf("failed to download file: %w", err)
+	}
+
+	return body, file, nil
+}
+
+func (s *Service) GetFileURL(ctx context.Context, userID entity.UserID, fileID entity.FileID) (entity.FileURL, error) {
+	file, err := s.GetFile(ctx, userID, fileID)
+	if err != nil {
+		return entity.FileURL{}, err
+	}
+
+	expiresAt := time.Now().Add(s.cfg.URLLifetime)
+
+	presignedURL, err := s.filesStore.PresignFile(ctx, file, s.cfg.URLLifetime)
+	if err != nil {
+		return entity.FileURL{}, fmt.Errorf("failed to presign file: %w", err)
+	}
+
+	return entity.FileURL{
+		URL:       presignedURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *Service) DeleteFile(ctx context.Context, userID entity.UserID, fileID entity.FileID) error {
+	if err := s.filesStore.DeleteFile(ctx, userID, fileID); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package marketplaceservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (s *Service) CreateOffer(
+	ctx context.Context,
+	buyerID entity.UserID,
+	listingID entity.ListingID,
+	offer entity.ListingOffer,
+) (entity.ListingOffer, error) {
+	validatedOffer, err := offer.Validate()
+	if err != nil {
+		return entity.ListingOffer{}, fmt.Errorf("offer validation failed: %w", err)
+	}
+
+	validatedOffer.ID = entity.ListingOfferID(uuid.New())
+	validatedOffer.ListingID = listingID
+	validatedOffer.BuyerID = buyerID
+
+	createdOffer, err := s.marketplaceStore.CreateOffer(ctx, validatedOffer)
+	if err != nil {
+		return entity.ListingOffer{}, fmt.Errorf("failed to create offer: %w", err)
+	}
+
+	return createdOffer, nil
+}
+
+// ListListingOffers lists the offers on a listing: all of them for its
+// seller, and the viewer's own for anybody else.
+func (s *Service) ListListingOffers(
+	ctx context.Context,
+	viewerID entity.UserID,
+	listingID entity.ListingID,
+	filter entity.ListingOfferFilter,
+) ([]entity.ListingOffer, error) {
+	listing, err := s.GetListing(ctx, viewerID, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buyerID *entity.UserID
+	if listing.SellerID != viewerID {
+		buyerID = &viewerID
+	}
+
+	offers, err := s.marketplaceStore.ListOffers(ctx, &listingID, buyerID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offers: %w", err)
+	}
+
+	return offers, nil
+}
+
+// ListBuyerOffers lists the offers the buyer made.
+func (s *Service) ListBuyerOffers(
+	ctx context.Context,
+	buyerID entity.UserID,
+	filter entity.ListingOfferFilter,
+) ([]entity.ListingOffer, error) {
+	offers, err := s.marketplaceStore.ListOffers(ctx, nil, &buyerID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offers: %w", err)
+	}
+
+	return offers, nil
+}
+
+// AcceptOffer reserves the listing for the offer's buyer.
+func (s *Service) AcceptOffer(
+	ctx context.Context,
+	sellerID entity.UserID,
+	listingID entity.ListingID,
+	offerID entity.ListingOfferID,
+) (entity.ListingOffer, error) {
+	return s.respondToOffer(ctx, sellerID, listingID, offerID, entity.ListingOfferStatusAccepted)
+}
+
+func (s *Service) DeclineOffer(
+	ctx context.Context,
+	sellerID entity.UserID,
+	listingID entity.ListingID,
+	offerID entity.ListingOfferID,
+) (entity.ListingOffer, error) {
+	return s.respondToOffer(ctx, sellerID, listingID, offerID, entity.ListingOfferStatusDeclined)
+}
+
+func (s *Service) respondToOffer(
+	ctx context.Context,
+	sellerID entity.UserID,
+	listingID entity.ListingID,
+	offerID entity.ListingOfferID,
+	status entity.ListingOfferStatus,
+) (entity.ListingOffer, error) {
+	offer, err := s.marketplaceStore.RespondToOffer(ctx, sellerID, listingID, offerID, status)
+	if err != nil {
+		return entity.ListingOffer{}, fmt.Errorf("failed to respond to offer: %w", err)
+	}
+
+	return offer, nil
+}
@@ -0,0 +1,204 @@
+package marketplaceservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type marketplaceStore interface {
+	CreateListing(ctx context.Context, listing entity.Listing) (entity.Listing, error)
+	GetListing(ctx context.Context, listingID entity.ListingID) (entity.Listing, error)
+	ListListings(ctx context.Context, filter entity.ListingFilter) ([]entity.Listing, error)
+	UpdateListing(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID,
+		update entity.ListingUpdate) (entity.Listing, error)
+	TransitionListing(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID,
+		status entity.ListingStatus) (entity.Listing, error)
+	GetListingPhotoKey(ctx context.Context, listingID entity.ListingID, position int) (string, error)
+	CreateOffer(ctx context.Context, offer entity.ListingOffer) (entity.ListingOffer, error)
+	ListOffers(ctx context.Context, listingID *entity.ListingID, buyerID *entity.UserID,
+		filter entity.ListingOfferFilter) ([]entity.ListingOffer, error)
+	RespondToOffer(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID, offerID entity.ListingOfferID,
+		status entity.ListingOfferStatus) (entity.ListingOffer, error)
+}
+
+type objectsStore interface {
+	PresignObject(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+type Config struct {
+	URLLifetime time.Duration
+}
+
+type Service struct {
+	cfg              Config
+	marketplaceStore marketplaceStore
+	objectsStore     objectsStore
+}
+
+func New(cfg Config, marketplaceStore marketplaceStore, objectsStore objectsStore) *Service {
+	return &Service{
+		cfg:              cfg,
+		marketplaceStore: marketplaceStore,
+		objectsStore:     objectsStore,
+	}
+}
+
+// CreateListing drafts a listing of one of the seller's wardrobe items.
+func (s *Service) CreateListing(ctx context.Context, sellerID entity.UserID, listing entity.Listing) (entity.Listing, error) {
+	validatedListing, err := listing.Validate()
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("listing validation failed: %w", err)
+	}
+
+	validatedListing.ID = entity.ListingID(uuid.New())
+	validatedListing.SellerID = sellerID
+
+	createdListing, err := s.marketplaceStore.CreateListing(ctx, validatedListing)
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to create listing: %w", err)
+	}
+
+	return createdListing, nil
+}
+
+// GetListing returns a listing as the viewer sees it: drafts are only found
+// by their sellers.
+func (s *Service) GetListing(ctx context.Context, viewerID entity.UserID, listingID entity.ListingID) (entity.Listing, error) {
+	listing, err := s.marketplaceStore.GetListing(ctx, listingID)
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to get listing: %w", err)
+	}
+
+	if listing.Status == entity.ListingStatusDraft && listing.SellerID != viewerID {
+		return entity.Listing{}, fmt.Errorf("failed to get listing: %w", entity.ErrListingNotFound)
+	}
+
+	return listing, nil
+}
+
+// BrowseListings lists the listings on sale.
+func (s *Service) BrowseListings(ctx context.Context, filter entity.ListingFilter) ([]entity.Listing, error) {
+	filter.SellerID = nil
+	filter.Statuses = []entity.ListingStatus{entity.ListingStatusActive}
+
+	return s.listListings(ctx, filter)
+}
+
+// SearchListings lists the listings on sale matching the filter's text, the
+// most relevant first.
+func (s *Service) SearchListings(ctx context.Context, filter entity.ListingFilter) ([]entity.Listing, error) {
+	if filter.Text == "" {
+		return nil, fmt.Errorf("listing filter validation failed: %w: search text is required", entity.ErrInvalidListing)
+	}
+
+	return s.BrowseListings(ctx, filter)
+}
+
+// ListSellerListings lists the seller's listings in every status, unless the
+// filter picks some.
+func (s *Service) ListSellerListings(
+	ctx context.Context,
+	sellerID entity.UserID,
+	filter entity.ListingFilter,
+) ([]entity.Listing, error) {
+	filter.SellerID = &sellerID
+
+	return s.listListings(ctx, filter)
+}
+
+func (s *Service) listListings(ctx context.Context, filter entity.ListingFilter) ([]entity.Listing, error) {
+	validatedFilter, err := filter.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("listing filter validation failed: %w", err)
+	}
+
+	listings, err := s.marketplaceStore.ListListings(ctx, validatedFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listings: %w", err)
+	}
+
+	return listings, nil
+}
+
+func (s *Service) UpdateListing(
+	ctx context.Context,
+	sellerID entity.UserID,
+	listingID entity.ListingID,
+	update entity.ListingUpdate,
+) (entity.Listing, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("listing update validation failed: %w", err)
+	}
+
+	listing, err := s.marketplaceStore.UpdateListing(ctx, sellerID, listingID, validatedUpdate)
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to update listing: %w", err)
+	}
+
+	return listing, nil
+}
+
+// PublishListing puts a draft on sale.
+func (s *Service) PublishListing(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID) (entity.Listing, error) {
+	return s.transitionListing(ctx, sellerID, listingID, entity.ListingStatusActive)
+}
+
+// WithdrawListing takes a listing off sale for good.
+func (s *Service) WithdrawListing(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID) (entity.Listing, error) {
+	return s.transitionListing(ctx, sellerID, listingID, entity.ListingStatusWithdrawn)
+}
+
+// MarkListingSold closes the sale of a reserved listing and archives the
+// wardrobe item.
+func (s *Service) MarkListingSold(ctx context.Context, sellerID entity.UserID, listingID entity.ListingID) (entity.Listing, error) {
+	return s.transitionListing(ctx, sellerID, listingID, entity.ListingStatusSold)
+}
+
+func (s *Service) transitionListing(
+	ctx context.Context,
+	sellerID entity.UserID,
+	listingID entity.ListingID,
+	status entity.ListingStatus,
+) (entity.Listing, error) {
+	listing, err := s.marketplaceStore.TransitionListing(ctx, sellerID, listingID, status)
+	if err != nil {
+		return entity.Listing{}, fmt.Errorf("failed to move listing to %s: %w", status, err)
+	}
+
+	return listing, nil
+}
+
+// GetListingPhotoURL returns a temporary link to a listing's photo, counting
+// from 1.
+func (s *Service) GetListingPhotoURL(
+	ctx context.Context,
+	viewerID entity.UserID,
+	listingID entity.ListingID,
+	position int,
+) (entity.FileURL, error) {
+	if _, err := s.GetListing(ctx, viewerID, listingID); err != nil {
+		return entity.FileURL{}, err
+	}
+
+	key, err := s.marketplaceStore.GetListingPhotoKey(ctx, listingID, position)
+	if err != nil {
+		return entity.FileURL{}, fmt.Errorf("failed to get listing photo: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.cfg.URLLifetime)
+
+	presignedURL, err := s.objectsStore.PresignObject(ctx, key, s.cfg.URLLifetime)
+	if err != nil {
+		return entity.FileURL{}, fmt.Errorf("failed to presign listing photo: %w", err)
+	}
+
+	return entity.FileURL{
+		URL:       presignedURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package messagingservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// BlockUser stops the two users from messaging each other.
+func (s *Service) BlockUser(ctx context.Context, blockerID, blockedID entity.UserID) (entity.Block, error) {
+	block := entity.Block{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+
+	validatedBlock, err := block.Validate()
+	if err != nil {
+		return entity.Block{}, fmt.Errorf("block validation failed: %w", err)
+	}
+
+	createdBlock, err := s.messagingStore.BlockUser(ctx, validatedBlock)
+	if err != nil {
+		return entity.Block{}, fmt.Errorf("failed to block user: %w", err)
+	}
+
+	return createdBlock, nil
+}
+
+func (s *Service) UnblockUser(ctx context.Context, blockerID, blockedID entity.UserID) error {
+	if err := s.messagingStore.UnblockUser(ctx, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListBlocks(ctx context.Context, blockerID entity.UserID) ([]entity.Block, error) {
+	blocks, err := s.messagingStore.ListBlocks(ctx, blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocks: %w", err)
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,212 @@
+package messagingservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+type messagingStore interface {
+	StartConversation(ctx context.Context, conversation entity.Conversation) (entity.Conversation, error)
+	GetConversation(ctx context.Context, userID entity.UserID, conversationID entity.ConversationID) (entity.Conversation, error)
+	ListConversations(ctx context.Context, userID entity.UserID, filter entity.ConversationFilter) ([]entity.Conversation, error)
+	MarkConversationRead(ctx context.Context, userID entity.UserID,
+		conversationID entity.ConversationID) (entity.Conversation, error)
+	GetUnreadCount(ctx context.Context, userID entity.UserID) (entity.UnreadCount, error)
+	SendMessage(ctx context.Context, message entity.Message) (entity.Message, error)
+	ListMessages(ctx context.Context, userID entity.UserID, conversationID entity.ConversationID,
+		page entity.Page) ([]entity.Message, *string, error)
+	GetMessagePhotoKey(ctx context.Context, userID entity.UserID, conversationID entity.ConversationID,
+		messageID entity.MessageID, position int) (string, error)
+	BlockUser(ctx context.Context, block entity.Block) (entity.Block, error)
+	UnblockUser(ctx context.Context, blockerID, blockedID entity.UserID) error
+	ListBlocks(ctx context.Context, blockerID entity.UserID) ([]entity.Block, error)
+}
+
+type objectsStore interface {
+	PresignObject(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+type eventsPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+type Config struct {
+	URLLifetime time.Duration
+}
+
+type Service struct {
+	cfg             Config
+	messagingStore  messagingStore
+	objectsStore    objectsStore
+	eventsPublisher eventsPublisher
+}
+
+func New(cfg Config, messagingStore messagingStore, objectsStore objectsStore, eventsPublisher eventsPublisher) *Service {
+	return &Service{
+		cfg:             cfg,
+		messagingStore:  messagingStore,
+		objectsStore:    objectsStore,
+		eventsPublisher: eventsPublisher,
+	}
+}
+
+// StartConversation opens the buyer's conversation about a listing, or
+// returns the one they already have.
+func (s *Service) StartConversation(
+	ctx context.Context,
+	buyerID entity.UserID,
+	listingID entity.ListingID,
+) (entity.Conversation, error) {
+	conversation, err := s.messagingStore.StartConversation(ctx, entity.Conversation{
+		ID:        entity.ConversationID(uuid.New()),
+		ListingID: listingID,
+		BuyerID:   buyerID,
+	})
+	if err != nil {
+		return entity.Conversation{}, fmt.Errorf("failed to start conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+func (s *Service) GetConversation(
+	ctx context.Context,
+	userID entity.UserID,
+	conversationID entity.ConversationID,
+) (entity.Conversation, error) {
+	conversation, err := s.messagingStore.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		return entity.Conversation{}, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+func (s *Service) ListConversations(
+	ctx context.Context,
+	userID entity.UserID,
+	filter entity.ConversationFilter,
+) ([]entity.Conversation, error) {
+	conversations, err := s.messagingStore.ListConversations(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+
+	return conversations, nil
+}
+
+func (s *Service) MarkConversationRead(
+	ctx context.Context,
+	userID entity.UserID,
+	conversationID entity.ConversationID,
+) (entity.Conversation, error) {
+	conversation, err := s.messagingStore.MarkConversationRead(ctx, userID, conversationID)
+	if err != nil {
+		return entity.Conversation{}, fmt.Errorf("failed to mark conversation read: %w", err)
+	}
+
+	return conversation, nil
+}
+
+func (s *Service) GetUnreadCount(ctx context.Context, userID entity.UserID) (entity.UnreadCount, error) {
+	count, err := s.messagingStore.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return entity.UnreadCount{}, fmt.Errorf("failed to get unread count: %w", err)
+	}
+
+	return count, nil
+}
+
+// SendMessage sends a message and tells the other participant about it.
+func (s *Service) SendMessage(
+	ctx context.Context,
+	senderID entity.UserID,
+	conversationID entity.ConversationID,
+	message entity.Message,
+) (entity.Message, error) {
+	validatedMessage, err := message.Validate()
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("message validation failed: %w", err)
+	}
+
+	conversation, err := s.messagingStore.GetConversation(ctx, senderID, conversationID)
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	validatedMessage.ID = entity.MessageID(uuid.New())
+	validatedMessage.ConversationID = conversationID
+	validatedMessage.SenderID = senderID
+	validatedMessage.ReadAt = nil
+
+	sentMessage, err := s.messagingStore.SendMessage(ctx, validatedMessage)
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	recipientID := conversation.SellerID
+	if recipientID == senderID {
+		recipientID = conversation.BuyerID
+	}
+
+	event := entity.NewEvent(recipientID, entity.EventMessageReceived, entity.MessageReceivedData{
+		ConversationID: conversationID,
+		MessageID:      sentMessage.ID,
+		SenderID:       senderID,
+	})
+
+	if err := s.eventsPublisher.Publish(ctx, event); err != nil {
+		log.Warn().Err(err).Str("message", sentMessage.ID.String()).Msg("failed to publish message")
+	}
+
+	return sentMessage, nil
+}
+
+func (s *Service) ListMessages(
+	ctx context.Context,
+	userID entity.UserID,
+	conversationID entity.ConversationID,
+	page entity.Page,
+) (entity.MessagePage, error) {
+	messages, nextCursor, err := s.messagingStore.ListMessages(ctx, userID, conversationID, page)
+	if err != nil {
+		return entity.MessagePage{}, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	return entity.MessagePage{
+		Messages:   messages,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetMessagePhotoURL returns a temporary link to a photo attached to a
+// message, counting from 1.
+func (s *Service) GetMessagePhotoURL(
+	ctx context.Context,
+	userID entity.UserID,
+	conversationID entity.ConversationID,
+	messageID entity.MessageID,
+	position int,
+) (entity.FileURL, error) {
+	key, err := s.messagingStore.GetMessagePhotoKey(ctx, userID, conversationID, messageID, position)
+	if err != nil {
+		return entity.FileURL{}, fmt.Errorf("failed to get message photo: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.cfg.URLLifetime)
+
+	presignedURL, err := s.objectsStore.PresignObject(ctx, key, s.cfg.URLLifetime)
+	if err != nil {
+		return entity.FileURL{}, fmt.Errorf("failed to presign message photo: %w", err)
+	}
+
+	return entity.FileURL{
+		URL:       presignedURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
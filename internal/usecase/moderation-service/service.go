@@ -0,0 +1,151 @@
+package moderationservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type moderationStore interface {
+	CreateReport(ctx context.Context, report entity.Report) (entity.Report, error)
+	ListReports(ctx context.Context, filter entity.ReportFilter) ([]entity.Report, *string, error)
+	DismissReport(ctx context.Context, moderatorID entity.UserID, reportID entity.ReportID,
+		request entity.ModerationRequest) (entity.Report, error)
+	HidePost(ctx context.Context, moderatorID entity.UserID, postID entity.PostID, request entity.ModerationRequest) error
+	HideComment(ctx context.Context, moderatorID entity.UserID, commentID entity.CommentID, request entity.ModerationRequest) error
+	SetUserStatus(ctx context.Context, moderatorID, userID entity.UserID, status entity.UserStatus,
+		request entity.ModerationRequest) error
+	ListAuditLog(ctx context.Context, page entity.Page) ([]entity.AuditLogEntry, *string, error)
+}
+
+type Service struct {
+	moderationStore moderationStore
+}
+
+func New(moderationStore moderationStore) *Service {
+	return &Service{
+		moderationStore: moderationStore,
+	}
+}
+
+func (s *Service) CreateReport(ctx context.Context, reporterID entity.UserID, report entity.Report) (entity.Report, error) {
+	validatedReport, err := report.Validate()
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("report validation failed: %w", err)
+	}
+
+	if validatedReport.TargetType == entity.ReportTargetUser && validatedReport.TargetID == uuid.UUID(reporterID) {
+		return entity.Report{}, fmt.Errorf("%w: users cannot report themselves", entity.ErrInvalidReport)
+	}
+
+	validatedReport.ID = entity.ReportID(uuid.New())
+	validatedReport.ReporterID = reporterID
+
+	createdReport, err := s.moderationStore.CreateReport(ctx, validatedReport)
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	return createdReport, nil
+}
+
+func (s *Service) ListReports(ctx context.Context, filter entity.ReportFilter) (entity.ReportPage, error) {
+	validatedFilter, err := filter.Validate()
+	if err != nil {
+		return entity.ReportPage{}, fmt.Errorf("filter validation failed: %w", err)
+	}
+
+	reports, nextCursor, err := s.moderationStore.ListReports(ctx, validatedFilter)
+	if err != nil {
+		return entity.ReportPage{}, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	return entity.ReportPage{Reports: reports, NextCursor: nextCursor}, nil
+}
+
+func (s *Service) DismissReport(
+	ctx context.Context,
+	moderatorID entity.UserID,
+	reportID entity.ReportID,
+	request entity.ModerationRequest,
+) (entity.Report, error) {
+	validatedRequest, err := request.Validate()
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("moderation request validation failed: %w", err)
+	}
+
+	report, err := s.moderationStore.DismissReport(ctx, moderatorID, reportID, validatedRequest)
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("failed to dismiss report: %w", err)
+	}
+
+	return report, nil
+}
+
+func (s *Service) HidePost(ctx context.Context, moderatorID entity.UserID, postID entity.PostID, request entity.ModerationRequest) error {
+	validatedRequest, err := request.Validate()
+	if err != nil {
+		return fmt.Errorf("moderation request validation failed: %w", err)
+	}
+
+	if err := s.moderationStore.HidePost(ctx, moderatorID, postID, validatedRequest); err != nil {
+		return fmt.Errorf("failed to hide post: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) HideComment(
+	ctx context.Context,
+	moderatorID entity.UserID,
+	commentID entity.CommentID,
+	request entity.ModerationRequest,
+) error {
+	validatedRequest, err := request.Validate()
+	if err != nil {
+		return fmt.Errorf("moderation request validation failed: %w", err)
+	}
+
+	if err := s.moderationStore.HideComment(ctx, moderatorID, commentID, validatedRequest); err != nil {
+		return fmt.Errorf("failed to hide comment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) SuspendUser(ctx context.Context, moderatorID, userID entity.UserID, request entity.ModerationRequest) error {
+	return s.setUserStatus(ctx, moderatorID, userID, entity.UserStatusSuspended, request)
+}
+
+func (s *Service) UnsuspendUser(ctx context.Context, moderatorID, userID entity.UserID, request entity.ModerationRequest) error {
+	return s.setUserStatus(ctx, moderatorID, userID, entity.UserStatusActive, request)
+}
+
+func (s *Service) setUserStatus(
+	ctx context.Context,
+	moderatorID, userID entity.UserID,
+	status entity.UserStatus,
+	request entity.ModerationRequest,
+) error {
+	validatedRequest, err := request.Validate()
+	if err != nil {
+		return fmt.Errorf("moderation request validation failed: %w", err)
+	}
+
+	if err := s.moderationStore.SetUserStatus(ctx, moderatorID, userID, status, validatedRequest); err != nil {
+		return fmt.Errorf("failed to set user status: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListAuditLog(ctx context.Context, page entity.Page) (entity.AuditLogPage, error) {
+	entries, nextCursor, err := s.moderationStore.ListAuditLog(ctx, page)
+	if err != nil {
+		return entity.AuditLogPage{}, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	return entity.AuditLogPage{Entries: entries, NextCursor: nextCursor}, nil
+}
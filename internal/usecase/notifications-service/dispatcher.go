@@ -0,0 +1,208 @@
+package notificationsservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	resultSent    = "sent"
+	resultRetried = "retried"
+	resultFailed  = "failed"
+)
+
+type dispatcherStore interface {
+	ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]entity.NotificationDelivery, error)
+	MarkDeliverySent(ctx context.Context, notificationID entity.NotificationID, channel entity.NotificationChannel) error
+	RetryDelivery(ctx context.Context, notificationID entity.NotificationID, channel entity.NotificationChannel,
+		lastError string, nextAttemptAt time.Time) error
+	MarkDeliveryFailed(ctx context.Context, notificationID entity.NotificationID, channel entity.NotificationChannel,
+		lastError string) error
+	PostponeDelivery(ctx context.Context, notificationID entity.NotificationID, channel entity.NotificationChannel,
+		until time.Time) error
+}
+
+type outboxStore interface {
+	Enqueue(ctx context.Context, message entity.OutboxMessage) error
+}
+
+type txManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type DispatcherConfig struct {
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Lease       time.Duration
+}
+
+// Dispatcher periodically hands notifications over to the channels outside
+// the app. A delivery is recorded as sent in the same transaction that
+// enqueues its text in the outbox, so the outbox relay sends it exactly once.
+// A delivery that falls into the user's quiet hours waits until they end. A
+// failed delivery is retried with exponential backoff until it runs out of
+// attempts, then it is marked failed.
+type Dispatcher struct {
+	cfg         DispatcherConfig
+	store       dispatcherStore
+	outboxStore outboxStore
+	txManager   txManager
+}
+
+func NewDispatcher(cfg DispatcherConfig, store dispatcherStore, outboxStore outboxStore, txManager txManager) *Dispatcher {
+	return &Dispatcher{
+		cfg:         cfg,
+		store:       store,
+		outboxStore: outboxStore,
+		txManager:   txManager,
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.Check(ctx); err != nil {
+				log.Warn().Err(err).Msg("failed to dispatch notifications")
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) Check(ctx context.Context) error {
+	deliveries, err := d.store.ClaimDueDeliveries(ctx, d.cfg.BatchSize, time.Now().Add(d.cfg.Lease))
+	if err != nil {
+		return fmt.Errorf("failed to claim notification deliveries: %w", err)
+	}
+
+	var sent, retried, failed, postponed int
+
+	for _, delivery := range deliveries {
+		if delivery.QuietHours != nil {
+			if until, quiet := delivery.QuietHours.EndsAt(time.Now()); quiet {
+				err := d.store.PostponeDelivery(ctx, delivery.Notification.ID, delivery.Channel, until)
+				if err != nil {
+					return fmt.Errorf("failed to postpone notification delivery: %w", err)
+				}
+
+				postponed++
+
+				continue
+			}
+		}
+
+		result, err := d.dispatch(ctx, delivery)
+		if err != nil {
+			return err
+		}
+
+		switch result {
+		case resultSent:
+			sent++
+		case resultRetried:
+			retried++
+		default:
+			failed++
+		}
+	}
+
+	if len(deliveries) > 0 {
+		log.Info().Int("sent", sent).Int("retried", retried).Int("failed", failed).Int("postponed", postponed).
+			Msg("notifications dispatched")
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, delivery entity.NotificationDelivery) (string, error) {
+	notificationID := delivery.Notification.ID
+
+	sendErr := d.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := d.send(ctx, delivery); err != nil {
+			return err
+		}
+
+		if err := d.store.MarkDeliverySent(ctx, notificationID, delivery.Channel); err != nil {
+			return fmt.Errorf("failed to record sent notification delivery: %w", err)
+		}
+
+		return nil
+	})
+	if sendErr == nil {
+		return resultSent, nil
+	}
+
+	log.Warn().Err(sendErr).Str("notification", notificationID.String()).Int("attempt", delivery.Attempts).
+		Msg("failed to send notification")
+
+	if errors.Is(sendErr, entity.ErrInvalidNotification) || delivery.Attempts >= d.cfg.MaxAttempts {
+		if err := d.store.MarkDeliveryFailed(ctx, notificationID, delivery.Channel, sendErr.Error()); err != nil {
+			return "", fmt.Errorf("failed to record failed notification delivery: %w", err)
+		}
+
+		return resultFailed, nil
+	}
+
+	nextAttemptAt := time.Now().Add(d.backoff(delivery.Attempts))
+
+	if err := d.store.RetryDelivery(ctx, notificationID, delivery.Channel, sendErr.Error(), nextAttemptAt); err != nil {
+		return "", fmt.Errorf("failed to record notification delivery retry: %w", err)
+	}
+
+	return resultRetried, nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery entity.NotificationDelivery) error {
+	switch delivery.Channel {
+	case entity.NotificationChannelSMS:
+		message := entity.NewOutboxMessage(entity.OutboxKindSMS, entity.SMSPayload{
+			Phone: delivery.Phone,
+			Text:  notificationText(delivery.Notification),
+		})
+
+		if err := d.outboxStore.Enqueue(ctx, message); err != nil {
+			return fmt.Errorf("failed to enqueue sms: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown channel %q", entity.ErrInvalidNotification, delivery.Channel)
+	}
+}
+
+// backoff is the delay before the attempt that follows the given one: the
+// base backoff doubled for every attempt made, up to the maximum.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.BaseBackoff
+
+	for range attempts - 1 {
+		if delay >= d.cfg.MaxBackoff/2 {
+			return d.cfg.MaxBackoff
+		}
+
+		delay *= 2
+	}
+
+	return min(delay, d.cfg.MaxBackoff)
+}
+
+func notificationText(notification entity.Notification) string {
+	if notification.Body == "" {
+		return notification.Title
+	}
+
+	return notification.Title + ": " + notification.Body
+}
@@ -0,0 +1,170 @@
+package notificationsservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	notificationsservice "github.com/romanpitatelev/clothing-service/internal/usecase/notifications-service"
+	"github.com/stretchr/testify/require"
+)
+
+var errEnqueueFailed = errors.New("enqueue failed")
+
+type dispatcherStore struct {
+	deliveries []entity.NotificationDelivery
+	sent       map[entity.NotificationID]bool
+	retries    map[entity.NotificationID]time.Time
+	failed     map[entity.NotificationID]string
+	postponed  map[entity.NotificationID]time.Time
+}
+
+func (s *dispatcherStore) ClaimDueDeliveries(_ context.Context, limit int, _ time.Time) ([]entity.NotificationDelivery, error) {
+	return s.deliveries[:min(limit, len(s.deliveries))], nil
+}
+
+func (s *dispatcherStore) MarkDeliverySent(_ context.Context, notificationID entity.NotificationID, _ entity.NotificationChannel) error {
+	s.sent[notificationID] = true
+
+	return nil
+}
+
+func (s *dispatcherStore) RetryDelivery(
+	_ context.Context,
+	notificationID entity.NotificationID,
+	_ entity.NotificationChannel,
+	_ string,
+	nextAttemptAt time.Time,
+) error {
+	s.retries[notificationID] = nextAttemptAt
+
+	return nil
+}
+
+func (s *dispatcherStore) MarkDeliveryFailed(
+	_ context.Context,
+	notificationID entity.NotificationID,
+	_ entity.NotificationChannel,
+	lastError string,
+) error {
+	s.failed[notificationID] = lastError
+
+	return nil
+}
+
+func (s *dispatcherStore) PostponeDelivery(
+	_ context.Context,
+	notificationID entity.NotificationID,
+	_ entity.NotificationChannel,
+	until time.Time,
+) error {
+	s.postponed[notificationID] = until
+
+	return nil
+}
+
+type outboxStore struct {
+	texts map[string]string
+}
+
+func (s *outboxStore) Enqueue(_ context.Context, message entity.OutboxMessage) error {
+	var payload entity.SMSPayload
+
+	err := json.Unmarshal(message.Payload, &payload)
+	if err != nil || message.Kind != entity.OutboxKindSMS || payload.Phone == "" {
+		return errEnqueueFailed
+	}
+
+	s.texts[payload.Phone] = payload.Text
+
+	return nil
+}
+
+type txManager struct{}
+
+func (txManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func newDelivery(phone string, attempts int, quietHours *entity.QuietHours) entity.NotificationDelivery {
+	return entity.NotificationDelivery{
+		Notification: entity.Notification{
+			ID:       entity.NotificationID(uuid.New()),
+			Category: entity.NotificationCategoryOrders,
+			Title:    "Заказ отправлен",
+			Body:     "Скоро он будет у вас",
+		},
+		Channel:    entity.NotificationChannelSMS,
+		Phone:      phone,
+		QuietHours: quietHours,
+		Attempts:   attempts,
+	}
+}
+
+func quietHours(now time.Time, from, until time.Duration) *entity.QuietHours {
+	return &entity.QuietHours{
+		Start:    now.Add(from).Format("15:04"),
+		End:      now.Add(until).Format("15:04"),
+		TimeZone: "UTC",
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+
+	sent := newDelivery("79031355001", 1, nil)
+	outsideQuietHours := newDelivery("79031355002", 1, quietHours(now, time.Hour, 2*time.Hour))
+	duringQuietHours := newDelivery("79031355003", 1, quietHours(now, -time.Hour, time.Hour))
+	firstFailure := newDelivery("", 1, nil)
+	lastFailure := newDelivery("", 3, nil)
+	unknownChannel := newDelivery("79031355004", 1, nil)
+	unknownChannel.Channel = "pigeon"
+
+	store := &dispatcherStore{
+		deliveries: []entity.NotificationDelivery{sent, outsideQuietHours, duringQuietHours, firstFailure, lastFailure, unknownChannel},
+		sent:       make(map[entity.NotificationID]bool),
+		retries:    make(map[entity.NotificationID]time.Time),
+		failed:     make(map[entity.NotificationID]string),
+		postponed:  make(map[entity.NotificationID]time.Time),
+	}
+	outbox := &outboxStore{texts: make(map[string]string)}
+
+	dispatcher := notificationsservice.NewDispatcher(notificationsservice.DispatcherConfig{
+		Interval:    time.Minute,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  time.Hour,
+		Lease:       time.Minute,
+	}, store, outbox, txManager{})
+
+	before := time.Now()
+
+	require.NoError(t, dispatcher.Check(context.Background()))
+
+	require.Equal(t, map[string]string{
+		"79031355001": "Заказ отправлен: Скоро он будет у вас",
+		"79031355002": "Заказ отправлен: Скоро он будет у вас",
+	}, outbox.texts)
+
+	require.Equal(t, map[entity.NotificationID]bool{
+		sent.Notification.ID:              true,
+		outsideQuietHours.Notification.ID: true,
+	}, store.sent)
+
+	require.Len(t, store.retries, 1)
+	require.WithinRange(t, store.retries[firstFailure.Notification.ID], before.Add(time.Minute), time.Now().Add(time.Minute))
+
+	require.Len(t, store.failed, 2)
+	require.Contains(t, store.failed[lastFailure.Notification.ID], errEnqueueFailed.Error())
+	require.Contains(t, store.failed[unknownChannel.Notification.ID], "pigeon")
+
+	require.Len(t, store.postponed, 1)
+	require.True(t, now.Add(time.Hour).Truncate(time.Minute).Equal(store.postponed[duringQuietHours.Notification.ID]))
+}
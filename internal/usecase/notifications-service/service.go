@@ -0,0 +1,121 @@
+package notificationsservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type notificationsStore interface {
+	CreateNotification(ctx context.Context, notification entity.Notification, channels []entity.NotificationChannel) error
+	ListNotifications(ctx context.Context, userID entity.UserID, filter entity.NotificationFilter) ([]entity.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID entity.UserID,
+		notificationID entity.NotificationID) (entity.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context, userID entity.UserID) error
+	GetNotificationSettings(ctx context.Context, userID entity.UserID) (entity.NotificationSettings, error)
+	UpdateNotificationSettings(ctx context.Context, userID entity.UserID,
+		settings entity.NotificationSettings) (entity.NotificationSettings, error)
+}
+
+type Service struct {
+	notificationsStore notificationsStore
+}
+
+func New(notificationsStore notificationsStore) *Service {
+	return &Service{
+		notificationsStore: notificationsStore,
+	}
+}
+
+// Notify records a notification through the channels the user opted in to
+// for its category. Nothing is recorded when the user opted out of all of
+// them; the Dispatcher sends it through the channels outside the app.
+func (s *Service) Notify(ctx context.Context, notification entity.Notification) error {
+	validatedNotification, err := notification.Validate()
+	if err != nil {
+		return fmt.Errorf("notification validation failed: %w", err)
+	}
+
+	settings, err := s.notificationsStore.GetNotificationSettings(ctx, validatedNotification.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	channels := settings.Preference(validatedNotification.Category).Channels()
+	if len(channels) == 0 {
+		return nil
+	}
+
+	validatedNotification.ID = entity.NotificationID(uuid.New())
+
+	if err := s.notificationsStore.CreateNotification(ctx, validatedNotification, channels); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListNotifications(
+	ctx context.Context,
+	userID entity.UserID,
+	filter entity.NotificationFilter,
+) ([]entity.Notification, error) {
+	notifications, err := s.notificationsStore.ListNotifications(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (s *Service) MarkNotificationRead(
+	ctx context.Context,
+	userID entity.UserID,
+	notificationID entity.NotificationID,
+) (entity.Notification, error) {
+	notification, err := s.notificationsStore.MarkNotificationRead(ctx, userID, notificationID)
+	if err != nil {
+		return entity.Notification{}, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	return notification, nil
+}
+
+func (s *Service) MarkAllNotificationsRead(ctx context.Context, userID entity.UserID) error {
+	if err := s.notificationsStore.MarkAllNotificationsRead(ctx, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetNotificationSettings(ctx context.Context, userID entity.UserID) (entity.NotificationSettings, error) {
+	settings, err := s.notificationsStore.GetNotificationSettings(ctx, userID)
+	if err != nil {
+		return entity.NotificationSettings{}, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateNotificationSettings replaces the user's settings. Categories left
+// out go back to their defaults and no quiet hours turn them off.
+func (s *Service) UpdateNotificationSettings(
+	ctx context.Context,
+	userID entity.UserID,
+	settings entity.NotificationSettings,
+) (entity.NotificationSettings, error) {
+	validatedSettings, err := settings.Validate()
+	if err != nil {
+		return entity.NotificationSettings{}, fmt.Errorf("notification settings validation failed: %w", err)
+	}
+
+	updatedSettings, err := s.notificationsStore.UpdateNotificationSettings(ctx, userID, validatedSettings)
+	if err != nil {
+		return entity.NotificationSettings{}, fmt.Errorf("failed to update notification settings: %w", err)
+	}
+
+	return updatedSettings, nil
+}
@@ -0,0 +1,230 @@
+package ordersservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+type paymentGateway interface {
+	CreatePayment(ctx context.Context, request entity.PaymentRequest) (entity.GatewayPayment, error)
+	CapturePayment(ctx context.Context, providerID string, amount int64, currency string) (entity.GatewayPayment, error)
+	CancelPayment(ctx context.Context, providerID string) (entity.GatewayPayment, error)
+	RefundPayment(ctx context.Context, providerID string, amount int64, currency string) (bool, error)
+	ParseWebhook(body []byte, signature string) (entity.PaymentEvent, error)
+}
+
+// PayOrder starts paying for the user's order. The returned payment holds the
+// URL the customer is sent to in order to confirm the payment.
+func (s *Service) PayOrder(ctx context.Context, userID entity.UserID, orderID entity.OrderID) (entity.Payment, error) {
+	payment, err := s.ordersStore.StartPayment(ctx, entity.Payment{
+		ID:      entity.PaymentID(uuid.New()),
+		OrderID: orderID,
+		UserID:  userID,
+	})
+	if err != nil {
+		return entity.Payment{}, fmt.Errorf("failed to start payment: %w", err)
+	}
+
+	if payment.ProviderID != "" {
+		return payment, nil
+	}
+
+	gatewayPayment, err := s.paymentGateway.CreatePayment(ctx, entity.PaymentRequest{
+		ID:          payment.ID,
+		OrderID:     orderID,
+		Amount:      payment.Amount,
+		Currency:    payment.Currency,
+		Description: "Order " + orderID.String(),
+	})
+	if err != nil {
+		if err := s.ordersStore.CancelPayment(ctx, payment.ID); err != nil {
+			log.Warn().Err(err).Msgf("failed to cancel payment %s", payment.ID)
+		}
+
+		return entity.Payment{}, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	payment, err = s.ordersStore.SetPaymentProvider(ctx, payment.ID, gatewayPayment)
+	if err != nil {
+		return entity.Payment{}, fmt.Errorf("failed to save payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+func (s *Service) ListPayments(ctx context.Context, userID entity.UserID, orderID entity.OrderID) ([]entity.Payment, error) {
+	payments, err := s.ordersStore.ListPayments(ctx, userID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// HandleWebhook applies a signed provider notification. A payment held by
+// the provider is captured while its order still waits for payment and is
+// released otherwise. Notifications may be delivered more than once; a held
+// payment is settled on every delivery until it is no longer held, so a
+// capture that failed is retried along with the notification.
+func (s *Service) HandleWebhook(ctx context.Context, body []byte, signature string) error {
+	event, err := s.paymentGateway.ParseWebhook(body, signature)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhook: %w", err)
+	}
+
+	update, err := s.applyPaymentEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to apply payment event: %w", err)
+	}
+
+	switch update.Payment.Status {
+	case entity.PaymentStatusWaitingForCapture:
+		return s.settle(ctx, update)
+	case entity.PaymentStatusSucceeded:
+		return s.refundCancelled(ctx, update)
+	default:
+	}
+
+	return nil
+}
+
+// refundCancelled returns the money of a payment that succeeded although its
+// order was cancelled in the meantime, e.g. captured by the provider while
+// the customer cancelled. Until the refund goes through the payment stays
+// succeeded on a cancelled order, and every redelivered notification tries
+// again; the provider deduplicates refunds of the same payment.
+func (s *Service) refundCancelled(ctx context.Context, update entity.PaymentUpdate) error {
+	if update.Payment.Status != entity.PaymentStatusSucceeded || update.OrderStatus != entity.OrderStatusCancelled {
+		return nil
+	}
+
+	payment := update.Payment
+
+	log.Warn().Msgf("refunding payment %s that succeeded for cancelled order %s", payment.ID, payment.OrderID)
+
+	done, err := s.paymentGateway.RefundPayment(ctx, payment.ProviderID, payment.Amount, payment.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to refund payment of cancelled order: %w", err)
+	}
+
+	if !done {
+		return nil
+	}
+
+	_, err = s.applyPaymentEvent(ctx, entity.PaymentEvent{
+		Type:       entity.PaymentEventRefundSucceeded,
+		ProviderID: payment.ProviderID,
+		Amount:     payment.Amount,
+		Currency:   payment.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply refund: %w", err)
+	}
+
+	return nil
+}
+
+// settle captures or releases a payment held by the provider and applies the
+// outcome right away rather than waiting for its notification.
+func (s *Service) settle(ctx context.Context, update entity.PaymentUpdate) error {
+	payment := update.Payment
+
+	var (
+		gatewayPayment entity.GatewayPayment
+		err            error
+	)
+
+	if update.OrderStatus.CanTransition(entity.OrderStatusPaid) {
+		gatewayPayment, err = s.paymentGateway.CapturePayment(ctx, payment.ProviderID, payment.Amount, payment.Currency)
+	} else {
+		gatewayPayment, err = s.paymentGateway.CancelPayment(ctx, payment.ProviderID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to settle payment: %w", err)
+	}
+
+	var eventType entity.PaymentEventType
+
+	switch gatewayPayment.Status {
+	case entity.PaymentStatusSucceeded:
+		eventType = entity.PaymentEventSucceeded
+	case entity.PaymentStatusCanceled:
+		eventType = entity.PaymentEventCanceled
+	default:
+		return nil
+	}
+
+	settled, err := s.applyPaymentEvent(ctx, entity.PaymentEvent{
+		Type:       eventType,
+		ProviderID: gatewayPayment.ProviderID,
+		Amount:     gatewayPayment.Amount,
+		Currency:   gatewayPayment.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply settled payment: %w", err)
+	}
+
+	return s.refundCancelled(ctx, settled)
+}
+
+// RefundOrder returns the money paid for an order to the customer. The order
+// becomes refunded once the provider has returned the money, which may happen
+// later, on the refund's notification.
+func (s *Service) RefundOrder(ctx context.Context, orderID entity.OrderID) (entity.Payment, error) {
+	payment, err := s.ordersStore.GetRefundablePayment(ctx, orderID)
+	if err != nil {
+		return entity.Payment{}, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	done, err := s.paymentGateway.RefundPayment(ctx, payment.ProviderID, payment.Amount, payment.Currency)
+	if err != nil {
+		return entity.Payment{}, fmt.Errorf("failed to refund payment: %w", err)
+	}
+
+	if !done {
+		return payment, nil
+	}
+
+	update, err := s.applyPaymentEvent(ctx, entity.PaymentEvent{
+		Type:       entity.PaymentEventRefundSucceeded,
+		ProviderID: payment.ProviderID,
+		Amount:     payment.Amount,
+		Currency:   payment.Currency,
+	})
+	if err != nil {
+		return entity.Payment{}, fmt.Errorf("failed to apply refund: %w", err)
+	}
+
+	return update.Payment, nil
+}
+
+// applyPaymentEvent applies a provider event and tells the order's owner when
+// the event moved the order.
+func (s *Service) applyPaymentEvent(ctx context.Context, event entity.PaymentEvent) (entity.PaymentUpdate, error) {
+	var update entity.PaymentUpdate
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		update, err = s.ordersStore.ApplyPaymentEvent(ctx, event)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if !update.OrderChanged {
+			return nil
+		}
+
+		return s.notifyOrderStatus(ctx, update.Payment.UserID, update.Payment.OrderID, update.OrderStatus)
+	})
+	if err != nil {
+		return entity.PaymentUpdate{}, err //nolint:wrapcheck
+	}
+
+	return update, nil
+}
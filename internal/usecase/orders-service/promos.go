@@ -0,0 +1,63 @@
+package ordersservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (s *Service) CreatePromoCode(ctx context.Context, promo entity.PromoCode) (entity.PromoCode, error) {
+	validatedPromo, err := promo.Validate()
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("promo code validation failed: %w", err)
+	}
+
+	validatedPromo.ID = entity.PromoCodeID(uuid.New())
+
+	createdPromo, err := s.ordersStore.CreatePromoCode(ctx, validatedPromo)
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("failed to create promo code: %w", err)
+	}
+
+	return createdPromo, nil
+}
+
+func (s *Service) GetPromoCode(ctx context.Context, promoID entity.PromoCodeID) (entity.PromoCode, error) {
+	promo, err := s.ordersStore.GetPromoCode(ctx, promoID)
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	return promo, nil
+}
+
+func (s *Service) ListPromoCodes(ctx context.Context, filter entity.PromoCodeFilter) ([]entity.PromoCode, error) {
+	promos, err := s.ordersStore.ListPromoCodes(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promo codes: %w", err)
+	}
+
+	return promos, nil
+}
+
+// UpdatePromoCode changes when and how much a code can be used. What a code
+// takes off is fixed once customers may have seen it.
+func (s *Service) UpdatePromoCode(
+	ctx context.Context,
+	promoID entity.PromoCodeID,
+	update entity.PromoCodeUpdate,
+) (entity.PromoCode, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("promo code validation failed: %w", err)
+	}
+
+	promo, err := s.ordersStore.UpdatePromoCode(ctx, promoID, validatedUpdate)
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("failed to update promo code: %w", err)
+	}
+
+	return promo, nil
+}
@@ -0,0 +1,302 @@
+package ordersservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type ordersStore interface {
+	AddCartItem(ctx context.Context, item entity.CartItem) (entity.CartItem, error)
+	ListCartItems(ctx context.Context, userID entity.UserID) ([]entity.CartItem, error)
+	UpdateCartItem(ctx context.Context, userID entity.UserID, itemID entity.CartItemID,
+		update entity.CartItemUpdate) (entity.CartItem, error)
+	DeleteCartItem(ctx context.Context, userID entity.UserID, itemID entity.CartItemID) error
+	Checkout(ctx context.Context, orderID entity.OrderID, userID entity.UserID, promoCode *string,
+		price entity.Pricer) (entity.Order, error)
+	GetOrder(ctx context.Context, userID entity.UserID, orderID entity.OrderID) (entity.Order, error)
+	ListOrders(ctx context.Context, userID entity.UserID, filter entity.OrderFilter) ([]entity.Order, error)
+	TransitionOrder(ctx context.Context, userID *entity.UserID, orderID entity.OrderID,
+		status entity.OrderStatus) (entity.Order, error)
+	StartPayment(ctx context.Context, payment entity.Payment) (entity.Payment, error)
+	SetPaymentProvider(ctx context.Context, paymentID entity.PaymentID,
+		gatewayPayment entity.GatewayPayment) (entity.Payment, error)
+	CancelPayment(ctx context.Context, paymentID entity.PaymentID) error
+	ListPayments(ctx context.Context, userID entity.UserID, orderID entity.OrderID) ([]entity.Payment, error)
+	GetRefundablePayment(ctx context.Context, orderID entity.OrderID) (entity.Payment, error)
+	ApplyPaymentEvent(ctx context.Context, event entity.PaymentEvent) (entity.PaymentUpdate, error)
+	CreatePromoCode(ctx context.Context, promo entity.PromoCode) (entity.PromoCode, error)
+	GetPromoCode(ctx context.Context, promoID entity.PromoCodeID) (entity.PromoCode, error)
+	ListPromoCodes(ctx context.Context, filter entity.PromoCodeFilter) ([]entity.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, promoID entity.PromoCodeID, update entity.PromoCodeUpdate) (entity.PromoCode, error)
+	GetPromoCodeUsage(ctx context.Context, code string, userID entity.UserID) (entity.PromoCode, entity.PromoUsage, error)
+}
+
+type pricingEngine interface {
+	Price(request entity.PricingRequest) (entity.Pricing, error)
+}
+
+type outboxStore interface {
+	Enqueue(ctx context.Context, message entity.OutboxMessage) error
+}
+
+type notifier interface {
+	Notify(ctx context.Context, notification entity.Notification) error
+}
+
+type txManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	ordersStore    ordersStore
+	paymentGateway paymentGateway
+	pricingEngine  pricingEngine
+	outboxStore    outboxStore
+	notifier       notifier
+	txManager      txManager
+}
+
+func New(
+	ordersStore ordersStore,
+	paymentGateway paymentGateway,
+	pricingEngine pricingEngine,
+	outboxStore outboxStore,
+	notifier notifier,
+	txManager txManager,
+) *Service {
+	return &Service{
+		ordersStore:    ordersStore,
+		paymentGateway: paymentGateway,
+		pricingEngine:  pricingEngine,
+		outboxStore:    outboxStore,
+		notifier:       notifier,
+		txManager:      txManager,
+	}
+}
+
+func (s *Service) AddCartItem(ctx context.Context, userID entity.UserID, item entity.CartItem) (entity.CartItem, error) {
+	validatedItem, err := item.Validate()
+	if err != nil {
+		return entity.CartItem{}, fmt.Errorf("cart item validation failed: %w", err)
+	}
+
+	validatedItem.ID = entity.CartItemID(uuid.New())
+	validatedItem.UserID = userID
+
+	addedItem, err := s.ordersStore.AddCartItem(ctx, validatedItem)
+	if err != nil {
+		return entity.CartItem{}, fmt.Errorf("failed to add cart item: %w", err)
+	}
+
+	return addedItem, nil
+}
+
+// GetCart returns the user's cart priced as it would be at checkout with the
+// promo code, if one is given.
+func (s *Service) GetCart(ctx context.Context, userID entity.UserID, promoCode *string) (entity.Cart, error) {
+	items, err := s.ordersStore.ListCartItems(ctx, userID)
+	if err != nil {
+		return entity.Cart{}, fmt.Errorf("failed to list cart items: %w", err)
+	}
+
+	cart, err := entity.NewCart(items)
+	if err != nil {
+		return entity.Cart{}, fmt.Errorf("failed to total cart: %w", err)
+	}
+
+	request := entity.PricingRequest{
+		Lines:    make([]entity.PricingLine, 0, len(items)),
+		Currency: cart.Currency,
+	}
+
+	for _, item := range items {
+		request.Lines = append(request.Lines, entity.PricingLine{
+			ProductID:  item.ProductID,
+			Categories: item.Categories,
+			Price:      item.Price,
+			Quantity:   item.Quantity,
+		})
+	}
+
+	if promoCode != nil {
+		promo, usage, err := s.ordersStore.GetPromoCodeUsage(ctx, *promoCode, userID)
+		if err != nil {
+			return entity.Cart{}, fmt.Errorf("failed to get promo code: %w", err)
+		}
+
+		request.Promo, request.Usage = &promo, usage
+	}
+
+	if cart.Pricing, err = s.price(request); err != nil {
+		return entity.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+func (s *Service) UpdateCartItem(
+	ctx context.Context,
+	userID entity.UserID,
+	itemID entity.CartItemID,
+	update entity.CartItemUpdate,
+) (entity.CartItem, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.CartItem{}, fmt.Errorf("cart item validation failed: %w", err)
+	}
+
+	updatedItem, err := s.ordersStore.UpdateCartItem(ctx, userID, itemID, validatedUpdate)
+	if err != nil {
+		return entity.CartItem{}, fmt.Errorf("failed to update cart item: %w", err)
+	}
+
+	return updatedItem, nil
+}
+
+func (s *Service) DeleteCartItem(ctx context.Context, userID entity.UserID, itemID entity.CartItemID) error {
+	if err := s.ordersStore.DeleteCartItem(ctx, userID, itemID); err != nil {
+		return fmt.Errorf("failed to delete cart item: %w", err)
+	}
+
+	return nil
+}
+
+// Checkout places an order for everything in the user's cart, redeeming the
+// request's promo code if there is one, and empties the cart.
+func (s *Service) Checkout(ctx context.Context, userID entity.UserID, request entity.CheckoutRequest) (entity.Order, error) {
+	order, err := s.ordersStore.Checkout(ctx, entity.OrderID(uuid.New()), userID, request.PromoCode, s.price)
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("failed to check out: %w", err)
+	}
+
+	return order, nil
+}
+
+func (s *Service) price(request entity.PricingRequest) (entity.Pricing, error) {
+	request.At = time.Now()
+
+	pricing, err := s.pricingEngine.Price(request)
+	if err != nil {
+		return entity.Pricing{}, fmt.Errorf("failed to price cart: %w", err)
+	}
+
+	return pricing, nil
+}
+
+func (s *Service) GetOrder(ctx context.Context, userID entity.UserID, orderID entity.OrderID) (entity.Order, error) {
+	order, err := s.ordersStore.GetOrder(ctx, userID, orderID)
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return order, nil
+}
+
+func (s *Service) ListOrders(ctx context.Context, userID entity.UserID, filter entity.OrderFilter) ([]entity.Order, error) {
+	if filter.Status != nil {
+		if err := filter.Status.Validate(); err != nil {
+			return nil, fmt.Errorf("filter validation failed: %w", err)
+		}
+	}
+
+	orders, err := s.ordersStore.ListOrders(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// CancelOrder lets users drop their orders that are not paid yet.
+func (s *Service) CancelOrder(ctx context.Context, userID entity.UserID, orderID entity.OrderID) (entity.Order, error) {
+	var order entity.Order
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		order, err = s.ordersStore.TransitionOrder(ctx, &userID, orderID, entity.OrderStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to cancel order: %w", err)
+		}
+
+		return s.notifyOrderStatus(ctx, order.UserID, order.ID, order.Status)
+	})
+	if err != nil {
+		return entity.Order{}, err //nolint:wrapcheck
+	}
+
+	return order, nil
+}
+
+// UpdateOrderStatus moves any user's order along its state machine. It is
+// meant for staff and for payment and delivery integrations.
+func (s *Service) UpdateOrderStatus(ctx context.Context, orderID entity.OrderID, update entity.OrderStatusUpdate) (entity.Order, error) {
+	if err := update.Status.Validate(); err != nil {
+		return entity.Order{}, fmt.Errorf("order status validation failed: %w", err)
+	}
+
+	var order entity.Order
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		order, err = s.ordersStore.TransitionOrder(ctx, nil, orderID, update.Status)
+		if err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+
+		return s.notifyOrderStatus(ctx, order.UserID, order.ID, order.Status)
+	})
+	if err != nil {
+		return entity.Order{}, err //nolint:wrapcheck
+	}
+
+	return order, nil
+}
+
+// notifyOrderStatus tells the order's owner about its new status. It runs in
+// the transaction that changed the order, so the event and the notification
+// go out exactly when the change commits.
+func (s *Service) notifyOrderStatus(ctx context.Context, userID entity.UserID, orderID entity.OrderID, status entity.OrderStatus) error {
+	event := entity.NewEvent(userID, entity.EventOrderStatusChanged, entity.OrderStatusChangedData{
+		OrderID: orderID,
+		Status:  status,
+	})
+
+	if err := s.outboxStore.Enqueue(ctx, entity.NewOutboxMessage(entity.OutboxKindEvent, event)); err != nil {
+		return fmt.Errorf("failed to enqueue order status event: %w", err)
+	}
+
+	err := s.notifier.Notify(ctx, entity.Notification{
+		UserID:   userID,
+		Category: entity.NotificationCategoryOrders,
+		Title:    orderStatusTitle(status),
+		Body:     "Номер заказа: " + orderID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to notify about order status: %w", err)
+	}
+
+	return nil
+}
+
+func orderStatusTitle(status entity.OrderStatus) string {
+	switch status {
+	case entity.OrderStatusPaid:
+		return "Заказ оплачен"
+	case entity.OrderStatusShipped:
+		return "Заказ отправлен"
+	case entity.OrderStatusDelivered:
+		return "Заказ доставлен"
+	case entity.OrderStatusCancelled:
+		return "Заказ отменён"
+	case entity.OrderStatusRefunded:
+		return "Деньги за заказ возвращены"
+	default:
+		return "Статус заказа изменился"
+	}
+}
@@ -0,0 +1,234 @@
+package outboxservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	errUnknownKind = errors.New("unknown outbox message kind")
+	errExpired     = errors.New("outbox message expired")
+)
+
+const (
+	resultSent    = "sent"
+	resultRetried = "retried"
+	resultDead    = "dead"
+)
+
+//nolint:gochecknoglobals
+var (
+	relayedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_messages_total",
+		Help: "Outbox messages relayed, by kind and result.",
+	}, []string{"kind", "result"})
+
+	relayLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "outbox_relay_lag_seconds",
+		Help:    "Time from enqueueing an outbox message to sending it.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 12), //nolint:mnd
+	}, []string{"kind"})
+)
+
+type relayStore interface {
+	ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]entity.OutboxMessage, error)
+	MarkSent(ctx context.Context, messageID entity.OutboxMessageID) error
+	Retry(ctx context.Context, messageID entity.OutboxMessageID, lastError string, nextAttemptAt time.Time) error
+	MarkDead(ctx context.Context, messageID entity.OutboxMessageID, lastError string) error
+	DeleteFinished(ctx context.Context, before time.Time) (int64, error)
+}
+
+type smsRegistration interface {
+	SendOTP(ctx context.Context, phone string, otp string) error
+	SendSMS(ctx context.Context, phone string, text string) error
+}
+
+type eventsPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+type RelayConfig struct {
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Lease       time.Duration
+	Retention   time.Duration
+}
+
+// Relay periodically performs the side effects recorded in the outbox. A
+// failed message is retried with exponential backoff until it runs out of
+// attempts or expires, then it is marked dead. Sent and dead messages are
+// deleted once they are older than the retention period.
+type Relay struct {
+	cfg             RelayConfig
+	store           relayStore
+	smsRegistration smsRegistration
+	eventsPublisher eventsPublisher
+}
+
+func NewRelay(cfg RelayConfig, store relayStore, smsRegistration smsRegistration, eventsPublisher eventsPublisher) *Relay {
+	return &Relay{
+		cfg:             cfg,
+		store:           store,
+		smsRegistration: smsRegistration,
+		eventsPublisher: eventsPublisher,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Check(ctx); err != nil {
+				log.Warn().Err(err).Msg("failed to relay outbox messages")
+			}
+		}
+	}
+}
+
+func (r *Relay) Check(ctx context.Context) error {
+	messages, err := r.store.ClaimDue(ctx, r.cfg.BatchSize, time.Now().Add(r.cfg.Lease))
+	if err != nil {
+		return fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	var sent, retried, dead int
+
+	for _, message := range messages {
+		result, err := r.relay(ctx, message)
+		if err != nil {
+			return err
+		}
+
+		relayedMessages.WithLabelValues(string(message.Kind), result).Inc()
+
+		switch result {
+		case resultSent:
+			relayLag.WithLabelValues(string(message.Kind)).Observe(time.Since(message.CreatedAt).Seconds())
+
+			sent++
+		case resultRetried:
+			retried++
+		default:
+			dead++
+		}
+	}
+
+	deleted, err := r.store.DeleteFinished(ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		return fmt.Errorf("failed to delete finished outbox messages: %w", err)
+	}
+
+	if len(messages) > 0 || deleted > 0 {
+		log.Info().Int("sent", sent).Int("retried", retried).Int("dead", dead).Int64("deleted", deleted).
+			Msg("outbox messages relayed")
+	}
+
+	return nil
+}
+
+func (r *Relay) relay(ctx context.Context, message entity.OutboxMessage) (string, error) {
+	sendErr := r.send(ctx, message)
+	if sendErr == nil {
+		if err := r.store.MarkSent(ctx, message.ID); err != nil {
+			return "", fmt.Errorf("failed to record sent outbox message: %w", err)
+		}
+
+		return resultSent, nil
+	}
+
+	log.Warn().Err(sendErr).Str("message", message.ID.String()).Int("attempt", message.Attempts).
+		Msg("failed to relay outbox message")
+
+	if errors.Is(sendErr, errUnknownKind) || errors.Is(sendErr, errExpired) || message.Attempts >= r.cfg.MaxAttempts {
+		if err := r.store.MarkDead(ctx, message.ID, sendErr.Error()); err != nil {
+			return "", fmt.Errorf("failed to record dead outbox message: %w", err)
+		}
+
+		return resultDead, nil
+	}
+
+	if err := r.store.Retry(ctx, message.ID, sendErr.Error(), time.Now().Add(r.backoff(message.Attempts))); err != nil {
+		return "", fmt.Errorf("failed to record outbox message retry: %w", err)
+	}
+
+	return resultRetried, nil
+}
+
+func (r *Relay) send(ctx context.Context, message entity.OutboxMessage) error {
+	switch message.Kind {
+	case entity.OutboxKindOTP:
+		var payload entity.OTPPayload
+
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to decode otp payload: %w", err)
+		}
+
+		if !payload.ExpiresAt.IsZero() && time.Now().After(payload.ExpiresAt) {
+			return fmt.Errorf("%w: otp expired at %s", errExpired, payload.ExpiresAt.Format(time.RFC3339))
+		}
+
+		if err := r.smsRegistration.SendOTP(ctx, payload.Phone, payload.OTP); err != nil {
+			return fmt.Errorf("failed to send otp: %w", err)
+		}
+
+		return nil
+	case entity.OutboxKindSMS:
+		var payload entity.SMSPayload
+
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to decode sms payload: %w", err)
+		}
+
+		if err := r.smsRegistration.SendSMS(ctx, payload.Phone, payload.Text); err != nil {
+			return fmt.Errorf("failed to send sms: %w", err)
+		}
+
+		return nil
+	case entity.OutboxKindEvent:
+		var event entity.Event
+
+		if err := json.Unmarshal(message.Payload, &event); err != nil {
+			return fmt.Errorf("failed to decode event payload: %w", err)
+		}
+
+		if err := r.eventsPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnknownKind, message.Kind)
+	}
+}
+
+// backoff is the delay before the attempt that follows the given one: the
+// base backoff doubled for every attempt made, up to the maximum.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.cfg.BaseBackoff
+
+	for range attempts - 1 {
+		if delay >= r.cfg.MaxBackoff/2 {
+			return r.cfg.MaxBackoff
+		}
+
+		delay *= 2
+	}
+
+	return min(delay, r.cfg.MaxBackoff)
+}
@@ -0,0 +1,160 @@
+package outboxservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	outboxservice "github.com/romanpitatelev/clothing-service/internal/usecase/outbox-service"
+	"github.com/stretchr/testify/require"
+)
+
+var errSMSFailed = errors.New("sms failed")
+
+type relayStore struct {
+	messages []entity.OutboxMessage
+	sent     map[entity.OutboxMessageID]bool
+	retries  map[entity.OutboxMessageID]time.Time
+	dead     map[entity.OutboxMessageID]string
+	before   time.Time
+}
+
+func (s *relayStore) ClaimDue(_ context.Context, limit int, _ time.Time) ([]entity.OutboxMessage, error) {
+	return s.messages[:min(limit, len(s.messages))], nil
+}
+
+func (s *relayStore) MarkSent(_ context.Context, messageID entity.OutboxMessageID) error {
+	s.sent[messageID] = true
+
+	return nil
+}
+
+func (s *relayStore) Retry(_ context.Context, messageID entity.OutboxMessageID, _ string, nextAttemptAt time.Time) error {
+	s.retries[messageID] = nextAttemptAt
+
+	return nil
+}
+
+func (s *relayStore) MarkDead(_ context.Context, messageID entity.OutboxMessageID, lastError string) error {
+	s.dead[messageID] = lastError
+
+	return nil
+}
+
+func (s *relayStore) DeleteFinished(_ context.Context, before time.Time) (int64, error) {
+	s.before = before
+
+	return 0, nil
+}
+
+type smsRegistration struct {
+	otps  map[string]string
+	texts map[string]string
+}
+
+func (s *smsRegistration) SendOTP(_ context.Context, phone string, otp string) error {
+	if phone == "" {
+		return errSMSFailed
+	}
+
+	s.otps[phone] = otp
+
+	return nil
+}
+
+func (s *smsRegistration) SendSMS(_ context.Context, phone string, text string) error {
+	if phone == "" {
+		return errSMSFailed
+	}
+
+	s.texts[phone] = text
+
+	return nil
+}
+
+type eventsPublisher struct {
+	events []entity.Event
+}
+
+func (p *eventsPublisher) Publish(_ context.Context, event entity.Event) error {
+	p.events = append(p.events, event)
+
+	return nil
+}
+
+func newOTPMessage(phone string, attempts int) entity.OutboxMessage {
+	message := entity.NewOutboxMessage(entity.OutboxKindOTP, entity.OTPPayload{
+		Phone:     phone,
+		OTP:       "1234",
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	message.Attempts = attempts
+	message.CreatedAt = time.Now()
+
+	return message
+}
+
+func TestRelay_Check(t *testing.T) {
+	t.Parallel()
+
+	sent := newOTPMessage("79031234567", 1)
+	firstFailure := newOTPMessage("", 1)
+	thirdFailure := newOTPMessage("", 3)
+	lastFailure := newOTPMessage("", 5)
+	unknown := entity.NewOutboxMessage("carrier.pigeon", nil)
+	unknown.Attempts = 1
+	expired := entity.NewOutboxMessage(entity.OutboxKindOTP, entity.OTPPayload{
+		Phone:     "79031234568",
+		OTP:       "5678",
+		ExpiresAt: time.Now().Add(-time.Second),
+	})
+	expired.Attempts = 1
+	text := entity.NewOutboxMessage(entity.OutboxKindSMS, entity.SMSPayload{Phone: "79031234569", Text: "Цена снизилась"})
+	text.Attempts = 1
+	event := entity.NewEvent(entity.UserID(uuid.New()), entity.EventFollowerAdded, entity.FollowerAddedData{})
+	published := entity.NewOutboxMessage(entity.OutboxKindEvent, event)
+	published.Attempts = 1
+
+	store := &relayStore{
+		messages: []entity.OutboxMessage{sent, firstFailure, thirdFailure, lastFailure, unknown, expired, text, published},
+		sent:     make(map[entity.OutboxMessageID]bool),
+		retries:  make(map[entity.OutboxMessageID]time.Time),
+		dead:     make(map[entity.OutboxMessageID]string),
+	}
+	sms := &smsRegistration{otps: make(map[string]string), texts: make(map[string]string)}
+	events := &eventsPublisher{}
+
+	relay := outboxservice.NewRelay(outboxservice.RelayConfig{
+		BatchSize:   10,
+		MaxAttempts: 5,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  3 * time.Minute,
+		Lease:       time.Minute,
+		Retention:   time.Hour,
+	}, store, sms, events)
+
+	before := time.Now()
+
+	require.NoError(t, relay.Check(context.Background()))
+
+	require.Equal(t, map[string]string{"79031234567": "1234"}, sms.otps)
+	require.Equal(t, map[string]string{"79031234569": "Цена снизилась"}, sms.texts)
+	require.Len(t, events.events, 1)
+	require.Equal(t, event.ID, events.events[0].ID)
+	require.JSONEq(t, string(event.Data), string(events.events[0].Data))
+	require.Equal(t, map[entity.OutboxMessageID]bool{sent.ID: true, text.ID: true, published.ID: true}, store.sent)
+
+	require.Len(t, store.retries, 2)
+	require.WithinRange(t, store.retries[firstFailure.ID], before.Add(time.Minute), time.Now().Add(time.Minute))
+	require.WithinRange(t, store.retries[thirdFailure.ID], before.Add(3*time.Minute), time.Now().Add(3*time.Minute))
+
+	require.Len(t, store.dead, 3)
+	require.Contains(t, store.dead[lastFailure.ID], errSMSFailed.Error())
+	require.Contains(t, store.dead[unknown.ID], "carrier.pigeon")
+	require.Contains(t, store.dead[expired.ID], "expired")
+
+	require.WithinRange(t, store.before, before.Add(-time.Hour), time.Now().Add(-time.Hour))
+}
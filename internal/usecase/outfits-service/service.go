@@ -0,0 +1,121 @@
+package outfitsservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const copySuffix = " (copy)"
+
+type outfitsStore interface {
+	CreateOutfit(ctx context.Context, outfit entity.Outfit) (entity.Outfit, error)
+	GetOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) (entity.Outfit, error)
+	ListOutfits(ctx context.Context, userID entity.UserID, filter entity.OutfitFilter) ([]entity.Outfit, error)
+	UpdateOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID, update entity.OutfitUpdate) (entity.Outfit, error)
+	DeleteOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) error
+}
+
+type Service struct {
+	outfitsStore outfitsStore
+}
+
+func New(outfitsStore outfitsStore) *Service {
+	return &Service{
+		outfitsStore: outfitsStore,
+	}
+}
+
+func (s *Service) CreateOutfit(ctx context.Context, userID entity.UserID, outfit entity.Outfit) (entity.Outfit, error) {
+	validatedOutfit, err := outfit.Validate()
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("outfit validation failed: %w", err)
+	}
+
+	validatedOutfit.ID = entity.OutfitID(uuid.New())
+	validatedOutfit.UserID = userID
+
+	createdOutfit, err := s.outfitsStore.CreateOutfit(ctx, validatedOutfit)
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("failed to create outfit: %w", err)
+	}
+
+	return createdOutfit, nil
+}
+
+func (s *Service) GetOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) (entity.Outfit, error) {
+	outfit, err := s.outfitsStore.GetOutfit(ctx, userID, outfitID)
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("failed to get outfit: %w", err)
+	}
+
+	return outfit, nil
+}
+
+func (s *Service) ListOutfits(ctx context.Context, userID entity.UserID, filter entity.OutfitFilter) ([]entity.Outfit, error) {
+	if filter.Season != nil {
+		if err := filter.Season.Validate(); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	if filter.Occasion != nil {
+		occasion := strings.ToLower(strings.TrimSpace(*filter.Occasion))
+		filter.Occasion = &occasion
+	}
+
+	outfits, err := s.outfitsStore.ListOutfits(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outfits: %w", err)
+	}
+
+	return outfits, nil
+}
+
+func (s *Service) UpdateOutfit(
+	ctx context.Context,
+	userID entity.UserID,
+	outfitID entity.OutfitID,
+	update entity.OutfitUpdate,
+) (entity.Outfit, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("outfit validation failed: %w", err)
+	}
+
+	updatedOutfit, err := s.outfitsStore.UpdateOutfit(ctx, userID, outfitID, validatedUpdate)
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("failed to update outfit: %w", err)
+	}
+
+	return updatedOutfit, nil
+}
+
+func (s *Service) DeleteOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) error {
+	if err := s.outfitsStore.DeleteOutfit(ctx, userID, outfitID); err != nil {
+		return fmt.Errorf("failed to delete outfit: %w", err)
+	}
+
+	return nil
+}
+
+// DuplicateOutfit copies an outfit with all of its items under a new ID.
+func (s *Service) DuplicateOutfit(ctx context.Context, userID entity.UserID, outfitID entity.OutfitID) (entity.Outfit, error) {
+	outfit, err := s.outfitsStore.GetOutfit(ctx, userID, outfitID)
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("failed to get outfit: %w", err)
+	}
+
+	outfit.ID = entity.OutfitID(uuid.New())
+	outfit.Name += copySuffix
+
+	duplicate, err := s.outfitsStore.CreateOutfit(ctx, outfit)
+	if err != nil {
+		return entity.Outfit{}, fmt.Errorf("failed to duplicate outfit: %w", err)
+	}
+
+	return duplicate, nil
+}
@@ -0,0 +1,123 @@
+package realtimeservice
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+type eventsStore interface {
+	Listen(ctx context.Context, handle func(event entity.Event)) error
+}
+
+type HubConfig struct {
+	BufferSize    int
+	RetryInterval time.Duration
+}
+
+// Hub fans the events published by any replica out to the clients connected
+// to this one. A client that falls BufferSize events behind misses the
+// events that do not fit; clients catch up through the API on reconnect.
+type Hub struct {
+	cfg         HubConfig
+	eventsStore eventsStore
+
+	mu          sync.RWMutex
+	subscribers map[entity.UserID]map[chan entity.Event]struct{}
+	closed      bool
+}
+
+func NewHub(cfg HubConfig, eventsStore eventsStore) *Hub {
+	return &Hub{
+		cfg:         cfg,
+		eventsStore: eventsStore,
+		subscribers: make(map[entity.UserID]map[chan entity.Event]struct{}),
+	}
+}
+
+// Run listens to events until ctx is done, reconnecting after failures.
+// Subscriptions are closed when Run returns so open streams end with it.
+func (h *Hub) Run(ctx context.Context) {
+	defer h.close()
+
+	for {
+		err := h.eventsStore.Listen(ctx, h.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Warn().Err(err).Msg("events listener stopped, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(h.cfg.RetryInterval):
+		}
+	}
+}
+
+// Subscribe returns the user's events and a function to stop receiving them.
+func (h *Hub) Subscribe(userID entity.UserID) (<-chan entity.Event, func()) {
+	events := make(chan entity.Event, h.cfg.BufferSize)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(events)
+
+		return events, func() {}
+	}
+
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan entity.Event]struct{})
+	}
+
+	h.subscribers[userID][events] = struct{}{}
+	h.mu.Unlock()
+
+	return events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if _, ok := h.subscribers[userID][events]; !ok {
+			return
+		}
+
+		delete(h.subscribers[userID], events)
+
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+	}
+}
+
+func (h *Hub) dispatch(event entity.Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for events := range h.subscribers[event.UserID] {
+		select {
+		case events <- event:
+		default:
+			log.Warn().Str("user", event.UserID.String()).Str("event", string(event.Type)).Msg("dropped event for slow client")
+		}
+	}
+}
+
+func (h *Hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for userID, subscriptions := range h.subscribers {
+		for events := range subscriptions {
+			close(events)
+		}
+
+		delete(h.subscribers, userID)
+	}
+
+	h.closed = true
+}
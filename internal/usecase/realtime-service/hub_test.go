@@ -0,0 +1,140 @@
+package realtimeservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	realtimeservice "github.com/romanpitatelev/clothing-service/internal/usecase/realtime-service"
+	"github.com/stretchr/testify/require"
+)
+
+var errConnectionLost = errors.New("connection lost")
+
+// eventsStore hands each Listen call its own channel of events and fails the
+// call when the channel is closed.
+type eventsStore struct {
+	listens chan chan entity.Event
+}
+
+func (s *eventsStore) Listen(ctx context.Context, handle func(event entity.Event)) error {
+	events := make(chan entity.Event)
+
+	select {
+	case s.listens <- events:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return errConnectionLost
+			}
+
+			handle(event)
+		}
+	}
+}
+
+func receive(t *testing.T, events <-chan entity.Event) entity.Event {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+
+		return entity.Event{}
+	}
+}
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := &eventsStore{listens: make(chan chan entity.Event)}
+	hub := realtimeservice.NewHub(realtimeservice.HubConfig{
+		BufferSize:    1,
+		RetryInterval: time.Millisecond,
+	}, store)
+
+	go hub.Run(ctx)
+
+	alice := entity.UserID(uuid.New())
+	bob := entity.UserID(uuid.New())
+
+	aliceEvents, unsubscribeAlice := hub.Subscribe(alice)
+	aliceTab, unsubscribeAliceTab := hub.Subscribe(alice)
+	bobEvents, unsubscribeBob := hub.Subscribe(bob)
+
+	defer unsubscribeAlice()
+	defer unsubscribeBob()
+
+	listener := <-store.listens
+
+	followed := entity.NewEvent(alice, entity.EventFollowerAdded, entity.FollowerAddedData{FollowerID: bob})
+	listener <- followed
+
+	require.Equal(t, followed.ID, receive(t, aliceEvents).ID)
+	require.Equal(t, followed.ID, receive(t, aliceTab).ID)
+
+	t.Run("slow clients miss events", func(t *testing.T) {
+		unsubscribeAliceTab()
+
+		first := entity.NewEvent(bob, entity.EventFollowerAdded, entity.FollowerAddedData{FollowerID: alice})
+		second := entity.NewEvent(bob, entity.EventFollowerAdded, entity.FollowerAddedData{FollowerID: alice})
+
+		listener <- first
+		listener <- second
+
+		// Events are dispatched in order, so once alice got this one bob's
+		// buffer has seen both of his.
+		sync := entity.NewEvent(alice, entity.EventFollowerAdded, entity.FollowerAddedData{FollowerID: bob})
+		listener <- sync
+
+		require.Equal(t, sync.ID, receive(t, aliceEvents).ID)
+		require.Equal(t, first.ID, receive(t, bobEvents).ID)
+		require.Empty(t, bobEvents)
+		require.Empty(t, aliceTab)
+	})
+
+	t.Run("reconnects", func(t *testing.T) {
+		close(listener)
+
+		listener = <-store.listens
+
+		dropped := entity.NewEvent(alice, entity.EventPriceDropped, entity.PriceDroppedData{NewPrice: 100})
+		listener <- dropped
+
+		require.Equal(t, dropped.ID, receive(t, aliceEvents).ID)
+	})
+
+	t.Run("closes subscriptions on shutdown", func(t *testing.T) {
+		cancel()
+
+		require.Eventually(t, func() bool {
+			select {
+			case _, ok := <-bobEvents:
+				return !ok
+			default:
+				return false
+			}
+		}, time.Second, time.Millisecond)
+
+		events, unsubscribe := hub.Subscribe(alice)
+		defer unsubscribe()
+
+		_, ok := <-events
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,46 @@
+package searchservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type searchStore interface {
+	SearchItems(ctx context.Context, userID entity.UserID, query entity.SearchQuery) ([]entity.WardrobeItem, *string, error)
+	CountFacets(ctx context.Context, userID entity.UserID, query entity.SearchQuery) (entity.SearchFacets, error)
+}
+
+type Service struct {
+	searchStore searchStore
+}
+
+func New(searchStore searchStore) *Service {
+	return &Service{
+		searchStore: searchStore,
+	}
+}
+
+func (s *Service) SearchItems(ctx context.Context, userID entity.UserID, query entity.SearchQuery) (entity.SearchResult, error) {
+	validatedQuery, err := query.Validate()
+	if err != nil {
+		return entity.SearchResult{}, fmt.Errorf("search query validation failed: %w", err)
+	}
+
+	items, nextCursor, err := s.searchStore.SearchItems(ctx, userID, validatedQuery)
+	if err != nil {
+		return entity.SearchResult{}, fmt.Errorf("failed to search wardrobe items: %w", err)
+	}
+
+	facets, err := s.searchStore.CountFacets(ctx, userID, validatedQuery)
+	if err != nil {
+		return entity.SearchResult{}, fmt.Errorf("failed to count search facets: %w", err)
+	}
+
+	return entity.SearchResult{
+		Items:      items,
+		Facets:     facets,
+		NextCursor: nextCursor,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package sizesservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/sizechart"
+)
+
+type sizesStore interface {
+	GetProfile(ctx context.Context, userID entity.UserID) (entity.SizeProfile, error)
+	SaveProfile(ctx context.Context, profile entity.SizeProfile) (entity.SizeProfile, error)
+}
+
+type taxonomyStore interface {
+	GetCategoryPath(ctx context.Context, categoryID string) (entity.CategoryPath, error)
+}
+
+type Service struct {
+	sizesStore    sizesStore
+	taxonomyStore taxonomyStore
+}
+
+func New(sizesStore sizesStore, taxonomyStore taxonomyStore) *Service {
+	return &Service{
+		sizesStore:    sizesStore,
+		taxonomyStore: taxonomyStore,
+	}
+}
+
+// GetProfile returns the user's size profile, or an empty one if the user has
+// not filled it in yet.
+func (s *Service) GetProfile(ctx context.Context, userID entity.UserID) (entity.SizeProfile, error) {
+	profile, err := s.sizesStore.GetProfile(ctx, userID)
+
+	switch {
+	case errors.Is(err, entity.ErrSizeProfileNotFound):
+		profile = entity.SizeProfile{UserID: userID, Region: entity.DefaultSizeRegion}
+	case err != nil:
+		return entity.SizeProfile{}, fmt.Errorf("failed to get size profile: %w", err)
+	}
+
+	return withSizes(profile), nil
+}
+
+func (s *Service) UpdateProfile(ctx context.Context, userID entity.UserID, update entity.SizeProfileUpdate) (entity.SizeProfile, error) {
+	profile, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return entity.SizeProfile{}, err
+	}
+
+	profile.Apply(update)
+
+	validatedProfile, err := profile.Validate()
+	if err != nil {
+		return entity.SizeProfile{}, fmt.Errorf("size profile validation failed: %w", err)
+	}
+
+	savedProfile, err := s.sizesStore.SaveProfile(ctx, validatedProfile)
+	if err != nil {
+		return entity.SizeProfile{}, fmt.Errorf("failed to save size profile: %w", err)
+	}
+
+	return withSizes(savedProfile), nil
+}
+
+// GetSizeHint recommends a size of the category and, when the request has a
+// size, tells how far off it is.
+func (s *Service) GetSizeHint(ctx context.Context, userID entity.UserID, request entity.SizeHintRequest) (entity.SizeHint, error) {
+	request.Category = strings.TrimSpace(request.Category)
+	if request.Category == "" {
+		return entity.SizeHint{}, fmt.Errorf("%w: category is required", entity.ErrInvalidCategory)
+	}
+
+	if request.Region != nil {
+		if err := request.Region.Validate(); err != nil {
+			return entity.SizeHint{}, fmt.Errorf("%w: %w", entity.ErrUnknownSize, err)
+		}
+	}
+
+	path, err := s.taxonomyStore.GetCategoryPath(ctx, request.Category)
+	if err != nil {
+		return entity.SizeHint{}, fmt.Errorf("failed to get category path: %w", err)
+	}
+
+	group, err := sizechart.GroupOf(path[0].ID)
+	if err != nil {
+		return entity.SizeHint{}, fmt.Errorf("%w: %w", entity.ErrUnknownSize, err)
+	}
+
+	profile, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return entity.SizeHint{}, err
+	}
+
+	if profile.Chart == nil {
+		return entity.SizeHint{}, fmt.Errorf("%w: size chart is not set", entity.ErrIncompleteSizeProfile)
+	}
+
+	measurements := measurementsOf(profile)
+
+	recommended, err := sizechart.Recommend(*profile.Chart, group, measurements)
+	if err != nil {
+		return entity.SizeHint{}, fmt.Errorf("%w: %w", entity.ErrIncompleteSizeProfile, err)
+	}
+
+	hint := entity.SizeHint{
+		Category:    request.Category,
+		Recommended: recommended,
+	}
+
+	if request.Size == "" {
+		return hint, nil
+	}
+
+	region := profile.Region
+	if request.Region != nil {
+		region = *request.Region
+	}
+
+	difference, err := sizechart.Difference(*profile.Chart, group, measurements, request.Size, region)
+	if err != nil {
+		return entity.SizeHint{}, fmt.Errorf("failed to compare sizes: %w", err)
+	}
+
+	verdict := entity.SizeVerdictYourSize
+
+	switch {
+	case difference < 0:
+		verdict = entity.SizeVerdictSmaller
+	case difference > 0:
+		verdict = entity.SizeVerdictLarger
+	}
+
+	hint.Size = &request.Size
+	hint.Difference = &difference
+	hint.Verdict = &verdict
+
+	return hint, nil
+}
+
+func measurementsOf(profile entity.SizeProfile) sizechart.Measurements {
+	return sizechart.Measurements{
+		Chest:    profile.ChestCM,
+		Waist:    profile.WaistCM,
+		Hips:     profile.HipsCM,
+		ShoeSize: profile.ShoeSize,
+		Fit:      profile.PreferredFit,
+	}
+}
+
+// withSizes fills in the recommended size of every group the measurements
+// are enough for.
+func withSizes(profile entity.SizeProfile) entity.SizeProfile {
+	profile.Sizes = make(map[string]entity.Sizes)
+
+	if profile.Chart == nil {
+		return profile
+	}
+
+	for _, group := range sizechart.Groups {
+		if sizes, err := sizechart.Recommend(*profile.Chart, group, measurementsOf(profile)); err == nil {
+			profile.Sizes[string(group)] = sizes
+		}
+	}
+
+	return profile
+}
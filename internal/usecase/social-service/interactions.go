@@ -0,0 +1,194 @@
+package socialservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (s *Service) Like(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error) {
+	state, err := s.postsStore.Like(ctx, viewerID, authorID, postID)
+	if err != nil {
+		return entity.LikeState{}, fmt.Errorf("failed to like post: %w", err)
+	}
+
+	return state, nil
+}
+
+func (s *Service) Unlike(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error) {
+	state, err := s.postsStore.Unlike(ctx, viewerID, authorID, postID)
+	if err != nil {
+		return entity.LikeState{}, fmt.Errorf("failed to unlike post: %w", err)
+	}
+
+	return state, nil
+}
+
+func (s *Service) CreateComment(
+	ctx context.Context,
+	viewerID, authorID entity.UserID,
+	postID entity.PostID,
+	comment entity.Comment,
+) (entity.Comment, error) {
+	validatedComment, err := comment.Validate()
+	if err != nil {
+		return entity.Comment{}, fmt.Errorf("comment validation failed: %w", err)
+	}
+
+	validatedComment.ID = entity.CommentID(uuid.New())
+	validatedComment.PostID = postID
+	validatedComment.AuthorID = viewerID
+
+	createdComment, err := s.postsStore.CreateComment(ctx, authorID, validatedComment)
+	if err != nil {
+		return entity.Comment{}, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return createdComment, nil
+}
+
+func (s *Service) ListComments(
+	ctx context.Context,
+	viewerID, authorID entity.UserID,
+	postID entity.PostID,
+	parentID *entity.CommentID,
+	page entity.Page,
+) (entity.CommentPage, error) {
+	comments, nextCursor, err := s.postsStore.ListComments(ctx, viewerID, authorID, postID, parentID, page)
+	if err != nil {
+		return entity.CommentPage{}, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return entity.CommentPage{Comments: comments, NextCursor: nextCursor}, nil
+}
+
+func (s *Service) UpdateComment(
+	ctx context.Context,
+	userID, authorID entity.UserID,
+	postID entity.PostID,
+	commentID entity.CommentID,
+	update entity.CommentUpdate,
+) (entity.Comment, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.Comment{}, fmt.Errorf("comment validation failed: %w", err)
+	}
+
+	updatedComment, err := s.postsStore.UpdateComment(ctx, userID, authorID, postID, commentID, validatedUpdate)
+	if err != nil {
+		return entity.Comment{}, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return updatedComment, nil
+}
+
+func (s *Service) DeleteComment(
+	ctx context.Context,
+	userID, authorID entity.UserID,
+	postID entity.PostID,
+	commentID entity.CommentID,
+) error {
+	if err := s.postsStore.DeleteComment(ctx, userID, authorID, postID, commentID); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) CreateCollection(ctx context.Context, userID entity.UserID, collection entity.Collection) (entity.Collection, error) {
+	validatedCollection, err := collection.Validate()
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("collection validation failed: %w", err)
+	}
+
+	validatedCollection.ID = entity.CollectionID(uuid.New())
+	validatedCollection.UserID = userID
+
+	createdCollection, err := s.postsStore.CreateCollection(ctx, validatedCollection)
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	return createdCollection, nil
+}
+
+func (s *Service) GetCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) (entity.Collection, error) {
+	collection, err := s.postsStore.GetCollection(ctx, userID, collectionID)
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("failed to get collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+func (s *Service) ListCollections(ctx context.Context, userID entity.UserID, filter entity.CollectionFilter) ([]entity.Collection, error) {
+	collections, err := s.postsStore.ListCollections(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	return collections, nil
+}
+
+func (s *Service) UpdateCollection(
+	ctx context.Context,
+	userID entity.UserID,
+	collectionID entity.CollectionID,
+	update entity.CollectionUpdate,
+) (entity.Collection, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("collection validation failed: %w", err)
+	}
+
+	updatedCollection, err := s.postsStore.UpdateCollection(ctx, userID, collectionID, validatedUpdate)
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	return updatedCollection, nil
+}
+
+func (s *Service) DeleteCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) error {
+	if err := s.postsStore.DeleteCollection(ctx, userID, collectionID); err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) SavePost(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error {
+	if err := s.postsStore.SavePost(ctx, userID, collectionID, postID); err != nil {
+		return fmt.Errorf("failed to save post: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) UnsavePost(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error {
+	if err := s.postsStore.UnsavePost(ctx, userID, collectionID, postID); err != nil {
+		return fmt.Errorf("failed to unsave post: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListCollectionPosts(
+	ctx context.Context,
+	userID entity.UserID,
+	collectionID entity.CollectionID,
+	page entity.Page,
+) (entity.PostPage, error) {
+	if _, err := s.postsStore.GetCollection(ctx, userID, collectionID); err != nil {
+		return entity.PostPage{}, fmt.Errorf("failed to get collection: %w", err)
+	}
+
+	posts, nextCursor, err := s.postsStore.ListCollectionPosts(ctx, userID, collectionID, page)
+	if err != nil {
+		return entity.PostPage{}, fmt.Errorf("failed to list collection posts: %w", err)
+	}
+
+	return entity.PostPage{Posts: posts, NextCursor: nextCursor}, nil
+}
@@ -0,0 +1,208 @@
+package socialservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type postsStore interface {
+	CreatePost(ctx context.Context, post entity.Post) (entity.Post, error)
+	GetPost(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.Post, error)
+	ListPosts(ctx context.Context, viewerID, authorID entity.UserID, page entity.Page) ([]entity.Post, *string, error)
+	Feed(ctx context.Context, userID entity.UserID, page entity.Page) ([]entity.Post, *string, error)
+	UpdatePost(ctx context.Context, userID entity.UserID, postID entity.PostID, update entity.PostUpdate) (entity.Post, error)
+	DeletePost(ctx context.Context, userID entity.UserID, postID entity.PostID) error
+
+	Like(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error)
+	Unlike(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.LikeState, error)
+
+	CreateComment(ctx context.Context, authorID entity.UserID, comment entity.Comment) (entity.Comment, error)
+	ListComments(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID,
+		parentID *entity.CommentID, page entity.Page) ([]entity.Comment, *string, error)
+	UpdateComment(ctx context.Context, userID, authorID entity.UserID, postID entity.PostID,
+		commentID entity.CommentID, update entity.CommentUpdate) (entity.Comment, error)
+	DeleteComment(ctx context.Context, userID, authorID entity.UserID, postID entity.PostID, commentID entity.CommentID) error
+
+	CreateCollection(ctx context.Context, collection entity.Collection) (entity.Collection, error)
+	GetCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) (entity.Collection, error)
+	ListCollections(ctx context.Context, userID entity.UserID, filter entity.CollectionFilter) ([]entity.Collection, error)
+	UpdateCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID,
+		update entity.CollectionUpdate) (entity.Collection, error)
+	DeleteCollection(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID) error
+	SavePost(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error
+	UnsavePost(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID, postID entity.PostID) error
+	ListCollectionPosts(ctx context.Context, userID entity.UserID, collectionID entity.CollectionID,
+		page entity.Page) ([]entity.Post, *string, error)
+}
+
+type followsStore interface {
+	Follow(ctx context.Context, followerID, followeeID entity.UserID) (bool, error)
+	Unfollow(ctx context.Context, followerID, followeeID entity.UserID) error
+	ListFollowers(ctx context.Context, userID entity.UserID, page entity.Page) ([]entity.FollowUser, *string, error)
+	ListFollowing(ctx context.Context, userID entity.UserID, page entity.Page) ([]entity.FollowUser, *string, error)
+}
+
+type outboxStore interface {
+	Enqueue(ctx context.Context, message entity.OutboxMessage) error
+}
+
+type notifier interface {
+	Notify(ctx context.Context, notification entity.Notification) error
+}
+
+type txManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	postsStore   postsStore
+	followsStore followsStore
+	outboxStore  outboxStore
+	notifier     notifier
+	txManager    txManager
+}
+
+func New(postsStore postsStore, followsStore followsStore, outboxStore outboxStore, notifier notifier, txManager txManager) *Service {
+	return &Service{
+		postsStore:   postsStore,
+		followsStore: followsStore,
+		outboxStore:  outboxStore,
+		notifier:     notifier,
+		txManager:    txManager,
+	}
+}
+
+func (s *Service) CreatePost(ctx context.Context, userID entity.UserID, post entity.Post) (entity.Post, error) {
+	validatedPost, err := post.Validate()
+	if err != nil {
+		return entity.Post{}, fmt.Errorf("post validation failed: %w", err)
+	}
+
+	validatedPost.ID = entity.PostID(uuid.New())
+	validatedPost.AuthorID = userID
+
+	createdPost, err := s.postsStore.CreatePost(ctx, validatedPost)
+	if err != nil {
+		return entity.Post{}, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	return createdPost, nil
+}
+
+func (s *Service) GetPost(ctx context.Context, viewerID, authorID entity.UserID, postID entity.PostID) (entity.Post, error) {
+	post, err := s.postsStore.GetPost(ctx, viewerID, authorID, postID)
+	if err != nil {
+		return entity.Post{}, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	return post, nil
+}
+
+func (s *Service) ListPosts(ctx context.Context, viewerID, authorID entity.UserID, page entity.Page) (entity.PostPage, error) {
+	posts, nextCursor, err := s.postsStore.ListPosts(ctx, viewerID, authorID, page)
+	if err != nil {
+		return entity.PostPage{}, fmt.Errorf("failed to list posts: %w", err)
+	}
+
+	return entity.PostPage{Posts: posts, NextCursor: nextCursor}, nil
+}
+
+func (s *Service) Feed(ctx context.Context, userID entity.UserID, page entity.Page) (entity.PostPage, error) {
+	posts, nextCursor, err := s.postsStore.Feed(ctx, userID, page)
+	if err != nil {
+		return entity.PostPage{}, fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	return entity.PostPage{Posts: posts, NextCursor: nextCursor}, nil
+}
+
+func (s *Service) UpdatePost(ctx context.Context, userID entity.UserID, postID entity.PostID, update entity.PostUpdate) (entity.Post, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.Post{}, fmt.Errorf("post validation failed: %w", err)
+	}
+
+	updatedPost, err := s.postsStore.UpdatePost(ctx, userID, postID, validatedUpdate)
+	if err != nil {
+		return entity.Post{}, fmt.Errorf("failed to update post: %w", err)
+	}
+
+	return updatedPost, nil
+}
+
+func (s *Service) DeletePost(ctx context.Context, userID entity.UserID, postID entity.PostID) error {
+	if err := s.postsStore.DeletePost(ctx, userID, postID); err != nil {
+		return fmt.Errorf("failed to delete post: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Follow(ctx context.Context, followerID, followeeID entity.UserID) error {
+	if followerID == followeeID {
+		return fmt.Errorf("%w: users cannot follow themselves", entity.ErrInvalidFollow)
+	}
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		followed, err := s.followsStore.Follow(ctx, followerID, followeeID)
+		if err != nil {
+			return fmt.Errorf("failed to follow user: %w", err)
+		}
+
+		if !followed {
+			return nil
+		}
+
+		event := entity.NewEvent(followeeID, entity.EventFollowerAdded, entity.FollowerAddedData{FollowerID: followerID})
+
+		if err := s.outboxStore.Enqueue(ctx, entity.NewOutboxMessage(entity.OutboxKindEvent, event)); err != nil {
+			return fmt.Errorf("failed to enqueue follower event: %w", err)
+		}
+
+		err = s.notifier.Notify(ctx, entity.Notification{
+			UserID:   followeeID,
+			Category: entity.NotificationCategorySocial,
+			Title:    "Новый подписчик",
+			Body:     "На вас подписался новый пользователь",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to notify about follower: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+func (s *Service) Unfollow(ctx context.Context, followerID, followeeID entity.UserID) error {
+	if err := s.followsStore.Unfollow(ctx, followerID, followeeID); err != nil {
+		return fmt.Errorf("failed to unfollow user: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListFollowers(ctx context.Context, userID entity.UserID, page entity.Page) (entity.FollowPage, error) {
+	users, nextCursor, err := s.followsStore.ListFollowers(ctx, userID, page)
+	if err != nil {
+		return entity.FollowPage{}, fmt.Errorf("failed to list followers: %w", err)
+	}
+
+	return entity.FollowPage{Users: users, NextCursor: nextCursor}, nil
+}
+
+func (s *Service) ListFollowing(ctx context.Context, userID entity.UserID, page entity.Page) (entity.FollowPage, error) {
+	users, nextCursor, err := s.followsStore.ListFollowing(ctx, userID, page)
+	if err != nil {
+		return entity.FollowPage{}, fmt.Errorf("failed to list following: %w", err)
+	}
+
+	return entity.FollowPage{Users: users, NextCursor: nextCursor}, nil
+}
@@ -0,0 +1,387 @@
+package suggestionsservice
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const (
+	DefaultSuggestions = 10
+	MaxSuggestions     = 50
+
+	candidatesPerSlot = 6
+
+	// Below outerwearRequiredBelow every suggestion gets outerwear, above
+	// outerwearExcludedAbove none does.
+	outerwearRequiredBelow = 15.0
+	outerwearExcludedAbove = 22.0
+
+	seasonWeight      = 0.5
+	temperatureWeight = 1.0
+	formalityWeight   = 2.0
+	maxFormalityGap   = 2.0
+	suitsOccasionFrom = 0.75
+)
+
+//nolint:gochecknoglobals
+var (
+	// slots maps root categories of the taxonomy to outfit slots. Dresses take
+	// the top slot and leave no room for a bottom.
+	slots = map[string]entity.OutfitSlot{
+		"tops":        entity.OutfitSlotTop,
+		"dresses":     entity.OutfitSlotTop,
+		"bottoms":     entity.OutfitSlotBottom,
+		"shoes":       entity.OutfitSlotShoes,
+		"outerwear":   entity.OutfitSlotOuterwear,
+		"accessories": entity.OutfitSlotAccessories,
+	}
+
+	// comfort is the temperature range in degrees Celsius a category is worn in.
+	comfort = map[string][2]float64{
+		"t-shirts":     {18, 40},
+		"polos":        {16, 35},
+		"shirts":       {10, 30},
+		"oxford":       {10, 30},
+		"flannel":      {0, 20},
+		"dress-shirts": {10, 30},
+		"sweaters":     {-20, 16},
+		"hoodies":      {0, 18},
+		"dresses":      {15, 35},
+		"jeans":        {-10, 25},
+		"trousers":     {-15, 28},
+		"shorts":       {20, 40},
+		"skirts":       {15, 35},
+		"jackets":      {5, 18},
+		"coats":        {-10, 10},
+		"parkas":       {-40, 0},
+		"sneakers":     {5, 32},
+		"boots":        {-30, 15},
+		"loafers":      {8, 30},
+		"sandals":      {20, 40},
+	}
+
+	// formality ranks categories from 0 (sport) to 2 (formal). Unknown
+	// categories count as smart casual.
+	formality = map[string]float64{
+		"t-shirts":     0,
+		"hoodies":      0,
+		"shorts":       0,
+		"sneakers":     0,
+		"sandals":      0,
+		"parkas":       0,
+		"polos":        1,
+		"jeans":        1,
+		"sweaters":     1,
+		"flannel":      0.5,
+		"shirts":       1.5,
+		"oxford":       1.5,
+		"dress-shirts": 2,
+		"trousers":     2,
+		"loafers":      2,
+		"coats":        2,
+	}
+	defaultFormality = 1.0
+
+	occasions = map[string]float64{
+		"sport":    0,
+		"beach":    0,
+		"casual":   0.5,
+		"date":     1.5,
+		"party":    1.5,
+		"office":   2,
+		"business": 2,
+		"formal":   2,
+		"wedding":  2,
+	}
+
+	neutralColors = []string{"black", "white", "grey", "gray", "beige", "navy", "brown", "cream", "denim", "khaki"}
+
+	complementaryColors = [][2]string{{"red", "green"}, {"blue", "orange"}, {"yellow", "purple"}}
+)
+
+type candidate struct {
+	item      entity.WardrobeItem
+	slot      entity.OutfitSlot
+	isDress   bool
+	score     float64
+	formality float64
+}
+
+// Suggest ranks combinations of the items for the request, best first. It only
+// looks at the items it is given, so it behaves the same offline as in
+// production.
+func Suggest(items []entity.WardrobeItem, request entity.SuggestionRequest) []entity.OutfitSuggestion {
+	limit := request.Limit
+	if limit <= 0 {
+		limit = DefaultSuggestions
+	}
+
+	limit = min(limit, MaxSuggestions)
+
+	bySlot := candidatesBySlot(items, request)
+
+	if len(bySlot[entity.OutfitSlotTop]) == 0 || len(bySlot[entity.OutfitSlotShoes]) == 0 {
+		return []entity.OutfitSuggestion{}
+	}
+
+	outerwear := optional(bySlot[entity.OutfitSlotOuterwear])
+
+	switch {
+	case request.MaxTemperature != nil && *request.MaxTemperature < outerwearRequiredBelow:
+		outerwear = outerwear[1:]
+	case request.MinTemperature != nil && *request.MinTemperature > outerwearExcludedAbove:
+		outerwear = outerwear[:1]
+	}
+
+	accessories := optional(bySlot[entity.OutfitSlotAccessories])
+	suggestions := make([]entity.OutfitSuggestion, 0)
+
+	for _, top := range bySlot[entity.OutfitSlotTop] {
+		bottoms := bySlot[entity.OutfitSlotBottom]
+		if top.isDress {
+			bottoms = []*candidate{nil}
+		}
+
+		for _, bottom := range bottoms {
+			for _, shoes := range bySlot[entity.OutfitSlotShoes] {
+				for _, coat := range outerwear {
+					for _, accessory := range accessories {
+						outfit := slices.DeleteFunc(
+							[]*candidate{top, bottom, shoes, coat, accessory},
+							func(c *candidate) bool { return c == nil },
+						)
+
+						suggestions = append(suggestions, score(outfit, request))
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+
+		return key(suggestions[i]) < key(suggestions[j])
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions
+}
+
+// candidatesBySlot drops items that do not fit the season or the temperature
+// and keeps the best scoring ones of every slot.
+func candidatesBySlot(items []entity.WardrobeItem, request entity.SuggestionRequest) map[entity.OutfitSlot][]*candidate {
+	bySlot := make(map[entity.OutfitSlot][]*candidate)
+
+	for _, item := range items {
+		slot, ok := slots[item.Category]
+		if !ok || !fitsSeason(item, request.Season) {
+			continue
+		}
+
+		temperatureScore, ok := temperatureFit(item, request)
+		if !ok {
+			continue
+		}
+
+		c := &candidate{
+			item:      item,
+			slot:      slot,
+			isDress:   item.Category == "dresses",
+			score:     temperatureScore,
+			formality: lookup(formality, item, defaultFormality),
+		}
+
+		if request.Season != nil && item.Season != nil && *item.Season == *request.Season {
+			c.score += seasonWeight
+		}
+
+		if target, ok := occasions[request.Occasion]; ok {
+			c.score += formalityWeight * formalityFit(c.formality, target)
+		}
+
+		bySlot[slot] = append(bySlot[slot], c)
+	}
+
+	for slot, candidates := range bySlot {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].score != candidates[j].score {
+				return candidates[i].score > candidates[j].score
+			}
+
+			return candidates[i].item.ID.String() < candidates[j].item.ID.String()
+		})
+
+		if len(candidates) > candidatesPerSlot {
+			bySlot[slot] = candidates[:candidatesPerSlot]
+		}
+	}
+
+	return bySlot
+}
+
+// optional prepends the choice of leaving a slot empty.
+func optional(candidates []*candidate) []*candidate {
+	return append([]*candidate{nil}, candidates...)
+}
+
+func score(outfit []*candidate, request entity.SuggestionRequest) entity.OutfitSuggestion {
+	var (
+		total, closeness float64
+		colors           []string
+		reasons          []string
+	)
+
+	items := make([]entity.OutfitItem, 0, len(outfit))
+
+	for _, c := range outfit {
+		items = append(items, entity.OutfitItem{Slot: c.slot, ItemID: c.item.ID})
+		total += c.score
+		colors = append(colors, c.item.Colors...)
+
+		if target, ok := occasions[request.Occasion]; ok {
+			closeness += formalityFit(c.formality, target)
+		}
+	}
+
+	if request.Season != nil {
+		reasons = append(reasons, "fits "+string(*request.Season))
+	}
+
+	if request.MinTemperature != nil || request.MaxTemperature != nil {
+		reasons = append(reasons, "suits the temperature")
+	}
+
+	if _, ok := occasions[request.Occasion]; ok && closeness/float64(len(outfit)) >= suitsOccasionFrom {
+		reasons = append(reasons, "suits "+request.Occasion)
+	}
+
+	harmony, reason := colorHarmony(colors)
+	if reason != "" {
+		reasons = append(reasons, reason)
+	}
+
+	return entity.OutfitSuggestion{
+		Items:   items,
+		Score:   math.Round((total/float64(len(outfit))+harmony)*100) / 100,
+		Reasons: reasons,
+	}
+}
+
+func fitsSeason(item entity.WardrobeItem, season *entity.Season) bool {
+	return season == nil ||
+		item.Season == nil ||
+		*item.Season == entity.SeasonAllSeason ||
+		*item.Season == *season
+}
+
+// temperatureFit reports whether the item can be worn in the requested range
+// and how much of the range it covers.
+func temperatureFit(item entity.WardrobeItem, request entity.SuggestionRequest) (float64, bool) {
+	if request.MinTemperature == nil && request.MaxTemperature == nil {
+		return 0, true
+	}
+
+	itemRange, ok := comfort[category(item, comfort)]
+	if !ok {
+		return 0, true
+	}
+
+	low, high := itemRange[0], itemRange[1]
+
+	if request.MinTemperature != nil {
+		low = max(low, *request.MinTemperature)
+	}
+
+	if request.MaxTemperature != nil {
+		high = min(high, *request.MaxTemperature)
+	}
+
+	if low > high {
+		return 0, false
+	}
+
+	if request.MinTemperature == nil || request.MaxTemperature == nil || *request.MinTemperature == *request.MaxTemperature {
+		return temperatureWeight, true
+	}
+
+	return temperatureWeight * (high - low) / (*request.MaxTemperature - *request.MinTemperature), true
+}
+
+// formalityFit is 1 when the item matches the occasion and 0 when it is as far
+// off as it can be.
+func formalityFit(itemFormality, target float64) float64 {
+	return 1 - math.Abs(itemFormality-target)/maxFormalityGap
+}
+
+// colorHarmony prefers neutral outfits with at most one accent color or a pair
+// of complementary ones, and penalizes every further accent.
+func colorHarmony(colors []string) (float64, string) {
+	accents := make([]string, 0, len(colors))
+
+	for _, color := range colors {
+		color = strings.ToLower(strings.TrimSpace(color))
+		if !slices.Contains(neutralColors, color) && !slices.Contains(accents, color) {
+			accents = append(accents, color)
+		}
+	}
+
+	switch len(accents) {
+	case 0:
+		if len(colors) == 0 {
+			return 0, ""
+		}
+
+		return 1, "neutral palette"
+	case 1:
+		return 1.5, "single accent color"
+	case 2: //nolint:mnd
+		for _, pair := range complementaryColors {
+			if (pair[0] == accents[0] && pair[1] == accents[1]) || (pair[0] == accents[1] && pair[1] == accents[0]) {
+				return 1, "complementary colors"
+			}
+		}
+
+		return -0.5, ""
+	default:
+		return -float64(len(accents) - 2), "" //nolint:mnd
+	}
+}
+
+// category returns the subcategory when table knows it and the category
+// otherwise.
+func category[T any](item entity.WardrobeItem, table map[string]T) string {
+	if item.Subcategory != nil {
+		if _, ok := table[*item.Subcategory]; ok {
+			return *item.Subcategory
+		}
+	}
+
+	return item.Category
+}
+
+func lookup(table map[string]float64, item entity.WardrobeItem, fallback float64) float64 {
+	if value, ok := table[category(item, table)]; ok {
+		return value
+	}
+
+	return fallback
+}
+
+func key(suggestion entity.OutfitSuggestion) string {
+	ids := make([]string, 0, len(suggestion.Items))
+	for _, item := range suggestion.Items {
+		ids = append(ids, item.ItemID.String())
+	}
+
+	return strings.Join(ids, ",")
+}
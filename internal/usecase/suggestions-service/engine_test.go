@@ -0,0 +1,222 @@
+package suggestionsservice_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	suggestionsservice "github.com/romanpitatelev/clothing-service/internal/usecase/suggestions-service"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals
+var (
+	whiteTShirt  = item(1, "tops", "t-shirts", nil, "white")
+	blueOxford   = item(2, "tops", "oxford", nil, "blue")
+	redSweater   = item(3, "tops", "sweaters", utils.Pointer(entity.SeasonWinter), "red")
+	greenShorts  = item(4, "bottoms", "shorts", utils.Pointer(entity.SeasonSummer), "green")
+	greyTrousers = item(5, "bottoms", "trousers", nil, "grey")
+	whiteSneaker = item(6, "shoes", "sneakers", nil, "white")
+	brownLoafers = item(7, "shoes", "loafers", nil, "brown")
+	blackBoots   = item(8, "shoes", "boots", utils.Pointer(entity.SeasonWinter), "black")
+	navyCoat     = item(9, "outerwear", "coats", nil, "navy")
+	yellowDress  = item(10, "dresses", "", utils.Pointer(entity.SeasonSummer), "yellow")
+	purpleScarf  = item(11, "accessories", "scarves", nil, "purple")
+	orangeBag    = item(12, "accessories", "bags", nil, "orange")
+)
+
+func item(n byte, category, subcategory string, season *entity.Season, colors ...string) entity.WardrobeItem {
+	wardrobeItem := entity.WardrobeItem{
+		ID:       entity.WardrobeItemID(uuid.UUID{15: n}),
+		Name:     category + " " + subcategory,
+		Category: category,
+		Season:   season,
+		Colors:   colors,
+	}
+
+	if subcategory != "" {
+		wardrobeItem.Subcategory = &subcategory
+	}
+
+	return wardrobeItem
+}
+
+func ids(items ...entity.WardrobeItem) []entity.WardrobeItemID {
+	result := make([]entity.WardrobeItemID, 0, len(items))
+	for _, wardrobeItem := range items {
+		result = append(result, wardrobeItem.ID)
+	}
+
+	return result
+}
+
+func suggestedIDs(suggestion entity.OutfitSuggestion) []entity.WardrobeItemID {
+	result := make([]entity.WardrobeItemID, 0, len(suggestion.Items))
+	for _, outfitItem := range suggestion.Items {
+		result = append(result, outfitItem.ItemID)
+	}
+
+	return result
+}
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+
+	wardrobe := []entity.WardrobeItem{
+		whiteTShirt, blueOxford, redSweater, greenShorts, greyTrousers,
+		whiteSneaker, brownLoafers, blackBoots, navyCoat, yellowDress,
+	}
+
+	tests := []struct {
+		name    string
+		items   []entity.WardrobeItem
+		request entity.SuggestionRequest
+		count   int
+		best    []entity.WardrobeItemID
+		check   func(t *testing.T, suggestion entity.OutfitSuggestion)
+	}{
+		{
+			name:    "empty wardrobe",
+			items:   nil,
+			request: entity.SuggestionRequest{},
+			count:   0,
+		},
+		{
+			name:    "no shoes",
+			items:   []entity.WardrobeItem{whiteTShirt, greyTrousers},
+			request: entity.SuggestionRequest{},
+			count:   0,
+		},
+		{
+			name:    "top without bottom",
+			items:   []entity.WardrobeItem{whiteTShirt, whiteSneaker},
+			request: entity.SuggestionRequest{},
+			count:   0,
+		},
+		{
+			name:    "dress needs no bottom",
+			items:   []entity.WardrobeItem{yellowDress, whiteSneaker},
+			request: entity.SuggestionRequest{},
+			count:   1,
+			best:    ids(yellowDress, whiteSneaker),
+		},
+		{
+			name:    "office prefers formal items",
+			items:   wardrobe,
+			request: entity.SuggestionRequest{Occasion: "office", Limit: 1},
+			count:   1,
+			best:    ids(blueOxford, greyTrousers, brownLoafers, navyCoat),
+			check: func(t *testing.T, suggestion entity.OutfitSuggestion) {
+				t.Helper()
+				require.Contains(t, suggestion.Reasons, "suits office")
+			},
+		},
+		{
+			name:  "summer heat drops outerwear and warm items",
+			items: wardrobe,
+			request: entity.SuggestionRequest{
+				Season:         utils.Pointer(entity.SeasonSummer),
+				MinTemperature: utils.Pointer(25.0),
+				MaxTemperature: utils.Pointer(30.0),
+				Occasion:       "casual",
+				Limit:          50,
+			},
+			count: 10,
+			check: func(t *testing.T, suggestion entity.OutfitSuggestion) {
+				t.Helper()
+
+				for _, outfitItem := range suggestion.Items {
+					require.NotEqual(t, entity.OutfitSlotOuterwear, outfitItem.Slot)
+					require.NotContains(t, ids(redSweater, blackBoots, navyCoat), outfitItem.ItemID)
+				}
+			},
+		},
+		{
+			name:    "cold weather requires outerwear",
+			items:   wardrobe,
+			request: entity.SuggestionRequest{MinTemperature: utils.Pointer(-5.0), MaxTemperature: utils.Pointer(5.0)},
+			count:   2,
+			best:    ids(redSweater, greyTrousers, blackBoots, navyCoat),
+		},
+		{
+			name:    "cold weather without outerwear",
+			items:   []entity.WardrobeItem{redSweater, greyTrousers, blackBoots},
+			request: entity.SuggestionRequest{MaxTemperature: utils.Pointer(5.0)},
+			count:   0,
+		},
+		{
+			name:    "single accent beats clashing colors",
+			items:   []entity.WardrobeItem{whiteTShirt, greenShorts, whiteSneaker, purpleScarf},
+			request: entity.SuggestionRequest{Limit: 1},
+			count:   1,
+			best:    ids(whiteTShirt, greenShorts, whiteSneaker),
+			check: func(t *testing.T, suggestion entity.OutfitSuggestion) {
+				t.Helper()
+				require.Contains(t, suggestion.Reasons, "single accent color")
+			},
+		},
+		{
+			name:    "limit caps the result",
+			items:   wardrobe,
+			request: entity.SuggestionRequest{Limit: 3},
+			count:   3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			suggestions := suggestionsservice.Suggest(test.items, test.request)
+			require.Len(t, suggestions, test.count)
+
+			for i := 1; i < len(suggestions); i++ {
+				require.GreaterOrEqual(t, suggestions[i-1].Score, suggestions[i].Score)
+			}
+
+			if test.best != nil {
+				require.Equal(t, test.best, suggestedIDs(suggestions[0]))
+			}
+
+			if test.check != nil {
+				for _, suggestion := range suggestions {
+					test.check(t, suggestion)
+				}
+			}
+		})
+	}
+}
+
+func TestSuggestIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	items := []entity.WardrobeItem{whiteTShirt, blueOxford, greyTrousers, whiteSneaker, brownLoafers}
+	reversed := []entity.WardrobeItem{brownLoafers, whiteSneaker, greyTrousers, blueOxford, whiteTShirt}
+
+	request := entity.SuggestionRequest{Occasion: "date"}
+
+	require.Equal(t, suggestionsservice.Suggest(items, request), suggestionsservice.Suggest(reversed, request))
+}
+
+func TestSuggestColorHarmony(t *testing.T) {
+	t.Parallel()
+
+	scoreWith := func(accessory entity.WardrobeItem) entity.OutfitSuggestion {
+		for _, suggestion := range suggestionsservice.Suggest([]entity.WardrobeItem{yellowDress, whiteSneaker, accessory}, entity.SuggestionRequest{}) {
+			if len(suggestion.Items) == 3 { //nolint:mnd
+				return suggestion
+			}
+		}
+
+		t.Fatalf("no suggestion with %s", accessory.Name)
+
+		return entity.OutfitSuggestion{}
+	}
+
+	complementary := scoreWith(purpleScarf)
+	clashing := scoreWith(orangeBag)
+
+	require.Contains(t, complementary.Reasons, "complementary colors")
+	require.Greater(t, complementary.Score, clashing.Score)
+}
@@ -0,0 +1,53 @@
+package suggestionsservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const pageSize = 100
+
+type wardrobeStore interface {
+	ListItems(ctx context.Context, userID entity.UserID, filter entity.WardrobeFilter) ([]entity.WardrobeItem, error)
+}
+
+type Service struct {
+	wardrobeStore wardrobeStore
+}
+
+func New(wardrobeStore wardrobeStore) *Service {
+	return &Service{
+		wardrobeStore: wardrobeStore,
+	}
+}
+
+// SuggestOutfits ranks combinations of the user's whole wardrobe.
+func (s *Service) SuggestOutfits(
+	ctx context.Context,
+	userID entity.UserID,
+	request entity.SuggestionRequest,
+) ([]entity.OutfitSuggestion, error) {
+	validatedRequest, err := request.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("suggestion request validation failed: %w", err)
+	}
+
+	var items []entity.WardrobeItem
+
+	for offset := 0; ; offset += pageSize {
+		page, err := s.wardrobeStore.ListItems(ctx, userID, entity.WardrobeFilter{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list wardrobe items: %w", err)
+		}
+
+		items = append(items, page...)
+
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	return Suggest(items, validatedRequest), nil
+}
@@ -0,0 +1,137 @@
+package taxonomyservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type taxonomyStore interface {
+	ListCategories(ctx context.Context) ([]entity.Category, error)
+	GetCategory(ctx context.Context, categoryID string) (entity.Category, error)
+	CreateCategory(ctx context.Context, category entity.Category) (entity.Category, error)
+	UpdateCategory(ctx context.Context, categoryID string, update entity.CategoryUpdate) (entity.Category, error)
+	DeleteCategory(ctx context.Context, categoryID string) error
+	UpsertAttribute(ctx context.Context, categoryID string, attribute entity.CategoryAttribute) (entity.Category, error)
+	DeleteAttribute(ctx context.Context, categoryID, name string) error
+}
+
+type Service struct {
+	taxonomyStore taxonomyStore
+}
+
+func New(taxonomyStore taxonomyStore) *Service {
+	return &Service{
+		taxonomyStore: taxonomyStore,
+	}
+}
+
+// ListCategories returns the taxonomy as a tree of root categories.
+func (s *Service) ListCategories(ctx context.Context) ([]entity.Category, error) {
+	categories, err := s.taxonomyStore.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	return buildTree(categories), nil
+}
+
+// buildTree nests categories under their parents. Parents have to come
+// before their children.
+func buildTree(categories []entity.Category) []entity.Category {
+	children := make(map[string][]entity.Category)
+
+	for i := len(categories) - 1; i >= 0; i-- {
+		category := categories[i]
+
+		if subcategories := children[category.ID]; len(subcategories) > 0 {
+			category.Children = subcategories
+			delete(children, category.ID)
+		}
+
+		var parentID string
+		if category.ParentID != nil {
+			parentID = *category.ParentID
+		}
+
+		children[parentID] = append([]entity.Category{category}, children[parentID]...)
+	}
+
+	roots := children[""]
+	if roots == nil {
+		roots = []entity.Category{}
+	}
+
+	return roots
+}
+
+func (s *Service) GetCategory(ctx context.Context, categoryID string) (entity.Category, error) {
+	category, err := s.taxonomyStore.GetCategory(ctx, categoryID)
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *Service) CreateCategory(ctx context.Context, category entity.Category) (entity.Category, error) {
+	validatedCategory, err := category.Validate()
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("category validation failed: %w", err)
+	}
+
+	createdCategory, err := s.taxonomyStore.CreateCategory(ctx, validatedCategory)
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return createdCategory, nil
+}
+
+func (s *Service) UpdateCategory(ctx context.Context, categoryID string, update entity.CategoryUpdate) (entity.Category, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("category validation failed: %w", err)
+	}
+
+	if validatedUpdate.ParentID != nil && *validatedUpdate.ParentID == categoryID {
+		return entity.Category{}, fmt.Errorf("%w: category cannot be its own parent", entity.ErrInvalidCategory)
+	}
+
+	updatedCategory, err := s.taxonomyStore.UpdateCategory(ctx, categoryID, validatedUpdate)
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return updatedCategory, nil
+}
+
+func (s *Service) DeleteCategory(ctx context.Context, categoryID string) error {
+	if err := s.taxonomyStore.DeleteCategory(ctx, categoryID); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) UpsertAttribute(ctx context.Context, categoryID string, attribute entity.CategoryAttribute) (entity.Category, error) {
+	if err := attribute.Validate(); err != nil {
+		return entity.Category{}, fmt.Errorf("attribute validation failed: %w", err)
+	}
+
+	category, err := s.taxonomyStore.UpsertAttribute(ctx, categoryID, attribute)
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to save attribute: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *Service) DeleteAttribute(ctx context.Context, categoryID, name string) error {
+	if err := s.taxonomyStore.DeleteAttribute(ctx, categoryID, name); err != nil {
+		return fmt.Errorf("failed to delete attribute: %w", err)
+	}
+
+	return nil
+}
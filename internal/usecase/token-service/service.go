@@ -26,6 +26,7 @@ type Config struct {
 type usersStore interface {
 	GetUser(ctx context.Context, userID entity.UserID) (entity.User, error)
 	VerifyUserWithOTP(ctx context.Context, validateUserRequest entity.ValidateUserRequest, otpLifetime time.Duration) (entity.User, error)
+	IsUserSuspended(ctx context.Context, userID entity.UserID) (bool, error)
 }
 
 type Service struct {
@@ -44,6 +45,7 @@ func (s *Service) GenerateAccessToken(user entity.User) (string, error) {
 	claims := jwt.MapClaims{
 		"userId": user.UserID,
 		"phone":  user.Phone,
+		"role":   user.Role,
 		"exp":    time.Now().Add(accessTokenDurationSeconds * time.Second).Unix(),
 	}
 
@@ -95,7 +97,7 @@ func (s *Service) ParseToken(tokenStr string) (entity.Claims, error) {
 		return s.cfg.PublicKey, nil
 	})
 	if err != nil {
-		return entity.Claims{}, fmt.Errorf("failed to parse token: %w", err)
+		return entity.Claims{}, fmt.Errorf("%w: failed to parse token: %w", entity.ErrInvalidToken, err)
 	}
 
 	claims, ok := token.Claims.(*entity.Claims)
@@ -110,12 +112,44 @@ func (s *Service) ParseToken(tokenStr string) (entity.Claims, error) {
 	return *claims, nil
 }
 
+// Authenticate parses the access token and rejects it when its user has been
+// suspended since the token was issued.
+func (s *Service) Authenticate(ctx context.Context, tokenStr string) (entity.Claims, error) {
+	claims, err := s.ParseToken(tokenStr)
+	if err != nil {
+		return entity.Claims{}, err
+	}
+
+	if err := s.checkSuspended(ctx, claims.UserID); err != nil {
+		return entity.Claims{}, err
+	}
+
+	return claims, nil
+}
+
+func (s *Service) checkSuspended(ctx context.Context, userID entity.UserID) error {
+	suspended, err := s.usersStore.IsUserSuspended(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check user status: %w", err)
+	}
+
+	if suspended {
+		return entity.ErrUserSuspended
+	}
+
+	return nil
+}
+
 func (s *Service) ValidateUser(ctx context.Context, validateUserRequest entity.ValidateUserRequest) (entity.Tokens, error) {
 	user, err := s.usersStore.VerifyUserWithOTP(ctx, validateUserRequest, s.cfg.OTPLifetime)
 	if err != nil {
 		return entity.Tokens{}, fmt.Errorf("verification failed: %w", err)
 	}
 
+	if err := s.checkSuspended(ctx, user.UserID); err != nil {
+		return entity.Tokens{}, err
+	}
+
 	tokens, err := s.generateTokens(user)
 	if err != nil {
 		return entity.Tokens{}, fmt.Errorf("failed to generate tokens in ValidateUser(): %w", err)
@@ -135,6 +169,10 @@ func (s *Service) RefreshToken(ctx context.Context, tokens entity.Tokens) (entit
 		return entity.Tokens{}, fmt.Errorf("user not found in RefreshToken(): %w", err)
 	}
 
+	if err := s.checkSuspended(ctx, user.UserID); err != nil {
+		return entity.Tokens{}, err
+	}
+
 	tokens, err = s.generateTokens(user)
 	if err != nil {
 		return entity.Tokens{}, fmt.Errorf("error creating access token: %w", err)
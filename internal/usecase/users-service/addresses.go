@@ -0,0 +1,71 @@
+package usersservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (s *Service) CreateAddress(ctx context.Context, userID entity.UserID, address entity.Address) (entity.Address, error) {
+	validatedAddress, err := address.Validate()
+	if err != nil {
+		return entity.Address{}, fmt.Errorf("address validation failed: %w", err)
+	}
+
+	validatedAddress.ID = entity.AddressID(uuid.New())
+	validatedAddress.UserID = userID
+
+	createdAddress, err := s.usersStore.CreateAddress(ctx, validatedAddress)
+	if err != nil {
+		return entity.Address{}, fmt.Errorf("failed to create address: %w", err)
+	}
+
+	return createdAddress, nil
+}
+
+func (s *Service) GetAddress(ctx context.Context, userID entity.UserID, addressID entity.AddressID) (entity.Address, error) {
+	address, err := s.usersStore.GetAddress(ctx, userID, addressID)
+	if err != nil {
+		return entity.Address{}, fmt.Errorf("failed to get address: %w", err)
+	}
+
+	return address, nil
+}
+
+func (s *Service) ListAddresses(ctx context.Context, userID entity.UserID) ([]entity.Address, error) {
+	addresses, err := s.usersStore.ListAddresses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+func (s *Service) UpdateAddress(
+	ctx context.Context,
+	userID entity.UserID,
+	addressID entity.AddressID,
+	update entity.AddressUpdate,
+) (entity.Address, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.Address{}, fmt.Errorf("address update validation failed: %w", err)
+	}
+
+	updatedAddress, err := s.usersStore.UpdateAddress(ctx, userID, addressID, validatedUpdate)
+	if err != nil {
+		return entity.Address{}, fmt.Errorf("failed to update address: %w", err)
+	}
+
+	return updatedAddress, nil
+}
+
+func (s *Service) DeleteAddress(ctx context.Context, userID entity.UserID, addressID entity.AddressID) error {
+	if err := s.usersStore.DeleteAddress(ctx, userID, addressID); err != nil {
+		return fmt.Errorf("failed to delete address: %w", err)
+	}
+
+	return nil
+}
@@ -18,27 +18,44 @@ type usersStore interface {
 	GetUser(ctx context.Context, userID entity.UserID) (entity.User, error)
 	UpdateUser(ctx context.Context, userID entity.UserID, updatedUser entity.UserUpdate) (entity.User, error)
 	DeleteUser(ctx context.Context, userID entity.UserID) error
+	CreateAddress(ctx context.Context, address entity.Address) (entity.Address, error)
+	GetAddress(ctx context.Context, userID entity.UserID, addressID entity.AddressID) (entity.Address, error)
+	ListAddresses(ctx context.Context, userID entity.UserID) ([]entity.Address, error)
+	UpdateAddress(
+		ctx context.Context,
+		userID entity.UserID,
+		addressID entity.AddressID,
+		update entity.AddressUpdate,
+	) (entity.Address, error)
+	DeleteAddress(ctx context.Context, userID entity.UserID, addressID entity.AddressID) error
 }
 
-type smsRegistration interface {
-	SendOTP(ctx context.Context, phone string, otp string) error
+type outboxStore interface {
+	Enqueue(ctx context.Context, message entity.OutboxMessage) error
+}
+
+type txManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Config struct {
 	OTPMaxValue int
+	OTPLifetime time.Duration
 }
 
 type Service struct {
-	cfg             Config
-	usersStore      usersStore
-	smsRegistration smsRegistration
+	cfg         Config
+	usersStore  usersStore
+	outboxStore outboxStore
+	txManager   txManager
 }
 
-func New(cfg Config, usersStore usersStore, smsRegistration smsRegistration) *Service {
+func New(cfg Config, usersStore usersStore, outboxStore outboxStore, txManager txManager) *Service {
 	return &Service{
-		cfg:             cfg,
-		usersStore:      usersStore,
-		smsRegistration: smsRegistration,
+		cfg:         cfg,
+		usersStore:  usersStore,
+		outboxStore: outboxStore,
+		txManager:   txManager,
 	}
 }
 
@@ -51,12 +68,15 @@ func (s *Service) CreateUser(ctx context.Context, user entity.User) (entity.User
 	otp := s.generateOTP()
 	validatedUser.UserID = entity.UserID(uuid.New())
 
-	if user, err = s.usersStore.CreateUnverifiedUser(ctx, validatedUser, otp); err != nil {
-		return entity.User{}, fmt.Errorf("failed to create user: %w", err)
-	}
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if user, err = s.usersStore.CreateUnverifiedUser(ctx, validatedUser, otp); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
 
-	if err = s.smsRegistration.SendOTP(ctx, user.Phone, otp); err != nil {
-		return entity.User{}, fmt.Errorf("failed to send otp: %w", err)
+		return s.enqueueOTP(ctx, user.Phone, otp)
+	})
+	if err != nil {
+		return entity.User{}, fmt.Errorf("failed to register user: %w", err)
 	}
 
 	return user, nil
@@ -65,16 +85,36 @@ func (s *Service) CreateUser(ctx context.Context, user entity.User) (entity.User
 func (s *Service) LoginUser(ctx context.Context, userID entity.UserID) error {
 	otp := s.generateOTP()
 
-	user, err := s.usersStore.UpdateUser(ctx, userID, entity.UserUpdate{
-		OTP:          &otp,
-		OTPCreatedAt: utils.Pointer(time.Now()),
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		user, err := s.usersStore.UpdateUser(ctx, userID, entity.UserUpdate{
+			OTP:          &otp,
+			OTPCreatedAt: utils.Pointer(time.Now()),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		return s.enqueueOTP(ctx, user.Phone, otp)
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+		return fmt.Errorf("failed to log user in: %w", err)
 	}
 
-	if err = s.smsRegistration.SendOTP(ctx, user.Phone, otp); err != nil {
-		return fmt.Errorf("failed to send otp: %w", err)
+	return nil
+}
+
+// enqueueOTP records the code to be texted once the transaction of the
+// context commits, so a code is never sent for a change that was rolled back
+// and a failed text is retried.
+func (s *Service) enqueueOTP(ctx context.Context, phone string, otp string) error {
+	message := entity.NewOutboxMessage(entity.OutboxKindOTP, entity.OTPPayload{
+		Phone:     phone,
+		OTP:       otp,
+		ExpiresAt: time.Now().Add(s.cfg.OTPLifetime),
+	})
+
+	if err := s.outboxStore.Enqueue(ctx, message); err != nil {
+		return fmt.Errorf("failed to enqueue otp: %w", err)
 	}
 
 	return nil
@@ -90,19 +130,26 @@ func (s *Service) GetUser(ctx context.Context, userID entity.UserID) (entity.Use
 }
 
 func (s *Service) UpdateUser(ctx context.Context, userID entity.UserID, newInfoUser entity.UserUpdate) (entity.User, error) {
-	_, err := s.usersStore.GetUser(ctx, userID)
-	if err != nil {
-		return entity.User{}, fmt.Errorf("user not found: %w", err)
-	}
-
 	newInfoUserValidated, err := newInfoUser.Validate()
 	if err != nil {
 		return entity.User{}, fmt.Errorf("new info validation failed: %w", err)
 	}
 
-	updatedUser, err := s.usersStore.UpdateUser(ctx, userID, newInfoUserValidated)
+	var updatedUser entity.User
+
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.usersStore.GetUser(ctx, userID); err != nil {
+			return fmt.Errorf("user not found: %w", err)
+		}
+
+		if updatedUser, err = s.usersStore.UpdateUser(ctx, userID, newInfoUserValidated); err != nil {
+			return fmt.Errorf("failed to update user info: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return entity.User{}, fmt.Errorf("failed to update user info: %w", err)
+		return entity.User{}, fmt.Errorf("failed to update user: %w", err)
 	}
 
 	return updatedUser, nil
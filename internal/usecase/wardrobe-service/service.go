@@ -0,0 +1,197 @@
+package wardrobeservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+type wardrobeStore interface {
+	CreateItem(ctx context.Context, item entity.WardrobeItem) (entity.WardrobeItem, error)
+	GetItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) (entity.WardrobeItem, error)
+	ListItems(ctx context.Context, userID entity.UserID, filter entity.WardrobeFilter) ([]entity.WardrobeItem, error)
+	UpdateItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID, update entity.WardrobeItemUpdate) (entity.WardrobeItem, error)
+	DeleteItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) error
+	CreateWearLogEntry(ctx context.Context, entry entity.WearLogEntry) (entity.WearLogEntry, error)
+	ListWearLog(ctx context.Context, userID entity.UserID, filter entity.WearLogFilter) ([]entity.WearLogEntry, error)
+	DeleteWearLogEntry(ctx context.Context, userID entity.UserID, entryID entity.WearLogEntryID) error
+	GetItemWearStats(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) (entity.ItemWearStats, error)
+}
+
+type taxonomyStore interface {
+	GetCategoryPath(ctx context.Context, categoryID string) (entity.CategoryPath, error)
+}
+
+type txManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	wardrobeStore wardrobeStore
+	taxonomyStore taxonomyStore
+	txManager     txManager
+}
+
+func New(wardrobeStore wardrobeStore, taxonomyStore taxonomyStore, txManager txManager) *Service {
+	return &Service{
+		wardrobeStore: wardrobeStore,
+		taxonomyStore: taxonomyStore,
+		txManager:     txManager,
+	}
+}
+
+// validateTaxonomy checks an item's category, subcategory and attributes
+// against the path of its most specific category.
+func (s *Service) validateTaxonomy(ctx context.Context, category string, subcategory *string, attributes map[string]any) error {
+	leaf, field := category, "category"
+	if subcategory != nil && *subcategory != "" {
+		leaf, field = *subcategory, "subcategory"
+	} else {
+		subcategory = nil
+	}
+
+	path, err := s.taxonomyStore.GetCategoryPath(ctx, leaf)
+
+	switch {
+	case errors.Is(err, entity.ErrCategoryNotFound):
+		return &entity.ValidationError{
+			Err:    entity.ErrInvalidWardrobeItem,
+			Fields: []entity.FieldError{{Field: field, Message: "unknown category"}},
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get category path: %w", err)
+	}
+
+	return path.ValidateItem(category, subcategory, attributes) //nolint:wrapcheck
+}
+
+func (s *Service) CreateItem(ctx context.Context, userID entity.UserID, item entity.WardrobeItem) (entity.WardrobeItem, error) {
+	validatedItem, err := item.Validate()
+	if err != nil {
+		return entity.WardrobeItem{}, fmt.Errorf("wardrobe item validation failed: %w", err)
+	}
+
+	validatedItem.ID = entity.WardrobeItemID(uuid.New())
+	validatedItem.UserID = userID
+
+	var createdItem entity.WardrobeItem
+
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		err := s.validateTaxonomy(ctx, validatedItem.Category, validatedItem.Subcategory, validatedItem.Attributes)
+		if err != nil {
+			return fmt.Errorf("wardrobe item validation failed: %w", err)
+		}
+
+		if createdItem, err = s.wardrobeStore.CreateItem(ctx, validatedItem); err != nil {
+			return fmt.Errorf("failed to create wardrobe item: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.WardrobeItem{}, err //nolint:wrapcheck
+	}
+
+	return createdItem, nil
+}
+
+func (s *Service) GetItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) (entity.WardrobeItem, error) {
+	item, err := s.wardrobeStore.GetItem(ctx, userID, itemID)
+	if err != nil {
+		return entity.WardrobeItem{}, fmt.Errorf("failed to get wardrobe item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (s *Service) ListItems(ctx context.Context, userID entity.UserID, filter entity.WardrobeFilter) ([]entity.WardrobeItem, error) {
+	if filter.Season != nil {
+		if err := filter.Season.Validate(); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	items, err := s.wardrobeStore.ListItems(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wardrobe items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *Service) UpdateItem(
+	ctx context.Context,
+	userID entity.UserID,
+	itemID entity.WardrobeItemID,
+	update entity.WardrobeItemUpdate,
+) (entity.WardrobeItem, error) {
+	validatedUpdate, err := update.Validate()
+	if err != nil {
+		return entity.WardrobeItem{}, fmt.Errorf("wardrobe item validation failed: %w", err)
+	}
+
+	var updatedItem entity.WardrobeItem
+
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if validatedUpdate.Category != nil || validatedUpdate.Subcategory != nil || validatedUpdate.Attributes != nil {
+			if err := s.validateUpdateTaxonomy(ctx, userID, itemID, validatedUpdate); err != nil {
+				return err
+			}
+		}
+
+		updatedItem, err = s.wardrobeStore.UpdateItem(ctx, userID, itemID, validatedUpdate)
+		if err != nil {
+			return fmt.Errorf("failed to update wardrobe item: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.WardrobeItem{}, err //nolint:wrapcheck
+	}
+
+	return updatedItem, nil
+}
+
+// validateUpdateTaxonomy checks the taxonomy of the item as it will be after
+// the update.
+func (s *Service) validateUpdateTaxonomy(
+	ctx context.Context,
+	userID entity.UserID,
+	itemID entity.WardrobeItemID,
+	update entity.WardrobeItemUpdate,
+) error {
+	item, err := s.wardrobeStore.GetItem(ctx, userID, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to get wardrobe item: %w", err)
+	}
+
+	if update.Category != nil {
+		item.Category = *update.Category
+	}
+
+	if update.Subcategory != nil {
+		item.Subcategory = update.Subcategory
+	}
+
+	if update.Attributes != nil {
+		item.Attributes = *update.Attributes
+	}
+
+	if err := s.validateTaxonomy(ctx, item.Category, item.Subcategory, item.Attributes); err != nil {
+		return fmt.Errorf("wardrobe item validation failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteItem(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) error {
+	if err := s.wardrobeStore.DeleteItem(ctx, userID, itemID); err != nil {
+		return fmt.Errorf("failed to delete wardrobe item: %w", err)
+	}
+
+	return nil
+}
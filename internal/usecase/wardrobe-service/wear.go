@@ -0,0 +1,60 @@
+package wardrobeservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (s *Service) CreateWearLogEntry(ctx context.Context, userID entity.UserID, entry entity.WearLogEntry) (entity.WearLogEntry, error) {
+	validatedEntry, err := entry.Validate(time.Now())
+	if err != nil {
+		return entity.WearLogEntry{}, fmt.Errorf("wear log entry validation failed: %w", err)
+	}
+
+	validatedEntry.ID = entity.WearLogEntryID(uuid.New())
+	validatedEntry.UserID = userID
+
+	createdEntry, err := s.wardrobeStore.CreateWearLogEntry(ctx, validatedEntry)
+	if err != nil {
+		return entity.WearLogEntry{}, fmt.Errorf("failed to create wear log entry: %w", err)
+	}
+
+	return createdEntry, nil
+}
+
+func (s *Service) ListWearLog(ctx context.Context, userID entity.UserID, filter entity.WearLogFilter) ([]entity.WearLogEntry, error) {
+	validatedFilter, err := filter.Validate(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("wear log filter validation failed: %w", err)
+	}
+
+	entries, err := s.wardrobeStore.ListWearLog(ctx, userID, validatedFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wear log: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *Service) DeleteWearLogEntry(ctx context.Context, userID entity.UserID, entryID entity.WearLogEntryID) error {
+	if err := s.wardrobeStore.DeleteWearLogEntry(ctx, userID, entryID); err != nil {
+		return fmt.Errorf("failed to delete wear log entry: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetItemWearStats(ctx context.Context, userID entity.UserID, itemID entity.WardrobeItemID) (entity.ItemWearStats, error) {
+	stats, err := s.wardrobeStore.GetItemWearStats(ctx, userID, itemID)
+	if err != nil {
+		return entity.ItemWearStats{}, fmt.Errorf("failed to get item wear stats: %w", err)
+	}
+
+	stats.CalculateCostPerWear()
+
+	return stats, nil
+}
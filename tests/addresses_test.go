@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"net/http"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestAddresses() {
+	user := s.createUser("79031355650")
+	stranger := s.createUser("79031355651")
+
+	addressesPath := userPath + "/" + user.UserID.String() + "/addresses"
+
+	home := entity.Address{
+		Label:      "Home",
+		Recipient:  "John Ivanov",
+		Phone:      "8 (903) 135-56-50",
+		Country:    "ru",
+		City:       "Moscow",
+		Street:     "Tverskaya",
+		House:      "1",
+		Apartment:  utils.Pointer("15"),
+		PostalCode: utils.Pointer("125009"),
+		Latitude:   utils.Pointer(55.757),
+		Longitude:  utils.Pointer(37.615),
+	}
+
+	office := home
+	office.Label = "Office"
+	office.Apartment = nil
+	office.Latitude, office.Longitude = nil, nil
+
+	var created, second entity.Address
+
+	s.Run("create", func() {
+		for _, modify := range []func(a *entity.Address){
+			func(a *entity.Address) { a.Phone = "123" },
+			func(a *entity.Address) { a.Country = "Russia" },
+			func(a *entity.Address) { a.City = " " },
+			func(a *entity.Address) { a.PostalCode = utils.Pointer("#1") },
+			func(a *entity.Address) { a.Latitude = nil },
+			func(a *entity.Address) { a.Latitude = utils.Pointer(91.0) },
+		} {
+			address := home
+			modify(&address)
+
+			s.sendRequest(http.MethodPost, addressesPath, http.StatusBadRequest, address, nil, user)
+		}
+
+		s.sendRequest(http.MethodPost, addressesPath, http.StatusForbidden, home, nil, stranger)
+
+		s.sendRequest(http.MethodPost, addressesPath, http.StatusCreated, home, &created, user)
+		s.Require().Equal("79031355650", created.Phone)
+		s.Require().Equal("RU", created.Country)
+		s.Require().True(created.IsDefault)
+
+		s.sendRequest(http.MethodPost, addressesPath, http.StatusCreated, office, &second, user)
+		s.Require().False(second.IsDefault)
+	})
+
+	s.Run("default address", func() {
+		var addresses []entity.Address
+
+		s.sendRequest(http.MethodPatch, addressesPath+"/"+second.ID.String(), http.StatusOK,
+			entity.AddressUpdate{IsDefault: utils.Pointer(true)}, &second, user)
+		s.Require().True(second.IsDefault)
+
+		s.sendRequest(http.MethodGet, addressesPath, http.StatusOK, nil, &addresses, user)
+		s.Require().Len(addresses, 2)
+		s.Require().Equal(second.ID, addresses[0].ID)
+		s.Require().False(addresses[1].IsDefault)
+
+		third := office
+		third.Label = "Dacha"
+		third.IsDefault = true
+
+		s.sendRequest(http.MethodPost, addressesPath, http.StatusCreated, third, &third, user)
+		s.Require().True(third.IsDefault)
+
+		s.sendRequest(http.MethodDelete, addressesPath+"/"+third.ID.String(), http.StatusNoContent, nil, nil, user)
+		s.sendRequest(http.MethodGet, addressesPath, http.StatusOK, nil, &addresses, user)
+		s.Require().Len(addresses, 2)
+		s.Require().Equal(second.ID, addresses[0].ID)
+		s.Require().True(addresses[0].IsDefault)
+	})
+
+	s.Run("update", func() {
+		s.sendRequest(http.MethodPatch, addressesPath+"/"+created.ID.String(), http.StatusBadRequest,
+			entity.AddressUpdate{}, nil, user)
+		s.sendRequest(http.MethodPatch, addressesPath+"/"+created.ID.String(), http.StatusBadRequest,
+			entity.AddressUpdate{Phone: utils.Pointer("12345")}, nil, user)
+		s.sendRequest(http.MethodPatch, addressesPath+"/"+created.ID.String(), http.StatusBadRequest,
+			entity.AddressUpdate{Longitude: utils.Pointer(37.6)}, nil, user)
+		s.sendRequest(http.MethodPatch, addressesPath+"/"+entity.AddressID{}.String(), http.StatusNotFound,
+			entity.AddressUpdate{House: utils.Pointer("2")}, nil, user)
+
+		var updated entity.Address
+
+		s.sendRequest(http.MethodPatch, addressesPath+"/"+created.ID.String(), http.StatusOK,
+			entity.AddressUpdate{House: utils.Pointer("2"), Phone: utils.Pointer("9031355651")}, &updated, user)
+		s.Require().Equal("2", updated.House)
+		s.Require().Equal("79031355651", updated.Phone)
+		s.Require().Equal(created.Street, updated.Street)
+		s.Require().False(updated.IsDefault)
+	})
+
+	s.Run("delete user hides addresses", func() {
+		s.sendRequest(http.MethodGet, addressesPath+"/"+created.ID.String(), http.StatusOK, nil, nil, user)
+		s.sendRequest(http.MethodDelete, userPath+"/"+user.UserID.String(), http.StatusNoContent, nil, nil, user)
+
+		s.sendRequest(http.MethodGet, addressesPath+"/"+created.ID.String(), http.StatusNotFound, nil, nil, user)
+
+		var addresses []entity.Address
+
+		s.sendRequest(http.MethodGet, addressesPath, http.StatusOK, nil, &addresses, user)
+		s.Require().Empty(addresses)
+
+		s.sendRequest(http.MethodPost, addressesPath, http.StatusNotFound, home, nil, user)
+	})
+}
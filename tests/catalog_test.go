@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+const (
+	productsPath = "/api/v1/products"
+
+	catalogYML = `<?xml version="1.0" encoding="UTF-8"?>
+<yml_catalog date="2025-05-01 10:00">
+  <shop>
+    <categories>
+      <category id="1">Women</category>
+      <category id="2" parentId="1">Dresses</category>
+    </categories>
+    <offers>
+      <offer id="d-44" group_id="d" available="true">
+        <price>4990</price>
+        <currencyId>RUR</currencyId>
+        <categoryId>2</categoryId>
+        <picture>%[1]s/dress.png</picture>
+        <picture>%[1]s/missing.png</picture>
+        <name>Linen dress</name>
+        <vendor>Acme</vendor>
+        <param name="Размер">44</param>
+      </offer>
+      <offer id="d-46" group_id="d" available="true">
+        <price>4990</price>
+        <currencyId>RUR</currencyId>
+        <categoryId>2</categoryId>
+        <picture>%[1]s/dress.png</picture>
+        <name>Linen dress</name>
+        <param name="Размер">46</param>
+      </offer>
+      %[2]s
+      <offer id="broken">
+        <price>free</price>
+        <name>Broken</name>
+      </offer>
+    </offers>
+  </shop>
+</yml_catalog>`
+
+	scarfOffer = `<offer id="scarf">
+        <price>1990</price>
+        <picture>%s/scarf.png</picture>
+        <name>Silk scarf</name>
+        <vendor>Acme</vendor>
+      </offer>`
+)
+
+func (s *IntegrationTestSuite) TestCatalog() {
+	user := s.createUser("79031355600")
+
+	admin := s.createUser("79031355601")
+	admin.Role = entity.RoleAdmin
+
+	images := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dress.png", "/scarf.png":
+			_, _ = w.Write(append(pngHeader, r.URL.Path...))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer images.Close()
+
+	feed := func(withScarf bool) *strings.Reader {
+		scarf := ""
+		if withScarf {
+			scarf = fmt.Sprintf(scarfOffer, images.URL)
+		}
+
+		return strings.NewReader(fmt.Sprintf(catalogYML, images.URL, scarf))
+	}
+
+	listProducts := func(query url.Values) []entity.Product {
+		var page entity.ProductPage
+
+		s.sendRequest(http.MethodGet, productsPath+"?"+query.Encode(), http.StatusOK, nil, &page, user)
+
+		return page.Products
+	}
+
+	var dress, scarf entity.Product
+
+	s.Run("import feed", func() {
+		imp, err := s.catalogService.Import(context.Background(), "acme", entity.FeedFormatYML, feed(true))
+		s.Require().NoError(err)
+		s.Require().Equal(entity.FeedImportStatusSucceeded, imp.Status)
+		s.Require().Equal(2, imp.Offers)
+		s.Require().Equal(2, imp.Created)
+		s.Require().Equal(1, imp.Rejected)
+		s.Require().Equal(2, imp.ImagesMirrored)
+		s.Require().Equal(1, imp.ImagesFailed)
+		s.Require().Len(imp.Errors, 2)
+
+		products := listProducts(url.Values{"feed": {"acme"}})
+		s.Require().Len(products, 2)
+
+		scarf, dress = products[0], products[1]
+
+		s.Require().Equal("d", dress.ExternalID)
+		s.Require().Equal(int64(499000), dress.Price)
+		s.Require().Equal(entity.DefaultCurrency, dress.Currency)
+		s.Require().Equal("Women > Dresses", *dress.Category)
+		s.Require().Equal("dresses", *dress.CategoryID)
+		s.Require().Equal([]entity.ProductSize{{Size: "44", Available: true}, {Size: "46", Available: true}}, dress.Sizes)
+		s.Require().Len(dress.Images, 2)
+		s.Require().True(dress.Images[0].Mirrored)
+		s.Require().False(dress.Images[1].Mirrored)
+
+		s.Require().Equal("scarf", scarf.ExternalID)
+		s.Require().Nil(scarf.CategoryID)
+	})
+
+	s.Run("filter products", func() {
+		s.Require().Len(listProducts(url.Values{"category": {"dresses"}}), 1)
+		s.Require().Len(listProducts(url.Values{"brand": {"acme"}}), 2)
+		s.Require().Len(listProducts(url.Values{"feed": {"other"}}), 0)
+
+		s.sendRequest(http.MethodGet, productsPath+"?available=maybe", http.StatusBadRequest, nil, nil, user)
+	})
+
+	s.Run("product image url", func() {
+		var imageURL entity.FileURL
+
+		imagePath := productsPath + "/" + dress.ID.String() + "/images/"
+
+		s.sendRequest(http.MethodGet, imagePath+"0/url", http.StatusOK, nil, &imageURL, user)
+		s.Require().NotEmpty(imageURL.URL)
+
+		s.sendRequest(http.MethodGet, imagePath+"1/url", http.StatusNotFound, nil, nil, user)
+		s.sendRequest(http.MethodGet, imagePath+"5/url", http.StatusNotFound, nil, nil, user)
+	})
+
+	s.Run("reimport unchanged feed", func() {
+		imp, err := s.catalogService.Import(context.Background(), "acme", entity.FeedFormatYML, feed(true))
+		s.Require().NoError(err)
+		s.Require().Equal(2, imp.Unchanged)
+		s.Require().Zero(imp.ImagesMirrored)
+		s.Require().Equal(1, imp.ImagesFailed)
+
+		keys, err := s.storage.ListAllFiles(context.Background(), "")
+		s.Require().NoError(err)
+		s.Require().Len(keys, 2)
+	})
+
+	s.Run("products missing from the feed are deactivated", func() {
+		imp, err := s.catalogService.Import(context.Background(), "acme", entity.FeedFormatYML, feed(false))
+		s.Require().NoError(err)
+		s.Require().Equal(1, imp.Unchanged)
+		s.Require().Equal(1, imp.Deactivated)
+
+		var got entity.Product
+
+		s.sendRequest(http.MethodGet, productsPath+"/"+scarf.ID.String(), http.StatusOK, nil, &got, user)
+		s.Require().False(got.Available)
+
+		s.Require().Len(listProducts(url.Values{"available": {"true"}}), 1)
+
+		imp, err = s.catalogService.Import(context.Background(), "acme", entity.FeedFormatYML, feed(true))
+		s.Require().NoError(err)
+		s.Require().Equal(1, imp.Updated)
+
+		s.sendRequest(http.MethodGet, productsPath+"/"+scarf.ID.String(), http.StatusOK, nil, &got, user)
+		s.Require().True(got.Available)
+	})
+
+	s.Run("invalid feed", func() {
+		_, err := s.catalogService.Import(context.Background(), "acme", entity.FeedFormatYML, strings.NewReader("<yml"))
+		s.Require().ErrorIs(err, entity.ErrInvalidFeed)
+
+		_, err = s.catalogService.Import(context.Background(), "Acme Store", entity.FeedFormatCSV, strings.NewReader(""))
+		s.Require().ErrorIs(err, entity.ErrInvalidFeed)
+	})
+
+	s.Run("import stats", func() {
+		s.sendRequest(http.MethodGet, adminPath+"/catalog/imports", http.StatusForbidden, nil, nil, user)
+
+		var imports []entity.FeedImport
+
+		s.sendRequest(http.MethodGet, adminPath+"/catalog/imports?feed=acme&limit=2", http.StatusOK, nil, &imports, admin)
+		s.Require().Len(imports, 2)
+		s.Require().Equal(entity.FeedImportStatusFailed, imports[0].Status)
+		s.Require().NotEmpty(imports[0].Errors)
+		s.Require().Equal(entity.FeedImportStatusSucceeded, imports[1].Status)
+		s.Require().Equal(1, imports[1].Updated)
+	})
+
+	s.sendRequest(http.MethodGet, productsPath+"/"+entity.ProductID{}.String(), http.StatusNotFound, nil, nil, user)
+}
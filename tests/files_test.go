@@ -0,0 +1,223 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	filesservice "github.com/romanpitatelev/clothing-service/internal/usecase/files-service"
+)
+
+const maxFileSize = 1 << 20
+
+//nolint:gochecknoglobals
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func (s *IntegrationTestSuite) TestFiles() {
+	owner := s.createUser("79031355532")
+
+	filesPath := userPath + "/" + owner.UserID.String() + "/files"
+
+	var file entity.File
+
+	s.Run("upload file of another user", func() {
+		s.sendRawRequest(http.MethodPost, filesPath, http.StatusForbidden, pngHeader, entity.User{UserID: entity.UserID(uuid.New())})
+	})
+
+	s.Run("upload not an image", func() {
+		s.sendRawRequest(http.MethodPost, filesPath, http.StatusUnsupportedMediaType, []byte("plain text"), owner)
+	})
+
+	s.Run("upload too large file", func() {
+		data := append(bytes.Clone(pngHeader), make([]byte, maxFileSize)...)
+
+		s.sendRawRequest(http.MethodPost, filesPath, http.StatusRequestEntityTooLarge, data, owner)
+	})
+
+	s.Run("upload file successfully", func() {
+		data := s.sendRawRequest(http.MethodPost, filesPath, http.StatusCreated, pngHeader, owner)
+		s.Require().NoError(json.Unmarshal(data, &file))
+		s.Require().Equal(owner.UserID, file.UserID)
+		s.Require().Equal("image/png", file.ContentType)
+		s.Require().Equal(entity.FileStatusReady, file.Status)
+		s.Require().Equal(int64(len(pngHeader)), file.Size)
+	})
+
+	s.Run("get file", func() {
+		var got entity.File
+
+		s.sendRequest(http.MethodGet, filesPath+"/"+file.ID.String(), http.StatusOK, nil, &got, owner)
+		s.Require().Equal(file.ID, got.ID)
+		s.Require().Equal(file.Checksum, got.Checksum)
+	})
+
+	s.Run("download file", func() {
+		data := s.sendRawRequest(http.MethodGet, filesPath+"/"+file.ID.String()+"/content", http.StatusOK, nil, owner)
+		s.Require().Equal(pngHeader, data)
+	})
+
+	s.Run("get file url", func() {
+		var fileURL entity.FileURL
+
+		s.sendRequest(http.MethodGet, filesPath+"/"+file.ID.String()+"/url", http.StatusOK, nil, &fileURL, owner)
+		s.Require().NotEmpty(fileURL.URL)
+	})
+
+	s.Run("orphaned objects are removed", func() {
+		for _, key := range []string{"sha256/00/orphan", "users/" + owner.UserID.String() + "/orphan", "untracked"} {
+			err := s.storage.UploadFile(context.Background(), pngHeader, key, "image/png")
+			s.Require().NoError(err)
+		}
+
+		removed, err := s.filesRepo.RemoveOrphanedObjects(context.Background(), time.Hour)
+		s.Require().NoError(err)
+		s.Require().Empty(removed)
+
+		removed, err = s.filesRepo.RemoveOrphanedObjects(context.Background(), -time.Second)
+		s.Require().NoError(err)
+		s.Require().ElementsMatch([]string{"sha256/00/orphan", "users/" + owner.UserID.String() + "/orphan"}, removed)
+	})
+
+	s.Run("untracked objects outside the file prefixes survive reconciling", func() {
+		reconciler := filesservice.NewReconciler(filesservice.ReconcilerConfig{
+			Interval:          time.Minute,
+			PendingTTL:        time.Hour,
+			OrphanGracePeriod: -time.Second,
+		}, s.filesRepo)
+
+		s.Require().NoError(reconciler.Reconcile(context.Background()))
+
+		objects, err := s.storage.ListAllFiles(context.Background(), "untracked")
+		s.Require().NoError(err)
+		s.Require().Equal([]string{"untracked"}, objects)
+
+		s.Require().NoError(s.storage.DeleteFiles(context.Background(), "untracked"))
+	})
+
+	var duplicate entity.File
+
+	s.Run("upload the same content again", func() {
+		data := s.sendRawRequest(http.MethodPost, filesPath, http.StatusCreated, pngHeader, owner)
+		s.Require().NoError(json.Unmarshal(data, &duplicate))
+		s.Require().NotEqual(file.ID, duplicate.ID)
+		s.Require().Equal(file.Checksum, duplicate.Checksum)
+
+		objects, err := s.storage.ListAllFiles(context.Background(), "")
+		s.Require().NoError(err)
+		s.Require().Len(objects, 1)
+	})
+
+	s.Run("delete file keeps shared object", func() {
+		s.sendRequest(http.MethodDelete, filesPath+"/"+file.ID.String(), http.StatusNoContent, nil, nil, owner)
+		s.sendRequest(http.MethodGet, filesPath+"/"+file.ID.String(), http.StatusNotFound, nil, nil, owner)
+		s.sendRequest(http.MethodDelete, filesPath+"/"+file.ID.String(), http.StatusNotFound, nil, nil, owner)
+
+		data := s.sendRawRequest(http.MethodGet, filesPath+"/"+duplicate.ID.String()+"/content", http.StatusOK, nil, owner)
+		s.Require().Equal(pngHeader, data)
+	})
+
+	s.Run("delete last reference removes object", func() {
+		s.sendRequest(http.MethodDelete, filesPath+"/"+duplicate.ID.String(), http.StatusNoContent, nil, nil, owner)
+
+		objects, err := s.storage.ListAllFiles(context.Background(), "")
+		s.Require().NoError(err)
+		s.Require().Empty(objects)
+	})
+
+	s.Run("interrupted purge is finished by the reconciler", func() {
+		key := "sha256/ee/purged"
+
+		s.Require().NoError(s.storage.UploadFile(context.Background(), pngHeader, key, "image/png"))
+
+		_, err := s.db.Exec(context.Background(), `
+INSERT INTO blobs (bucket_key, checksum, content_type, size, ref_count, purged_at)
+VALUES ($1, 'purged', 'image/png', 1, 0, NOW())`, key)
+		s.Require().NoError(err)
+
+		purged, err := s.filesRepo.PurgeUnreferencedBlobs(context.Background())
+		s.Require().NoError(err)
+		s.Require().Equal(1, purged)
+
+		objects, err := s.storage.ListAllFiles(context.Background(), "")
+		s.Require().NoError(err)
+		s.Require().Empty(objects)
+
+		var count int
+
+		err = s.db.QueryRow(context.Background(), `SELECT COUNT(*) FROM blobs`).Scan(&count)
+		s.Require().NoError(err)
+		s.Require().Zero(count)
+	})
+}
+
+func (s *IntegrationTestSuite) TestFilesExpirePending() {
+	owner := s.createUser("79031355533")
+
+	fileID := entity.FileID(uuid.New())
+	key := "sha256/ff/stale"
+
+	_, err := s.db.Exec(context.Background(), `
+INSERT INTO blobs (bucket_key, checksum, content_type, size, ref_count)
+VALUES ($1, 'stale', 'image/png', 1, 1)`, key)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(context.Background(), `
+INSERT INTO files (id, user_id, bucket_key, content_type, size, checksum, status, created_at)
+VALUES ($1, $2, $3, 'image/png', 1, 'stale', $4, NOW() - INTERVAL '2 hours')`,
+		fileID, owner.UserID, key, entity.FileStatusPending)
+	s.Require().NoError(err)
+
+	s.Run("fresh pending files are kept", func() {
+		expired, err := s.filesRepo.ExpirePendingFiles(context.Background(), 3*time.Hour)
+		s.Require().NoError(err)
+		s.Require().Zero(expired)
+	})
+
+	s.Run("stale pending file is expired", func() {
+		expired, err := s.filesRepo.ExpirePendingFiles(context.Background(), time.Hour)
+		s.Require().NoError(err)
+		s.Require().Equal(int64(1), expired)
+
+		var (
+			status   entity.FileStatus
+			refCount int64
+		)
+
+		err = s.db.QueryRow(context.Background(), `SELECT status FROM files WHERE id = $1`, fileID).Scan(&status)
+		s.Require().NoError(err)
+		s.Require().Equal(entity.FileStatusDeleted, status)
+
+		err = s.db.QueryRow(context.Background(), `SELECT ref_count FROM blobs WHERE bucket_key = $1`, key).Scan(&refCount)
+		s.Require().NoError(err)
+		s.Require().Zero(refCount)
+	})
+}
+
+// sendRawRequest sends body as is and returns the raw response body.
+func (s *IntegrationTestSuite) sendRawRequest(method, path string, status int, body []byte, user entity.User) []byte {
+	request, err := http.NewRequestWithContext(context.Background(), method,
+		fmt.Sprintf("http://localhost:%d%s", port, path), bytes.NewReader(body))
+	s.Require().NoError(err, "failed to create request")
+
+	request.Header.Set("Authorization", "Bearer "+s.getToken(user))
+
+	response, err := http.DefaultClient.Do(request)
+	s.Require().NoError(err, "failed to execute request")
+
+	defer func() {
+		err = response.Body.Close()
+		s.Require().NoError(err)
+	}()
+
+	data, err := io.ReadAll(response.Body)
+	s.Require().NoError(err)
+	s.Require().Equal(status, response.StatusCode, "unexpected status code: %s", string(data))
+
+	return data
+}
@@ -12,15 +12,65 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/romanpitatelev/clothing-service/internal/controller/rest"
+	cataloghandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/catalog-handler"
+	fileshandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/files-handler"
 	iamhandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/iam-handler"
+	marketplacehandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/marketplace-handler"
+	messaginghandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/messaging-handler"
+	moderationhandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/moderation-handler"
+	notificationshandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/notifications-handler"
+	ordershandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/orders-handler"
+	outfitshandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/outfits-handler"
+	realtimehandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/realtime-handler"
+	sizeshandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/sizes-handler"
+	socialhandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/social-handler"
+	taxonomyhandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/taxonomy-handler"
 	usershandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/users-handler"
+	wardrobehandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/wardrobe-handler"
 	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/pricing"
+	catalogrepo "github.com/romanpitatelev/clothing-service/internal/repository/catalog-repo"
+	eventsrepo "github.com/romanpitatelev/clothing-service/internal/repository/events-repo"
+	filesrepo "github.com/romanpitatelev/clothing-service/internal/repository/files-repo"
+	followsrepo "github.com/romanpitatelev/clothing-service/internal/repository/follows-repo"
+	marketplacerepo "github.com/romanpitatelev/clothing-service/internal/repository/marketplace-repo"
+	messagingrepo "github.com/romanpitatelev/clothing-service/internal/repository/messaging-repo"
+	moderationrepo "github.com/romanpitatelev/clothing-service/internal/repository/moderation-repo"
+	notificationsrepo "github.com/romanpitatelev/clothing-service/internal/repository/notifications-repo"
+	ordersrepo "github.com/romanpitatelev/clothing-service/internal/repository/orders-repo"
+	outboxrepo "github.com/romanpitatelev/clothing-service/internal/repository/outbox-repo"
+	outfitsrepo "github.com/romanpitatelev/clothing-service/internal/repository/outfits-repo"
+	postsrepo "github.com/romanpitatelev/clothing-service/internal/repository/posts-repo"
+	searchrepo "github.com/romanpitatelev/clothing-service/internal/repository/search-repo"
+	sizesrepo "github.com/romanpitatelev/clothing-service/internal/repository/sizes-repo"
 	smsregistrationrepo "github.com/romanpitatelev/clothing-service/internal/repository/sms-registration-repo"
 	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+	taxonomyrepo "github.com/romanpitatelev/clothing-service/internal/repository/taxonomy-repo"
 	usersrepo "github.com/romanpitatelev/clothing-service/internal/repository/users-repo"
+	wardroberepo "github.com/romanpitatelev/clothing-service/internal/repository/wardrobe-repo"
+	yookassarepo "github.com/romanpitatelev/clothing-service/internal/repository/yookassa-repo"
+	catalogservice "github.com/romanpitatelev/clothing-service/internal/usecase/catalog-service"
+	filesservice "github.com/romanpitatelev/clothing-service/internal/usecase/files-service"
+	marketplaceservice "github.com/romanpitatelev/clothing-service/internal/usecase/marketplace-service"
+	messagingservice "github.com/romanpitatelev/clothing-service/internal/usecase/messaging-service"
+	moderationservice "github.com/romanpitatelev/clothing-service/internal/usecase/moderation-service"
+	notificationsservice "github.com/romanpitatelev/clothing-service/internal/usecase/notifications-service"
+	ordersservice "github.com/romanpitatelev/clothing-service/internal/usecase/orders-service"
+	outboxservice "github.com/romanpitatelev/clothing-service/internal/usecase/outbox-service"
+	outfitsservice "github.com/romanpitatelev/clothing-service/internal/usecase/outfits-service"
+	realtimeservice "github.com/romanpitatelev/clothing-service/internal/usecase/realtime-service"
+	searchservice "github.com/romanpitatelev/clothing-service/internal/usecase/search-service"
+	sizesservice "github.com/romanpitatelev/clothing-service/internal/usecase/sizes-service"
+	socialservice "github.com/romanpitatelev/clothing-service/internal/usecase/social-service"
+	suggestionsservice "github.com/romanpitatelev/clothing-service/internal/usecase/suggestions-service"
+	taxonomyservice "github.com/romanpitatelev/clothing-service/internal/usecase/taxonomy-service"
 	"github.com/romanpitatelev/clothing-service/internal/usecase/token-service"
 	usersservice "github.com/romanpitatelev/clothing-service/internal/usecase/users-service"
+	wardrobeservice "github.com/romanpitatelev/clothing-service/internal/usecase/wardrobe-service"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
 	"github.com/rs/zerolog/log"
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/stretchr/testify/suite"
@@ -38,16 +88,85 @@ const (
 
 type IntegrationTestSuite struct {
 	suite.Suite
-	cancelFunc   context.CancelFunc
-	db           *store.DataStore
-	usersRepo    *usersrepo.Repo
-	tokenService *tokenservice.Service
-	usersService *usersservice.Service
-	iamHandler   *iamhandler.Handler
-	usersHandler *usershandler.Handler
-	server       *rest.Server
-	smsRepo      *smsregistrationrepo.SMSService
-	smsChan      chan otpResp
+	cancelFunc context.CancelFunc
+	db         *store.DataStore
+	// seededCategories and seededAttributes hold the taxonomy the migrations
+	// created, as JSON arrays; everything else is removed after each test.
+	seededCategories []byte
+	seededAttributes []byte
+	usersRepo        *usersrepo.Repo
+	outboxRepo       *outboxrepo.Repo
+	outboxRelay      *outboxservice.Relay
+	tokenService     *tokenservice.Service
+	usersService     *usersservice.Service
+	iamHandler       *iamhandler.Handler
+	usersHandler     *usershandler.Handler
+	storage          *filesrepo.Memory
+	filesRepo        *filesrepo.Repo
+	filesService     *filesservice.Service
+	filesHandler     *fileshandler.Handler
+	server           *rest.Server
+	smsRepo          *smsregistrationrepo.SMSService
+	smsChan          chan otpResp
+
+	wardrobeRepo    *wardroberepo.Repo
+	wardrobeService *wardrobeservice.Service
+	wardrobeHandler *wardrobehandler.Handler
+
+	taxonomyRepo    *taxonomyrepo.Repo
+	taxonomyService *taxonomyservice.Service
+	taxonomyHandler *taxonomyhandler.Handler
+
+	outfitsRepo    *outfitsrepo.Repo
+	outfitsService *outfitsservice.Service
+	outfitsHandler *outfitshandler.Handler
+
+	suggestionsService *suggestionsservice.Service
+
+	searchRepo    *searchrepo.Repo
+	searchService *searchservice.Service
+
+	sizesRepo    *sizesrepo.Repo
+	sizesService *sizesservice.Service
+	sizesHandler *sizeshandler.Handler
+
+	postsRepo     *postsrepo.Repo
+	followsRepo   *followsrepo.Repo
+	socialService *socialservice.Service
+	socialHandler *socialhandler.Handler
+
+	moderationRepo    *moderationrepo.Repo
+	moderationService *moderationservice.Service
+	moderationHandler *moderationhandler.Handler
+
+	catalogRepo    *catalogrepo.Repo
+	catalogService *catalogservice.Service
+	catalogHandler *cataloghandler.Handler
+	priceWatcher   *catalogservice.PriceWatcher
+
+	ordersRepo    *ordersrepo.Repo
+	ordersService *ordersservice.Service
+	ordersHandler *ordershandler.Handler
+
+	marketplaceRepo    *marketplacerepo.Repo
+	marketplaceService *marketplaceservice.Service
+	marketplaceHandler *marketplacehandler.Handler
+
+	messagingRepo    *messagingrepo.Repo
+	messagingService *messagingservice.Service
+	messagingHandler *messaginghandler.Handler
+
+	eventsRepo      *eventsrepo.Repo
+	realtimeHub     *realtimeservice.Hub
+	realtimeHandler *realtimehandler.Handler
+
+	notificationsRepo       *notificationsrepo.Repo
+	notificationsService    *notificationsservice.Service
+	notificationsDispatcher *notificationsservice.Dispatcher
+	notificationsHandler    *notificationshandler.Handler
+
+	paymentRepo     *yookassarepo.Client
+	paymentProvider *paymentProvider
 }
 
 func (s *IntegrationTestSuite) SetupSuite() {
@@ -58,7 +177,12 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 	var err error
 
-	s.db, err = store.New(ctx, store.Config{Dsn: pgDSN})
+	s.db, err = store.New(ctx, store.Config{
+		Dsn:          pgDSN,
+		TxIsoLevel:   pgx.ReadCommitted,
+		TxMaxRetries: 3,
+		TxRetryDelay: time.Millisecond,
+	})
 	s.Require().NoError(err)
 
 	log.Info().Msg("starting new db ...")
@@ -66,6 +190,11 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	err = s.db.Migrate(migrate.Up)
 	s.Require().NoError(err)
 
+	err = s.db.QueryRow(ctx, `
+SELECT (SELECT jsonb_agg(c) FROM categories c),
+	(SELECT COALESCE(jsonb_agg(a), '[]') FROM category_attributes a)`).Scan(&s.seededCategories, &s.seededAttributes)
+	s.Require().NoError(err)
+
 	log.Info().Msg("migrations are ready")
 
 	privateKey, err := readPrivateKey()
@@ -73,6 +202,8 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 	publicKey := &privateKey.PublicKey
 	s.usersRepo = usersrepo.New(s.db)
+	s.outboxRepo = outboxrepo.New(s.db)
+	s.eventsRepo = eventsrepo.New(s.db)
 	s.smsRepo = smsregistrationrepo.New(smsregistrationrepo.Config{
 		Host:   "localhost:" + strconv.Itoa(port+1),
 		Schema: "http",
@@ -88,12 +219,125 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	}, s.usersRepo)
 	s.usersService = usersservice.New(usersservice.Config{
 		OTPMaxValue: 9999,
-	}, s.usersRepo, s.smsRepo)
+		OTPLifetime: otpDuration,
+	}, s.usersRepo, s.outboxRepo, s.db)
+	s.outboxRelay = outboxservice.NewRelay(outboxservice.RelayConfig{
+		Interval:    50 * time.Millisecond,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+		Lease:       time.Minute,
+		Retention:   time.Hour,
+	}, s.outboxRepo, s.smsRepo, s.eventsRepo)
+
+	s.storage = filesrepo.NewMemory()
+	s.filesRepo = filesrepo.NewRepo(s.db, s.storage)
+	s.filesService = filesservice.New(filesservice.Config{
+		MaxFileSize: maxFileSize,
+		URLLifetime: time.Minute,
+	}, s.filesRepo)
+
+	s.wardrobeRepo = wardroberepo.New(s.db)
+	s.taxonomyRepo = taxonomyrepo.New(s.db)
+	s.taxonomyService = taxonomyservice.New(s.taxonomyRepo)
+	s.outfitsRepo = outfitsrepo.New(s.db)
+	s.outfitsService = outfitsservice.New(s.outfitsRepo)
+	s.suggestionsService = suggestionsservice.New(s.wardrobeRepo)
+	s.searchRepo = searchrepo.New(s.db)
+	s.searchService = searchservice.New(s.searchRepo)
+	s.sizesRepo = sizesrepo.New(s.db)
+	s.sizesService = sizesservice.New(s.sizesRepo, s.taxonomyRepo)
+	s.postsRepo = postsrepo.New(s.db)
+	s.followsRepo = followsrepo.New(s.db)
+	s.notificationsRepo = notificationsrepo.New(s.db)
+	s.notificationsService = notificationsservice.New(s.notificationsRepo)
+	s.notificationsDispatcher = notificationsservice.NewDispatcher(notificationsservice.DispatcherConfig{
+		Interval:    time.Minute,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  time.Hour,
+		Lease:       time.Minute,
+	}, s.notificationsRepo, s.outboxRepo, s.db)
+	s.socialService = socialservice.New(s.postsRepo, s.followsRepo, s.outboxRepo, s.notificationsService, s.db)
+	s.moderationRepo = moderationrepo.New(s.db)
+	s.moderationService = moderationservice.New(s.moderationRepo)
+	s.catalogRepo = catalogrepo.New(s.db)
+	s.catalogService = catalogservice.New(catalogservice.Config{
+		URLLifetime: time.Minute,
+	}, s.catalogRepo, s.filesRepo, catalogrepo.NewFetcher(catalogrepo.FetcherConfig{
+		Timeout: time.Second,
+		MaxSize: 1 << 20,
+	}))
+	s.priceWatcher = catalogservice.NewPriceWatcher(catalogservice.PriceWatcherConfig{
+		Interval:  time.Minute,
+		BatchSize: 10,
+	}, s.catalogRepo, s.notificationsService, s.outboxRepo, s.db)
+	s.ordersRepo = ordersrepo.New(s.db)
+	s.paymentProvider = newPaymentProvider()
+	s.paymentRepo = yookassarepo.New(yookassarepo.Config{
+		Host:          "localhost:" + strconv.Itoa(port+2),
+		Schema:        "http",
+		ShopID:        paymentShopID,
+		SecretKey:     paymentSecretKey,
+		ReturnURL:     "https://example.com/orders",
+		WebhookSecret: paymentWebhookSecret,
+		Timeout:       time.Second,
+	})
+	s.ordersService = ordersservice.New(s.ordersRepo, s.paymentRepo, pricing.New(pricing.Config{}), s.outboxRepo, s.notificationsService, s.db)
+	s.marketplaceRepo = marketplacerepo.New(s.db)
+	s.marketplaceService = marketplaceservice.New(marketplaceservice.Config{
+		URLLifetime: time.Minute,
+	}, s.marketplaceRepo, s.filesRepo)
+	s.messagingRepo = messagingrepo.New(s.db)
+	s.messagingService = messagingservice.New(messagingservice.Config{
+		URLLifetime: time.Minute,
+	}, s.messagingRepo, s.filesRepo, s.eventsRepo)
+	s.realtimeHub = realtimeservice.NewHub(realtimeservice.HubConfig{
+		BufferSize:    10,
+		RetryInterval: 100 * time.Millisecond,
+	}, s.eventsRepo)
+	s.wardrobeService = wardrobeservice.New(s.wardrobeRepo, s.taxonomyRepo, s.db)
 
 	s.usersHandler = usershandler.New(s.usersService)
 	s.iamHandler = iamhandler.New(s.tokenService)
-
-	s.server = rest.New(rest.Config{Port: port}, s.usersHandler, s.iamHandler)
+	s.filesHandler = fileshandler.New(fileshandler.Config{
+		MaxUploadSize: maxFileSize,
+	}, s.filesService)
+	s.wardrobeHandler = wardrobehandler.New(s.wardrobeService, s.searchService)
+	s.taxonomyHandler = taxonomyhandler.New(s.taxonomyService)
+	s.outfitsHandler = outfitshandler.New(s.outfitsService, s.suggestionsService)
+	s.sizesHandler = sizeshandler.New(s.sizesService)
+	s.socialHandler = socialhandler.New(s.socialService)
+	s.moderationHandler = moderationhandler.New(s.moderationService)
+	s.catalogHandler = cataloghandler.New(s.catalogService)
+	s.ordersHandler = ordershandler.New(s.ordersService)
+	s.marketplaceHandler = marketplacehandler.New(s.marketplaceService)
+	s.messagingHandler = messaginghandler.New(s.messagingService)
+	s.realtimeHandler = realtimehandler.New(realtimehandler.Config{
+		HeartbeatInterval: time.Second,
+	}, s.realtimeHub)
+	s.notificationsHandler = notificationshandler.New(s.notificationsService)
+
+	s.server = rest.New(
+		rest.Config{Port: port},
+		s.usersHandler,
+		s.iamHandler,
+		s.filesHandler,
+		s.wardrobeHandler,
+		s.taxonomyHandler,
+		s.outfitsHandler,
+		s.sizesHandler,
+		s.socialHandler,
+		s.moderationHandler,
+		s.catalogHandler,
+		s.ordersHandler,
+		s.marketplaceHandler,
+		s.messagingHandler,
+		s.realtimeHandler,
+		s.notificationsHandler,
+	)
 
 	log.Info().Msg("sms client is ready")
 
@@ -102,6 +346,12 @@ func (s *IntegrationTestSuite) SetupSuite() {
 		s.runServer(ctx, ":"+strconv.Itoa(port+1))
 	}()
 
+	go s.runPaymentServer(ctx, ":"+strconv.Itoa(port+2))
+
+	go s.realtimeHub.Run(ctx)
+
+	go s.outboxRelay.Run(ctx)
+
 	//nolint:testifylint
 	go func() {
 		err = s.server.Run(ctx)
@@ -116,7 +366,21 @@ func (s *IntegrationTestSuite) TearDownSuite() {
 }
 
 func (s *IntegrationTestSuite) TearDownTest() {
-	err := s.db.Truncate(context.Background(), "users")
+	err := s.db.Truncate(context.Background(), "outbox_messages", "notification_deliveries", "notifications", "notification_preferences", "quiet_hours", "payment_events", "payments", "promo_redemptions", "order_items", "orders", "promo_codes", "cart_items", "addresses", "price_alerts", "wishlist_items", "product_price_history", "product_images", "product_sizes", "products", "catalog_imports", "catalog_feeds", "moderation_audit_log", "reports", "comments", "collection_posts", "collections", "post_likes", "posts", "follows", "size_profiles", "wear_log_items", "wear_logs", "outfit_items", "outfits", "user_blocks", "message_photos", "messages", "conversations", "listing_offers", "listing_photos", "listings", "wardrobe_item_photos", "wardrobe_items", "files", "blobs", "users")
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(context.Background(), `
+DELETE FROM category_attributes a
+WHERE NOT EXISTS (
+	SELECT 1
+	FROM jsonb_populate_recordset(NULL::category_attributes, $1) seeded
+	WHERE seeded.category_id = a.category_id AND seeded.name = a.name
+)`, s.seededAttributes)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(context.Background(), `
+DELETE FROM categories
+WHERE id NOT IN (SELECT id FROM jsonb_populate_recordset(NULL::categories, $1))`, s.seededCategories)
 	s.Require().NoError(err)
 }
 
@@ -176,6 +440,7 @@ func (s *IntegrationTestSuite) sendRequest(method, path string, status int, enti
 func (s *IntegrationTestSuite) getToken(user entity.User) string {
 	claims := entity.Claims{
 		UserID: user.UserID,
+		Role:   user.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -190,3 +455,19 @@ func (s *IntegrationTestSuite) getToken(user entity.User) string {
 
 	return token
 }
+
+func (s *IntegrationTestSuite) createUser(phone string) entity.User {
+	user := entity.User{
+		UserID:        entity.UserID(uuid.New()),
+		FirstName:     utils.Pointer("John"),
+		LastName:      utils.Pointer("Ivanov"),
+		BirthDate:     utils.Pointer(time.Now()),
+		Phone:         phone,
+		PhoneVerified: true,
+	}
+
+	err := s.db.UpsertUser(context.Background(), user)
+	s.Require().NoError(err)
+
+	return user
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestPostInteractions() {
+	author := s.createUser("79031355587")
+	fan := s.createUser("79031355588")
+	troll := s.createUser("79031355589")
+
+	var post, private entity.Post
+
+	s.sendRequest(http.MethodPost, userPath+"/"+author.UserID.String()+"/posts", http.StatusCreated,
+		entity.Post{OutfitID: s.createOutfit(author, "Friday").ID}, &post, author)
+	s.sendRequest(http.MethodPost, userPath+"/"+author.UserID.String()+"/posts", http.StatusCreated,
+		entity.Post{OutfitID: s.createOutfit(author, "Sunday").ID, Visibility: entity.PostVisibilityPrivate}, &private, author)
+
+	postPath := userPath + "/" + author.UserID.String() + "/posts/" + post.ID.String()
+	privatePath := userPath + "/" + author.UserID.String() + "/posts/" + private.ID.String()
+
+	s.Run("likes", func() {
+		var state entity.LikeState
+
+		s.sendRequest(http.MethodPut, postPath+"/like", http.StatusOK, nil, &state, fan)
+		s.Require().Equal(entity.LikeState{Liked: true, LikesCount: 1}, state)
+
+		s.sendRequest(http.MethodPut, postPath+"/like", http.StatusOK, nil, &state, fan)
+		s.Require().Equal(entity.LikeState{Liked: true, LikesCount: 1}, state)
+
+		s.sendRequest(http.MethodPut, postPath+"/like", http.StatusOK, nil, &state, troll)
+		s.Require().Equal(2, state.LikesCount)
+
+		s.sendRequest(http.MethodDelete, postPath+"/like", http.StatusOK, nil, &state, troll)
+		s.sendRequest(http.MethodDelete, postPath+"/like", http.StatusOK, nil, &state, troll)
+		s.Require().Equal(entity.LikeState{Liked: false, LikesCount: 1}, state)
+
+		s.sendRequest(http.MethodPut, privatePath+"/like", http.StatusNotFound, nil, nil, fan)
+
+		var got entity.Post
+
+		s.sendRequest(http.MethodGet, postPath, http.StatusOK, nil, &got, fan)
+		s.Require().Equal(1, got.LikesCount)
+		s.Require().True(got.Liked)
+
+		s.sendRequest(http.MethodGet, postPath, http.StatusOK, nil, &got, troll)
+		s.Require().False(got.Liked)
+	})
+
+	var comment, reply entity.Comment
+
+	s.Run("comments", func() {
+		s.sendRequest(http.MethodPost, postPath+"/comments", http.StatusBadRequest,
+			entity.Comment{Body: utils.Pointer("  ")}, nil, fan)
+		s.sendRequest(http.MethodPost, privatePath+"/comments", http.StatusNotFound,
+			entity.Comment{Body: utils.Pointer("hi")}, nil, fan)
+		s.sendRequest(http.MethodPost, postPath+"/comments", http.StatusBadRequest,
+			entity.Comment{Body: utils.Pointer("hi"), ParentID: utils.Pointer(entity.CommentID(uuid.New()))}, nil, fan)
+
+		s.sendRequest(http.MethodPost, postPath+"/comments", http.StatusCreated,
+			entity.Comment{Body: utils.Pointer(" Nice look ")}, &comment, fan)
+		s.Require().Equal("Nice look", *comment.Body)
+		s.Require().Equal(fan.UserID, comment.AuthorID)
+
+		s.sendRequest(http.MethodPost, postPath+"/comments", http.StatusCreated,
+			entity.Comment{Body: utils.Pointer("Thanks"), ParentID: &comment.ID}, &reply, author)
+		s.Require().Equal(comment.ID, *reply.ParentID)
+
+		var nested entity.Comment
+
+		s.sendRequest(http.MethodPost, postPath+"/comments", http.StatusCreated,
+			entity.Comment{Body: utils.Pointer("Ugly"), ParentID: &reply.ID}, &nested, troll)
+		s.Require().Equal(comment.ID, *nested.ParentID)
+
+		var page entity.CommentPage
+
+		s.sendRequest(http.MethodGet, postPath+"/comments", http.StatusOK, nil, &page, troll)
+		s.Require().Len(page.Comments, 1)
+		s.Require().Equal(2, page.Comments[0].RepliesCount)
+
+		var ids []entity.CommentID
+
+		query := url.Values{"limit": {"1"}}
+
+		for {
+			s.sendRequest(http.MethodGet, postPath+"/comments/"+comment.ID.String()+"/replies?"+query.Encode(),
+				http.StatusOK, nil, &page, fan)
+
+			for _, c := range page.Comments {
+				ids = append(ids, c.ID)
+			}
+
+			if page.NextCursor == nil {
+				break
+			}
+
+			query.Set("cursor", *page.NextCursor)
+		}
+
+		s.Require().Equal([]entity.CommentID{reply.ID, nested.ID}, ids)
+
+		var got entity.Post
+
+		s.sendRequest(http.MethodGet, postPath, http.StatusOK, nil, &got, fan)
+		s.Require().Equal(3, got.CommentsCount)
+
+		s.Run("edit", func() {
+			var edited entity.Comment
+
+			s.sendRequest(http.MethodPatch, postPath+"/comments/"+comment.ID.String(), http.StatusForbidden,
+				entity.CommentUpdate{Body: "Edited"}, nil, author)
+			s.sendRequest(http.MethodPatch, postPath+"/comments/"+comment.ID.String(), http.StatusOK,
+				entity.CommentUpdate{Body: "Edited"}, &edited, fan)
+			s.Require().Equal("Edited", *edited.Body)
+		})
+
+		s.Run("moderate", func() {
+			s.sendRequest(http.MethodDelete, postPath+"/comments/"+reply.ID.String(), http.StatusForbidden, nil, nil, troll)
+			s.sendRequest(http.MethodDelete, postPath+"/comments/"+nested.ID.String(), http.StatusNoContent, nil, nil, author)
+			s.sendRequest(http.MethodDelete, postPath+"/comments/"+nested.ID.String(), http.StatusNotFound, nil, nil, author)
+			s.sendRequest(http.MethodPatch, postPath+"/comments/"+nested.ID.String(), http.StatusNotFound,
+				entity.CommentUpdate{Body: "Sorry"}, nil, troll)
+
+			s.sendRequest(http.MethodDelete, postPath+"/comments/"+comment.ID.String(), http.StatusNoContent, nil, nil, fan)
+
+			s.sendRequest(http.MethodGet, postPath+"/comments", http.StatusOK, nil, &page, fan)
+			s.Require().Len(page.Comments, 1)
+			s.Require().True(page.Comments[0].Deleted)
+			s.Require().Nil(page.Comments[0].Body)
+			s.Require().Equal(1, page.Comments[0].RepliesCount)
+
+			s.sendRequest(http.MethodGet, postPath, http.StatusOK, nil, &got, fan)
+			s.Require().Equal(1, got.CommentsCount)
+		})
+	})
+
+	s.Run("collections", func() {
+		collectionsPath := userPath + "/" + fan.UserID.String() + "/collections"
+
+		var collection entity.Collection
+
+		s.sendRequest(http.MethodPost, collectionsPath, http.StatusForbidden, entity.Collection{Name: "Looks"}, nil, troll)
+		s.sendRequest(http.MethodPost, collectionsPath, http.StatusBadRequest, entity.Collection{Name: " "}, nil, fan)
+		s.sendRequest(http.MethodPost, collectionsPath, http.StatusCreated, entity.Collection{Name: "Looks"}, &collection, fan)
+		s.sendRequest(http.MethodPost, collectionsPath, http.StatusConflict, entity.Collection{Name: "looks"}, nil, fan)
+
+		var other entity.Collection
+
+		s.sendRequest(http.MethodPost, collectionsPath, http.StatusCreated, entity.Collection{Name: "Work"}, &other, fan)
+
+		collectionPath := collectionsPath + "/" + collection.ID.String()
+
+		s.sendRequest(http.MethodPut, collectionPath+"/posts/"+private.ID.String(), http.StatusNotFound, nil, nil, fan)
+		s.sendRequest(http.MethodPut, collectionPath+"/posts/"+post.ID.String(), http.StatusNoContent, nil, nil, fan)
+		s.sendRequest(http.MethodPut, collectionPath+"/posts/"+post.ID.String(), http.StatusNoContent, nil, nil, fan)
+		s.sendRequest(http.MethodPut, collectionsPath+"/"+other.ID.String()+"/posts/"+post.ID.String(), http.StatusNoContent, nil, nil, fan)
+
+		var got entity.Post
+
+		s.sendRequest(http.MethodGet, postPath, http.StatusOK, nil, &got, fan)
+		s.Require().Equal(2, got.SavesCount)
+
+		var page entity.PostPage
+
+		s.sendRequest(http.MethodGet, collectionPath+"/posts", http.StatusOK, nil, &page, fan)
+		s.Require().Len(page.Posts, 1)
+		s.Require().Equal(post.ID, page.Posts[0].ID)
+
+		s.sendRequest(http.MethodGet, collectionsPath+"/"+uuid.New().String()+"/posts", http.StatusNotFound, nil, nil, fan)
+
+		var renamed entity.Collection
+
+		s.sendRequest(http.MethodPatch, collectionPath, http.StatusOK,
+			entity.CollectionUpdate{Name: utils.Pointer("Favourites")}, &renamed, fan)
+		s.Require().Equal("Favourites", renamed.Name)
+		s.Require().Equal(1, renamed.PostsCount)
+
+		s.sendRequest(http.MethodDelete, collectionsPath+"/"+other.ID.String()+"/posts/"+post.ID.String(), http.StatusNoContent, nil, nil, fan)
+		s.sendRequest(http.MethodDelete, collectionsPath+"/"+other.ID.String()+"/posts/"+post.ID.String(), http.StatusNoContent, nil, nil, fan)
+		s.sendRequest(http.MethodDelete, collectionPath, http.StatusNoContent, nil, nil, fan)
+		s.sendRequest(http.MethodGet, collectionPath, http.StatusNotFound, nil, nil, fan)
+
+		s.sendRequest(http.MethodGet, postPath, http.StatusOK, nil, &got, fan)
+		s.Require().Equal(0, got.SavesCount)
+
+		var collections []entity.Collection
+
+		s.sendRequest(http.MethodGet, collectionsPath, http.StatusOK, nil, &collections, fan)
+		s.Require().Len(collections, 1)
+		s.Require().Equal(other.ID, collections[0].ID)
+	})
+}
@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"net/http"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestMarketplace() {
+	seller := s.createUser("79031355652")
+	buyer := s.createUser("79031355653")
+	bidder := s.createUser("79031355654")
+
+	coat := s.createWardrobeItem(seller, entity.WardrobeItem{
+		Name:     "Wool coat",
+		Category: "outerwear",
+		Brand:    utils.Pointer("Max Mara"),
+		Size:     utils.Pointer("M"),
+	})
+	scarf := s.createWardrobeItem(seller, entity.WardrobeItem{Name: "Scarf", Category: "accessories"})
+
+	listingsPath := userPath + "/" + seller.UserID.String() + "/listings"
+	marketPath := "/api/v1/listings"
+
+	var listing entity.Listing
+
+	s.Run("create", func() {
+		s.sendRequest(http.MethodPost, listingsPath, http.StatusBadRequest,
+			entity.Listing{ItemID: coat.ID, Price: 0, Condition: entity.ConditionGood}, nil, seller)
+		s.sendRequest(http.MethodPost, listingsPath, http.StatusBadRequest,
+			entity.Listing{ItemID: coat.ID, Price: 1000, Condition: "worn out"}, nil, seller)
+		s.sendRequest(http.MethodPost, listingsPath, http.StatusForbidden,
+			entity.Listing{ItemID: coat.ID, Price: 1000, Condition: entity.ConditionGood}, nil, buyer)
+		s.sendRequest(http.MethodPost, userPath+"/"+buyer.UserID.String()+"/listings", http.StatusNotFound,
+			entity.Listing{ItemID: coat.ID, Price: 1000, Condition: entity.ConditionGood}, nil, buyer)
+
+		s.sendRequest(http.MethodPost, listingsPath, http.StatusCreated, entity.Listing{
+			ItemID:      coat.ID,
+			Description: utils.Pointer("Worn twice"),
+			Price:       1500000,
+			Condition:   entity.ConditionLikeNew,
+		}, &listing, seller)
+		s.Require().Equal("Wool coat", listing.Title)
+		s.Require().Equal("outerwear", listing.Category)
+		s.Require().Equal("Max Mara", *listing.Brand)
+		s.Require().Equal(entity.ListingStatusDraft, listing.Status)
+		s.Require().Equal(entity.DefaultCurrency, listing.Currency)
+
+		s.sendRequest(http.MethodPost, listingsPath, http.StatusConflict,
+			entity.Listing{ItemID: coat.ID, Price: 1000, Condition: entity.ConditionGood}, nil, seller)
+	})
+
+	s.Run("drafts are private", func() {
+		var listings []entity.Listing
+
+		s.sendRequest(http.MethodGet, marketPath+"/"+listing.ID.String(), http.StatusNotFound, nil, nil, buyer)
+		s.sendRequest(http.MethodGet, marketPath+"/"+listing.ID.String(), http.StatusOK, nil, nil, seller)
+
+		s.sendRequest(http.MethodGet, marketPath, http.StatusOK, nil, &listings, buyer)
+		s.Require().Empty(listings)
+
+		s.sendRequest(http.MethodPost, marketPath+"/"+listing.ID.String()+"/offers", http.StatusNotFound,
+			entity.ListingOffer{Amount: 1000}, nil, buyer)
+	})
+
+	s.Run("update and publish", func() {
+		s.sendRequest(http.MethodPatch, listingsPath+"/"+listing.ID.String(), http.StatusBadRequest,
+			entity.ListingUpdate{}, nil, seller)
+		s.sendRequest(http.MethodPatch, listingsPath+"/"+listing.ID.String(), http.StatusOK,
+			entity.ListingUpdate{Title: utils.Pointer("Max Mara wool coat"), Price: utils.Pointer(int64(1400000))},
+			&listing, seller)
+		s.Require().Equal("Max Mara wool coat", listing.Title)
+		s.Require().Equal(int64(1400000), listing.Price)
+
+		s.sendRequest(http.MethodPost, listingsPath+"/"+listing.ID.String()+"/sold", http.StatusConflict, nil, nil, seller)
+		s.sendRequest(http.MethodPost, listingsPath+"/"+listing.ID.String()+"/publish", http.StatusForbidden, nil, nil, buyer)
+		s.sendRequest(http.MethodPost, listingsPath+"/"+listing.ID.String()+"/publish", http.StatusOK, nil, &listing, seller)
+		s.Require().Equal(entity.ListingStatusActive, listing.Status)
+		s.Require().NotNil(listing.PublishedAt)
+	})
+
+	s.Run("browse and search", func() {
+		var listings []entity.Listing
+
+		s.sendRequest(http.MethodGet, marketPath+"?category=outerwear&maxPrice=1500000", http.StatusOK, nil, &listings, buyer)
+		s.Require().Len(listings, 1)
+		s.Require().Equal(listing.ID, listings[0].ID)
+
+		s.sendRequest(http.MethodGet, marketPath+"?minPrice=2000000", http.StatusOK, nil, &listings, buyer)
+		s.Require().Empty(listings)
+
+		s.sendRequest(http.MethodGet, marketPath+"?minPrice=cheap", http.StatusBadRequest, nil, nil, buyer)
+
+		s.sendRequest(http.MethodGet, marketPath+"/search?q=mara", http.StatusOK, nil, &listings, buyer)
+		s.Require().Len(listings, 1)
+
+		s.sendRequest(http.MethodGet, marketPath+"/search?q=dress", http.StatusOK, nil, &listings, buyer)
+		s.Require().Empty(listings)
+
+		s.sendRequest(http.MethodGet, marketPath+"/search", http.StatusBadRequest, nil, nil, buyer)
+	})
+
+	s.Run("offers", func() {
+		var (
+			low, high entity.ListingOffer
+			offers    []entity.ListingOffer
+		)
+
+		offersPath := marketPath + "/" + listing.ID.String() + "/offers"
+
+		s.sendRequest(http.MethodPost, offersPath, http.StatusBadRequest, entity.ListingOffer{Amount: 0}, nil, buyer)
+		s.sendRequest(http.MethodPost, offersPath, http.StatusBadRequest, entity.ListingOffer{Amount: 1000}, nil, seller)
+
+		s.sendRequest(http.MethodPost, offersPath, http.StatusCreated, entity.ListingOffer{Amount: 1000000}, &low, bidder)
+		s.sendRequest(http.MethodPost, offersPath, http.StatusCreated,
+			entity.ListingOffer{Amount: 1300000, Message: utils.Pointer("Can pick up today")}, &high, buyer)
+		s.Require().Equal(entity.ListingOfferStatusPending, high.Status)
+
+		s.sendRequest(http.MethodPost, offersPath, http.StatusConflict, entity.ListingOffer{Amount: 1350000}, nil, buyer)
+
+		s.sendRequest(http.MethodGet, offersPath, http.StatusOK, nil, &offers, seller)
+		s.Require().Len(offers, 2)
+
+		s.sendRequest(http.MethodGet, offersPath, http.StatusOK, nil, &offers, buyer)
+		s.Require().Len(offers, 1)
+		s.Require().Equal(high.ID, offers[0].ID)
+
+		offerPath := listingsPath + "/" + listing.ID.String() + "/offers/"
+
+		s.sendRequest(http.MethodPost, offerPath+low.ID.String()+"/accept", http.StatusForbidden, nil, nil, bidder)
+		s.sendRequest(http.MethodPost, offerPath+low.ID.String()+"/decline", http.StatusOK, nil, &low, seller)
+		s.Require().Equal(entity.ListingOfferStatusDeclined, low.Status)
+		s.sendRequest(http.MethodPost, offerPath+low.ID.String()+"/accept", http.StatusConflict, nil, nil, seller)
+
+		s.sendRequest(http.MethodPost, offerPath+high.ID.String()+"/accept", http.StatusOK, nil, &high, seller)
+		s.Require().Equal(entity.ListingOfferStatusAccepted, high.Status)
+
+		s.sendRequest(http.MethodGet, marketPath+"/"+listing.ID.String(), http.StatusOK, nil, &listing, bidder)
+		s.Require().Equal(entity.ListingStatusReserved, listing.Status)
+		s.Require().Equal(buyer.UserID, *listing.BuyerID)
+
+		s.sendRequest(http.MethodPost, offersPath, http.StatusConflict, entity.ListingOffer{Amount: 1400000}, nil, bidder)
+		s.sendRequest(http.MethodPatch, listingsPath+"/"+listing.ID.String(), http.StatusConflict,
+			entity.ListingUpdate{Price: utils.Pointer(int64(1))}, nil, seller)
+
+		s.sendRequest(http.MethodGet, userPath+"/"+buyer.UserID.String()+"/offers", http.StatusOK, nil, &offers, buyer)
+		s.Require().Len(offers, 1)
+		s.sendRequest(http.MethodGet, userPath+"/"+buyer.UserID.String()+"/offers", http.StatusForbidden, nil, nil, bidder)
+	})
+
+	s.Run("sold archives the item", func() {
+		var items []entity.WardrobeItem
+
+		s.sendRequest(http.MethodPost, listingsPath+"/"+listing.ID.String()+"/sold", http.StatusOK, nil, &listing, seller)
+		s.Require().Equal(entity.ListingStatusSold, listing.Status)
+		s.Require().NotNil(listing.SoldAt)
+
+		wardrobePath := userPath + "/" + seller.UserID.String() + "/wardrobe"
+
+		s.sendRequest(http.MethodGet, wardrobePath, http.StatusOK, nil, &items, seller)
+		s.Require().Len(items, 1)
+		s.Require().Equal(scarf.ID, items[0].ID)
+
+		s.sendRequest(http.MethodGet, wardrobePath+"?archived=true", http.StatusOK, nil, &items, seller)
+		s.Require().Len(items, 2)
+
+		s.sendRequest(http.MethodPost, listingsPath, http.StatusNotFound,
+			entity.Listing{ItemID: coat.ID, Price: 1000, Condition: entity.ConditionGood}, nil, seller)
+		s.sendRequest(http.MethodPost, listingsPath+"/"+listing.ID.String()+"/withdraw", http.StatusConflict, nil, nil, seller)
+	})
+
+	s.Run("withdraw", func() {
+		var (
+			scarfListing entity.Listing
+			listings     []entity.Listing
+		)
+
+		s.sendRequest(http.MethodPost, listingsPath, http.StatusCreated,
+			entity.Listing{ItemID: scarf.ID, Title: "Silk scarf", Price: 300000, Condition: entity.ConditionNewWithTags},
+			&scarfListing, seller)
+		s.sendRequest(http.MethodPost, listingsPath+"/"+scarfListing.ID.String()+"/withdraw", http.StatusOK,
+			nil, &scarfListing, seller)
+		s.Require().Equal(entity.ListingStatusWithdrawn, scarfListing.Status)
+
+		s.sendRequest(http.MethodGet, listingsPath, http.StatusOK, nil, &listings, seller)
+		s.Require().Len(listings, 2)
+
+		s.sendRequest(http.MethodGet, listingsPath+"?status=sold", http.StatusOK, nil, &listings, seller)
+		s.Require().Len(listings, 1)
+		s.Require().Equal(listing.ID, listings[0].ID)
+
+		s.sendRequest(http.MethodGet, listingsPath+"?status=lost", http.StatusBadRequest, nil, nil, seller)
+
+		s.sendRequest(http.MethodPost, listingsPath, http.StatusCreated,
+			entity.Listing{ItemID: scarf.ID, Price: 250000, Condition: entity.ConditionNewWithTags}, nil, seller)
+	})
+}
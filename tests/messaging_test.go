@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestMessaging() {
+	ctx := context.Background()
+
+	seller := s.createUser("79031355655")
+	buyer := s.createUser("79031355656")
+	stranger := s.createUser("79031355657")
+
+	item := s.createWardrobeItem(seller, entity.WardrobeItem{Name: "Leather jacket", Category: "outerwear"})
+
+	draft, err := s.marketplaceService.CreateListing(ctx, seller.UserID, entity.Listing{
+		ItemID:    item.ID,
+		Price:     2000000,
+		Condition: entity.ConditionGood,
+	})
+	s.Require().NoError(err)
+
+	sellerPath := userPath + "/" + seller.UserID.String()
+	buyerPath := userPath + "/" + buyer.UserID.String()
+	conversationsPath := "/api/v1/listings/" + draft.ID.String() + "/conversations"
+
+	var conversation entity.Conversation
+
+	s.Run("start conversation", func() {
+		s.sendRequest(http.MethodPost, conversationsPath, http.StatusNotFound, nil, nil, buyer)
+
+		_, err := s.marketplaceService.PublishListing(ctx, seller.UserID, draft.ID)
+		s.Require().NoError(err)
+
+		s.sendRequest(http.MethodPost, conversationsPath, http.StatusBadRequest, nil, nil, seller)
+
+		s.sendRequest(http.MethodPost, conversationsPath, http.StatusOK, nil, &conversation, buyer)
+		s.Require().Equal(seller.UserID, conversation.SellerID)
+		s.Require().Equal(buyer.UserID, conversation.BuyerID)
+		s.Require().Nil(conversation.LastMessageAt)
+
+		var again entity.Conversation
+
+		s.sendRequest(http.MethodPost, conversationsPath, http.StatusOK, nil, &again, buyer)
+		s.Require().Equal(conversation.ID, again.ID)
+	})
+
+	var photo entity.File
+
+	data := s.sendRawRequest(http.MethodPost, buyerPath+"/files", http.StatusCreated, pngHeader, buyer)
+	s.Require().NoError(json.Unmarshal(data, &photo))
+
+	s.Run("send and list messages", func() {
+		buyerMessages := buyerPath + "/conversations/" + conversation.ID.String() + "/messages"
+		sellerMessages := sellerPath + "/conversations/" + conversation.ID.String() + "/messages"
+
+		s.sendRequest(http.MethodPost, buyerMessages, http.StatusBadRequest, entity.Message{Body: utils.Pointer(" ")}, nil, buyer)
+		s.sendRequest(http.MethodPost, buyerMessages, http.StatusForbidden,
+			entity.Message{PhotoIDs: []entity.FileID{photo.ID}}, nil, seller)
+		s.sendRequest(http.MethodPost, sellerMessages, http.StatusBadRequest,
+			entity.Message{PhotoIDs: []entity.FileID{photo.ID}}, nil, seller)
+		s.sendRequest(http.MethodPost, userPath+"/"+stranger.UserID.String()+"/conversations/"+conversation.ID.String()+"/messages",
+			http.StatusNotFound, entity.Message{Body: utils.Pointer("Hi")}, nil, stranger)
+
+		var message entity.Message
+
+		for _, body := range []string{"Hi!", "Is it still available?"} {
+			s.sendRequest(http.MethodPost, buyerMessages, http.StatusCreated, entity.Message{Body: utils.Pointer(body)}, &message, buyer)
+		}
+
+		s.sendRequest(http.MethodPost, buyerMessages, http.StatusCreated,
+			entity.Message{PhotoIDs: []entity.FileID{photo.ID}}, &message, buyer)
+		s.Require().Equal([]entity.FileID{photo.ID}, message.PhotoIDs)
+
+		var photoURL entity.FileURL
+
+		s.sendRequest(http.MethodGet, sellerMessages+"/"+message.ID.String()+"/photos/1/url", http.StatusOK, nil, &photoURL, seller)
+		s.Require().NotEmpty(photoURL.URL)
+		s.sendRequest(http.MethodGet, sellerMessages+"/"+message.ID.String()+"/photos/2/url", http.StatusNotFound, nil, nil, seller)
+
+		var page entity.MessagePage
+
+		s.sendRequest(http.MethodGet, sellerMessages+"?limit=2", http.StatusOK, nil, &page, seller)
+		s.Require().Len(page.Messages, 2)
+		s.Require().Equal(message.ID, page.Messages[0].ID)
+		s.Require().NotNil(page.NextCursor)
+
+		s.sendRequest(http.MethodGet, sellerMessages+"?limit=2&cursor="+*page.NextCursor, http.StatusOK, nil, &page, seller)
+		s.Require().Len(page.Messages, 1)
+		s.Require().Equal("Hi!", *page.Messages[0].Body)
+		s.Require().Nil(page.NextCursor)
+
+		s.sendRequest(http.MethodGet, sellerMessages+"?cursor=broken", http.StatusBadRequest, nil, nil, seller)
+	})
+
+	s.Run("read receipts", func() {
+		var (
+			count entity.UnreadCount
+			read  entity.Conversation
+			page  entity.MessagePage
+		)
+
+		s.sendRequest(http.MethodGet, sellerPath+"/conversations/unread", http.StatusOK, nil, &count, seller)
+		s.Require().Equal(entity.UnreadCount{Conversations: 1, Messages: 3}, count)
+
+		s.sendRequest(http.MethodGet, buyerPath+"/conversations/unread", http.StatusOK, nil, &count, buyer)
+		s.Require().Equal(entity.UnreadCount{}, count)
+
+		var conversations []entity.Conversation
+
+		s.sendRequest(http.MethodGet, sellerPath+"/conversations", http.StatusOK, nil, &conversations, seller)
+		s.Require().Len(conversations, 1)
+		s.Require().Equal(3, conversations[0].UnreadCount)
+		s.Require().NotNil(conversations[0].LastMessageAt)
+
+		s.sendRequest(http.MethodPost, sellerPath+"/conversations/"+conversation.ID.String()+"/read", http.StatusOK,
+			nil, &read, seller)
+		s.Require().Zero(read.UnreadCount)
+
+		s.sendRequest(http.MethodGet, buyerPath+"/conversations/"+conversation.ID.String()+"/messages", http.StatusOK,
+			nil, &page, buyer)
+		s.Require().Len(page.Messages, 3)
+
+		for _, message := range page.Messages {
+			s.Require().NotNil(message.ReadAt)
+		}
+
+		s.sendRequest(http.MethodGet, sellerPath+"/conversations/unread", http.StatusOK, nil, &count, seller)
+		s.Require().Equal(entity.UnreadCount{}, count)
+
+		s.sendRequest(http.MethodGet, sellerPath+"/conversations/unread", http.StatusForbidden, nil, nil, buyer)
+	})
+
+	s.Run("blocks", func() {
+		var (
+			block  entity.Block
+			blocks []entity.Block
+		)
+
+		messagesPath := sellerPath + "/conversations/" + conversation.ID.String() + "/messages"
+
+		s.sendRequest(http.MethodPost, sellerPath+"/blocks", http.StatusBadRequest,
+			entity.Block{BlockedID: seller.UserID}, nil, seller)
+		s.sendRequest(http.MethodPost, sellerPath+"/blocks", http.StatusCreated,
+			entity.Block{BlockedID: buyer.UserID}, &block, seller)
+		s.Require().Equal(buyer.UserID, block.BlockedID)
+		s.sendRequest(http.MethodPost, sellerPath+"/blocks", http.StatusCreated,
+			entity.Block{BlockedID: buyer.UserID}, nil, seller)
+
+		s.sendRequest(http.MethodGet, sellerPath+"/blocks", http.StatusOK, nil, &blocks, seller)
+		s.Require().Len(blocks, 1)
+
+		s.sendRequest(http.MethodPost, messagesPath, http.StatusForbidden, entity.Message{Body: utils.Pointer("Sold")}, nil, seller)
+		s.sendRequest(http.MethodPost, buyerPath+"/conversations/"+conversation.ID.String()+"/messages", http.StatusForbidden,
+			entity.Message{Body: utils.Pointer("Hello?")}, nil, buyer)
+
+		s.sendRequest(http.MethodDelete, sellerPath+"/blocks/"+buyer.UserID.String(), http.StatusNoContent, nil, nil, seller)
+		s.sendRequest(http.MethodDelete, sellerPath+"/blocks/"+buyer.UserID.String(), http.StatusNotFound, nil, nil, seller)
+
+		s.sendRequest(http.MethodPost, messagesPath, http.StatusCreated, entity.Message{Body: utils.Pointer("Sorry")}, nil, seller)
+	})
+}
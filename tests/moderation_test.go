@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+const adminPath = "/api/v1/admin"
+
+func (s *IntegrationTestSuite) TestModeration() {
+	author := s.createUser("79031355597")
+	reporter := s.createUser("79031355598")
+
+	admin := s.createUser("79031355599")
+	admin.Role = entity.RoleAdmin
+
+	var post entity.Post
+
+	s.sendRequest(http.MethodPost, userPath+"/"+author.UserID.String()+"/posts", http.StatusCreated,
+		entity.Post{OutfitID: s.createOutfit(author, "Friday").ID}, &post, author)
+
+	postPath := userPath + "/" + author.UserID.String() + "/posts/" + post.ID.String()
+
+	var comment entity.Comment
+
+	s.sendRequest(http.MethodPost, postPath+"/comments", http.StatusCreated,
+		entity.Comment{Body: utils.Pointer("Buy followers cheap")}, &comment, author)
+
+	reportsPath := userPath + "/" + reporter.UserID.String() + "/reports"
+
+	report := func(targetType entity.ReportTargetType, targetID uuid.UUID, status int) entity.Report {
+		var created entity.Report
+
+		s.sendRequest(http.MethodPost, reportsPath, status, entity.Report{
+			TargetType: targetType,
+			TargetID:   targetID,
+			Reason:     entity.ReportReasonSpam,
+		}, &created, reporter)
+
+		return created
+	}
+
+	var postReport, commentReport, userReport entity.Report
+
+	s.Run("report", func() {
+		s.sendRequest(http.MethodPost, reportsPath, http.StatusForbidden,
+			entity.Report{TargetType: entity.ReportTargetPost, TargetID: uuid.UUID(post.ID), Reason: entity.ReportReasonSpam}, nil, author)
+		s.sendRequest(http.MethodPost, reportsPath, http.StatusBadRequest,
+			entity.Report{TargetType: entity.ReportTargetPost, TargetID: uuid.UUID(post.ID), Reason: "boring"}, nil, reporter)
+
+		report(entity.ReportTargetPost, uuid.New(), http.StatusBadRequest)
+		report(entity.ReportTargetUser, uuid.UUID(reporter.UserID), http.StatusBadRequest)
+
+		postReport = report(entity.ReportTargetPost, uuid.UUID(post.ID), http.StatusCreated)
+		s.Require().Equal(entity.ReportStatusOpen, postReport.Status)
+
+		report(entity.ReportTargetPost, uuid.UUID(post.ID), http.StatusConflict)
+
+		commentReport = report(entity.ReportTargetComment, uuid.UUID(comment.ID), http.StatusCreated)
+		userReport = report(entity.ReportTargetUser, uuid.UUID(author.UserID), http.StatusCreated)
+	})
+
+	s.Run("queue requires admin", func() {
+		s.sendRequest(http.MethodGet, adminPath+"/reports", http.StatusForbidden, nil, nil, reporter)
+		s.sendRequest(http.MethodPost, adminPath+"/posts/"+post.ID.String()+"/hide", http.StatusForbidden, nil, nil, reporter)
+	})
+
+	s.Run("queue", func() {
+		var ids []entity.ReportID
+
+		query := url.Values{"limit": {"2"}}
+
+		for {
+			var page entity.ReportPage
+
+			s.sendRequest(http.MethodGet, adminPath+"/reports?"+query.Encode(), http.StatusOK, nil, &page, admin)
+
+			for _, r := range page.Reports {
+				ids = append(ids, r.ID)
+			}
+
+			if page.NextCursor == nil {
+				break
+			}
+
+			query.Set("cursor", *page.NextCursor)
+		}
+
+		s.Require().Equal([]entity.ReportID{postReport.ID, commentReport.ID, userReport.ID}, ids)
+
+		var page entity.ReportPage
+
+		s.sendRequest(http.MethodGet, adminPath+"/reports?targetType=comment", http.StatusOK, nil, &page, admin)
+		s.Require().Len(page.Reports, 1)
+		s.Require().Equal(commentReport.ID, page.Reports[0].ID)
+
+		s.sendRequest(http.MethodGet, adminPath+"/reports?status=closed", http.StatusBadRequest, nil, nil, admin)
+	})
+
+	s.Run("dismiss report", func() {
+		var dismissed entity.Report
+
+		s.sendRequest(http.MethodPost, adminPath+"/reports/"+commentReport.ID.String()+"/dismiss", http.StatusOK,
+			entity.ModerationRequest{Note: utils.Pointer("just bad taste")}, &dismissed, admin)
+		s.Require().Equal(entity.ReportStatusDismissed, dismissed.Status)
+		s.Require().Equal(admin.UserID, *dismissed.ResolvedBy)
+
+		s.sendRequest(http.MethodPost, adminPath+"/reports/"+commentReport.ID.String()+"/dismiss", http.StatusNotFound, nil, nil, admin)
+	})
+
+	s.Run("hide content", func() {
+		s.sendRequest(http.MethodPost, adminPath+"/comments/"+comment.ID.String()+"/hide", http.StatusNoContent, nil, nil, admin)
+
+		var comments entity.CommentPage
+
+		s.sendRequest(http.MethodGet, postPath+"/comments", http.StatusOK, nil, &comments, reporter)
+		s.Require().True(comments.Comments[0].Deleted)
+		s.Require().Nil(comments.Comments[0].Body)
+
+		s.sendRequest(http.MethodPatch, postPath+"/comments/"+comment.ID.String(), http.StatusNotFound,
+			entity.CommentUpdate{Body: "Edited"}, nil, author)
+
+		s.sendRequest(http.MethodPost, adminPath+"/posts/"+post.ID.String()+"/hide", http.StatusNoContent, nil, nil, admin)
+		s.sendRequest(http.MethodPost, adminPath+"/posts/"+post.ID.String()+"/hide", http.StatusNotFound, nil, nil, admin)
+
+		s.sendRequest(http.MethodGet, postPath, http.StatusNotFound, nil, nil, author)
+
+		var page entity.ReportPage
+
+		s.sendRequest(http.MethodGet, adminPath+"/reports?status=resolved", http.StatusOK, nil, &page, admin)
+		s.Require().Len(page.Reports, 1)
+		s.Require().Equal(postReport.ID, page.Reports[0].ID)
+	})
+
+	s.Run("suspend user", func() {
+		s.sendRequest(http.MethodPost, adminPath+"/users/"+admin.UserID.String()+"/suspend", http.StatusForbidden, nil, nil, admin)
+		s.sendRequest(http.MethodPost, adminPath+"/users/"+uuid.NewString()+"/suspend", http.StatusNotFound, nil, nil, admin)
+
+		s.sendRequest(http.MethodPost, adminPath+"/users/"+author.UserID.String()+"/suspend", http.StatusNoContent,
+			entity.ModerationRequest{Note: utils.Pointer("spam")}, nil, admin)
+
+		s.sendRequest(http.MethodGet, userPath+"/"+author.UserID.String(), http.StatusForbidden, nil, nil, author)
+
+		tokens := entity.Tokens{RefreshToken: s.getToken(author)}
+
+		s.sendRequest(http.MethodPost, userPath+"/"+author.UserID.String()+"/refresh", http.StatusForbidden, tokens, nil, author)
+
+		s.sendRequest(http.MethodPost, adminPath+"/users/"+author.UserID.String()+"/unsuspend", http.StatusNoContent, nil, nil, admin)
+		s.sendRequest(http.MethodGet, userPath+"/"+author.UserID.String(), http.StatusOK, nil, nil, author)
+		s.sendRequest(http.MethodPost, userPath+"/"+author.UserID.String()+"/refresh", http.StatusOK, tokens, nil, author)
+
+		var page entity.ReportPage
+
+		s.sendRequest(http.MethodGet, adminPath+"/reports", http.StatusOK, nil, &page, admin)
+		s.Require().Empty(page.Reports)
+	})
+
+	s.Run("audit log", func() {
+		var log entity.AuditLogPage
+
+		s.sendRequest(http.MethodGet, adminPath+"/audit-log", http.StatusOK, nil, &log, admin)
+
+		actions := make([]entity.ModerationAction, 0, len(log.Entries))
+		for _, entry := range log.Entries {
+			actions = append(actions, entry.Action)
+			s.Require().Equal(admin.UserID, entry.ModeratorID)
+		}
+
+		s.Require().Equal([]entity.ModerationAction{
+			entity.ModerationActionUnsuspendUser,
+			entity.ModerationActionSuspendUser,
+			entity.ModerationActionHidePost,
+			entity.ModerationActionHideComment,
+			entity.ModerationActionDismissReport,
+		}, actions)
+		s.Require().Equal("spam", *log.Entries[1].Note)
+
+		s.sendRequest(http.MethodGet, adminPath+"/audit-log", http.StatusForbidden, nil, nil, reporter)
+	})
+}
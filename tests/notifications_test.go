@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	notificationsservice "github.com/romanpitatelev/clothing-service/internal/usecase/notifications-service"
+)
+
+func (s *IntegrationTestSuite) TestNotifications() {
+	author := s.createUser("79031355660")
+	follower := s.createUser("79031355661")
+	fan := s.createUser("79031355662")
+
+	authorPath := userPath + "/" + author.UserID.String()
+	notificationsPath := authorPath + "/notifications"
+	settingsPath := authorPath + "/notification-settings"
+
+	follow := func(user entity.User) {
+		s.sendRequest(http.MethodPut, userPath+"/"+user.UserID.String()+"/following/"+author.UserID.String(),
+			http.StatusNoContent, nil, nil, user)
+	}
+
+	s.Run("default settings", func() {
+		var settings entity.NotificationSettings
+
+		s.sendRequest(http.MethodGet, settingsPath, http.StatusOK, nil, &settings, author)
+		s.Require().Len(settings.Preferences, len(entity.NotificationCategories))
+		s.Require().Equal(entity.DefaultNotificationPreference(entity.NotificationCategorySocial),
+			settings.Preference(entity.NotificationCategorySocial))
+		s.Require().False(settings.Preference(entity.NotificationCategoryMarketing).InApp)
+		s.Require().Nil(settings.QuietHours)
+
+		s.sendRequest(http.MethodGet, settingsPath, http.StatusForbidden, nil, nil, follower)
+	})
+
+	s.Run("in-app notifications", func() {
+		var (
+			notifications []entity.Notification
+			notification  entity.Notification
+		)
+
+		follow(follower)
+		follow(follower)
+
+		s.sendRequest(http.MethodGet, notificationsPath, http.StatusOK, nil, &notifications, author)
+		s.Require().Len(notifications, 1)
+		s.Require().Equal(entity.NotificationCategorySocial, notifications[0].Category)
+		s.Require().Nil(notifications[0].ReadAt)
+
+		s.sendRequest(http.MethodPost, notificationsPath+"/"+notifications[0].ID.String()+"/read", http.StatusNotFound,
+			nil, nil, follower)
+		s.sendRequest(http.MethodPost, userPath+"/"+follower.UserID.String()+"/notifications/"+notifications[0].ID.String()+"/read",
+			http.StatusNotFound, nil, nil, follower)
+
+		s.sendRequest(http.MethodPost, notificationsPath+"/"+notifications[0].ID.String()+"/read", http.StatusOK,
+			nil, &notification, author)
+		s.Require().NotNil(notification.ReadAt)
+
+		follow(fan)
+
+		s.sendRequest(http.MethodGet, notificationsPath+"?unread=true", http.StatusOK, nil, &notifications, author)
+		s.Require().Len(notifications, 1)
+
+		s.sendRequest(http.MethodGet, notificationsPath+"?unread=maybe", http.StatusBadRequest, nil, nil, author)
+
+		s.sendRequest(http.MethodPost, notificationsPath+"/read", http.StatusNoContent, nil, nil, author)
+
+		s.sendRequest(http.MethodGet, notificationsPath+"?unread=true", http.StatusOK, nil, &notifications, author)
+		s.Require().Empty(notifications)
+	})
+
+	s.Run("update settings", func() {
+		s.sendRequest(http.MethodPut, settingsPath, http.StatusBadRequest, entity.NotificationSettings{
+			Preferences: []entity.NotificationPreference{{Category: "gossip", InApp: true}},
+		}, nil, author)
+		s.sendRequest(http.MethodPut, settingsPath, http.StatusBadRequest, entity.NotificationSettings{
+			QuietHours: &entity.QuietHours{Start: "25:00", End: "08:00"},
+		}, nil, author)
+		s.sendRequest(http.MethodPut, settingsPath, http.StatusBadRequest, entity.NotificationSettings{
+			QuietHours: &entity.QuietHours{Start: "22:00", End: "08:00", TimeZone: "Mars/Olympus"},
+		}, nil, author)
+
+		var settings entity.NotificationSettings
+
+		s.sendRequest(http.MethodPut, settingsPath, http.StatusOK, entity.NotificationSettings{
+			Preferences: []entity.NotificationPreference{
+				{Category: entity.NotificationCategorySocial, SMS: true},
+			},
+			QuietHours: &entity.QuietHours{Start: "22:00", End: "08:00"},
+		}, &settings, author)
+		s.Require().Len(settings.Preferences, len(entity.NotificationCategories))
+		s.Require().Equal(entity.DefaultTimeZone, settings.QuietHours.TimeZone)
+
+		s.sendRequest(http.MethodGet, settingsPath, http.StatusOK, nil, &settings, author)
+		s.Require().Equal(entity.NotificationPreference{Category: entity.NotificationCategorySocial, SMS: true},
+			settings.Preference(entity.NotificationCategorySocial))
+		s.Require().Equal(&entity.QuietHours{Start: "22:00", End: "08:00", TimeZone: entity.DefaultTimeZone},
+			settings.QuietHours)
+	})
+
+	s.Run("quiet hours hold texts back", func() {
+		var notifications []entity.Notification
+
+		now := time.Now().UTC()
+
+		s.sendRequest(http.MethodPut, settingsPath, http.StatusOK, entity.NotificationSettings{
+			Preferences: []entity.NotificationPreference{
+				{Category: entity.NotificationCategorySocial, SMS: true},
+			},
+			QuietHours: &entity.QuietHours{
+				Start:    now.Add(-time.Hour).Format("15:04"),
+				End:      now.Add(time.Hour).Format("15:04"),
+				TimeZone: "UTC",
+			},
+		}, nil, author)
+
+		s.sendRequest(http.MethodDelete, userPath+"/"+fan.UserID.String()+"/following/"+author.UserID.String(),
+			http.StatusNoContent, nil, nil, fan)
+		follow(fan)
+
+		s.sendRequest(http.MethodGet, notificationsPath, http.StatusOK, nil, &notifications, author)
+		s.Require().Len(notifications, 2)
+
+		s.Require().NoError(s.notificationsDispatcher.Check(context.Background()))
+
+		select {
+		case sms := <-s.smsChan:
+			s.FailNow("notification was texted during quiet hours", sms.text)
+		case <-time.After(500 * time.Millisecond):
+		}
+
+		s.sendRequest(http.MethodPut, settingsPath, http.StatusOK, entity.NotificationSettings{
+			Preferences: []entity.NotificationPreference{
+				{Category: entity.NotificationCategorySocial, SMS: true},
+			},
+		}, nil, author)
+
+		_, err := s.db.Exec(context.Background(), `UPDATE notification_deliveries SET deliver_after = NOW()`)
+		s.Require().NoError(err)
+
+		s.Require().NoError(s.notificationsDispatcher.Check(context.Background()))
+
+		select {
+		case sms := <-s.smsChan:
+			s.Require().Equal(author.Phone, sms.phone)
+			s.Require().Contains(sms.text, "Новый подписчик")
+		case <-time.After(5 * time.Second):
+			s.FailNow("notification was not texted")
+		}
+	})
+}
+
+func (s *IntegrationTestSuite) TestNotificationsDispatchedOnce() {
+	const deliveries = 30
+
+	user := s.createUser("79031355663")
+
+	for i := range deliveries {
+		err := s.notificationsRepo.CreateNotification(context.Background(), entity.Notification{
+			ID:       entity.NotificationID(uuid.New()),
+			UserID:   user.UserID,
+			Category: entity.NotificationCategorySocial,
+			Title:    fmt.Sprintf("Уведомление %d", i),
+		}, []entity.NotificationChannel{entity.NotificationChannelSMS})
+		s.Require().NoError(err)
+	}
+
+	var wg sync.WaitGroup
+
+	for range 2 {
+		dispatcher := notificationsservice.NewDispatcher(notificationsservice.DispatcherConfig{
+			Interval:    time.Minute,
+			BatchSize:   3,
+			MaxAttempts: 3,
+			BaseBackoff: time.Minute,
+			MaxBackoff:  time.Hour,
+			Lease:       time.Minute,
+		}, s.notificationsRepo, s.outboxRepo, s.db)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range deliveries {
+				s.NoError(dispatcher.Check(context.Background()))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	texts := make(map[string]int)
+
+	for range deliveries {
+		select {
+		case sms := <-s.smsChan:
+			s.Require().Equal(user.Phone, sms.phone)
+
+			texts[sms.text]++
+		case <-time.After(5 * time.Second):
+			s.FailNow("notifications were not texted", "%d of %d texted", len(texts), deliveries)
+		}
+	}
+
+	select {
+	case sms := <-s.smsChan:
+		s.FailNow("notification was texted twice", sms.text)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	s.Require().Len(texts, deliveries)
+
+	var pending int
+
+	err := s.db.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM notification_deliveries WHERE status <> 'sent'`).Scan(&pending)
+	s.Require().NoError(err)
+	s.Require().Zero(pending)
+}
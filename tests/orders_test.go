@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+const ordersCSV = `id;group_id;name;price;size;stock;available
+coat-m;coat;Trench coat;12000;M;2;true
+coat-l;coat;Trench coat;12000;L;1;true
+belt;;Leather belt;1500.50;;;true
+`
+
+func (s *IntegrationTestSuite) TestOrders() {
+	user := s.createUser("79031355620")
+	buyer := s.createUser("79031355621")
+	rival := s.createUser("79031355622")
+
+	admin := s.createUser("79031355623")
+	admin.Role = entity.RoleAdmin
+
+	cartPath := func(user entity.User) string {
+		return userPath + "/" + user.UserID.String() + "/cart"
+	}
+
+	ordersPath := func(user entity.User) string {
+		return userPath + "/" + user.UserID.String() + "/orders"
+	}
+
+	_, err := s.catalogService.Import(context.Background(), "store", entity.FeedFormatCSV, strings.NewReader(ordersCSV))
+	s.Require().NoError(err)
+
+	var page entity.ProductPage
+
+	s.sendRequest(http.MethodGet, productsPath+"?feed=store", http.StatusOK, nil, &page, user)
+	s.Require().Len(page.Products, 2)
+
+	belt, coat := page.Products[0], page.Products[1]
+	s.Require().Equal("coat", coat.ExternalID)
+
+	sizeOf := func(size string) entity.ProductSize {
+		var product entity.Product
+
+		s.sendRequest(http.MethodGet, productsPath+"/"+coat.ID.String(), http.StatusOK, nil, &product, user)
+
+		for _, productSize := range product.Sizes {
+			if productSize.Size == size {
+				return productSize
+			}
+		}
+
+		s.FailNow("size not found", size)
+
+		return entity.ProductSize{}
+	}
+
+	var coatItem, beltItem entity.CartItem
+
+	s.Run("fill the cart", func() {
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusBadRequest,
+			entity.CartItem{ProductID: coat.ID}, nil, user)
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusBadRequest,
+			entity.CartItem{ProductID: coat.ID, Size: utils.Pointer("XL")}, nil, user)
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusBadRequest,
+			entity.CartItem{ProductID: belt.ID, Size: utils.Pointer("M")}, nil, user)
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusNotFound,
+			entity.CartItem{ProductID: entity.ProductID(user.UserID)}, nil, user)
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusForbidden,
+			entity.CartItem{ProductID: belt.ID}, nil, buyer)
+
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusCreated,
+			entity.CartItem{ProductID: coat.ID, Size: utils.Pointer("M")}, &coatItem, user)
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusCreated,
+			entity.CartItem{ProductID: coat.ID, Size: utils.Pointer("M")}, &coatItem, user)
+		s.Require().Equal(2, coatItem.Quantity)
+		s.Require().True(coatItem.Available)
+
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusCreated,
+			entity.CartItem{ProductID: belt.ID}, &beltItem, user)
+
+		var cart entity.Cart
+
+		s.sendRequest(http.MethodGet, cartPath(user), http.StatusOK, nil, &cart, user)
+		s.Require().Len(cart.Items, 2)
+		s.Require().Equal(int64(2*1200000+150050), cart.Total)
+		s.Require().Equal(entity.DefaultCurrency, cart.Currency)
+	})
+
+	s.Run("update the cart", func() {
+		var item entity.CartItem
+
+		s.sendRequest(http.MethodPatch, cartPath(user)+"/items/"+coatItem.ID.String(), http.StatusOK,
+			entity.CartItemUpdate{Quantity: utils.Pointer(3)}, &item, user)
+		s.Require().Equal(3, item.Quantity)
+		s.Require().False(item.Available)
+
+		s.sendRequest(http.MethodPatch, cartPath(user)+"/items/"+coatItem.ID.String(), http.StatusOK,
+			entity.CartItemUpdate{Quantity: utils.Pointer(2)}, &item, user)
+
+		s.sendRequest(http.MethodPatch, cartPath(user)+"/items/"+beltItem.ID.String(), http.StatusBadRequest,
+			entity.CartItemUpdate{Size: utils.Pointer("L")}, nil, user)
+		s.sendRequest(http.MethodPatch, cartPath(user)+"/items/"+coatItem.ID.String(), http.StatusBadRequest,
+			entity.CartItemUpdate{Quantity: utils.Pointer(0)}, nil, user)
+		s.sendRequest(http.MethodPatch, cartPath(user)+"/items/"+entity.CartItemID{}.String(), http.StatusNotFound,
+			entity.CartItemUpdate{Quantity: utils.Pointer(1)}, nil, user)
+	})
+
+	var order entity.Order
+
+	s.Run("checkout reserves stock", func() {
+		s.sendRequest(http.MethodPost, ordersPath(user), http.StatusCreated, nil, &order, user)
+		s.Require().Equal(entity.OrderStatusCreated, order.Status)
+		s.Require().Equal(int64(2*1200000+150050), order.Total)
+		s.Require().Len(order.Items, 2)
+		s.Require().Equal("Trench coat", order.Items[0].Name)
+		s.Require().Equal("M", *order.Items[0].Size)
+		s.Require().Equal(2, order.Items[0].Quantity)
+		s.Require().Equal(int64(1200000), order.Items[0].Price)
+
+		var cart entity.Cart
+
+		s.sendRequest(http.MethodGet, cartPath(user), http.StatusOK, nil, &cart, user)
+		s.Require().Empty(cart.Items)
+
+		s.sendRequest(http.MethodPost, ordersPath(user), http.StatusBadRequest, nil, nil, user)
+
+		size := sizeOf("M")
+		s.Require().Equal(0, *size.Stock)
+		s.Require().False(size.Available)
+
+		s.sendRequest(http.MethodPost, cartPath(buyer)+"/items", http.StatusCreated,
+			entity.CartItem{ProductID: coat.ID, Size: utils.Pointer("M")}, nil, buyer)
+		s.sendRequest(http.MethodPost, ordersPath(buyer), http.StatusConflict, nil, nil, buyer)
+	})
+
+	s.Run("concurrent checkouts do not oversell", func() {
+		for _, customer := range []entity.User{buyer, rival} {
+			var cart entity.Cart
+
+			s.sendRequest(http.MethodGet, cartPath(customer), http.StatusOK, nil, &cart, customer)
+
+			for _, item := range cart.Items {
+				s.sendRequest(http.MethodDelete, cartPath(customer)+"/items/"+item.ID.String(), http.StatusNoContent, nil, nil, customer)
+			}
+
+			s.sendRequest(http.MethodPost, cartPath(customer)+"/items", http.StatusCreated,
+				entity.CartItem{ProductID: coat.ID, Size: utils.Pointer("L")}, nil, customer)
+		}
+
+		var wg sync.WaitGroup
+
+		errs := make([]error, 2)
+
+		for i, customer := range []entity.User{buyer, rival} {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, errs[i] = s.ordersService.Checkout(context.Background(), customer.UserID, entity.CheckoutRequest{})
+			}()
+		}
+
+		wg.Wait()
+
+		succeeded := 0
+
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+
+				continue
+			}
+
+			s.Require().ErrorIs(err, entity.ErrOutOfStock)
+		}
+
+		s.Require().Equal(1, succeeded)
+		s.Require().Equal(0, *sizeOf("L").Stock)
+	})
+
+	s.Run("cancel releases stock", func() {
+		s.sendRequest(http.MethodPost, ordersPath(user)+"/"+order.ID.String()+"/cancel", http.StatusOK, nil, &order, user)
+		s.Require().Equal(entity.OrderStatusCancelled, order.Status)
+
+		size := sizeOf("M")
+		s.Require().Equal(2, *size.Stock)
+		s.Require().True(size.Available)
+
+		s.sendRequest(http.MethodPost, ordersPath(user)+"/"+order.ID.String()+"/cancel", http.StatusConflict, nil, nil, user)
+		s.sendRequest(http.MethodPost, ordersPath(user)+"/"+order.ID.String()+"/cancel", http.StatusForbidden, nil, nil, buyer)
+	})
+
+	s.Run("order status state machine", func() {
+		s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusCreated,
+			entity.CartItem{ProductID: belt.ID}, nil, user)
+		s.sendRequest(http.MethodPost, ordersPath(user), http.StatusCreated, nil, &order, user)
+
+		statusPath := adminPath + "/orders/" + order.ID.String() + "/status"
+
+		s.sendRequest(http.MethodPost, statusPath, http.StatusForbidden,
+			entity.OrderStatusUpdate{Status: entity.OrderStatusPaid}, nil, user)
+		s.sendRequest(http.MethodPost, statusPath, http.StatusBadRequest,
+			entity.OrderStatusUpdate{Status: "lost"}, nil, admin)
+		s.sendRequest(http.MethodPost, statusPath, http.StatusConflict,
+			entity.OrderStatusUpdate{Status: entity.OrderStatusShipped}, nil, admin)
+
+		for _, status := range []entity.OrderStatus{entity.OrderStatusPaid, entity.OrderStatusShipped, entity.OrderStatusDelivered} {
+			s.sendRequest(http.MethodPost, statusPath, http.StatusOK, entity.OrderStatusUpdate{Status: status}, &order, admin)
+			s.Require().Equal(status, order.Status)
+		}
+
+		s.sendRequest(http.MethodPost, statusPath, http.StatusConflict,
+			entity.OrderStatusUpdate{Status: entity.OrderStatusCancelled}, nil, admin)
+		s.sendRequest(http.MethodPost, adminPath+"/orders/"+entity.OrderID{}.String()+"/status", http.StatusNotFound,
+			entity.OrderStatusUpdate{Status: entity.OrderStatusPaid}, nil, admin)
+	})
+
+	s.Run("list orders", func() {
+		var orders []entity.Order
+
+		s.sendRequest(http.MethodGet, ordersPath(user), http.StatusOK, nil, &orders, user)
+		s.Require().Len(orders, 2)
+		s.Require().Equal(entity.OrderStatusDelivered, orders[0].Status)
+
+		s.sendRequest(http.MethodGet, ordersPath(user)+"?status=cancelled", http.StatusOK, nil, &orders, user)
+		s.Require().Len(orders, 1)
+
+		s.sendRequest(http.MethodGet, ordersPath(user)+"?status=lost", http.StatusBadRequest, nil, nil, user)
+		s.sendRequest(http.MethodGet, ordersPath(user)+"/"+order.ID.String(), http.StatusOK, nil, &order, user)
+		s.sendRequest(http.MethodGet, ordersPath(buyer)+"/"+order.ID.String(), http.StatusNotFound, nil, nil, buyer)
+	})
+}
@@ -12,6 +12,7 @@ type otpResp struct {
 	phone  string
 	otp    string
 	sender string
+	text   string
 }
 
 func (s *IntegrationTestSuite) runServer(ctx context.Context, bindAddr string) {
@@ -33,6 +34,7 @@ func (s *IntegrationTestSuite) runServer(ctx context.Context, bindAddr string) {
 					phone:  r.URL.Query().Get("number"),
 					otp:    smsregistrationrepo.ExtractOTP(r.URL.Query().Get("text")),
 					sender: r.URL.Query().Get("sign"),
+					text:   r.URL.Query().Get("text"),
 				}
 			}()
 		}),
@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestOutbox() {
+	user := entity.User{
+		Phone: "79031355663",
+	}
+
+	status := func(messageID uuid.UUID) (entity.OutboxStatus, int, string) {
+		var (
+			status   entity.OutboxStatus
+			attempts int
+			payload  string
+		)
+
+		err := s.db.QueryRow(context.Background(),
+			`SELECT status, attempts, payload::text FROM outbox_messages WHERE id = $1`, messageID).
+			Scan(&status, &attempts, &payload)
+		s.Require().NoError(err)
+
+		return status, attempts, payload
+	}
+
+	s.Run("otp is relayed after registration commits", func() {
+		s.sendRequest(http.MethodPost, userPath+"/register", http.StatusOK, user, &user, entity.User{})
+
+		select {
+		case sms := <-s.smsChan:
+			s.Require().Equal(user.Phone, sms.phone)
+		case <-time.After(5 * time.Second):
+			s.FailNow("otp was not texted")
+		}
+
+		var messageID uuid.UUID
+
+		err := s.db.QueryRow(context.Background(),
+			`SELECT id FROM outbox_messages WHERE kind = $1`, entity.OutboxKindOTP).Scan(&messageID)
+		s.Require().NoError(err)
+
+		s.Require().Eventually(func() bool {
+			messageStatus, attempts, payload := status(messageID)
+
+			return messageStatus == entity.OutboxStatusSent && attempts == 1 && payload == "{}"
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	s.Run("failed registration enqueues nothing", func() {
+		s.sendRequest(http.MethodPost, userPath+"/register", http.StatusConflict, user, nil, entity.User{})
+
+		var count int
+
+		err := s.db.QueryRow(context.Background(), `SELECT COUNT(*) FROM outbox_messages`).Scan(&count)
+		s.Require().NoError(err)
+		s.Require().Equal(1, count)
+	})
+
+	s.Run("unknown kind is dead-lettered", func() {
+		messageID := uuid.New()
+
+		_, err := s.db.Exec(context.Background(),
+			`INSERT INTO outbox_messages (id, kind, payload) VALUES ($1, 'carrier.pigeon', '{}')`, messageID)
+		s.Require().NoError(err)
+
+		s.Require().Eventually(func() bool {
+			messageStatus, _, _ := status(messageID)
+
+			return messageStatus == entity.OutboxStatusDead
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	s.Run("expired otp is not texted", func() {
+		message := entity.NewOutboxMessage(entity.OutboxKindOTP, entity.OTPPayload{
+			Phone:     user.Phone,
+			OTP:       "1234",
+			ExpiresAt: time.Now().Add(-time.Second),
+		})
+		s.Require().NoError(s.outboxRepo.Enqueue(context.Background(), message))
+
+		s.Require().Eventually(func() bool {
+			messageStatus, _, payload := status(uuid.UUID(message.ID))
+
+			return messageStatus == entity.OutboxStatusDead && payload == "{}"
+		}, 5*time.Second, 50*time.Millisecond)
+
+		select {
+		case sms := <-s.smsChan:
+			s.FailNow("expired otp was texted", sms.text)
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	s.Run("finished messages are deleted after retention", func() {
+		_, err := s.db.Exec(context.Background(), `UPDATE outbox_messages SET created_at = NOW() - INTERVAL '2 hours'`)
+		s.Require().NoError(err)
+
+		s.Require().Eventually(func() bool {
+			var count int
+
+			err := s.db.QueryRow(context.Background(), `SELECT COUNT(*) FROM outbox_messages`).Scan(&count)
+			s.Require().NoError(err)
+
+			return count == 0
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestOutfits() {
+	owner := s.createUser("79031355537")
+	stranger := s.createUser("79031355538")
+
+	outfitsPath := userPath + "/" + owner.UserID.String() + "/outfits"
+
+	shirt := s.createWardrobeItem(owner, entity.WardrobeItem{Name: "Shirt", Category: "tops"})
+	jeans := s.createWardrobeItem(owner, entity.WardrobeItem{Name: "Jeans", Category: "bottoms", Subcategory: utils.Pointer("jeans")})
+	boots := s.createWardrobeItem(owner, entity.WardrobeItem{Name: "Boots", Category: "shoes"})
+	foreign := s.createWardrobeItem(stranger, entity.WardrobeItem{Name: "Hat", Category: "accessories"})
+
+	var cover entity.File
+
+	data := s.sendRawRequest(http.MethodPost, userPath+"/"+owner.UserID.String()+"/files", http.StatusCreated, pngHeader, owner)
+	s.Require().NoError(json.Unmarshal(data, &cover))
+
+	outfit := entity.Outfit{
+		Name:         "Friday",
+		Occasions:    []string{"Office", "party", "office"},
+		Season:       utils.Pointer(entity.SeasonAutumn),
+		CoverPhotoID: &cover.ID,
+		Items: []entity.OutfitItem{
+			{Slot: entity.OutfitSlotTop, ItemID: shirt.ID},
+			{Slot: entity.OutfitSlotBottom, ItemID: jeans.ID},
+			{Slot: entity.OutfitSlotShoes, ItemID: boots.ID},
+		},
+	}
+
+	var created entity.Outfit
+
+	s.Run("create outfit for another user", func() {
+		s.sendRequest(http.MethodPost, outfitsPath, http.StatusForbidden, outfit, nil, stranger)
+	})
+
+	s.Run("create invalid outfit", func() {
+		invalid := outfit
+		invalid.Items = []entity.OutfitItem{
+			{Slot: entity.OutfitSlotTop, ItemID: shirt.ID},
+			{Slot: entity.OutfitSlotTop, ItemID: jeans.ID},
+		}
+
+		s.sendRequest(http.MethodPost, outfitsPath, http.StatusBadRequest, invalid, nil, owner)
+
+		invalid.Items = []entity.OutfitItem{{Slot: entity.OutfitSlotAccessories, ItemID: foreign.ID}}
+
+		s.sendRequest(http.MethodPost, outfitsPath, http.StatusBadRequest, invalid, nil, owner)
+
+		invalid = outfit
+		invalid.CoverPhotoID = utils.Pointer(entity.FileID(uuid.New()))
+
+		s.sendRequest(http.MethodPost, outfitsPath, http.StatusBadRequest, invalid, nil, owner)
+	})
+
+	s.Run("create outfit successfully", func() {
+		s.sendRequest(http.MethodPost, outfitsPath, http.StatusCreated, outfit, &created, owner)
+		s.Require().Equal(owner.UserID, created.UserID)
+		s.Require().Equal([]string{"office", "party"}, created.Occasions)
+		s.Require().Equal(outfit.Items, created.Items)
+		s.Require().Equal(cover.ID, *created.CoverPhotoID)
+	})
+
+	outfitPath := outfitsPath + "/" + created.ID.String()
+
+	s.Run("get and list outfits", func() {
+		var got entity.Outfit
+
+		s.sendRequest(http.MethodGet, outfitPath, http.StatusOK, nil, &got, owner)
+		s.Require().Equal(created.ID, got.ID)
+
+		s.sendRequest(http.MethodGet, outfitPath, http.StatusForbidden, nil, nil, stranger)
+
+		var outfits []entity.Outfit
+
+		s.sendRequest(http.MethodGet, outfitsPath+"?occasion=office", http.StatusOK, nil, &outfits, owner)
+		s.Require().Len(outfits, 1)
+
+		s.sendRequest(http.MethodGet, outfitsPath+"?occasion=wedding", http.StatusOK, nil, &outfits, owner)
+		s.Require().Empty(outfits)
+	})
+
+	s.Run("update outfit", func() {
+		var updated entity.Outfit
+
+		update := entity.OutfitUpdate{
+			Name:         utils.Pointer("Casual Friday"),
+			CoverPhotoID: utils.Pointer(entity.FileID(uuid.Nil)),
+			Items: &[]entity.OutfitItem{
+				{Slot: entity.OutfitSlotShoes, ItemID: boots.ID},
+				{Slot: entity.OutfitSlotTop, ItemID: shirt.ID},
+			},
+		}
+
+		s.sendRequest(http.MethodPatch, outfitPath, http.StatusOK, update, &updated, owner)
+		s.Require().Equal(*update.Name, updated.Name)
+		s.Require().Nil(updated.CoverPhotoID)
+		s.Require().Equal(*update.Items, updated.Items)
+	})
+
+	var duplicate entity.Outfit
+
+	s.Run("duplicate outfit", func() {
+		s.sendRequest(http.MethodPost, outfitPath+"/duplicate", http.StatusCreated, nil, &duplicate, owner)
+		s.Require().NotEqual(created.ID, duplicate.ID)
+		s.Require().Equal("Casual Friday (copy)", duplicate.Name)
+		s.Require().Len(duplicate.Items, 2)
+	})
+
+	s.Run("suggest outfits", func() {
+		var suggestions []entity.OutfitSuggestion
+
+		s.sendRequest(http.MethodGet, outfitsPath+"/suggestions", http.StatusForbidden, nil, nil, stranger)
+		s.sendRequest(http.MethodGet, outfitsPath+"/suggestions?minTemp=20&maxTemp=10", http.StatusBadRequest, nil, nil, owner)
+		s.sendRequest(http.MethodGet, outfitsPath+"/suggestions?occasion=casual&season=autumn", http.StatusOK, nil, &suggestions, owner)
+		s.Require().Len(suggestions, 1)
+		s.Require().Equal([]entity.OutfitItem{
+			{Slot: entity.OutfitSlotTop, ItemID: shirt.ID},
+			{Slot: entity.OutfitSlotBottom, ItemID: jeans.ID},
+			{Slot: entity.OutfitSlotShoes, ItemID: boots.ID},
+		}, suggestions[0].Items)
+	})
+
+	s.Run("deleting an item detaches it from outfits", func() {
+		s.sendRequest(http.MethodDelete, userPath+"/"+owner.UserID.String()+"/wardrobe/"+shirt.ID.String(),
+			http.StatusNoContent, nil, nil, owner)
+
+		var got entity.Outfit
+
+		s.sendRequest(http.MethodGet, outfitsPath+"/"+duplicate.ID.String(), http.StatusOK, nil, &got, owner)
+		s.Require().Equal([]entity.OutfitItem{{Slot: entity.OutfitSlotShoes, ItemID: boots.ID}}, got.Items)
+	})
+
+	s.Run("delete outfit", func() {
+		s.sendRequest(http.MethodDelete, outfitPath, http.StatusNoContent, nil, nil, owner)
+		s.sendRequest(http.MethodGet, outfitPath, http.StatusNotFound, nil, nil, owner)
+		s.sendRequest(http.MethodPost, outfitPath+"/duplicate", http.StatusNotFound, nil, nil, owner)
+	})
+}
+
+func (s *IntegrationTestSuite) createWardrobeItem(user entity.User, item entity.WardrobeItem) entity.WardrobeItem {
+	created, err := s.wardrobeService.CreateItem(context.Background(), user.UserID, item)
+	s.Require().NoError(err)
+
+	return created
+}
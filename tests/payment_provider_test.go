@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	ordershandler "github.com/romanpitatelev/clothing-service/internal/controller/rest/orders-handler"
+	yookassarepo "github.com/romanpitatelev/clothing-service/internal/repository/yookassa-repo"
+)
+
+const (
+	paymentShopID        = "shop"
+	paymentSecretKey     = "secret"
+	paymentWebhookSecret = "webhook-secret"
+	webhookPath          = "/api/v1/payments/webhook"
+)
+
+// paymentProvider fakes the YooKassa payment API. Payments wait for the
+// customer until the test confirms them.
+type paymentProvider struct {
+	mu       sync.Mutex
+	payments map[string]*yookassarepo.Payment
+	keys     map[string]string
+	captures int
+	refunds  int
+}
+
+func newPaymentProvider() *paymentProvider {
+	return &paymentProvider{
+		payments: make(map[string]*yookassarepo.Payment),
+		keys:     make(map[string]string),
+	}
+}
+
+func (p *paymentProvider) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /v3/payments", func(w http.ResponseWriter, r *http.Request) {
+		var request yookassarepo.PaymentRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if id, ok := p.keys[r.Header.Get("Idempotence-Key")]; ok {
+			writeJSON(w, p.payments[id])
+
+			return
+		}
+
+		id := uuid.NewString()
+		p.keys[r.Header.Get("Idempotence-Key")] = id
+		p.payments[id] = &yookassarepo.Payment{
+			ID:     id,
+			Status: "pending",
+			Amount: request.Amount,
+			Confirmation: &yookassarepo.Confirmation{
+				Type:            request.Confirmation.Type,
+				ConfirmationURL: "https://pay.example.com/confirm/" + id,
+			},
+			Metadata: request.Metadata,
+		}
+
+		writeJSON(w, p.payments[id])
+	})
+
+	mux.HandleFunc("POST /v3/payments/{id}/capture", func(w http.ResponseWriter, r *http.Request) {
+		p.settle(w, r.PathValue("id"), "succeeded")
+	})
+
+	mux.HandleFunc("POST /v3/payments/{id}/cancel", func(w http.ResponseWriter, r *http.Request) {
+		p.settle(w, r.PathValue("id"), "canceled")
+	})
+
+	mux.HandleFunc("POST /v3/refunds", func(w http.ResponseWriter, r *http.Request) {
+		var request yookassarepo.RefundRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		payment, ok := p.payments[request.PaymentID]
+		if !ok || payment.Status != "succeeded" {
+			http.Error(w, "payment cannot be refunded", http.StatusBadRequest)
+
+			return
+		}
+
+		p.refunds++
+
+		writeJSON(w, yookassarepo.Refund{
+			ID:        uuid.NewString(),
+			Status:    "succeeded",
+			PaymentID: payment.ID,
+			Amount:    request.Amount,
+		})
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if shopID, secretKey, ok := r.BasicAuth(); !ok || shopID != paymentShopID || secretKey != paymentSecretKey {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// settle captures or cancels a held payment. Settling it again the same way
+// returns the payment as it is, like the real API does for retried requests.
+func (p *paymentProvider) settle(w http.ResponseWriter, id, status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[id]
+	if !ok {
+		http.Error(w, "payment not found", http.StatusNotFound)
+
+		return
+	}
+
+	if payment.Status != "waiting_for_capture" && payment.Status != status {
+		http.Error(w, "payment is "+payment.Status, http.StatusBadRequest)
+
+		return
+	}
+
+	if payment.Status != status && status == "succeeded" {
+		p.captures++
+	}
+
+	payment.Status = status
+	payment.Paid = status == "succeeded"
+
+	writeJSON(w, payment)
+}
+
+// confirm plays the customer confirming the payment: the money is held and
+// the provider's notification about it is returned.
+func (p *paymentProvider) confirm(id string) yookassarepo.Notification {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment := p.payments[id]
+	payment.Status = "waiting_for_capture"
+
+	return paymentNotification("payment.waiting_for_capture", payment)
+}
+
+// capture plays the provider capturing a payment on its own, as it does for
+// payments created without a hold, and returns its notification.
+func (p *paymentProvider) capture(id string) yookassarepo.Notification {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment := p.payments[id]
+	payment.Status = "succeeded"
+	payment.Paid = true
+	p.captures++
+
+	return paymentNotification("payment.succeeded", payment)
+}
+
+func (p *paymentProvider) get(id string) yookassarepo.Payment {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return *p.payments[id]
+}
+
+func (p *paymentProvider) captureCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.captures
+}
+
+func (p *paymentProvider) refundCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.refunds
+}
+
+func paymentNotification(event string, object any) yookassarepo.Notification {
+	data, _ := json.Marshal(object)
+
+	return yookassarepo.Notification{
+		Type:   "notification",
+		Event:  event,
+		Object: data,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func (s *IntegrationTestSuite) runPaymentServer(ctx context.Context, bindAddr string) {
+	//nolint:gosec
+	server := &http.Server{
+		Addr:    bindAddr,
+		Handler: s.paymentProvider.handler(),
+	}
+	go func() {
+		<-ctx.Done()
+
+		_ = server.Close()
+	}()
+
+	err := server.ListenAndServe()
+	s.Require().ErrorIs(err, http.ErrServerClosed)
+}
+
+// sendWebhook posts a payment notification signed with the given secret.
+func (s *IntegrationTestSuite) sendWebhook(notification yookassarepo.Notification, secret string, status int) {
+	body, err := json.Marshal(notification)
+	s.Require().NoError(err)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		fmt.Sprintf("http://localhost:%d%s", port, webhookPath), bytes.NewReader(body))
+	s.Require().NoError(err)
+
+	request.Header.Set(ordershandler.SignatureHeader, yookassarepo.Sign(secret, body))
+
+	response, err := http.DefaultClient.Do(request)
+	s.Require().NoError(err)
+	s.Require().NoError(response.Body.Close())
+	s.Require().Equal(status, response.StatusCode, "unexpected status code")
+}
@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	yookassarepo "github.com/romanpitatelev/clothing-service/internal/repository/yookassa-repo"
+)
+
+const paymentsCSV = `id;group_id;name;price;size;stock;available
+belt;;Leather belt;1500.50;;;true
+`
+
+func (s *IntegrationTestSuite) TestPayments() {
+	user := s.createUser("79031355630")
+	other := s.createUser("79031355631")
+
+	admin := s.createUser("79031355632")
+	admin.Role = entity.RoleAdmin
+
+	ordersPath := userPath + "/" + user.UserID.String() + "/orders"
+
+	_, err := s.catalogService.Import(context.Background(), "store", entity.FeedFormatCSV, strings.NewReader(paymentsCSV))
+	s.Require().NoError(err)
+
+	var page entity.ProductPage
+
+	s.sendRequest(http.MethodGet, productsPath+"?feed=store", http.StatusOK, nil, &page, user)
+	s.Require().Len(page.Products, 1)
+
+	placeOrder := func() entity.Order {
+		var order entity.Order
+
+		s.sendRequest(http.MethodPost, userPath+"/"+user.UserID.String()+"/cart/items", http.StatusCreated,
+			entity.CartItem{ProductID: page.Products[0].ID}, nil, user)
+		s.sendRequest(http.MethodPost, ordersPath, http.StatusCreated, nil, &order, user)
+
+		return order
+	}
+
+	getOrder := func(orderID entity.OrderID) entity.Order {
+		var order entity.Order
+
+		s.sendRequest(http.MethodGet, ordersPath+"/"+orderID.String(), http.StatusOK, nil, &order, user)
+
+		return order
+	}
+
+	order := placeOrder()
+	paymentsPath := ordersPath + "/" + order.ID.String() + "/payments"
+
+	var payment entity.Payment
+
+	s.Run("create payment", func() {
+		s.sendRequest(http.MethodPost, paymentsPath, http.StatusCreated, nil, &payment, user)
+		s.Require().Equal(entity.PaymentStatusPending, payment.Status)
+		s.Require().Equal(order.Total, payment.Amount)
+		s.Require().NotEmpty(payment.ProviderID)
+		s.Require().NotNil(payment.ConfirmationURL)
+		s.Require().Contains(*payment.ConfirmationURL, payment.ProviderID)
+		s.Require().Equal("1500.50", s.paymentProvider.get(payment.ProviderID).Amount.Value)
+
+		var again entity.Payment
+
+		s.sendRequest(http.MethodPost, paymentsPath, http.StatusCreated, nil, &again, user)
+		s.Require().Equal(payment.ID, again.ID)
+
+		s.sendRequest(http.MethodPost, paymentsPath, http.StatusForbidden, nil, nil, other)
+		s.sendRequest(http.MethodPost, ordersPath+"/"+uuid.NewString()+"/payments", http.StatusNotFound, nil, nil, user)
+	})
+
+	s.Run("webhook signature is checked", func() {
+		notification := paymentNotification("payment.waiting_for_capture", s.paymentProvider.get(payment.ProviderID))
+
+		s.sendWebhook(notification, "wrong-secret", http.StatusUnauthorized)
+		s.Require().Equal(entity.OrderStatusCreated, getOrder(order.ID).Status)
+	})
+
+	s.Run("held payment is captured and pays the order", func() {
+		notification := s.paymentProvider.confirm(payment.ProviderID)
+
+		s.sendWebhook(notification, paymentWebhookSecret, http.StatusOK)
+		s.Require().Equal(entity.OrderStatusPaid, getOrder(order.ID).Status)
+		s.Require().Equal("succeeded", s.paymentProvider.get(payment.ProviderID).Status)
+		s.Require().Equal(1, s.paymentProvider.captureCount())
+
+		var payments []entity.Payment
+
+		s.sendRequest(http.MethodGet, paymentsPath, http.StatusOK, nil, &payments, user)
+		s.Require().Len(payments, 1)
+		s.Require().Equal(entity.PaymentStatusSucceeded, payments[0].Status)
+
+		s.sendRequest(http.MethodPost, paymentsPath, http.StatusConflict, nil, nil, user)
+	})
+
+	s.Run("redelivered notifications are no-ops", func() {
+		s.sendWebhook(paymentNotification("payment.waiting_for_capture", s.paymentProvider.get(payment.ProviderID)),
+			paymentWebhookSecret, http.StatusOK)
+		s.sendWebhook(paymentNotification("payment.succeeded", s.paymentProvider.get(payment.ProviderID)),
+			paymentWebhookSecret, http.StatusOK)
+		s.sendWebhook(paymentNotification("payment.succeeded", s.paymentProvider.get(payment.ProviderID)),
+			paymentWebhookSecret, http.StatusOK)
+
+		s.Require().Equal(1, s.paymentProvider.captureCount())
+		s.Require().Equal(entity.OrderStatusPaid, getOrder(order.ID).Status)
+
+		s.sendWebhook(paymentNotification("payment.succeeded", yookassarepo.Payment{ID: "unknown"}),
+			paymentWebhookSecret, http.StatusNotFound)
+	})
+
+	s.Run("refund", func() {
+		refundPath := adminPath + "/orders/" + order.ID.String() + "/refund"
+
+		s.sendRequest(http.MethodPost, refundPath, http.StatusForbidden, nil, nil, user)
+
+		var refunded entity.Payment
+
+		s.sendRequest(http.MethodPost, refundPath, http.StatusOK, nil, &refunded, admin)
+		s.Require().Equal(payment.ID, refunded.ID)
+		s.Require().Equal(entity.PaymentStatusRefunded, refunded.Status)
+		s.Require().Equal(entity.OrderStatusRefunded, getOrder(order.ID).Status)
+
+		s.sendRequest(http.MethodPost, refundPath, http.StatusConflict, nil, nil, admin)
+
+		s.sendWebhook(paymentNotification("refund.succeeded", yookassarepo.Refund{
+			ID:        uuid.NewString(),
+			Status:    "succeeded",
+			PaymentID: payment.ProviderID,
+			Amount:    s.paymentProvider.get(payment.ProviderID).Amount,
+		}), paymentWebhookSecret, http.StatusOK)
+		s.Require().Equal(entity.OrderStatusRefunded, getOrder(order.ID).Status)
+	})
+
+	s.Run("payment of a cancelled order is released", func() {
+		order := placeOrder()
+		paymentsPath := ordersPath + "/" + order.ID.String() + "/payments"
+
+		var payment entity.Payment
+
+		s.sendRequest(http.MethodPost, paymentsPath, http.StatusCreated, nil, &payment, user)
+		s.sendRequest(http.MethodPost, ordersPath+"/"+order.ID.String()+"/cancel", http.StatusOK, nil, nil, user)
+
+		s.sendWebhook(s.paymentProvider.confirm(payment.ProviderID), paymentWebhookSecret, http.StatusOK)
+		s.Require().Equal("canceled", s.paymentProvider.get(payment.ProviderID).Status)
+		s.Require().Equal(entity.OrderStatusCancelled, getOrder(order.ID).Status)
+
+		var payments []entity.Payment
+
+		s.sendRequest(http.MethodGet, paymentsPath, http.StatusOK, nil, &payments, user)
+		s.Require().Len(payments, 1)
+		s.Require().Equal(entity.PaymentStatusCanceled, payments[0].Status)
+	})
+
+	s.Run("payment captured for a cancelled order is refunded", func() {
+		order := placeOrder()
+		paymentsPath := ordersPath + "/" + order.ID.String() + "/payments"
+
+		var payment entity.Payment
+
+		s.sendRequest(http.MethodPost, paymentsPath, http.StatusCreated, nil, &payment, user)
+		s.sendRequest(http.MethodPost, ordersPath+"/"+order.ID.String()+"/cancel", http.StatusOK, nil, nil, user)
+
+		refunds := s.paymentProvider.refundCount()
+		notification := s.paymentProvider.capture(payment.ProviderID)
+
+		s.sendWebhook(notification, paymentWebhookSecret, http.StatusOK)
+		s.sendWebhook(notification, paymentWebhookSecret, http.StatusOK)
+		s.Require().Equal(refunds+1, s.paymentProvider.refundCount())
+		s.Require().Equal(entity.OrderStatusCancelled, getOrder(order.ID).Status)
+
+		var payments []entity.Payment
+
+		s.sendRequest(http.MethodGet, paymentsPath, http.StatusOK, nil, &payments, user)
+		s.Require().Len(payments, 1)
+		s.Require().Equal(entity.PaymentStatusRefunded, payments[0].Status)
+	})
+}
@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+const promosCSV = `id;group_id;name;price;category;available
+shirt;;Oxford shirt;3000;Men > Oxford;true
+dress;;Summer dress;5000;Women > Dresses;true
+`
+
+func (s *IntegrationTestSuite) TestPromoCodes() {
+	user := s.createUser("79031355640")
+	buyer := s.createUser("79031355641")
+	rival := s.createUser("79031355642")
+
+	admin := s.createUser("79031355643")
+	admin.Role = entity.RoleAdmin
+
+	promosPath := adminPath + "/promo-codes"
+
+	cartPath := func(user entity.User) string {
+		return userPath + "/" + user.UserID.String() + "/cart"
+	}
+
+	ordersPath := func(user entity.User) string {
+		return userPath + "/" + user.UserID.String() + "/orders"
+	}
+
+	_, err := s.catalogService.Import(context.Background(), "store", entity.FeedFormatCSV, strings.NewReader(promosCSV))
+	s.Require().NoError(err)
+
+	var page entity.ProductPage
+
+	s.sendRequest(http.MethodGet, productsPath+"?feed=store", http.StatusOK, nil, &page, user)
+	s.Require().Len(page.Products, 2)
+
+	dress, shirt := page.Products[0], page.Products[1]
+	s.Require().Equal("shirt", shirt.ExternalID)
+
+	addToCart := func(user entity.User, products ...entity.Product) {
+		for _, product := range products {
+			s.sendRequest(http.MethodPost, cartPath(user)+"/items", http.StatusCreated,
+				entity.CartItem{ProductID: product.ID}, nil, user)
+		}
+	}
+
+	create := func(promo entity.PromoCode) entity.PromoCode {
+		var created entity.PromoCode
+
+		s.sendRequest(http.MethodPost, promosPath, http.StatusCreated, promo, &created, admin)
+
+		return created
+	}
+
+	var tops, welcome, once entity.PromoCode
+
+	s.Run("manage promo codes", func() {
+		s.sendRequest(http.MethodPost, promosPath, http.StatusForbidden,
+			entity.PromoCode{Code: "SALE", Kind: entity.PromoKindPercent, Value: 10}, nil, user)
+
+		for _, promo := range []entity.PromoCode{
+			{Code: "SALE", Kind: "bogus", Value: 10},
+			{Code: "SALE", Kind: entity.PromoKindPercent, Value: 0},
+			{Code: "SALE", Kind: entity.PromoKindPercent, Value: 101},
+			{Code: "S", Kind: entity.PromoKindPercent, Value: 10},
+			{Code: "SALE", Kind: entity.PromoKindFreeShipping, Value: 10},
+			{Code: "SALE", Kind: entity.PromoKindPercent, Value: 10, Categories: []string{"spacesuits"}},
+			{Code: "SALE", Kind: entity.PromoKindPercent, Value: 10, UsageLimit: utils.Pointer(0)},
+		} {
+			s.sendRequest(http.MethodPost, promosPath, http.StatusBadRequest, promo, nil, admin)
+		}
+
+		tops = create(entity.PromoCode{Code: "tops20", Kind: entity.PromoKindPercent, Value: 20, Categories: []string{"tops"}})
+		s.Require().Equal("TOPS20", tops.Code)
+		s.Require().True(tops.Active)
+		s.Require().Equal(entity.DefaultCurrency, tops.Currency)
+
+		s.sendRequest(http.MethodPost, promosPath, http.StatusConflict,
+			entity.PromoCode{Code: "Tops20", Kind: entity.PromoKindFixed, Value: 100}, nil, admin)
+
+		welcome = create(entity.PromoCode{Code: "WELCOME", Kind: entity.PromoKindFixed, Value: 100000, FirstOrderOnly: true})
+		once = create(entity.PromoCode{Code: "ONCE", Kind: entity.PromoKindPercent, Value: 10, UsageLimit: utils.Pointer(1)})
+		create(entity.PromoCode{Code: "BIG", Kind: entity.PromoKindFreeShipping, MinOrder: 1000000})
+
+		var promos []entity.PromoCode
+
+		s.sendRequest(http.MethodGet, promosPath, http.StatusOK, nil, &promos, admin)
+		s.Require().Len(promos, 4)
+	})
+
+	s.Run("cart preview", func() {
+		addToCart(user, shirt, dress)
+
+		var cart entity.Cart
+
+		s.sendRequest(http.MethodGet, cartPath(user), http.StatusOK, nil, &cart, user)
+		s.Require().Equal(int64(800000), cart.Subtotal)
+		s.Require().Equal(int64(800000), cart.Total)
+		s.Require().Nil(cart.PromoCode)
+
+		s.sendRequest(http.MethodGet, cartPath(user)+"?promoCode=tops20", http.StatusOK, nil, &cart, user)
+		s.Require().Equal(int64(60000), cart.Discount)
+		s.Require().Equal(int64(740000), cart.Total)
+		s.Require().Equal("TOPS20", *cart.PromoCode)
+
+		s.sendRequest(http.MethodGet, cartPath(user)+"?promoCode=NOPE", http.StatusNotFound, nil, nil, user)
+		s.sendRequest(http.MethodGet, cartPath(user)+"?promoCode=BIG", http.StatusUnprocessableEntity, nil, nil, user)
+	})
+
+	s.Run("checkout redeems the code", func() {
+		var order entity.Order
+
+		s.sendRequest(http.MethodPost, ordersPath(user), http.StatusCreated,
+			entity.CheckoutRequest{PromoCode: utils.Pointer("tops20")}, &order, user)
+		s.Require().Equal(int64(800000), order.Subtotal)
+		s.Require().Equal(int64(60000), order.Discount)
+		s.Require().Equal(int64(740000), order.Total)
+		s.Require().Equal(entity.DefaultCurrency, order.Currency)
+		s.Require().Equal("TOPS20", *order.PromoCode)
+
+		s.sendRequest(http.MethodGet, promosPath+"/"+tops.ID.String(), http.StatusOK, nil, &tops, admin)
+		s.Require().Equal(1, tops.Redemptions)
+	})
+
+	s.Run("first order only", func() {
+		addToCart(user, dress)
+
+		s.sendRequest(http.MethodPost, ordersPath(user), http.StatusUnprocessableEntity,
+			entity.CheckoutRequest{PromoCode: utils.Pointer("WELCOME")}, nil, user)
+
+		var cart entity.Cart
+
+		s.sendRequest(http.MethodGet, cartPath(user), http.StatusOK, nil, &cart, user)
+		s.Require().Len(cart.Items, 1)
+
+		addToCart(buyer, dress)
+
+		var order entity.Order
+
+		s.sendRequest(http.MethodPost, ordersPath(buyer), http.StatusCreated,
+			entity.CheckoutRequest{PromoCode: utils.Pointer("WELCOME")}, &order, buyer)
+		s.Require().Equal(int64(400000), order.Total)
+
+		s.sendRequest(http.MethodGet, promosPath+"/"+welcome.ID.String(), http.StatusOK, nil, &welcome, admin)
+		s.Require().Equal(1, welcome.Redemptions)
+	})
+
+	s.Run("usage limit holds under concurrency", func() {
+		addToCart(buyer, shirt)
+		addToCart(rival, shirt)
+
+		var wg sync.WaitGroup
+
+		orders := make([]entity.Order, 2)
+		errs := make([]error, 2)
+
+		for i, customer := range []entity.User{buyer, rival} {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				orders[i], errs[i] = s.ordersService.Checkout(context.Background(), customer.UserID,
+					entity.CheckoutRequest{PromoCode: utils.Pointer("ONCE")})
+			}()
+		}
+
+		wg.Wait()
+
+		var redeemed entity.Order
+
+		for i, err := range errs {
+			if err == nil {
+				redeemed = orders[i]
+
+				continue
+			}
+
+			s.Require().ErrorIs(err, entity.ErrPromoCodeNotApplicable)
+		}
+
+		s.Require().Equal(int64(30000), redeemed.Discount)
+
+		s.sendRequest(http.MethodGet, promosPath+"/"+once.ID.String(), http.StatusOK, nil, &once, admin)
+		s.Require().Equal(1, once.Redemptions)
+
+		owner, customer := buyer, rival
+		if redeemed.UserID == rival.UserID {
+			owner, customer = rival, buyer
+		}
+
+		s.sendRequest(http.MethodPost, ordersPath(owner)+"/"+redeemed.ID.String()+"/cancel", http.StatusOK, nil, nil, owner)
+		s.sendRequest(http.MethodPost, ordersPath(customer), http.StatusCreated,
+			entity.CheckoutRequest{PromoCode: utils.Pointer("ONCE")}, nil, customer)
+	})
+
+	s.Run("deactivate and reschedule", func() {
+		now := time.Now()
+
+		s.sendRequest(http.MethodPatch, promosPath+"/"+tops.ID.String(), http.StatusBadRequest,
+			entity.PromoCodeUpdate{}, nil, admin)
+		s.sendRequest(http.MethodPatch, promosPath+"/"+tops.ID.String(), http.StatusBadRequest,
+			entity.PromoCodeUpdate{StartsAt: utils.Pointer(now), EndsAt: utils.Pointer(now.Add(-time.Hour))}, nil, admin)
+		s.sendRequest(http.MethodPatch, promosPath+"/"+entity.PromoCodeID{}.String(), http.StatusNotFound,
+			entity.PromoCodeUpdate{Active: utils.Pointer(false)}, nil, admin)
+
+		s.sendRequest(http.MethodPatch, promosPath+"/"+tops.ID.String(), http.StatusOK,
+			entity.PromoCodeUpdate{Active: utils.Pointer(false)}, &tops, admin)
+		s.Require().False(tops.Active)
+
+		s.sendRequest(http.MethodGet, cartPath(user)+"?promoCode=TOPS20", http.StatusUnprocessableEntity, nil, nil, user)
+
+		newcomer := s.createUser("79031355644")
+		addToCart(newcomer, dress)
+
+		s.sendRequest(http.MethodGet, cartPath(newcomer)+"?promoCode=WELCOME", http.StatusOK, nil, nil, newcomer)
+		s.sendRequest(http.MethodPatch, promosPath+"/"+welcome.ID.String(), http.StatusOK,
+			entity.PromoCodeUpdate{EndsAt: utils.Pointer(now.Add(-time.Minute))}, &welcome, admin)
+		s.sendRequest(http.MethodGet, cartPath(newcomer)+"?promoCode=WELCOME", http.StatusUnprocessableEntity, nil, nil, newcomer)
+
+		var promos []entity.PromoCode
+
+		s.sendRequest(http.MethodGet, promosPath+"?active=false", http.StatusOK, nil, &promos, admin)
+		s.Require().Len(promos, 1)
+		s.Require().Equal(tops.ID, promos[0].ID)
+	})
+}
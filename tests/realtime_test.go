@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+)
+
+// openEventStream connects to the user's event stream and returns its reader
+// once the server has subscribed the user.
+func (s *IntegrationTestSuite) openEventStream(ctx context.Context, user entity.User) *bufio.Reader {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("http://localhost:%d/api/v1/events", port), nil)
+	s.Require().NoError(err)
+
+	request.Header.Set("Authorization", "Bearer "+s.getToken(user))
+
+	response, err := http.DefaultClient.Do(request)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, response.StatusCode)
+	s.Require().Equal("text/event-stream", response.Header.Get("Content-Type"))
+
+	s.T().Cleanup(func() {
+		_ = response.Body.Close()
+	})
+
+	reader := bufio.NewReader(response.Body)
+
+	line, err := reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Require().Equal(": connected\n", line)
+
+	return reader
+}
+
+// readEvent skips heartbeats and returns the next event of the stream.
+func (s *IntegrationTestSuite) readEvent(reader *bufio.Reader) (entity.EventType, json.RawMessage) {
+	var (
+		eventType entity.EventType
+		data      json.RawMessage
+	)
+
+	for {
+		line, err := reader.ReadString('\n')
+		s.Require().NoError(err)
+
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			eventType = entity.EventType(strings.TrimPrefix(line, "event: "))
+		case strings.HasPrefix(line, "data: "):
+			data = json.RawMessage(strings.TrimPrefix(line, "data: "))
+		case line == "" && eventType != "":
+			return eventType, data
+		}
+	}
+}
+
+func (s *IntegrationTestSuite) TestRealtimeEvents() {
+	author := s.createUser("79031355658")
+	follower := s.createUser("79031355659")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s.Run("requires a token", func() {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			fmt.Sprintf("http://localhost:%d/api/v1/events", port), nil)
+		s.Require().NoError(err)
+
+		response, err := http.DefaultClient.Do(request)
+		s.Require().NoError(err)
+		s.Require().NoError(response.Body.Close())
+		s.Require().Equal(http.StatusUnauthorized, response.StatusCode)
+	})
+
+	s.Run("new follower", func() {
+		stream := s.openEventStream(ctx, author)
+
+		// Give the listener a moment in case the hub is still connecting.
+		time.Sleep(100 * time.Millisecond)
+
+		s.sendRequest(http.MethodPut, userPath+"/"+follower.UserID.String()+"/following/"+author.UserID.String(),
+			http.StatusNoContent, nil, nil, follower)
+
+		eventType, data := s.readEvent(stream)
+		s.Require().Equal(entity.EventFollowerAdded, eventType)
+
+		var followed entity.FollowerAddedData
+
+		s.Require().NoError(json.Unmarshal(data, &followed))
+		s.Require().Equal(follower.UserID, followed.FollowerID)
+	})
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestSearch() {
+	owner := s.createUser("79031355557")
+	stranger := s.createUser("79031355558")
+
+	searchPath := userPath + "/" + owner.UserID.String() + "/wardrobe/search"
+
+	jacket := s.createWardrobeItem(owner, entity.WardrobeItem{
+		Name:     "Кожаная куртка",
+		Category: "outerwear",
+		Brand:    utils.Pointer("Zara"),
+		Size:     utils.Pointer("M"),
+		Colors:   []string{"black"},
+		Season:   utils.Pointer(entity.SeasonAutumn),
+	})
+	shirt := s.createWardrobeItem(owner, entity.WardrobeItem{
+		Name:     "Oxford shirt",
+		Category: "tops",
+		Brand:    utils.Pointer("Uniqlo"),
+		Size:     utils.Pointer("M"),
+		Colors:   []string{"white", "blue"},
+		Notes:    utils.Pointer("goes well with the leather jackets"),
+	})
+	jeans := s.createWardrobeItem(owner, entity.WardrobeItem{
+		Name:     "Jeans",
+		Category: "bottoms",
+		Brand:    utils.Pointer("Levi's"),
+		Size:     utils.Pointer("32"),
+		Colors:   []string{"blue"},
+	})
+	s.createWardrobeItem(stranger, entity.WardrobeItem{Name: "Куртка", Category: "outerwear"})
+
+	search := func(query url.Values, status int) entity.SearchResult {
+		var result entity.SearchResult
+
+		s.sendRequest(http.MethodGet, searchPath+"?"+query.Encode(), status, nil, &result, owner)
+
+		return result
+	}
+
+	s.Run("search for another user", func() {
+		s.sendRequest(http.MethodGet, searchPath, http.StatusForbidden, nil, nil, stranger)
+	})
+
+	s.Run("invalid search", func() {
+		search(url.Values{"season": {"monsoon"}}, http.StatusBadRequest)
+		search(url.Values{"cursor": {"garbage"}}, http.StatusBadRequest)
+	})
+
+	s.Run("russian word forms match", func() {
+		result := search(url.Values{"q": {"куртки"}}, http.StatusOK)
+		s.Require().Len(result.Items, 1)
+		s.Require().Equal(jacket.ID, result.Items[0].ID)
+	})
+
+	s.Run("english matches rank name above notes", func() {
+		jacketResult := search(url.Values{"q": {"jacket"}}, http.StatusOK)
+		s.Require().Len(jacketResult.Items, 1)
+		s.Require().Equal(shirt.ID, jacketResult.Items[0].ID)
+
+		brandResult := search(url.Values{"q": {"zara"}}, http.StatusOK)
+		s.Require().Len(brandResult.Items, 1)
+		s.Require().Equal(jacket.ID, brandResult.Items[0].ID)
+	})
+
+	s.Run("facets count without their own filter", func() {
+		result := search(url.Values{"color": {"blue"}, "size": {"M"}}, http.StatusOK)
+		s.Require().Len(result.Items, 1)
+		s.Require().Equal(shirt.ID, result.Items[0].ID)
+
+		s.Require().Equal([]entity.FacetCount{
+			{Value: "black", Count: 1},
+			{Value: "blue", Count: 1},
+			{Value: "white", Count: 1},
+		}, result.Facets.Colors)
+		s.Require().Equal([]entity.FacetCount{
+			{Value: "32", Count: 1},
+			{Value: "M", Count: 1},
+		}, result.Facets.Sizes)
+		s.Require().Equal([]entity.FacetCount{{Value: "tops", Count: 1}}, result.Facets.Categories)
+		s.Require().Empty(result.Facets.Seasons)
+	})
+
+	s.Run("cursor pagination", func() {
+		var ids []entity.WardrobeItemID
+
+		query := url.Values{"limit": {"2"}}
+
+		for {
+			result := search(query, http.StatusOK)
+			for _, item := range result.Items {
+				ids = append(ids, item.ID)
+			}
+
+			if result.NextCursor == nil {
+				break
+			}
+
+			query.Set("cursor", *result.NextCursor)
+		}
+
+		s.Require().Equal([]entity.WardrobeItemID{jeans.ID, shirt.ID, jacket.ID}, ids)
+
+		first := search(url.Values{"q": {"jeans or shirt"}, "limit": {"1"}}, http.StatusOK)
+		s.Require().NotNil(first.NextCursor)
+
+		search(url.Values{"cursor": {*first.NextCursor}}, http.StatusBadRequest)
+	})
+}
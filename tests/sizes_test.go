@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"net/http"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestSizeProfile() {
+	owner := s.createUser("79031355567")
+	stranger := s.createUser("79031355568")
+
+	profilePath := userPath + "/" + owner.UserID.String() + "/size-profile"
+
+	s.Run("empty profile", func() {
+		var profile entity.SizeProfile
+
+		s.sendRequest(http.MethodGet, profilePath, http.StatusOK, nil, &profile, owner)
+		s.Require().Equal(owner.UserID, profile.UserID)
+		s.Require().Equal(entity.DefaultSizeRegion, profile.Region)
+		s.Require().Nil(profile.UpdatedAt)
+		s.Require().Empty(profile.Sizes)
+
+		s.sendRequest(http.MethodGet, profilePath, http.StatusForbidden, nil, nil, stranger)
+	})
+
+	s.Run("hint without chart", func() {
+		s.sendRequest(http.MethodGet, profilePath+"/hint?category=tops", http.StatusUnprocessableEntity, nil, nil, owner)
+	})
+
+	s.Run("invalid update", func() {
+		s.sendRequest(http.MethodPatch, profilePath, http.StatusBadRequest,
+			entity.SizeProfileUpdate{HeightCM: utils.Pointer(500.0)}, nil, owner)
+		s.sendRequest(http.MethodPatch, profilePath, http.StatusBadRequest,
+			entity.SizeProfileUpdate{Region: utils.Pointer(entity.SizeRegion("JP"))}, nil, owner)
+	})
+
+	s.Run("update profile", func() {
+		var profile entity.SizeProfile
+
+		update := entity.SizeProfileUpdate{
+			Chart:    utils.Pointer(entity.SizeChartMen),
+			Region:   utils.Pointer(entity.SizeRegionEU),
+			HeightCM: utils.Pointer(182.0),
+			ChestCM:  utils.Pointer(95.0),
+			ShoeSize: utils.Pointer(42.0),
+		}
+
+		s.sendRequest(http.MethodPatch, profilePath, http.StatusOK, update, &profile, owner)
+		s.Require().InDelta(182.0, *profile.HeightCM, 0.01)
+		s.Require().NotNil(profile.UpdatedAt)
+		s.Require().Equal(entity.Sizes{"INT": "M", "RU": "48", "EU": "48", "US": "38", "UK": "38"}, profile.Sizes["tops"])
+		s.Require().Equal("42", profile.Sizes["shoes"][entity.SizeRegionEU])
+		s.Require().NotContains(profile.Sizes, "bottoms")
+
+		s.sendRequest(http.MethodPatch, profilePath, http.StatusOK,
+			entity.SizeProfileUpdate{WaistCM: utils.Pointer(86.0)}, &profile, owner)
+		s.Require().InDelta(95.0, *profile.ChestCM, 0.01)
+		s.Require().Equal("L", profile.Sizes["bottoms"][entity.SizeRegionINT])
+	})
+
+	s.Run("size hints", func() {
+		var hint entity.SizeHint
+
+		s.sendRequest(http.MethodGet, profilePath+"/hint?category=oxford&size=M", http.StatusOK, nil, &hint, owner)
+		s.Require().Equal("M", hint.Recommended[entity.SizeRegionINT])
+		s.Require().Equal(0, *hint.Difference)
+		s.Require().Equal(entity.SizeVerdictYourSize, *hint.Verdict)
+
+		s.sendRequest(http.MethodGet, profilePath+"/hint?category=jackets&size=52", http.StatusOK, nil, &hint, owner)
+		s.Require().Equal(2, *hint.Difference)
+		s.Require().Equal(entity.SizeVerdictLarger, *hint.Verdict)
+
+		s.sendRequest(http.MethodGet, profilePath+"/hint?category=sneakers&size=7&region=UK", http.StatusOK, nil, &hint, owner)
+		s.Require().Equal(-1, *hint.Difference)
+		s.Require().Equal(entity.SizeVerdictSmaller, *hint.Verdict)
+
+		s.sendRequest(http.MethodGet, profilePath+"/hint?category=jeans", http.StatusOK, nil, &hint, owner)
+		s.Require().Nil(hint.Verdict)
+
+		s.sendRequest(http.MethodGet, profilePath+"/hint?category=bags", http.StatusBadRequest, nil, nil, owner)
+		s.sendRequest(http.MethodGet, profilePath+"/hint?category=tops&size=47", http.StatusBadRequest, nil, nil, owner)
+		s.sendRequest(http.MethodGet, profilePath+"/hint?category=capes", http.StatusNotFound, nil, nil, owner)
+	})
+}
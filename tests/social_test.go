@@ -0,0 +1,203 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestSocial() {
+	author := s.createUser("79031355577")
+	follower := s.createUser("79031355578")
+	stranger := s.createUser("79031355579")
+
+	authorPath := userPath + "/" + author.UserID.String()
+	followerPath := userPath + "/" + follower.UserID.String()
+
+	outfit := s.createOutfit(author, "Friday")
+	foreignOutfit := s.createOutfit(stranger, "Sunday")
+
+	posts := make(map[entity.PostVisibility]entity.Post)
+
+	s.Run("create invalid post", func() {
+		s.sendRequest(http.MethodPost, authorPath+"/posts", http.StatusForbidden,
+			entity.Post{OutfitID: outfit.ID}, nil, stranger)
+		s.sendRequest(http.MethodPost, authorPath+"/posts", http.StatusBadRequest,
+			entity.Post{OutfitID: foreignOutfit.ID}, nil, author)
+		s.sendRequest(http.MethodPost, authorPath+"/posts", http.StatusBadRequest,
+			entity.Post{OutfitID: outfit.ID, Visibility: "friends"}, nil, author)
+	})
+
+	s.Run("create posts", func() {
+		for _, visibility := range []entity.PostVisibility{
+			entity.PostVisibilityPublic,
+			entity.PostVisibilityFollowers,
+			entity.PostVisibilityPrivate,
+		} {
+			var post entity.Post
+
+			s.sendRequest(http.MethodPost, authorPath+"/posts", http.StatusCreated,
+				entity.Post{OutfitID: outfit.ID, Caption: utils.Pointer(" " + string(visibility) + " "), Visibility: visibility}, &post, author)
+			s.Require().Equal(string(visibility), *post.Caption)
+			s.Require().Equal("Friday", post.OutfitName)
+
+			posts[visibility] = post
+		}
+	})
+
+	listPosts := func(user entity.User) []entity.PostVisibility {
+		var page entity.PostPage
+
+		s.sendRequest(http.MethodGet, authorPath+"/posts", http.StatusOK, nil, &page, user)
+
+		visibilities := make([]entity.PostVisibility, 0, len(page.Posts))
+		for _, post := range page.Posts {
+			visibilities = append(visibilities, post.Visibility)
+		}
+
+		return visibilities
+	}
+
+	s.Run("follow", func() {
+		s.sendRequest(http.MethodPut, followerPath+"/following/"+author.UserID.String(), http.StatusForbidden, nil, nil, stranger)
+		s.sendRequest(http.MethodPut, followerPath+"/following/"+follower.UserID.String(), http.StatusBadRequest, nil, nil, follower)
+		s.sendRequest(http.MethodPut, followerPath+"/following/"+uuid.New().String(), http.StatusNotFound, nil, nil, follower)
+
+		s.sendRequest(http.MethodPut, followerPath+"/following/"+author.UserID.String(), http.StatusNoContent, nil, nil, follower)
+		s.sendRequest(http.MethodPut, followerPath+"/following/"+author.UserID.String(), http.StatusNoContent, nil, nil, follower)
+
+		var followers entity.FollowPage
+
+		s.sendRequest(http.MethodGet, authorPath+"/followers", http.StatusOK, nil, &followers, stranger)
+		s.Require().Len(followers.Users, 1)
+		s.Require().Equal(follower.UserID, followers.Users[0].UserID)
+
+		var following entity.FollowPage
+
+		s.sendRequest(http.MethodGet, followerPath+"/following", http.StatusOK, nil, &following, stranger)
+		s.Require().Len(following.Users, 1)
+		s.Require().Equal(author.UserID, following.Users[0].UserID)
+	})
+
+	s.Run("visibility", func() {
+		s.Require().Equal([]entity.PostVisibility{
+			entity.PostVisibilityPrivate, entity.PostVisibilityFollowers, entity.PostVisibilityPublic,
+		}, listPosts(author))
+		s.Require().Equal([]entity.PostVisibility{
+			entity.PostVisibilityFollowers, entity.PostVisibilityPublic,
+		}, listPosts(follower))
+		s.Require().Equal([]entity.PostVisibility{entity.PostVisibilityPublic}, listPosts(stranger))
+
+		privatePath := authorPath + "/posts/" + posts[entity.PostVisibilityPrivate].ID.String()
+
+		s.sendRequest(http.MethodGet, privatePath, http.StatusOK, nil, nil, author)
+		s.sendRequest(http.MethodGet, privatePath, http.StatusNotFound, nil, nil, follower)
+	})
+
+	s.Run("update post", func() {
+		var post entity.Post
+
+		postPath := authorPath + "/posts/" + posts[entity.PostVisibilityFollowers].ID.String()
+
+		s.sendRequest(http.MethodPatch, postPath, http.StatusForbidden,
+			entity.PostUpdate{Visibility: utils.Pointer(entity.PostVisibilityPublic)}, nil, follower)
+		s.sendRequest(http.MethodPatch, postPath, http.StatusOK,
+			entity.PostUpdate{Visibility: utils.Pointer(entity.PostVisibilityPublic)}, &post, author)
+		s.Require().Equal(entity.PostVisibilityPublic, post.Visibility)
+		s.Require().Equal("followers", *post.Caption)
+
+		s.sendRequest(http.MethodGet, postPath, http.StatusOK, nil, nil, stranger)
+	})
+
+	s.Run("feed pagination", func() {
+		var own entity.Post
+
+		s.sendRequest(http.MethodPost, followerPath+"/posts", http.StatusCreated,
+			entity.Post{OutfitID: s.createOutfit(follower, "Monday").ID}, &own, follower)
+
+		s.sendRequest(http.MethodGet, followerPath+"/feed", http.StatusForbidden, nil, nil, author)
+		s.sendRequest(http.MethodGet, followerPath+"/feed?cursor=garbage", http.StatusBadRequest, nil, nil, follower)
+
+		var ids []entity.PostID
+
+		query := url.Values{"limit": {"1"}}
+
+		for {
+			var page entity.PostPage
+
+			s.sendRequest(http.MethodGet, followerPath+"/feed?"+query.Encode(), http.StatusOK, nil, &page, follower)
+			s.Require().LessOrEqual(len(page.Posts), 1)
+
+			for _, post := range page.Posts {
+				ids = append(ids, post.ID)
+			}
+
+			if page.NextCursor == nil {
+				break
+			}
+
+			query.Set("cursor", *page.NextCursor)
+		}
+
+		s.Require().Equal([]entity.PostID{
+			own.ID,
+			posts[entity.PostVisibilityFollowers].ID,
+			posts[entity.PostVisibilityPublic].ID,
+		}, ids)
+	})
+
+	s.Run("delete post", func() {
+		postPath := authorPath + "/posts/" + posts[entity.PostVisibilityPublic].ID.String()
+
+		s.sendRequest(http.MethodDelete, postPath, http.StatusNoContent, nil, nil, author)
+		s.sendRequest(http.MethodGet, postPath, http.StatusNotFound, nil, nil, author)
+		s.sendRequest(http.MethodDelete, postPath, http.StatusNotFound, nil, nil, author)
+	})
+
+	s.Run("deleted users vanish", func() {
+		s.sendRequest(http.MethodDelete, authorPath, http.StatusNoContent, nil, nil, author)
+
+		var feed entity.PostPage
+
+		s.sendRequest(http.MethodGet, followerPath+"/feed", http.StatusOK, nil, &feed, follower)
+		s.Require().Len(feed.Posts, 1)
+		s.Require().Equal(follower.UserID, feed.Posts[0].AuthorID)
+
+		var following entity.FollowPage
+
+		s.sendRequest(http.MethodGet, followerPath+"/following", http.StatusOK, nil, &following, follower)
+		s.Require().Empty(following.Users)
+
+		s.sendRequest(http.MethodPut, followerPath+"/following/"+author.UserID.String(), http.StatusNotFound, nil, nil, follower)
+	})
+
+	s.Run("unfollow", func() {
+		s.sendRequest(http.MethodPut, userPath+"/"+stranger.UserID.String()+"/following/"+follower.UserID.String(),
+			http.StatusNoContent, nil, nil, stranger)
+		s.sendRequest(http.MethodDelete, userPath+"/"+stranger.UserID.String()+"/following/"+follower.UserID.String(),
+			http.StatusNoContent, nil, nil, stranger)
+		s.sendRequest(http.MethodDelete, userPath+"/"+stranger.UserID.String()+"/following/"+follower.UserID.String(),
+			http.StatusNoContent, nil, nil, stranger)
+
+		var followers entity.FollowPage
+
+		s.sendRequest(http.MethodGet, followerPath+"/followers", http.StatusOK, nil, &followers, follower)
+		s.Require().Empty(followers.Users)
+	})
+}
+
+func (s *IntegrationTestSuite) createOutfit(user entity.User, name string) entity.Outfit {
+	item := s.createWardrobeItem(user, entity.WardrobeItem{Name: name + " shirt", Category: "tops"})
+
+	outfit, err := s.outfitsService.CreateOutfit(context.Background(), user.UserID, entity.Outfit{
+		Name:  name,
+		Items: []entity.OutfitItem{{Slot: entity.OutfitSlotTop, ItemID: item.ID}},
+	})
+	s.Require().NoError(err)
+
+	return outfit
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+const (
+	categoriesPath      = "/api/v1/categories"
+	adminCategoriesPath = "/api/v1/admin/categories"
+)
+
+func (s *IntegrationTestSuite) TestTaxonomy() {
+	user := s.createUser("79031355535")
+
+	admin := s.createUser("79031355536")
+	admin.Role = entity.RoleAdmin
+
+	defer func() {
+		_, err := s.db.Exec(context.Background(), `DELETE FROM categories WHERE id IN ('bikinis', 'swimwear')`)
+		s.Require().NoError(err)
+	}()
+
+	s.Run("list seeded taxonomy", func() {
+		var categories []entity.Category
+
+		s.sendRequest(http.MethodGet, categoriesPath, http.StatusOK, nil, &categories, user)
+		s.Require().NotEmpty(categories)
+		s.Require().Equal("tops", categories[0].ID)
+
+		var shirts entity.Category
+
+		for _, category := range categories[0].Children {
+			if category.ID == "shirts" {
+				shirts = category
+			}
+		}
+
+		s.Require().Equal("shirts", shirts.ID)
+		s.Require().NotEmpty(shirts.Children)
+		s.Require().Equal("oxford", shirts.Children[0].ID)
+	})
+
+	category := entity.Category{
+		ID:       "swimwear",
+		Name:     "Swimwear",
+		Position: 10,
+		Attributes: []entity.CategoryAttribute{
+			{Name: "coverage", Type: entity.AttributeTypeEnum, AllowedValues: []string{"low", "full"}},
+		},
+	}
+
+	s.Run("create category requires admin", func() {
+		s.sendRequest(http.MethodPost, adminCategoriesPath, http.StatusForbidden, category, nil, user)
+	})
+
+	s.Run("create invalid category", func() {
+		invalid := category
+		invalid.ID = "Swim Wear"
+
+		s.sendRequest(http.MethodPost, adminCategoriesPath, http.StatusBadRequest, invalid, nil, admin)
+
+		invalid = category
+		invalid.ParentID = utils.Pointer("unknown")
+
+		s.sendRequest(http.MethodPost, adminCategoriesPath, http.StatusNotFound, invalid, nil, admin)
+	})
+
+	s.Run("create category", func() {
+		var created entity.Category
+
+		s.sendRequest(http.MethodPost, adminCategoriesPath, http.StatusCreated, category, &created, admin)
+		s.Require().Equal(category.ID, created.ID)
+		s.Require().Equal(category.Attributes, created.Attributes)
+
+		s.sendRequest(http.MethodPost, adminCategoriesPath, http.StatusConflict, category, nil, admin)
+	})
+
+	s.Run("create subcategory and move it", func() {
+		subcategory := entity.Category{ID: "bikinis", ParentID: utils.Pointer("swimwear"), Name: "Bikinis"}
+
+		s.sendRequest(http.MethodPost, adminCategoriesPath, http.StatusCreated, subcategory, nil, admin)
+
+		var moved entity.Category
+
+		s.sendRequest(http.MethodPatch, adminCategoriesPath+"/swimwear", http.StatusBadRequest,
+			entity.CategoryUpdate{ParentID: utils.Pointer("bikinis")}, nil, admin)
+		s.sendRequest(http.MethodPatch, adminCategoriesPath+"/bikinis", http.StatusOK,
+			entity.CategoryUpdate{ParentID: utils.Pointer(""), Name: utils.Pointer("Bikini")}, &moved, admin)
+		s.Require().Nil(moved.ParentID)
+		s.Require().Equal("Bikini", moved.Name)
+
+		s.sendRequest(http.MethodDelete, adminCategoriesPath+"/bikinis", http.StatusNoContent, nil, nil, admin)
+	})
+
+	s.Run("manage attributes", func() {
+		var updated entity.Category
+
+		attribute := entity.CategoryAttribute{Type: entity.AttributeTypeBoolean, Required: true}
+
+		s.sendRequest(http.MethodPut, adminCategoriesPath+"/swimwear/attributes/padded", http.StatusOK, attribute, &updated, admin)
+		s.Require().Len(updated.Attributes, 2)
+
+		s.sendRequest(http.MethodPut, adminCategoriesPath+"/swimwear/attributes/shape", http.StatusBadRequest,
+			entity.CategoryAttribute{Type: entity.AttributeTypeEnum}, nil, admin)
+
+		item := entity.WardrobeItem{Category: "swimwear"}
+		wardrobePath := userPath + "/" + user.UserID.String() + "/wardrobe"
+
+		s.sendRequest(http.MethodPost, wardrobePath, http.StatusBadRequest, item, nil, user)
+
+		item.Attributes = map[string]any{"padded": false, "coverage": "full"}
+
+		var created entity.WardrobeItem
+
+		s.sendRequest(http.MethodPost, wardrobePath, http.StatusCreated, item, &created, user)
+		s.sendRequest(http.MethodDelete, adminCategoriesPath+"/swimwear", http.StatusConflict, nil, nil, admin)
+		s.sendRequest(http.MethodDelete, wardrobePath+"/"+created.ID.String(), http.StatusNoContent, nil, nil, user)
+
+		s.sendRequest(http.MethodDelete, adminCategoriesPath+"/swimwear/attributes/padded", http.StatusNoContent, nil, nil, admin)
+		s.sendRequest(http.MethodDelete, adminCategoriesPath+"/swimwear/attributes/padded", http.StatusNotFound, nil, nil, admin)
+	})
+
+	s.Run("delete category", func() {
+		s.sendRequest(http.MethodDelete, adminCategoriesPath+"/swimwear", http.StatusNoContent, nil, nil, admin)
+		s.sendRequest(http.MethodGet, categoriesPath+"/swimwear", http.StatusNotFound, nil, nil, user)
+	})
+}
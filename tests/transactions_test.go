@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/repository/store"
+)
+
+var errRollback = errors.New("rollback")
+
+func (s *IntegrationTestSuite) TestTransactions() {
+	first := s.createUser("79031355664")
+	second := s.createUser("79031355665")
+
+	ctx := context.Background()
+	query := `UPDATE users SET first_name = $2 WHERE id = $1`
+
+	firstName := func(user entity.User) string {
+		var name string
+
+		err := s.db.QueryRow(ctx, `SELECT first_name FROM users WHERE id = $1`, user.UserID).Scan(&name)
+		s.Require().NoError(err)
+
+		return name
+	}
+
+	s.Run("nested failure rolls back to savepoint", func() {
+		err := s.db.WithTx(ctx, func(ctx context.Context) error {
+			if _, err := s.db.GetTXFromContext(ctx).Exec(ctx, query, first.UserID, "Outer"); err != nil {
+				return err
+			}
+
+			err := s.db.WithTx(ctx, func(ctx context.Context) error {
+				if _, err := s.db.GetTXFromContext(ctx).Exec(ctx, query, second.UserID, "Inner"); err != nil {
+					return err
+				}
+
+				return errRollback
+			})
+			s.Require().ErrorIs(err, errRollback)
+
+			return nil
+		})
+		s.Require().NoError(err)
+
+		s.Require().Equal("Outer", firstName(first))
+		s.Require().Equal("John", firstName(second))
+	})
+
+	s.Run("serialization failure is retried", func() {
+		pool, err := pgxpool.New(ctx, pgDSN)
+		s.Require().NoError(err)
+
+		defer pool.Close()
+
+		txManager := store.NewTxManager(pool, store.TxConfig{
+			IsoLevel:   pgx.Serializable,
+			MaxRetries: 3,
+			RetryDelay: time.Millisecond,
+		})
+
+		var attempts int
+
+		err = txManager.Do(ctx, func(ctx context.Context) error {
+			attempts++
+
+			tx := s.db.GetTXFromContext(ctx)
+
+			var name string
+
+			if err := tx.QueryRow(ctx, `SELECT first_name FROM users WHERE id = $1`, second.UserID).Scan(&name); err != nil {
+				return err
+			}
+
+			if attempts == 1 {
+				_, err := s.db.Exec(ctx, query, second.UserID, "Concurrent")
+				s.Require().NoError(err)
+			}
+
+			_, err := tx.Exec(ctx, query, second.UserID, name+" Retried")
+
+			return err
+		})
+		s.Require().NoError(err)
+
+		s.Require().Equal(2, attempts)
+		s.Require().Equal("Concurrent Retried", firstName(second))
+	})
+
+	s.Run("failure is not retried", func() {
+		var attempts int
+
+		err := s.db.WithTx(ctx, func(ctx context.Context) error {
+			attempts++
+
+			if _, err := s.db.GetTXFromContext(ctx).Exec(ctx, query, first.UserID, "Rolled back"); err != nil {
+				return err
+			}
+
+			return errRollback
+		})
+		s.Require().ErrorIs(err, errRollback)
+
+		s.Require().Equal(1, attempts)
+		s.Require().Equal("Outer", firstName(first))
+	})
+}
@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestWardrobe() {
+	owner := s.createUser("79031355533")
+	stranger := s.createUser("79031355534")
+
+	wardrobePath := userPath + "/" + owner.UserID.String() + "/wardrobe"
+
+	var photo entity.File
+
+	data := s.sendRawRequest(http.MethodPost, userPath+"/"+owner.UserID.String()+"/files", http.StatusCreated, pngHeader, owner)
+	s.Require().NoError(json.Unmarshal(data, &photo))
+
+	item := entity.WardrobeItem{
+		Name:        "Oxford shirt",
+		Category:    "tops",
+		Subcategory: utils.Pointer("oxford"),
+		Brand:       utils.Pointer("Uniqlo"),
+		Size:        utils.Pointer("M"),
+		Colors:      []string{"white", "blue"},
+		Season:      utils.Pointer(entity.SeasonAllSeason),
+		Price:       utils.Pointer(int64(299900)),
+		Attributes:  map[string]any{"sleeveLength": "long", "collar": "button-down"},
+		PhotoIDs:    []entity.FileID{photo.ID},
+	}
+
+	var created entity.WardrobeItem
+
+	s.Run("create item for another user", func() {
+		s.sendRequest(http.MethodPost, wardrobePath, http.StatusForbidden, item, nil, stranger)
+	})
+
+	s.Run("create item without category", func() {
+		s.sendRequest(http.MethodPost, wardrobePath, http.StatusBadRequest, entity.WardrobeItem{Name: "nameless"}, nil, owner)
+	})
+
+	s.Run("create item with foreign photo", func() {
+		invalid := item
+		invalid.PhotoIDs = []entity.FileID{entity.FileID(uuid.New())}
+
+		s.sendRequest(http.MethodPost, wardrobePath, http.StatusBadRequest, invalid, nil, owner)
+	})
+
+	s.Run("create item outside the taxonomy", func() {
+		invalid := item
+		invalid.Category = "spacesuits"
+		invalid.Subcategory = nil
+
+		s.sendRequest(http.MethodPost, wardrobePath, http.StatusBadRequest, invalid, nil, owner)
+
+		invalid = item
+		invalid.Subcategory = utils.Pointer("jeans")
+
+		s.sendRequest(http.MethodPost, wardrobePath, http.StatusBadRequest, invalid, nil, owner)
+	})
+
+	s.Run("create item with invalid attributes", func() {
+		invalid := item
+		invalid.Attributes = map[string]any{"sleeveLength": "very long", "rise": "high", "fit": "slim"}
+
+		body, err := json.Marshal(invalid)
+		s.Require().NoError(err)
+
+		var response struct {
+			Fields []entity.FieldError `json:"fields"`
+		}
+
+		data := s.sendRawRequest(http.MethodPost, wardrobePath, http.StatusBadRequest, body, owner)
+		s.Require().NoError(json.Unmarshal(data, &response))
+		s.Require().Equal([]string{"attributes.rise", "attributes.sleeveLength"}, fieldNames(response.Fields))
+	})
+
+	s.Run("create item successfully", func() {
+		s.sendRequest(http.MethodPost, wardrobePath, http.StatusCreated, item, &created, owner)
+		s.Require().Equal(owner.UserID, created.UserID)
+		s.Require().Equal(item.Name, created.Name)
+		s.Require().Equal(item.Colors, created.Colors)
+		s.Require().Equal(entity.DefaultCurrency, created.Currency)
+		s.Require().Equal([]entity.FileID{photo.ID}, created.PhotoIDs)
+		s.Require().Equal(item.Attributes, created.Attributes)
+	})
+
+	itemPath := wardrobePath + "/" + created.ID.String()
+
+	s.Run("get item", func() {
+		var got entity.WardrobeItem
+
+		s.sendRequest(http.MethodGet, itemPath, http.StatusOK, nil, &got, owner)
+		s.Require().Equal(created.ID, got.ID)
+		s.Require().Equal(*item.Brand, *got.Brand)
+
+		s.sendRequest(http.MethodGet, itemPath, http.StatusForbidden, nil, nil, stranger)
+		s.sendRequest(http.MethodGet, wardrobePath+"/"+uuid.NewString(), http.StatusNotFound, nil, nil, owner)
+	})
+
+	s.Run("list items with filters", func() {
+		var items []entity.WardrobeItem
+
+		s.sendRequest(http.MethodGet, wardrobePath+"?category=tops", http.StatusOK, nil, &items, owner)
+		s.Require().Len(items, 1)
+
+		s.sendRequest(http.MethodGet, wardrobePath+"?category=oxford", http.StatusOK, nil, &items, owner)
+		s.Require().Len(items, 1)
+
+		s.sendRequest(http.MethodGet, wardrobePath+"?category=shoes", http.StatusOK, nil, &items, owner)
+		s.Require().Empty(items)
+
+		s.sendRequest(http.MethodGet, wardrobePath+"?season=monsoon", http.StatusBadRequest, nil, nil, owner)
+		s.sendRequest(http.MethodGet, wardrobePath+"?limit=-1", http.StatusBadRequest, nil, nil, owner)
+	})
+
+	s.Run("update item", func() {
+		var updated entity.WardrobeItem
+
+		update := entity.WardrobeItemUpdate{
+			Notes:    utils.Pointer("needs ironing"),
+			Colors:   &[]string{"white"},
+			PhotoIDs: &[]entity.FileID{},
+		}
+
+		s.sendRequest(http.MethodPatch, itemPath, http.StatusOK, update, &updated, owner)
+		s.Require().Equal(*update.Notes, *updated.Notes)
+		s.Require().Equal([]string{"white"}, updated.Colors)
+		s.Require().Empty(updated.PhotoIDs)
+		s.Require().Equal(item.Name, updated.Name)
+	})
+
+	s.Run("update item category", func() {
+		var updated entity.WardrobeItem
+
+		update := entity.WardrobeItemUpdate{
+			Category:    utils.Pointer("shoes"),
+			Subcategory: utils.Pointer("boots"),
+		}
+
+		s.sendRequest(http.MethodPatch, itemPath, http.StatusBadRequest, update, nil, owner)
+
+		update.Attributes = &map[string]any{"closure": "laces", "heelHeight": 3}
+
+		s.sendRequest(http.MethodPatch, itemPath, http.StatusOK, update, &updated, owner)
+		s.Require().Equal("boots", *updated.Subcategory)
+		s.Require().Equal(map[string]any{"closure": "laces", "heelHeight": float64(3)}, updated.Attributes)
+
+		update = entity.WardrobeItemUpdate{Subcategory: utils.Pointer("")}
+
+		s.sendRequest(http.MethodPatch, itemPath, http.StatusOK, update, &updated, owner)
+		s.Require().Nil(updated.Subcategory)
+
+		update = entity.WardrobeItemUpdate{Subcategory: utils.Pointer(" boots ")}
+
+		s.sendRequest(http.MethodPatch, itemPath, http.StatusOK, update, &updated, owner)
+		s.Require().Equal("boots", *updated.Subcategory)
+
+		update = entity.WardrobeItemUpdate{Subcategory: utils.Pointer("  ")}
+
+		s.sendRequest(http.MethodPatch, itemPath, http.StatusOK, update, &updated, owner)
+		s.Require().Nil(updated.Subcategory)
+
+		var subcategory *string
+
+		err := s.db.QueryRow(context.Background(), `SELECT subcategory FROM wardrobe_items WHERE id = $1`,
+			updated.ID).Scan(&subcategory)
+		s.Require().NoError(err)
+		s.Require().Nil(subcategory)
+	})
+
+	s.Run("delete item", func() {
+		s.sendRequest(http.MethodDelete, itemPath, http.StatusForbidden, nil, nil, stranger)
+		s.sendRequest(http.MethodDelete, itemPath, http.StatusNoContent, nil, nil, owner)
+		s.sendRequest(http.MethodGet, itemPath, http.StatusNotFound, nil, nil, owner)
+		s.sendRequest(http.MethodPatch, itemPath, http.StatusNotFound, entity.WardrobeItemUpdate{}, nil, owner)
+	})
+}
+
+func fieldNames(fields []entity.FieldError) []string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Field)
+	}
+
+	return names
+}
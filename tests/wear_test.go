@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+func (s *IntegrationTestSuite) TestWearLog() {
+	owner := s.createUser("79031355547")
+	stranger := s.createUser("79031355548")
+
+	wearLogPath := userPath + "/" + owner.UserID.String() + "/wear-log"
+	wardrobePath := userPath + "/" + owner.UserID.String() + "/wardrobe"
+
+	shirt := s.createWardrobeItem(owner, entity.WardrobeItem{Name: "Shirt", Category: "tops", Price: utils.Pointer(int64(300000))})
+	jeans := s.createWardrobeItem(owner, entity.WardrobeItem{Name: "Jeans", Category: "bottoms"})
+	scarf := s.createWardrobeItem(owner, entity.WardrobeItem{Name: "Scarf", Category: "accessories"})
+	foreign := s.createWardrobeItem(stranger, entity.WardrobeItem{Name: "Hat", Category: "accessories"})
+
+	var outfit entity.Outfit
+
+	s.sendRequest(http.MethodPost, userPath+"/"+owner.UserID.String()+"/outfits", http.StatusCreated, entity.Outfit{
+		Name: "Monday",
+		Items: []entity.OutfitItem{
+			{Slot: entity.OutfitSlotTop, ItemID: shirt.ID},
+			{Slot: entity.OutfitSlotBottom, ItemID: jeans.ID},
+		},
+	}, &outfit, owner)
+
+	day := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	s.Run("log for another user", func() {
+		entry := entity.WearLogEntry{WornOn: day, ItemIDs: []entity.WardrobeItemID{shirt.ID}}
+		s.sendRequest(http.MethodPost, wearLogPath, http.StatusForbidden, entry, nil, stranger)
+	})
+
+	s.Run("log invalid entries", func() {
+		s.sendRequest(http.MethodPost, wearLogPath, http.StatusBadRequest, entity.WearLogEntry{WornOn: day}, nil, owner)
+
+		future := entity.WearLogEntry{WornOn: time.Now().AddDate(0, 0, 3), ItemIDs: []entity.WardrobeItemID{shirt.ID}}
+		s.sendRequest(http.MethodPost, wearLogPath, http.StatusBadRequest, future, nil, owner)
+
+		foreignItem := entity.WearLogEntry{WornOn: day, ItemIDs: []entity.WardrobeItemID{foreign.ID}}
+		s.sendRequest(http.MethodPost, wearLogPath, http.StatusBadRequest, foreignItem, nil, owner)
+	})
+
+	var outfitEntry, itemEntry entity.WearLogEntry
+
+	s.Run("log outfit with an extra item", func() {
+		entry := entity.WearLogEntry{
+			WornOn:   day.Add(15 * time.Hour),
+			OutfitID: &outfit.ID,
+			ItemIDs:  []entity.WardrobeItemID{scarf.ID, shirt.ID},
+			Notes:    utils.Pointer("conference"),
+		}
+
+		s.sendRequest(http.MethodPost, wearLogPath, http.StatusCreated, entry, &outfitEntry, owner)
+		s.Require().True(day.Equal(outfitEntry.WornOn))
+		s.Require().Equal(outfit.ID, *outfitEntry.OutfitID)
+		s.Require().Equal([]entity.WardrobeItemID{shirt.ID, jeans.ID, scarf.ID}, outfitEntry.ItemIDs)
+	})
+
+	s.Run("log items", func() {
+		entry := entity.WearLogEntry{WornOn: day.AddDate(0, 0, 2), ItemIDs: []entity.WardrobeItemID{shirt.ID}}
+
+		s.sendRequest(http.MethodPost, wearLogPath, http.StatusCreated, entry, &itemEntry, owner)
+		s.Require().Nil(itemEntry.OutfitID)
+	})
+
+	s.Run("list wear log by date range", func() {
+		var entries []entity.WearLogEntry
+
+		s.sendRequest(http.MethodGet, wearLogPath+"?from=2025-03-01&to=2025-03-31", http.StatusOK, nil, &entries, owner)
+		s.Require().Len(entries, 2)
+		s.Require().Equal(outfitEntry.ID, entries[0].ID)
+		s.Require().Equal(itemEntry.ID, entries[1].ID)
+
+		s.sendRequest(http.MethodGet, wearLogPath+"?from=2025-03-04&to=2025-03-05", http.StatusOK, nil, &entries, owner)
+		s.Require().Len(entries, 1)
+		s.Require().Equal(itemEntry.ID, entries[0].ID)
+
+		s.sendRequest(http.MethodGet, wearLogPath+"?from=2025-03-31&to=2025-03-01", http.StatusBadRequest, nil, nil, owner)
+		s.sendRequest(http.MethodGet, wearLogPath+"?from=2023-01-01&to=2025-03-01", http.StatusBadRequest, nil, nil, owner)
+		s.sendRequest(http.MethodGet, wearLogPath+"?from=March", http.StatusBadRequest, nil, nil, owner)
+		s.sendRequest(http.MethodGet, wearLogPath, http.StatusForbidden, nil, nil, stranger)
+	})
+
+	s.Run("item wear stats", func() {
+		var stats entity.ItemWearStats
+
+		s.sendRequest(http.MethodGet, wardrobePath+"/"+shirt.ID.String()+"/stats", http.StatusOK, nil, &stats, owner)
+		s.Require().Equal(2, stats.TimesWorn)
+		s.Require().True(day.AddDate(0, 0, 2).Equal(*stats.LastWornOn))
+		s.Require().Equal(int64(150000), *stats.CostPerWear)
+		s.Require().Equal(entity.DefaultCurrency, stats.Currency)
+
+		s.sendRequest(http.MethodGet, wardrobePath+"/"+jeans.ID.String()+"/stats", http.StatusOK, nil, &stats, owner)
+		s.Require().Equal(1, stats.TimesWorn)
+		s.Require().Nil(stats.CostPerWear)
+
+		s.sendRequest(http.MethodGet, wardrobePath+"/"+foreign.ID.String()+"/stats", http.StatusNotFound, nil, nil, owner)
+	})
+
+	s.Run("delete wear log entry", func() {
+		s.sendRequest(http.MethodDelete, wearLogPath+"/"+itemEntry.ID.String(), http.StatusNoContent, nil, nil, owner)
+		s.sendRequest(http.MethodDelete, wearLogPath+"/"+itemEntry.ID.String(), http.StatusNotFound, nil, nil, owner)
+
+		var stats entity.ItemWearStats
+
+		s.sendRequest(http.MethodGet, wardrobePath+"/"+shirt.ID.String()+"/stats", http.StatusOK, nil, &stats, owner)
+		s.Require().Equal(1, stats.TimesWorn)
+		s.Require().Equal(int64(300000), *stats.CostPerWear)
+	})
+}
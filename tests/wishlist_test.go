@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/clothing-service/internal/entity"
+	"github.com/romanpitatelev/clothing-service/internal/utils"
+)
+
+const wishlistCSV = `id;name;price;size;available
+coat;Wool coat;%s;M;true
+hat;Felt hat;%s;;true
+`
+
+func (s *IntegrationTestSuite) TestWishlist() {
+	user := s.createUser("79031355610")
+	other := s.createUser("79031355611")
+
+	wishlistPath := userPath + "/" + user.UserID.String() + "/wishlist"
+	alertsPath := userPath + "/" + user.UserID.String() + "/price-alerts"
+
+	importPrices := func(coat, hat string) {
+		_, err := s.catalogService.Import(context.Background(), "shop", entity.FeedFormatCSV,
+			strings.NewReader(fmt.Sprintf(wishlistCSV, coat, hat)))
+		s.Require().NoError(err)
+	}
+
+	importPrices("10000", "2000")
+
+	var page entity.ProductPage
+
+	s.sendRequest(http.MethodGet, productsPath+"?feed=shop", http.StatusOK, nil, &page, user)
+	s.Require().Len(page.Products, 2)
+
+	hat, coat := page.Products[0], page.Products[1]
+	s.Require().Equal("coat", coat.ExternalID)
+
+	s.Run("add to wishlist", func() {
+		var item entity.WishlistItem
+
+		s.sendRequest(http.MethodPost, wishlistPath, http.StatusCreated, entity.WishlistItem{
+			ProductID:     coat.ID,
+			Size:          utils.Pointer("M"),
+			DropThreshold: 20,
+			Channel:       entity.AlertChannelSMS,
+		}, &item, user)
+		s.Require().Equal(int64(1000000), item.BaselinePrice)
+		s.Require().Equal("Wool coat", item.Product.Name)
+
+		s.sendRequest(http.MethodPost, wishlistPath, http.StatusCreated, entity.WishlistItem{ProductID: hat.ID}, &item, user)
+		s.Require().Equal(entity.DefaultDropThreshold, item.DropThreshold)
+		s.Require().Equal(entity.AlertChannelInApp, item.Channel)
+
+		s.sendRequest(http.MethodPost, wishlistPath, http.StatusConflict, entity.WishlistItem{ProductID: hat.ID}, nil, user)
+		s.sendRequest(http.MethodPost, wishlistPath, http.StatusNotFound,
+			entity.WishlistItem{ProductID: entity.ProductID(other.UserID)}, nil, user)
+		s.sendRequest(http.MethodPost, wishlistPath, http.StatusBadRequest,
+			entity.WishlistItem{ProductID: coat.ID, DropThreshold: 95}, nil, user)
+		s.sendRequest(http.MethodPost, wishlistPath, http.StatusForbidden, entity.WishlistItem{ProductID: coat.ID}, nil, other)
+	})
+
+	s.Run("update wishlist item", func() {
+		var item entity.WishlistItem
+
+		s.sendRequest(http.MethodPatch, wishlistPath+"/"+hat.ID.String(), http.StatusOK,
+			entity.WishlistItemUpdate{DropThreshold: utils.Pointer(50)}, &item, user)
+		s.Require().Equal(50, item.DropThreshold)
+
+		s.sendRequest(http.MethodPatch, wishlistPath+"/"+hat.ID.String(), http.StatusBadRequest,
+			entity.WishlistItemUpdate{Channel: utils.Pointer(entity.AlertChannel("email"))}, nil, user)
+		s.sendRequest(http.MethodPatch, wishlistPath+"/"+entity.ProductID{}.String(), http.StatusNotFound,
+			entity.WishlistItemUpdate{DropThreshold: utils.Pointer(30)}, nil, user)
+	})
+
+	s.Run("price history follows imports", func() {
+		importPrices("9000", "1000")
+		importPrices("9000", "1000")
+
+		var history []entity.PricePoint
+
+		s.sendRequest(http.MethodGet, productsPath+"/"+coat.ID.String()+"/price-history", http.StatusOK, nil, &history, user)
+		s.Require().Len(history, 2)
+		s.Require().Equal(int64(1000000), history[0].Price)
+		s.Require().Equal(int64(900000), history[1].Price)
+
+		s.sendRequest(http.MethodGet, productsPath+"/"+entity.ProductID{}.String()+"/price-history",
+			http.StatusNotFound, nil, nil, user)
+	})
+
+	var alerts []entity.PriceAlert
+
+	s.Run("drops below the threshold are ignored", func() {
+		s.Require().NoError(s.priceWatcher.Check(context.Background()))
+
+		s.sendRequest(http.MethodGet, alertsPath, http.StatusOK, nil, &alerts, user)
+		s.Require().Len(alerts, 1)
+		s.Require().Equal(hat.ID, alerts[0].ProductID)
+		s.Require().Equal(int64(200000), alerts[0].OldPrice)
+		s.Require().Equal(int64(100000), alerts[0].NewPrice)
+		s.Require().Equal(entity.PriceAlertStatusSent, alerts[0].Status)
+
+		var notifications []entity.Notification
+
+		s.sendRequest(http.MethodGet, userPath+"/"+user.UserID.String()+"/notifications", http.StatusOK, nil, &notifications, user)
+		s.Require().Len(notifications, 1)
+		s.Require().Equal(entity.NotificationCategoryPriceDrops, notifications[0].Category)
+		s.Require().Contains(notifications[0].Body, "Felt hat")
+	})
+
+	s.Run("sms alert", func() {
+		s.sendRequest(http.MethodPut, userPath+"/"+user.UserID.String()+"/notification-settings", http.StatusOK,
+			entity.NotificationSettings{
+				Preferences: []entity.NotificationPreference{
+					{Category: entity.NotificationCategoryPriceDrops, InApp: true, SMS: true},
+				},
+			}, nil, user)
+
+		importPrices("7500", "1000")
+
+		s.Require().NoError(s.priceWatcher.Check(context.Background()))
+		s.Require().NoError(s.notificationsDispatcher.Check(context.Background()))
+
+		select {
+		case sms := <-s.smsChan:
+			s.Require().Equal(user.Phone, sms.phone)
+			s.Require().Contains(sms.text, "Wool coat")
+			s.Require().Contains(sms.text, "7500 RUB")
+		case <-time.After(5 * time.Second):
+			s.FailNow("price alert was not texted")
+		}
+
+		s.Require().NoError(s.priceWatcher.Check(context.Background()))
+
+		s.sendRequest(http.MethodGet, alertsPath, http.StatusOK, nil, &alerts, user)
+		s.Require().Len(alerts, 2)
+		s.Require().Equal(coat.ID, alerts[0].ProductID)
+		s.Require().Equal(entity.AlertChannelSMS, alerts[0].Channel)
+		s.Require().NotNil(alerts[0].SentAt)
+
+		s.sendRequest(http.MethodGet, alertsPath, http.StatusForbidden, nil, nil, other)
+	})
+
+	s.Run("remove from wishlist", func() {
+		s.sendRequest(http.MethodDelete, wishlistPath+"/"+coat.ID.String(), http.StatusNoContent, nil, nil, user)
+		s.sendRequest(http.MethodDelete, wishlistPath+"/"+coat.ID.String(), http.StatusNotFound, nil, nil, user)
+
+		var items []entity.WishlistItem
+
+		s.sendRequest(http.MethodGet, wishlistPath, http.StatusOK, nil, &items, user)
+		s.Require().Len(items, 1)
+		s.Require().Equal(hat.ID, items[0].ProductID)
+	})
+}